@@ -210,6 +210,10 @@ type AddonMetadataSpec struct {
 	// +optional
 	// Indicates if the add-on will be used as a Managed Service.
 	ManagedService *bool `json:"managedService"`
+
+	// +optional
+	// Validators which are not run for the add-on, each with a justification.
+	ExcludedValidators *[]ExcludedValidator `json:"excludedValidators"`
 }
 
 // AddonMetadataStatus defines the observed state of AddonMetadata
@@ -250,3 +254,11 @@ type Channel struct {
 	Name       string `json:"name"`
 	CurrentCSV string `json:"currentCSV"`
 }
+
+// ExcludedValidator - a validator accepted not to pass for a given addon
+type ExcludedValidator struct {
+	// Code of the validator, e.g. 'AM0006'.
+	Code string `json:"code" validate:"required"`
+	// Justification why the validator does not apply to the addon.
+	Justification string `json:"justification" validate:"required"`
+}
@@ -2,8 +2,10 @@ package v1alpha1
 
 import (
 	"encoding/json"
+	"fmt"
 
 	ocmv1 "github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1"
+	"golang.org/x/mod/semver"
 	"k8s.io/apimachinery/pkg/util/yaml"
 )
 
@@ -51,3 +53,33 @@ func (a *AddonMetadataSpec) CombineWithImageSet(imageSet *AddonImageSetSpec) (*A
 
 	return combined, nil
 }
+
+// ToImageSet - Returns the AddonImageSetSpec of the given version holding the
+// imageSet fields of the AddonMetadataSpec. It is the reverse of CombineWithImageSet.
+func (a *AddonMetadataSpec) ToImageSet(version string, relatedImages []string) (*AddonImageSetSpec, error) {
+	if !semver.IsValid("v" + version) {
+		return nil, fmt.Errorf("'%s' is not a valid version; must match 'MAJOR.MINOR.PATCH'", version)
+	}
+
+	if a.IndexImage == nil || *a.IndexImage == "" {
+		return nil, fmt.Errorf("addon %q has no indexImage", a.ID)
+	}
+
+	copied := a.DeepCopy()
+
+	if relatedImages == nil {
+		relatedImages = []string{}
+	}
+
+	return &AddonImageSetSpec{
+		Name:                     fmt.Sprintf("%s.v%s", a.ID, version),
+		IndexImage:               *copied.IndexImage,
+		RelatedImages:            relatedImages,
+		AddOnParameters:          copied.AddOnParameters,
+		AddOnRequirements:        copied.AddOnRequirements,
+		SubOperators:             copied.SubOperators,
+		Config:                   copied.Config,
+		PullSecretName:           copied.PullSecretName,
+		AdditionalCatalogSources: copied.AdditionalCatalogSources,
+	}, nil
+}
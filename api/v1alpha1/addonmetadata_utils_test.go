@@ -0,0 +1,42 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	ocmv1 "github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToImageSet(t *testing.T) {
+	indexImage := "quay.io/osd-addons/reference-addon-index@sha256:abc"
+	params := []ocmv1.AddOnParameter{{ID: "size", Name: "Size"}}
+
+	meta := &AddonMetadataSpec{
+		ID:              "reference-addon",
+		IndexImage:      &indexImage,
+		AddOnParameters: &params,
+		PullSecretName:  "pull-secret",
+	}
+
+	imageSet, err := meta.ToImageSet("1.2.3", []string{"quay.io/osd-addons/reference-addon:v1"})
+	require.NoError(t, err)
+	require.Equal(t, "reference-addon.v1.2.3", imageSet.Name)
+	require.Equal(t, indexImage, imageSet.IndexImage)
+	require.Equal(t, []string{"quay.io/osd-addons/reference-addon:v1"}, imageSet.RelatedImages)
+	require.Equal(t, params, *imageSet.AddOnParameters)
+	require.Equal(t, "pull-secret", imageSet.PullSecretName)
+
+	combined, err := meta.CombineWithImageSet(imageSet)
+	require.NoError(t, err)
+	require.Equal(t, "1.2.3", *combined.ImageSetVersion)
+
+	imageSet, err = meta.ToImageSet("1.2.3", nil)
+	require.NoError(t, err)
+	require.NotNil(t, imageSet.RelatedImages)
+
+	_, err = meta.ToImageSet("1.2.3.4", nil)
+	require.Error(t, err)
+
+	_, err = (&AddonMetadataSpec{ID: "legacy"}).ToImageSet("1.2.3", nil)
+	require.Error(t, err)
+}
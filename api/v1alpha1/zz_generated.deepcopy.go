@@ -402,6 +402,15 @@ func (in *AddonMetadataSpec) DeepCopyInto(out *AddonMetadataSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ExcludedValidators != nil {
+		in, out := &in.ExcludedValidators, &out.ExcludedValidators
+		*out = new([]ExcludedValidator)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]ExcludedValidator, len(*in))
+			copy(*out, *in)
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonMetadataSpec.
@@ -443,3 +452,18 @@ func (in *Channel) DeepCopy() *Channel {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExcludedValidator) DeepCopyInto(out *ExcludedValidator) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExcludedValidator.
+func (in *ExcludedValidator) DeepCopy() *ExcludedValidator {
+	if in == nil {
+		return nil
+	}
+	out := new(ExcludedValidator)
+	in.DeepCopyInto(out)
+	return out
+}
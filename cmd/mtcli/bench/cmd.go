@@ -0,0 +1,243 @@
+package bench
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	"github.com/mt-sre/addon-metadata-operator/internal/testdata"
+	"github.com/mt-sre/addon-metadata-operator/pkg/extractor"
+	"github.com/mt-sre/addon-metadata-operator/pkg/metadata"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	pkgvalidate "github.com/mt-sre/addon-metadata-operator/pkg/validate"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/spf13/cobra"
+)
+
+const long = `Benchmark the validator suite by running every validator the given number
+of times against an addon and report the mean, 95th percentile and maximum
+duration as well as the heap allocations per run of each validator.
+
+Validators are run one after another so that allocations can be attributed
+to a single validator. Metadata is loaded and bundles are extracted once
+before benchmarking and are not part of the measurements.
+
+If no addon directory is given an embedded fixture addon is used which
+does not require extracting bundles. OCM is not contacted for the fixture.`
+
+func examples() string {
+	return strings.Join([]string{
+		"  # Benchmark all validators against the fixture addon.",
+		"  mtcli bench",
+		"  # Run each validator 50 times and print the results as JSON.",
+		"  mtcli bench --iterations 50 --output json",
+		"  # Benchmark a single validator against a staging addon.",
+		"  mtcli bench --env stage --enabled AM0001 <path/to/addon_dir>",
+	}, "\n")
+}
+
+func Cmd() *cobra.Command {
+	opts := &options{
+		Iterations: 10,
+		Env:        "stage",
+		Output:     outputTable,
+	}
+
+	cmd := &cobra.Command{
+		Use:           "bench [addon_dir]",
+		Short:         "Benchmark the validators against an addon.",
+		Long:          long,
+		Example:       examples(),
+		Args:          cobra.MaximumNArgs(1),
+		RunE:          run(opts),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	flags := cmd.Flags()
+
+	opts.AddIterationsFlag(flags)
+	opts.AddEnvFlag(flags)
+	opts.AddVersionFlag(flags)
+	opts.AddDisabledFlag(flags)
+	opts.AddEnabledFlag(flags)
+	opts.AddEnabledTagsFlag(flags)
+	opts.AddDisabledTagsFlag(flags)
+	opts.AddOutputFlag(flags)
+
+	cli.RegisterFlagCompletions(cmd, cli.FlagCompletions{
+		"env":           cli.CompleteEnvs,
+		"enabled":       cli.CompleteValidatorCodes,
+		"disabled":      cli.CompleteValidatorCodes,
+		"enabled-tags":  cli.CompleteValidatorTags,
+		"disabled-tags": cli.CompleteValidatorTags,
+	})
+
+	return cmd
+}
+
+func run(opts *options) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		if err := opts.VerifyFlags(); err != nil {
+			return fmt.Errorf("verifying flags: %w", err)
+		}
+
+		var (
+			mb         *types.MetaBundle
+			runnerOpts []validator.RunnerOption
+			err        error
+		)
+
+		if len(args) == 0 {
+			mb, err = loadFixture(ctx)
+			if err != nil {
+				return fmt.Errorf("loading fixture addon: %w", err)
+			}
+		} else {
+			mb, err = loadAddon(ctx, args[0], opts)
+			if err != nil {
+				return err
+			}
+
+			ocm, err := cli.NewOCMClient(opts.Env)
+			if err != nil {
+				return fmt.Errorf("initializing ocm client: %w", err)
+			}
+
+			defer func() { _ = ocm.CloseConnection() }()
+
+			runnerOpts = append(runnerOpts, validator.WithOCMClient{OCMClient: ocm})
+		}
+
+		runner, err := validator.NewRunner(ctx, runnerOpts...)
+		if err != nil {
+			return fmt.Errorf("initializing validators: %w", err)
+		}
+
+		filter, err := cli.ValidatorFilter(ctx, runner, opts.Disabled, opts.Enabled)
+		if err != nil {
+			return fmt.Errorf("generating validator filter: %w", err)
+		}
+
+		tagFilter, err := cli.TagFilter(opts.DisabledTags, opts.EnabledTags)
+		if err != nil {
+			return fmt.Errorf("generating validator filter: %w", err)
+		}
+
+		report, err := pkgvalidate.Benchmark(ctx, *mb, opts.Iterations,
+			pkgvalidate.WithRunner{Runner: runner},
+			pkgvalidate.WithFilters{validator.All(filter, tagFilter)},
+		)
+		if err != nil {
+			return fmt.Errorf("benchmarking validators: %w", err)
+		}
+
+		if opts.Output == outputJSON {
+			return printJSON(cmd.OutOrStdout(), report)
+		}
+
+		return printTable(cmd.OutOrStdout(), report)
+	}
+}
+
+func loadAddon(ctx context.Context, addonDir string, opts *options) (*types.MetaBundle, error) {
+	mb, err := metadata.NewLoader(addonDir,
+		metadata.WithEnv(opts.Env),
+		metadata.WithVersion(opts.Version),
+	).LoadMetaBundle(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading addon metadata from '%s': %w", addonDir, err)
+	}
+
+	bundles, err := extractor.New().ExtractBundles(ctx, *mb.AddonMeta.IndexImage, mb.AddonMeta.OperatorName)
+	if err != nil {
+		return nil, fmt.Errorf("extracting and parsing addon bundles: %w", err)
+	}
+
+	mb.Bundles = bundles
+
+	return mb, nil
+}
+
+// loadFixture loads the embedded fixture addon with its bundle
+// unpacked from the embedded files rather than extracted from
+// the index image referenced by the metadata.
+func loadFixture(ctx context.Context) (*types.MetaBundle, error) {
+	mb, err := metadata.NewLoader(testdata.FixtureAddonName,
+		metadata.WithFS{FS: testdata.FixtureAddon()},
+		metadata.WithEnv("stage"),
+	).LoadMetaBundle(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bundleDir, err := os.MkdirTemp("", "mtcli-bench-")
+	if err != nil {
+		return nil, err
+	}
+
+	defer os.RemoveAll(bundleDir)
+
+	if err := os.CopyFS(bundleDir, testdata.FixtureBundle()); err != nil {
+		return nil, fmt.Errorf("unpacking fixture bundle: %w", err)
+	}
+
+	bundle, err := operator.NewBundleFromDirectory(bundleDir)
+	if err != nil {
+		return nil, fmt.Errorf("loading fixture bundle: %w", err)
+	}
+
+	mb.Bundles = []operator.Bundle{bundle}
+
+	return mb, nil
+}
+
+func printJSON(out io.Writer, report pkgvalidate.BenchmarkReport) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(report); err != nil {
+		return fmt.Errorf("encoding report: %w", err)
+	}
+
+	return nil
+}
+
+func printTable(out io.Writer, report pkgvalidate.BenchmarkReport) error {
+	table, err := cli.NewTable(
+		cli.WithHeaders{"CODE", "NAME", "MEAN", "P95", "MAX", "ALLOCS/RUN", "BYTES/RUN", "ERRORS"},
+	)
+	if err != nil {
+		return fmt.Errorf("initializing table: %w", err)
+	}
+
+	for _, v := range report.Validators {
+		errors := cli.Field{Value: fmt.Sprint(v.Errors)}
+		if v.Errors > 0 {
+			errors.Color = cli.FieldColorRed
+		}
+
+		table.WriteRow(cli.TableRow{
+			cli.Field{Value: v.Code.String()},
+			cli.Field{Value: v.Name},
+			cli.Field{Value: v.Mean.String()},
+			cli.Field{Value: v.P95.String()},
+			cli.Field{Value: v.Max.String()},
+			cli.Field{Value: fmt.Sprint(v.AllocsPerRun)},
+			cli.Field{Value: fmt.Sprint(v.BytesPerRun)},
+			errors,
+		})
+	}
+
+	fmt.Fprintln(out, table.String())
+	fmt.Fprintf(out, "\n%d iterations per validator.\n", report.Iterations)
+
+	return nil
+}
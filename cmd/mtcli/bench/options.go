@@ -0,0 +1,122 @@
+package bench
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/spf13/pflag"
+)
+
+type options struct {
+	Iterations   int
+	Env          string
+	Version      string
+	Disabled     string
+	Enabled      string
+	EnabledTags  []string
+	DisabledTags []string
+	Output       string
+}
+
+func (o *options) AddIterationsFlag(flags *pflag.FlagSet) {
+	flags.IntVarP(
+		&o.Iterations,
+		"iterations",
+		"n",
+		o.Iterations,
+		"Number of times each validator is run.",
+	)
+}
+
+func (o *options) AddEnvFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Env,
+		"env",
+		o.Env,
+		"integration, stage or production; ignored for the fixture addon",
+	)
+}
+
+func (o *options) AddVersionFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Version,
+		"version",
+		o.Version,
+		"addon imageset version; ignored for the fixture addon",
+	)
+}
+
+func (o *options) AddDisabledFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Disabled,
+		"disabled",
+		o.Disabled,
+		"Disable specific validators by code or code prefix (e.g. 'OSD'), separated by ','. Can't be combined with --enabled.",
+	)
+}
+
+func (o *options) AddEnabledFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Enabled,
+		"enabled",
+		o.Enabled,
+		"Enable specific validators by code or code prefix (e.g. 'OSD'), separated by ','. Can't be combined with --disabled.",
+	)
+}
+
+func (o *options) AddEnabledTagsFlag(flags *pflag.FlagSet) {
+	flags.StringSliceVar(
+		&o.EnabledTags,
+		"enabled-tags",
+		o.EnabledTags,
+		fmt.Sprintf("Only run validators with any of the given tags, separated by ','; any of %s.", strings.Join(validator.Tags, ", ")),
+	)
+}
+
+func (o *options) AddDisabledTagsFlag(flags *pflag.FlagSet) {
+	flags.StringSliceVar(
+		&o.DisabledTags,
+		"disabled-tags",
+		o.DisabledTags,
+		"Skip validators with any of the given tags, separated by ','. Takes precedence over '--enabled-tags'.",
+	)
+}
+
+func (o *options) AddOutputFlag(flags *pflag.FlagSet) {
+	flags.StringVarP(
+		&o.Output,
+		"output",
+		"o",
+		o.Output,
+		"Output format; 'table' or 'json'.",
+	)
+}
+
+func (o *options) VerifyFlags() error {
+	if o.Iterations < 1 {
+		return fmt.Errorf("'%d' is not a valid number of iterations; must be greater than zero", o.Iterations)
+	}
+
+	switch o.Env {
+	case "stage", "integration", "production":
+	default:
+		return fmt.Errorf("'%s' is not a valid environment; must be one of 'integration', 'stage' or 'production'", o.Env)
+	}
+
+	if o.Output != outputTable && o.Output != outputJSON {
+		return fmt.Errorf("'%s' is not a valid output format; must be one of '%s' or '%s'", o.Output, outputTable, outputJSON)
+	}
+
+	if o.Disabled != "" && o.Enabled != "" {
+		return errors.New("'--disabled' and '--enabled' are mutually exclusive options")
+	}
+
+	return nil
+}
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
@@ -1,6 +1,8 @@
 package bundle
 
 import (
+	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/bundle/diff"
+	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/bundle/extract"
 	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/bundle/validate"
 	"github.com/spf13/cobra"
 )
@@ -11,6 +13,8 @@ func Cmd() *cobra.Command {
 		Short: "Run a bundle subcommand.",
 	}
 
+	cmd.AddCommand(diff.Cmd())
+	cmd.AddCommand(extract.Cmd())
 	cmd.AddCommand(validate.Cmd())
 
 	return cmd
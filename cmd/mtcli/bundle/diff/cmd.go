@@ -0,0 +1,137 @@
+package diff
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	"github.com/mt-sre/addon-metadata-operator/pkg/extractor"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/spf13/cobra"
+)
+
+const long = `Extract the bundles of two index images, e.g. the stage and production
+index images of an addon, and report the bundles which were added or
+removed, whose channels or default channel changed and whose bundle image
+changed. Bundles are matched by name and bundle images are compared as
+referenced by the index images, so a changed digest shows as a changed image.`
+
+func examples() string {
+	return strings.Join([]string{
+		"  # Review the promotion of an addon from stage to production.",
+		"  mtcli bundle diff --package reference-addon <production_index_image> <stage_index_image>",
+	}, "\n")
+}
+
+func Cmd() *cobra.Command {
+	var opts options
+
+	cmd := &cobra.Command{
+		Use:           "diff <old_index_image> <new_index_image>",
+		Short:         "Compare the bundles of two index images.",
+		Long:          long,
+		Example:       examples(),
+		Args:          cobra.ExactArgs(2),
+		RunE:          run(&opts),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	opts.AddPackageFlag(cmd.Flags())
+
+	return cmd
+}
+
+func run(opts *options) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		ext := extractor.New()
+
+		olds, err := extractBundles(cmd.Context(), ext, args[0], opts.Package)
+		if err != nil {
+			return err
+		}
+
+		news, err := extractBundles(cmd.Context(), ext, args[1], opts.Package)
+		if err != nil {
+			return err
+		}
+
+		printDiff(cmd.OutOrStdout(), operator.DiffBundles(olds, news))
+
+		return nil
+	}
+}
+
+func extractBundles(ctx context.Context, ext *extractor.MainExtractor, indexImage, pkgName string) ([]operator.Bundle, error) {
+	var (
+		bundles []operator.Bundle
+		err     error
+	)
+
+	if pkgName == "" {
+		bundles, err = ext.ExtractAllBundles(ctx, indexImage)
+	} else {
+		bundles, err = ext.ExtractBundles(ctx, indexImage, pkgName)
+	}
+
+	if err != nil {
+		return nil, cli.InfrastructureError(fmt.Errorf("extracting and parsing bundles from index image %q: %w", indexImage, err))
+	}
+
+	return bundles, nil
+}
+
+func printDiff(out io.Writer, diff operator.BundleDiff) {
+	if diff.Empty() {
+		fmt.Fprintln(out, "No differences.")
+
+		return
+	}
+
+	if len(diff.Added) > 0 {
+		fmt.Fprintln(out, "Added bundles:")
+
+		for _, b := range diff.Added {
+			fmt.Fprintf(out, "  + %s (%s)\n", b.Name, b.BundleImage)
+		}
+	}
+
+	if len(diff.Removed) > 0 {
+		fmt.Fprintln(out, "Removed bundles:")
+
+		for _, b := range diff.Removed {
+			fmt.Fprintf(out, "  - %s (%s)\n", b.Name, b.BundleImage)
+		}
+	}
+
+	if len(diff.ChannelChanges) > 0 {
+		fmt.Fprintln(out, "Changed channels:")
+
+		for _, c := range diff.ChannelChanges {
+			fmt.Fprintf(out, "  ~ %s: %s -> %s\n", c.Bundle,
+				formatChannels(c.OldChannels, c.OldDefaultChannel),
+				formatChannels(c.NewChannels, c.NewDefaultChannel),
+			)
+		}
+	}
+
+	if len(diff.ImageChanges) > 0 {
+		fmt.Fprintln(out, "Changed images:")
+
+		for _, c := range diff.ImageChanges {
+			fmt.Fprintf(out, "  ~ %s: %s -> %s\n", c.Bundle, c.OldImage, c.NewImage)
+		}
+	}
+}
+
+func formatChannels(channels []string, defaultChannel string) string {
+	res := "[" + strings.Join(channels, ", ") + "]"
+
+	if defaultChannel != "" {
+		res += fmt.Sprintf(" (default %s)", defaultChannel)
+	}
+
+	return res
+}
@@ -0,0 +1,18 @@
+package diff
+
+import (
+	"github.com/spf13/pflag"
+)
+
+type options struct {
+	Package string
+}
+
+func (o *options) AddPackageFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Package,
+		"package",
+		o.Package,
+		"Only compare the bundles of the given operator package instead of all packages.",
+	)
+}
@@ -0,0 +1,93 @@
+package extract
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	"github.com/mt-sre/addon-metadata-operator/pkg/extractor"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/spf13/cobra"
+)
+
+const long = `Extract the bundles of an index image and write their unpacked manifests
+and annotations to a directory tree, one directory per package and bundle.
+Each bundle directory uses the on-disk bundle format, so it can be inspected
+offline or passed to other tools such as 'mtcli bundle validate'.`
+
+func examples() string {
+	return strings.Join([]string{
+		"  # Write all bundles of an index image to ./bundles.",
+		"  mtcli bundle extract --to-dir ./bundles <index_image>",
+		"  # Only write the bundles of a single package.",
+		"  mtcli bundle extract --to-dir ./bundles --package reference-addon <index_image>",
+	}, "\n")
+}
+
+func Cmd() *cobra.Command {
+	var opts options
+
+	cmd := &cobra.Command{
+		Use:           "extract <index_image>",
+		Short:         "Write the bundles of an index image to disk.",
+		Long:          long,
+		Example:       examples(),
+		Args:          cobra.ExactArgs(1),
+		RunE:          run(&opts),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	flags := cmd.Flags()
+
+	opts.AddToDirFlag(flags)
+	opts.AddPackageFlag(flags)
+
+	return cmd
+}
+
+func run(opts *options) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := opts.VerifyFlags(); err != nil {
+			return cli.UsageError(fmt.Errorf("verifying flags: %w", err))
+		}
+
+		indexImage := args[0]
+		ext := extractor.New()
+
+		var (
+			bundles []operator.Bundle
+			err     error
+		)
+
+		if opts.Package == "" {
+			bundles, err = ext.ExtractAllBundles(cmd.Context(), indexImage)
+		} else {
+			bundles, err = ext.ExtractBundles(cmd.Context(), indexImage, opts.Package)
+		}
+
+		if err != nil {
+			return cli.InfrastructureError(fmt.Errorf("extracting and parsing bundles from index image %q: %w", indexImage, err))
+		}
+
+		out := cmd.OutOrStdout()
+
+		for _, b := range bundles {
+			pkg := b.Package
+			if pkg == "" {
+				pkg = b.Annotations.PackageName
+			}
+
+			dir := filepath.Join(opts.ToDir, pkg, b.Name)
+
+			if err := b.WriteToDirectory(dir); err != nil {
+				return fmt.Errorf("writing bundle %q: %w", b.Name, err)
+			}
+
+			fmt.Fprintln(out, dir)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,38 @@
+package extract
+
+import (
+	"errors"
+
+	"github.com/spf13/pflag"
+)
+
+type options struct {
+	ToDir   string
+	Package string
+}
+
+func (o *options) AddToDirFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.ToDir,
+		"to-dir",
+		o.ToDir,
+		"Directory the bundles are written to as '<package>/<bundle>/{manifests,metadata}'.",
+	)
+}
+
+func (o *options) AddPackageFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Package,
+		"package",
+		o.Package,
+		"Only extract the bundles of the given operator package instead of all packages.",
+	)
+}
+
+func (o *options) VerifyFlags() error {
+	if o.ToDir == "" {
+		return errors.New("'--to-dir' is required")
+	}
+
+	return nil
+}
@@ -2,7 +2,6 @@ package completion
 
 import (
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -27,16 +26,27 @@ var (
 		"  source <(mtcli completion zsh)",
 		"  # Set the mtcli completion code for zsh to autoload on startup",
 		"  mtcli completion zsh > ${fpath[1]}/_mtcli",
+		"",
+		"  # Load the mtcli completion code for fish into the current shell",
+		"  mtcli completion fish | source",
+		"  # Set the mtcli completion code for fish to autoload on startup",
+		"  mtcli completion fish > ~/.config/fish/completions/mtcli.fish",
+		"",
+		"  # Load the mtcli completion code for PowerShell into the current shell",
+		"  mtcli completion powershell | Out-String | Invoke-Expression",
+		"  # Set the mtcli completion code for PowerShell to load on startup",
+		"  mtcli completion powershell >> $PROFILE",
 	}
 )
 
 func Cmd() *cobra.Command {
 	return &cobra.Command{
 		Use:       "completion SHELL",
-		Short:     "Output shell completion code for the specified shell (bash or zsh)",
+		Short:     "Output shell completion code for the specified shell (bash, zsh, fish or powershell)",
 		Example:   strings.Join(completionExample, "\n"),
+		Args:      cobra.ExactArgs(1),
 		RunE:      run,
-		ValidArgs: []string{"zsh", "bash"},
+		ValidArgs: []string{"zsh", "bash", "fish", "powershell"},
 	}
 }
 
@@ -45,16 +55,29 @@ func run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("parsing arguments: %w", err)
 	}
 
+	out := cmd.OutOrStdout()
+
+	// The V2 bash and the zsh, fish and powershell completions
+	// call back into mtcli which supports dynamic completions
+	// such as validator codes.
 	shell := args[0]
 	switch shell {
 	case "bash":
-		if err := cmd.Root().GenBashCompletion(os.Stdout); err != nil {
+		if err := cmd.Root().GenBashCompletionV2(out, true); err != nil {
 			return fmt.Errorf("generating bash completions: %w", err)
 		}
 	case "zsh":
-		if err := cmd.Root().GenZshCompletion(os.Stdout); err != nil {
+		if err := cmd.Root().GenZshCompletion(out); err != nil {
 			return fmt.Errorf("generating zsh completions: %w", err)
 		}
+	case "fish":
+		if err := cmd.Root().GenFishCompletion(out, true); err != nil {
+			return fmt.Errorf("generating fish completions: %w", err)
+		}
+	case "powershell":
+		if err := cmd.Root().GenPowerShellCompletionWithDesc(out); err != nil {
+			return fmt.Errorf("generating powershell completions: %w", err)
+		}
 	}
 
 	return nil
@@ -0,0 +1,17 @@
+package describe
+
+import (
+	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/describe/validator"
+	"github.com/spf13/cobra"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "describe [command]",
+		Short: "Run a describe subcommand.",
+	}
+
+	cmd.AddCommand(validator.Cmd())
+
+	return cmd
+}
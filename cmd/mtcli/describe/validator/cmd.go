@@ -0,0 +1,127 @@
+package validator
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	pkgvalidator "github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/register"
+	"github.com/spf13/cobra"
+)
+
+func examples() string {
+	return strings.Join([]string{
+		"  # Describe the validator checking the addon label.",
+		"  mtcli describe validator AM0002",
+	}, "\n")
+}
+
+func Cmd() *cobra.Command {
+	return &cobra.Command{
+		Use:               "validator <code>",
+		Short:             "Describe a registered validator and how to resolve its failures.",
+		Example:           examples(),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeCode,
+		RunE:              run,
+		SilenceErrors:     true,
+		SilenceUsage:      true,
+	}
+}
+
+func completeCode(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cli.CompleteValidatorCodes(cmd, args, toComplete)
+}
+
+func run(cmd *cobra.Command, args []string) error {
+	code, err := pkgvalidator.ParseCode(args[0])
+	if err != nil {
+		return cli.UsageError(fmt.Errorf("parsing validator code: %w", err))
+	}
+
+	runner, err := pkgvalidator.NewRunner(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("listing validators: %w", err)
+	}
+
+	for _, v := range runner.GetValidators() {
+		if v.Code() == code {
+			describe(cmd.OutOrStdout(), v)
+
+			return nil
+		}
+	}
+
+	if v, ok := runner.ReplacementOf(code); ok {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s is deprecated and replaced by %s.\n\n", code, v.Code())
+		describe(cmd.OutOrStdout(), v)
+
+		return nil
+	}
+
+	return cli.UsageError(fmt.Errorf("no validator is registered with code %s", code))
+}
+
+func describe(out io.Writer, v pkgvalidator.Validator) {
+	docs := pkgvalidator.DocsOf(v)
+
+	envs := docs.Envs
+	if len(envs) == 0 {
+		envs = cli.Envs
+	}
+
+	_, fixable := v.(pkgvalidator.Fixer)
+
+	fmt.Fprintf(out, "Code:         %s\n", v.Code())
+	fmt.Fprintf(out, "Name:         %s\n", v.Name())
+	fmt.Fprintf(out, "Description:  %s\n", v.Description())
+	fmt.Fprintf(out, "Severity:     %s\n", docs.EffectiveSeverity())
+	fmt.Fprintf(out, "Environments: %s\n", strings.Join(envs, ", "))
+	fmt.Fprintf(out, "Fixable:      %t\n", fixable)
+
+	if len(docs.Tags) > 0 {
+		fmt.Fprintf(out, "Tags:         %s\n", strings.Join(docs.Tags, ", "))
+	}
+
+	if len(docs.DependsOn) > 0 {
+		deps := make([]string, 0, len(docs.DependsOn))
+		for _, code := range docs.DependsOn {
+			deps = append(deps, code.String())
+		}
+
+		fmt.Fprintf(out, "Depends on:   %s\n", strings.Join(deps, ", "))
+	}
+
+	if len(docs.Replaces) > 0 {
+		replaced := make([]string, 0, len(docs.Replaces))
+		for _, code := range docs.Replaces {
+			replaced = append(replaced, code.String())
+		}
+
+		fmt.Fprintf(out, "Replaces:     %s\n", strings.Join(replaced, ", "))
+	}
+
+	if docs.Details != "" {
+		fmt.Fprintf(out, "\nDetails:\n  %s\n", docs.Details)
+	}
+
+	if len(docs.ExampleFailures) > 0 {
+		fmt.Fprintln(out, "\nExample failures:")
+
+		for _, msg := range docs.ExampleFailures {
+			fmt.Fprintf(out, "  - %s\n", strings.ReplaceAll(msg, "\n", "\n    "))
+		}
+	}
+
+	if docs.Remediation != "" {
+		fmt.Fprintf(out, "\nRemediation:\n  %s\n", docs.Remediation)
+	}
+
+	fmt.Fprintf(out, "\nDocumentation:\n  %s\n", docs.EffectiveDocsURL(v.Code()))
+}
@@ -0,0 +1,224 @@
+package doctor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	"github.com/mt-sre/addon-metadata-operator/pkg/httputil"
+)
+
+// status is the outcome of a single check.
+type status string
+
+const (
+	statusOK      status = "ok"
+	statusWarning status = "warning"
+	statusFailed  status = "failed"
+)
+
+// result is the outcome of a check and, unless it is ok,
+// an actionable hint how to resolve it.
+type result struct {
+	Check  string `json:"check"`
+	Status status `json:"status"`
+	Detail string `json:"detail"`
+}
+
+func ok(detail string, args ...interface{}) result {
+	return result{Status: statusOK, Detail: fmt.Sprintf(detail, args...)}
+}
+
+func warning(detail string, args ...interface{}) result {
+	return result{Status: statusWarning, Detail: fmt.Sprintf(detail, args...)}
+}
+
+func failed(detail string, args ...interface{}) result {
+	return result{Status: statusFailed, Detail: fmt.Sprintf(detail, args...)}
+}
+
+// check verifies a single runtime prerequisite of mtcli.
+type check struct {
+	Name string
+	Run  func(context.Context, *options) result
+}
+
+// checkTimeout limits the duration of checks contacting remote services.
+const checkTimeout = 10 * time.Second
+
+var checks = []check{
+	{Name: "registry", Run: checkRegistry},
+	{Name: "registry credentials", Run: checkRegistryCredentials},
+	{Name: "temp dir", Run: checkTempDir},
+	{Name: "ocm credentials", Run: checkOCMCredentials},
+	{Name: "git", Run: checkBinary("git", "'validate --changed-since' and 'validate repo --changed-since'")},
+	{Name: "preflight", Run: checkBinary("preflight", "'validate --preflight'")},
+}
+
+func checkRegistry(ctx context.Context, opts *options) result {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	url := "https://" + opts.Registry + "/v2/"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return failed("creating request: %v", err)
+	}
+
+	res, err := httputil.NewClient(httputil.WithMaxRetries(-1)).Do(req)
+	if err != nil {
+		return failed("%s is unreachable: %v; check the network connection and proxy settings (e.g. '--http-proxy')", opts.Registry, err)
+	}
+
+	defer res.Body.Close()
+
+	// the API root requires authentication on most registries
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusUnauthorized {
+		return failed("%s responded with '%s'; check the registry status page", url, res.Status)
+	}
+
+	return ok("%s is reachable", opts.Registry)
+}
+
+type dockerConfig struct {
+	Auths       map[string]json.RawMessage `json:"auths"`
+	CredHelpers map[string]string          `json:"credHelpers"`
+	CredsStore  string                     `json:"credsStore"`
+}
+
+func checkRegistryCredentials(_ context.Context, opts *options) result {
+	const hint = "private index and bundle images can not be pulled; run 'docker login %s' if required"
+
+	path, err := dockerConfigPath()
+	if err != nil {
+		return warning("locating docker config: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return warning("%s does not exist; "+hint, path, opts.Registry)
+	} else if err != nil {
+		return warning("reading %s: %v", path, err)
+	}
+
+	var cfg dockerConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return failed("%s is not valid JSON: %v; fix or remove it", path, err)
+	}
+
+	for host := range cfg.Auths {
+		if strings.TrimPrefix(strings.TrimPrefix(host, "https://"), "http://") == opts.Registry {
+			return ok("found in %s", path)
+		}
+	}
+
+	if helper, found := cfg.CredHelpers[opts.Registry]; found {
+		return ok("provided by credential helper '%s'", helper)
+	}
+
+	if cfg.CredsStore != "" {
+		return ok("may be provided by credential store '%s'", cfg.CredsStore)
+	}
+
+	return warning("none for %s in %s; "+hint, opts.Registry, path, opts.Registry)
+}
+
+func dockerConfigPath() (string, error) {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return filepath.Join(dir, "config.json"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".docker", "config.json"), nil
+}
+
+// minFreeSpace is the space recommended for
+// unpacking index and bundle images.
+const minFreeSpace = 1 << 30
+
+func checkTempDir(_ context.Context, _ *options) result {
+	const hint = "bundles are extracted to it; set TMPDIR to another directory"
+
+	dir := os.TempDir()
+
+	tmp, err := os.MkdirTemp(dir, "mtcli-doctor-")
+	if err != nil {
+		return failed("%s is not writable: %v; "+hint, dir, err)
+	}
+
+	_ = os.RemoveAll(tmp)
+
+	free, err := freeSpace(dir)
+	if err != nil {
+		return warning("%s is writable, but its free space is unknown: %v", dir, err)
+	}
+
+	if free < minFreeSpace {
+		return warning("only %d MiB free in %s; "+hint, free>>20, dir)
+	}
+
+	return ok("%s is writable with %d MiB free", dir, free>>20)
+}
+
+func checkOCMCredentials(ctx context.Context, opts *options) result {
+	if !cli.HasOCMCredentials() {
+		return warning("not set; validators querying OCM fail unless OCM_TOKEN or OCM_CLIENT_ID and OCM_CLIENT_SECRET are set")
+	}
+
+	client, err := cli.NewOCMClient(opts.Env)
+	if err != nil {
+		return failed("%v; check OCM_TOKEN or OCM_CLIENT_ID and OCM_CLIENT_SECRET", err)
+	}
+
+	defer func() { _ = client.CloseConnection() }()
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	if _, err := client.QuotaRuleExists(ctx, "mtcli-doctor"); err != nil {
+		return failed("rejected by the %s OCM API: %v; refresh the token (e.g. 'ocm token')", opts.Env, err)
+	}
+
+	return ok("valid for the %s OCM API", opts.Env)
+}
+
+// checkBinary returns a check for an optional binary
+// required by the given features.
+func checkBinary(name, features string) func(context.Context, *options) result {
+	return func(context.Context, *options) result {
+		path, err := exec.LookPath(name)
+		if err != nil {
+			return warning("not found in PATH; install it to use %s", features)
+		}
+
+		return ok("found at %s", path)
+	}
+}
+
+// runChecks runs all checks in order.
+func runChecks(ctx context.Context, opts *options) []result {
+	res := make([]result, 0, len(checks))
+
+	for _, c := range checks {
+		r := c.Run(ctx, opts)
+		r.Check = c.Name
+
+		res = append(res, r)
+	}
+
+	return res
+}
@@ -0,0 +1,141 @@
+package doctor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+const long = `Check the runtime prerequisites of mtcli and print how to resolve the issues
+found: connectivity of and credentials for the image registry, a writable temp
+dir with enough free space for extracting bundles, valid OCM credentials and
+optional binaries such as git or preflight.
+
+Checks which only affect some features are reported as warnings. The command
+fails if any check failed.`
+
+func examples() string {
+	return strings.Join([]string{
+		"  # Check all prerequisites.",
+		"  mtcli doctor",
+		"  # Check the OCM credentials against production and print the results as JSON.",
+		"  mtcli doctor --env production --output json",
+	}, "\n")
+}
+
+func Cmd() *cobra.Command {
+	opts := &options{
+		Env:      "stage",
+		Registry: "quay.io",
+		Output:   outputTable,
+	}
+
+	cmd := &cobra.Command{
+		Use:           "doctor",
+		Short:         "Check the runtime prerequisites of mtcli.",
+		Long:          long,
+		Example:       examples(),
+		Args:          cobra.NoArgs,
+		RunE:          run(opts),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	flags := cmd.Flags()
+
+	opts.AddEnvFlag(flags)
+	opts.AddRegistryFlag(flags)
+	opts.AddOutputFlag(flags)
+
+	cli.RegisterFlagCompletions(cmd, cli.FlagCompletions{
+		"env": cli.CompleteEnvs,
+	})
+
+	return cmd
+}
+
+var ErrChecksFailed = errors.New("checks failed")
+
+func run(opts *options) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := opts.VerifyFlags(); err != nil {
+			return cli.UsageError(fmt.Errorf("verifying flags: %w", err))
+		}
+
+		results := runChecks(cmd.Context(), opts)
+
+		var err error
+
+		if opts.Output == outputJSON {
+			err = printJSON(cmd.OutOrStdout(), results)
+		} else {
+			err = printTable(cmd.OutOrStdout(), results)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		var numFailed int
+
+		for _, r := range results {
+			if r.Status == statusFailed {
+				numFailed++
+			}
+		}
+
+		if numFailed > 0 {
+			return fmt.Errorf("%w: %d", ErrChecksFailed, numFailed)
+		}
+
+		return nil
+	}
+}
+
+func printJSON(out io.Writer, results []result) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(results); err != nil {
+		return fmt.Errorf("encoding results: %w", err)
+	}
+
+	return nil
+}
+
+func printTable(out io.Writer, results []result) error {
+	table, err := cli.NewTable(
+		cli.WithHeaders{"CHECK", "STATUS", "DETAIL"},
+	)
+	if err != nil {
+		return fmt.Errorf("initializing table: %w", err)
+	}
+
+	for _, r := range results {
+		status := cli.Field{Value: string(r.Status)}
+
+		switch r.Status {
+		case statusOK:
+			status.Color = cli.FieldColorGreen
+		case statusWarning:
+			status.Color = cli.FieldColorYellow
+		case statusFailed:
+			status.Color = cli.FieldColorIntenselyBoldRed
+		}
+
+		table.WriteRow(cli.TableRow{
+			cli.Field{Value: r.Check},
+			status,
+			cli.Field{Value: r.Detail},
+		})
+	}
+
+	fmt.Fprintln(out, table.String())
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package doctor
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	"github.com/spf13/pflag"
+)
+
+type options struct {
+	Env      string
+	Registry string
+	Output   string
+}
+
+func (o *options) AddEnvFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Env,
+		"env",
+		o.Env,
+		"Environment whose OCM API the credentials are checked against; integration, stage or production.",
+	)
+}
+
+func (o *options) AddRegistryFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Registry,
+		"registry",
+		o.Registry,
+		"Host of the registry index and bundle images are pulled from.",
+	)
+}
+
+func (o *options) AddOutputFlag(flags *pflag.FlagSet) {
+	flags.StringVarP(
+		&o.Output,
+		"output",
+		"o",
+		o.Output,
+		"Output format; 'table' or 'json'.",
+	)
+}
+
+func (o *options) VerifyFlags() error {
+	if !slices.Contains(cli.Envs, o.Env) {
+		return fmt.Errorf("'%s' is not a valid environment; must be one of 'integration', 'stage' or 'production'", o.Env)
+	}
+
+	if o.Registry == "" {
+		return fmt.Errorf("'--registry' must not be empty")
+	}
+
+	if o.Output != outputTable && o.Output != outputJSON {
+		return fmt.Errorf("'%s' is not a valid output format; must be one of '%s' or '%s'", o.Output, outputTable, outputJSON)
+	}
+
+	return nil
+}
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
@@ -0,0 +1,11 @@
+//go:build !linux && !darwin
+
+package doctor
+
+import "errors"
+
+var errFreeSpaceUnsupported = errors.New("free space can not be determined on this platform")
+
+func freeSpace(string) (uint64, error) {
+	return 0, errFreeSpaceUnsupported
+}
@@ -0,0 +1,149 @@
+package fix
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	"github.com/mt-sre/addon-metadata-operator/pkg/metadata"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	pkgvalidate "github.com/mt-sre/addon-metadata-operator/pkg/validate"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/spf13/cobra"
+)
+
+const long = `Apply the fixes of all validators whose failures can be remediated
+mechanically, e.g. a label not following 'api.openshift.com/addon-<id>' or an
+icon which is not standard base64 encoded, to the metadata of a local addon
+directory. Each fix is written to the file defining the fixed field keeping
+its formatting and comments. No bundles are extracted; run 'mtcli validate'
+afterwards to check for remaining failures.`
+
+func examples() string {
+	return strings.Join([]string{
+		"  # Fix the staging metadata of an addon in place.",
+		"  mtcli fix --env stage <path/to/addon_dir>",
+		"  # List the available fixes and fail if there are any without applying them (e.g. in CI).",
+		"  mtcli fix --env stage --check <path/to/addon_dir>",
+		"  # Only apply the fixes of AM0002.",
+		"  mtcli fix --env stage --enabled AM0002 <path/to/addon_dir>",
+	}, "\n")
+}
+
+func Cmd() *cobra.Command {
+	opts := &options{
+		Env: "stage",
+	}
+
+	cmd := &cobra.Command{
+		Use:           "fix",
+		Short:         "Fix addon metadata failing validators which support it.",
+		Long:          long,
+		Example:       examples(),
+		Args:          cobra.ExactArgs(1),
+		RunE:          run(opts),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	flags := cmd.Flags()
+
+	opts.AddEnvFlag(flags)
+	opts.AddDisabledFlag(flags)
+	opts.AddEnabledFlag(flags)
+	opts.AddEnabledTagsFlag(flags)
+	opts.AddDisabledTagsFlag(flags)
+	opts.AddCheckFlag(flags)
+
+	cli.RegisterFlagCompletions(cmd, cli.FlagCompletions{
+		"env":           cli.CompleteEnvs,
+		"enabled":       cli.CompleteValidatorCodes,
+		"disabled":      cli.CompleteValidatorCodes,
+		"enabled-tags":  cli.CompleteValidatorTags,
+		"disabled-tags": cli.CompleteValidatorTags,
+	})
+
+	return cmd
+}
+
+var ErrFixable = errors.New("fixable failures found")
+
+func run(opts *options) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := opts.VerifyFlags(); err != nil {
+			return cli.UsageError(fmt.Errorf("verifying flags: %w", err))
+		}
+
+		addonDir := args[0]
+
+		if metadata.IsRemote(addonDir) {
+			return cli.UsageError(fmt.Errorf("%q is remote; only local addon dirs can be fixed", addonDir))
+		}
+
+		runner, err := validator.NewRunner(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("initializing validators: %w", err)
+		}
+
+		filter, err := cli.ValidatorFilter(cmd.Context(), runner, opts.Disabled, opts.Enabled)
+		if err != nil {
+			return cli.UsageError(fmt.Errorf("generating validator filter: %w", err))
+		}
+
+		tagFilter, err := cli.TagFilter(opts.DisabledTags, opts.EnabledTags)
+		if err != nil {
+			return cli.UsageError(fmt.Errorf("generating validator filter: %w", err))
+		}
+
+		filter = validator.All(filter, tagFilter)
+
+		loader := metadata.NewLoader(addonDir, metadata.WithEnv(opts.Env))
+
+		mb, err := loader.LoadMetaBundle(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("loading addon metadata from '%s': %w", addonDir, err)
+		}
+
+		fixes, err := pkgvalidate.Fixes(cmd.Context(), *mb,
+			pkgvalidate.WithRunner{Runner: runner},
+			pkgvalidate.WithFilters{filter, validator.EnabledForEnv(opts.Env)},
+		)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+
+		var all []types.Fix
+
+		for _, vf := range fixes {
+			for _, fix := range vf.Fixes {
+				file := metadata.FixFile(mb.Provenance, loader.MetadataPath(), fix)
+
+				fmt.Fprintf(out, "%s %s: %s: %s\n", vf.Code, vf.Name, file, fix.Description)
+			}
+
+			all = append(all, vf.Fixes...)
+		}
+
+		if len(all) == 0 {
+			fmt.Fprintln(out, "Nothing to fix.")
+
+			return nil
+		}
+
+		if opts.Check {
+			return fmt.Errorf("%w: %d", ErrFixable, len(all))
+		}
+
+		files, err := metadata.ApplyFixes(addonDir, mb.Provenance, loader.MetadataPath(), all...)
+		if err != nil {
+			return fmt.Errorf("applying fixes: %w", err)
+		}
+
+		fmt.Fprintf(out, "Applied %d fix(es) to %s.\n", len(all), strings.Join(files, ", "))
+
+		return nil
+	}
+}
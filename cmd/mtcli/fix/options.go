@@ -0,0 +1,87 @@
+package fix
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/spf13/pflag"
+)
+
+type options struct {
+	Env          string
+	Disabled     string
+	Enabled      string
+	EnabledTags  []string
+	DisabledTags []string
+	Check        bool
+}
+
+func (o *options) AddEnvFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Env,
+		"env",
+		o.Env,
+		"integration, stage or production",
+	)
+}
+
+func (o *options) AddDisabledFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Disabled,
+		"disabled",
+		o.Disabled,
+		"Do not apply the fixes of specific validators by code or code prefix (e.g. 'OSD'), separated by ','. Can't be combined with --enabled.",
+	)
+}
+
+func (o *options) AddEnabledFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Enabled,
+		"enabled",
+		o.Enabled,
+		"Only apply the fixes of specific validators by code or code prefix (e.g. 'OSD'), separated by ','. Can't be combined with --disabled.",
+	)
+}
+
+func (o *options) AddEnabledTagsFlag(flags *pflag.FlagSet) {
+	flags.StringSliceVar(
+		&o.EnabledTags,
+		"enabled-tags",
+		o.EnabledTags,
+		fmt.Sprintf("Only run validators with any of the given tags, separated by ','; any of %s.", strings.Join(validator.Tags, ", ")),
+	)
+}
+
+func (o *options) AddDisabledTagsFlag(flags *pflag.FlagSet) {
+	flags.StringSliceVar(
+		&o.DisabledTags,
+		"disabled-tags",
+		o.DisabledTags,
+		"Skip validators with any of the given tags, separated by ','. Takes precedence over '--enabled-tags'.",
+	)
+}
+
+func (o *options) AddCheckFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&o.Check,
+		"check",
+		o.Check,
+		"List the fixes and fail if there are any instead of applying them.",
+	)
+}
+
+func (o *options) VerifyFlags() error {
+	switch o.Env {
+	case "integration", "stage", "production":
+	default:
+		return fmt.Errorf("'%s' is not a valid environment; must be one of 'integration', 'stage' or 'production'", o.Env)
+	}
+
+	if o.Disabled != "" && o.Enabled != "" {
+		return errors.New("'--disabled' and '--enabled' are mutually exclusive options")
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package fmt
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/metadata"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const long = `Canonicalize the metadata and imageset files of an addon directory.
+Keys are ordered as declared by the API types, map keys and related images
+are sorted, base64 encoded icons are unwrapped and indentation is normalized.
+Comments are kept. The paths of all reformatted files are printed.`
+
+func examples() string {
+	return strings.Join([]string{
+		"  # Format all metadata and imageset files of an addon in place.",
+		"  mtcli fmt <path/to/addon_dir>",
+		"  # Fail if any file is not formatted without modifying it (e.g. in CI).",
+		"  mtcli fmt --check <path/to/addon_dir>",
+	}, "\n")
+}
+
+func Cmd() *cobra.Command {
+	opts := &options{}
+
+	cmd := &cobra.Command{
+		Use:           "fmt",
+		Short:         "Format addon metadata and imageset files.",
+		Long:          long,
+		Example:       examples(),
+		Args:          cobra.ExactArgs(1),
+		RunE:          run(opts),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	opts.AddCheckFlag(cmd.Flags())
+
+	return cmd
+}
+
+type options struct {
+	Check bool
+}
+
+func (o *options) AddCheckFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&o.Check,
+		"check",
+		o.Check,
+		"List files which are not formatted and fail instead of rewriting them.",
+	)
+}
+
+var ErrNotFormatted = errors.New("files are not formatted")
+
+func run(opts *options) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		unformatted, err := metadata.FormatDir(args[0], !opts.Check)
+		if err != nil {
+			return fmt.Errorf("formatting addon dir %q: %w", args[0], err)
+		}
+
+		out := cmd.OutOrStdout()
+
+		for _, name := range unformatted {
+			fmt.Fprintln(out, name)
+		}
+
+		if opts.Check && len(unformatted) > 0 {
+			return ErrNotFormatted
+		}
+
+		return nil
+	}
+}
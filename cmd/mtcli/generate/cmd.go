@@ -0,0 +1,19 @@
+package generate
+
+import (
+	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/generate/imageset"
+	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/generate/schema"
+	"github.com/spf13/cobra"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "generate [command]",
+		Short: "Run a generate subcommand.",
+	}
+
+	cmd.AddCommand(imageset.Cmd())
+	cmd.AddCommand(schema.Cmd())
+
+	return cmd
+}
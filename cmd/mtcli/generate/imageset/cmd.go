@@ -0,0 +1,116 @@
+package imageset
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	"github.com/mt-sre/addon-metadata-operator/pkg/extractor"
+	"github.com/mt-sre/addon-metadata-operator/pkg/metadata"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/spf13/cobra"
+)
+
+const long = `Generate an AddonImageSet resource of the given version from the addon
+metadata of an environment. The imageset takes its index image, parameters,
+requirements, sub operators, config, pull secret and additional catalog
+sources from the addon metadata, e.g. when moving a legacy addon to imagesets.`
+
+func examples() string {
+	return strings.Join([]string{
+		"  # Generate the AddonImageSet for version 1.2.3 of a staging addon.",
+		"  mtcli generate imageset --env stage --version 1.2.3 <path/to/addon_dir>",
+		"  # Use another index image and the related images of its head bundle.",
+		"  mtcli generate imageset --version 1.2.3 --index-image <index_image> --related-images-from-csv <path/to/addon_dir>",
+	}, "\n")
+}
+
+func Cmd() *cobra.Command {
+	opts := &options{
+		Env: "stage",
+	}
+
+	cmd := &cobra.Command{
+		Use:           "imageset <addon_dir>",
+		Short:         "Generate an AddonImageSet resource from addon metadata.",
+		Long:          long,
+		Example:       examples(),
+		Args:          cobra.ExactArgs(1),
+		RunE:          run(opts),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	flags := cmd.Flags()
+
+	opts.AddEnvFlag(flags)
+	opts.AddVersionFlag(flags)
+	opts.AddIndexImageFlag(flags)
+	opts.AddNamespaceFlag(flags)
+	opts.AddRelatedImagesFromCSVFlag(flags)
+
+	cli.RegisterFlagCompletions(cmd, cli.FlagCompletions{
+		"env": cli.CompleteEnvs,
+	})
+
+	return cmd
+}
+
+var (
+	ErrNoBundles    = errors.New("no bundles found")
+	ErrNoIndexImage = errors.New("addon metadata has no 'indexImage'")
+)
+
+func run(opts *options) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := opts.VerifyFlags(); err != nil {
+			return cli.UsageError(fmt.Errorf("verifying flags: %w", err))
+		}
+
+		addonDir := args[0]
+
+		meta, err := metadata.NewLoader(addonDir, metadata.WithEnv(opts.Env)).LoadMetadata(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("loading addon metadata from '%s': %w", addonDir, err)
+		}
+
+		if opts.IndexImage != "" {
+			meta.IndexImage = &opts.IndexImage
+		}
+
+		if meta.IndexImage == nil {
+			return cli.UsageError(fmt.Errorf("%w; provide '--index-image' for addons using imagesets", ErrNoIndexImage))
+		}
+
+		var relatedImages []string
+
+		if opts.RelatedImagesFromCSV {
+			bundles, err := extractor.New().ExtractBundles(cmd.Context(), *meta.IndexImage, meta.OperatorName)
+			if err != nil {
+				return cli.InfrastructureError(fmt.Errorf("extracting bundles from %q: %w", *meta.IndexImage, err))
+			}
+
+			head, ok := operator.HeadBundle(bundles...)
+			if !ok {
+				return fmt.Errorf("%w for operator %q in %q", ErrNoBundles, meta.OperatorName, *meta.IndexImage)
+			}
+
+			relatedImages = head.RelatedImages()
+		}
+
+		spec, err := meta.ToImageSet(opts.Version, relatedImages)
+		if err != nil {
+			return fmt.Errorf("generating imageset: %w", err)
+		}
+
+		out, err := cli.RenderObjects(cli.NewAddonImageSet(*spec, opts.Namespace))
+		if err != nil {
+			return fmt.Errorf("rendering imageset: %w", err)
+		}
+
+		_, err = cmd.OutOrStdout().Write(out)
+
+		return err
+	}
+}
@@ -0,0 +1,81 @@
+package imageset
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/pflag"
+	"golang.org/x/mod/semver"
+)
+
+type options struct {
+	Env                  string
+	Version              string
+	IndexImage           string
+	Namespace            string
+	RelatedImagesFromCSV bool
+}
+
+func (o *options) AddEnvFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Env,
+		"env",
+		o.Env,
+		"integration, stage or production",
+	)
+}
+
+func (o *options) AddVersionFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Version,
+		"version",
+		o.Version,
+		"Version of the generated imageset matching 'MAJOR.MINOR.PATCH'.",
+	)
+}
+
+func (o *options) AddIndexImageFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.IndexImage,
+		"index-image",
+		o.IndexImage,
+		"Index image of the generated imageset; defaults to 'indexImage' of the addon metadata.",
+	)
+}
+
+func (o *options) AddNamespaceFlag(flags *pflag.FlagSet) {
+	flags.StringVarP(
+		&o.Namespace,
+		"namespace",
+		"n",
+		o.Namespace,
+		"Namespace set on the generated resource.",
+	)
+}
+
+func (o *options) AddRelatedImagesFromCSVFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&o.RelatedImagesFromCSV,
+		"related-images-from-csv",
+		o.RelatedImagesFromCSV,
+		"Populate 'relatedImages' from the CSV of the head bundle within the index image.",
+	)
+}
+
+func (o *options) VerifyFlags() error {
+	switch o.Env {
+	case "stage", "integration", "production":
+	default:
+		return fmt.Errorf("'%s' is not a valid environment; must be one of 'integration', 'stage' or 'production'", o.Env)
+	}
+
+	if o.Version == "" {
+		return errors.New("'--version' must be provided")
+	}
+
+	if !semver.IsValid(fmt.Sprintf("v%v", o.Version)) {
+		return fmt.Errorf("'%s' is not a valid version; must match 'MAJOR.MINOR.PATCH'", o.Version)
+	}
+
+	return nil
+}
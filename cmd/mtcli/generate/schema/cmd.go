@@ -0,0 +1,71 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	"github.com/mt-sre/addon-metadata-operator/pkg/metadata"
+	"github.com/spf13/cobra"
+)
+
+const long = `Print a JSON Schema of the addon metadata format ('addon.yaml') or the
+addon imageset format generated from the Go types mtcli decodes them into,
+including the OCM parameter and requirement types. Editors and pipelines
+can use it to validate and autocomplete metadata without mtcli. Like strict
+mode, unknown fields are rejected. The schema only covers the structure of
+the metadata; run 'mtcli validate' for the checks of the validators.
+
+See 'mtcli schema export' for the schemas of the formats mtcli prints.`
+
+func examples() string {
+	return strings.Join([]string{
+		"  # Generate the schema of 'addon.yaml'.",
+		"  mtcli generate schema > addon-metadata.schema.json",
+		"  # Generate the schema of addon imagesets.",
+		"  mtcli generate schema --type imageset",
+	}, "\n")
+}
+
+func Cmd() *cobra.Command {
+	opts := &options{
+		Type: typeMetadata,
+	}
+
+	cmd := &cobra.Command{
+		Use:           "schema",
+		Short:         "Generate the JSON Schema of the addon metadata format.",
+		Long:          long,
+		Example:       examples(),
+		Args:          cobra.NoArgs,
+		RunE:          run(opts),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	opts.AddTypeFlag(cmd.Flags())
+
+	return cmd
+}
+
+func run(opts *options) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := opts.VerifyFlags(); err != nil {
+			return cli.UsageError(fmt.Errorf("verifying flags: %w", err))
+		}
+
+		schemas := map[string]func() ([]byte, error){
+			typeMetadata: metadata.MetadataSchema,
+			typeImageSet: metadata.ImageSetSchema,
+		}
+
+		schema, err := schemas[opts.Type]()
+		if err != nil {
+			return fmt.Errorf("generating schema: %w", err)
+		}
+
+		_, err = cmd.OutOrStdout().Write(schema)
+
+		return err
+	}
+}
@@ -0,0 +1,34 @@
+package schema
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+)
+
+const (
+	typeMetadata = "metadata"
+	typeImageSet = "imageset"
+)
+
+type options struct {
+	Type string
+}
+
+func (o *options) AddTypeFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Type,
+		"type",
+		o.Type,
+		fmt.Sprintf("Format to generate the schema of; one of '%s' or '%s'.", typeMetadata, typeImageSet),
+	)
+}
+
+func (o *options) VerifyFlags() error {
+	switch o.Type {
+	case typeMetadata, typeImageSet:
+		return nil
+	default:
+		return fmt.Errorf("'%s' is not a valid type; must be one of '%s' or '%s'", o.Type, typeMetadata, typeImageSet)
+	}
+}
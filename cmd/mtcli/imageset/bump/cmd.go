@@ -0,0 +1,120 @@
+package bump
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	"github.com/mt-sre/addon-metadata-operator/pkg/extractor"
+	"github.com/mt-sre/addon-metadata-operator/pkg/metadata"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/spf13/cobra"
+)
+
+const long = `Create a new imageset using the next version of the latest imageset
+and pin 'addonImageSetVersion' in the addon metadata to it unless it is set to 'latest'.`
+
+func examples() string {
+	return strings.Join([]string{
+		"  # Create the next patch imageset of a staging addon using a new index image.",
+		"  mtcli imageset bump --env stage --index-image quay.io/osd-addons/reference-addon-index@sha256:<digest> <path/to/addon_dir>",
+		"  # Create the next minor imageset with the related images of the new operator version.",
+		"  mtcli imageset bump --increment minor --related-images-from-csv --index-image <index_image> <path/to/addon_dir>",
+	}, "\n")
+}
+
+func Cmd() *cobra.Command {
+	opts := &options{
+		Env:       "stage",
+		Increment: string(metadata.IncrementPatch),
+	}
+
+	cmd := &cobra.Command{
+		Use:           "bump",
+		Short:         "Create the next imageset of an addon.",
+		Long:          long,
+		Example:       examples(),
+		Args:          cobra.ExactArgs(1),
+		RunE:          run(opts),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	flags := cmd.Flags()
+
+	opts.AddEnvFlag(flags)
+	opts.AddIndexImageFlag(flags)
+	opts.AddIncrementFlag(flags)
+	opts.AddRelatedImagesFromCSVFlag(flags)
+
+	cli.RegisterFlagCompletions(cmd, cli.FlagCompletions{
+		"env": cli.CompleteEnvs,
+	})
+
+	return cmd
+}
+
+var ErrNoBundles = errors.New("no bundles found")
+
+func run(opts *options) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := opts.VerifyFlags(); err != nil {
+			return fmt.Errorf("verifying flags: %w", err)
+		}
+
+		addonDir := args[0]
+
+		bumpOpts := []metadata.BumpOption{
+			metadata.WithEnv(opts.Env),
+			metadata.WithIndexImage(opts.IndexImage),
+			metadata.WithIncrement(opts.Increment),
+		}
+
+		if opts.RelatedImagesFromCSV {
+			images, err := relatedImages(cmd.Context(), addonDir, opts)
+			if err != nil {
+				return fmt.Errorf("retrieving related images: %w", err)
+			}
+
+			bumpOpts = append(bumpOpts, metadata.WithRelatedImages(images))
+		}
+
+		res, err := metadata.BumpImageSet(addonDir, bumpOpts...)
+		if err != nil {
+			return fmt.Errorf("bumping imageset: %w", err)
+		}
+
+		out := cmd.OutOrStdout()
+
+		fmt.Fprintf(out, "Created imageset %s\n", res.ImageSetPath)
+
+		if res.MetadataPath != "" {
+			fmt.Fprintf(out, "Updated 'addonImageSetVersion' to %s in %s\n", res.Version, res.MetadataPath)
+		}
+
+		return nil
+	}
+}
+
+// relatedImages returns the sorted related images of the CSV of
+// the head bundle for the addon's operator within the index image.
+func relatedImages(ctx context.Context, addonDir string, opts *options) ([]string, error) {
+	meta, err := metadata.NewLoader(addonDir, metadata.WithEnv(opts.Env)).Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading addon metadata: %w", err)
+	}
+
+	bundles, err := extractor.New().ExtractBundles(ctx, opts.IndexImage, meta.OperatorName)
+	if err != nil {
+		return nil, fmt.Errorf("extracting bundles from %q: %w", opts.IndexImage, err)
+	}
+
+	head, ok := operator.HeadBundle(bundles...)
+	if !ok {
+		return nil, fmt.Errorf("%w for operator %q in %q", ErrNoBundles, meta.OperatorName, opts.IndexImage)
+	}
+
+	return head.RelatedImages(), nil
+}
@@ -0,0 +1,65 @@
+package bump
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/pflag"
+)
+
+type options struct {
+	Env                  string
+	IndexImage           string
+	Increment            string
+	RelatedImagesFromCSV bool
+}
+
+func (o *options) AddEnvFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Env,
+		"env",
+		o.Env,
+		"integration, stage or production",
+	)
+}
+
+func (o *options) AddIndexImageFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.IndexImage,
+		"index-image",
+		o.IndexImage,
+		"Index image of the new imageset.",
+	)
+}
+
+func (o *options) AddIncrementFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Increment,
+		"increment",
+		o.Increment,
+		"Part of the version to bump; one of major, minor or patch.",
+	)
+}
+
+func (o *options) AddRelatedImagesFromCSVFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&o.RelatedImagesFromCSV,
+		"related-images-from-csv",
+		o.RelatedImagesFromCSV,
+		"Populate 'relatedImages' from the CSV of the head bundle within the new index image.",
+	)
+}
+
+func (o *options) VerifyFlags() error {
+	switch o.Env {
+	case "stage", "integration", "production":
+	default:
+		return fmt.Errorf("'%s' is not a valid environment; must be one of 'integration', 'stage' or 'production'", o.Env)
+	}
+
+	if o.IndexImage == "" {
+		return errors.New("'--index-image' must be provided")
+	}
+
+	return nil
+}
@@ -0,0 +1,17 @@
+package imageset
+
+import (
+	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/imageset/bump"
+	"github.com/spf13/cobra"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "imageset [command]",
+		Short: "Run an imageset subcommand.",
+	}
+
+	cmd.AddCommand(bump.Cmd())
+
+	return cmd
+}
@@ -0,0 +1,57 @@
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/metadata"
+	"github.com/spf13/cobra"
+)
+
+const long = `Run fast file-level checks against the metadata and imageset files of a
+local addon directory. Files are checked against the API schema, for being
+formatted as by 'mtcli fmt' and for following the naming conventions.
+No imagesets are resolved and no bundles are extracted which makes the
+command suitable for use as a git pre-commit hook.`
+
+func examples() string {
+	return strings.Join([]string{
+		"  # Lint all metadata and imageset files of an addon.",
+		"  mtcli lint <path/to/addon_dir>",
+	}, "\n")
+}
+
+func Cmd() *cobra.Command {
+	return &cobra.Command{
+		Use:           "lint",
+		Short:         "Lint addon metadata and imageset files.",
+		Long:          long,
+		Example:       examples(),
+		Args:          cobra.ExactArgs(1),
+		RunE:          run,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+}
+
+var ErrLintIssues = errors.New("lint issues found")
+
+func run(cmd *cobra.Command, args []string) error {
+	issues, err := metadata.Lint(args[0])
+	if err != nil {
+		return fmt.Errorf("linting addon dir %q: %w", args[0], err)
+	}
+
+	out := cmd.OutOrStdout()
+
+	for _, issue := range issues {
+		fmt.Fprintln(out, issue)
+	}
+
+	if len(issues) > 0 {
+		return fmt.Errorf("%w: %d", ErrLintIssues, len(issues))
+	}
+
+	return nil
+}
@@ -1,54 +1,187 @@
 package validators
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/mt-sre/addon-metadata-operator/internal/cli"
 	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
 	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/register"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 func examples() string {
 	return strings.Join([]string{
 		"  # List all the registered validators.",
 		"  mtcli list validators",
+		"  # List the validators checking bundles as JSON.",
+		"  mtcli list validators --tag bundle --output json",
+		"  # List the validators applying to production with codes AM0010 to AM0019.",
+		"  mtcli list validators --enabled-for-env production --code-prefix AM001",
 	}, "\n")
 }
 
 func Cmd() *cobra.Command {
-	return &cobra.Command{
-		Use:     "validators",
-		Short:   "List all the registered validators.",
-		Example: examples(),
-		RunE:    run,
+	opts := &options{
+		Output: outputTable,
 	}
+
+	cmd := &cobra.Command{
+		Use:           "validators",
+		Short:         "List all the registered validators.",
+		Example:       examples(),
+		Args:          cobra.NoArgs,
+		RunE:          run(opts),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	flags := cmd.Flags()
+
+	opts.AddOutputFlag(flags)
+	opts.AddCodePrefixFlag(flags)
+	opts.AddTagFlag(flags)
+	opts.AddEnabledForEnvFlag(flags)
+
+	cli.RegisterFlagCompletions(cmd, cli.FlagCompletions{
+		"output": func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return []string{outputTable, outputJSON, outputYAML}, cobra.ShellCompDirectiveNoFileComp
+		},
+		"tag": func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return validator.Tags, cobra.ShellCompDirectiveNoFileComp
+		},
+		"enabled-for-env": cli.CompleteEnvs,
+	})
+
+	return cmd
 }
 
-func run(cmd *cobra.Command, args []string) error {
-	runner, err := validator.NewRunner()
-	if err != nil {
-		return fmt.Errorf("listing validators: %s\n", err)
+// validatorInfo describes a registered validator.
+type validatorInfo struct {
+	Code        string   `json:"code" yaml:"code"`
+	Name        string   `json:"name" yaml:"name"`
+	Description string   `json:"description" yaml:"description"`
+	Severity    string   `json:"severity" yaml:"severity"`
+	Envs        []string `json:"envs" yaml:"envs"`
+	Tags        []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Fixable     bool     `json:"fixable" yaml:"fixable"`
+}
+
+func run(opts *options) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := opts.VerifyFlags(); err != nil {
+			return cli.UsageError(fmt.Errorf("verifying flags: %w", err))
+		}
+
+		runner, err := validator.NewRunner(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("listing validators: %w", err)
+		}
+
+		infos := []validatorInfo{}
+
+		for _, v := range runner.GetValidators(filters(opts)...) {
+			infos = append(infos, newValidatorInfo(v))
+		}
+
+		out := cmd.OutOrStdout()
+
+		switch opts.Output {
+		case outputJSON:
+			return printJSON(out, infos)
+		case outputYAML:
+			return printYAML(out, infos)
+		default:
+			return printTable(out, infos)
+		}
 	}
+}
 
+func filters(opts *options) []validator.Filter {
+	var res []validator.Filter
+
+	if opts.CodePrefix != "" {
+		prefix := strings.ToUpper(opts.CodePrefix)
+
+		res = append(res, func(v validator.Validator) bool {
+			return strings.HasPrefix(v.Code().String(), prefix)
+		})
+	}
+
+	if opts.Tag != "" {
+		res = append(res, validator.MatchesTags(opts.Tag))
+	}
+
+	if opts.EnabledForEnv != "" {
+		res = append(res, validator.EnabledForEnv(opts.EnabledForEnv))
+	}
+
+	return res
+}
+
+func newValidatorInfo(v validator.Validator) validatorInfo {
+	docs := validator.DocsOf(v)
+
+	envs := docs.Envs
+	if len(envs) == 0 {
+		envs = cli.Envs
+	}
+
+	_, fixable := v.(validator.Fixer)
+
+	return validatorInfo{
+		Code:        v.Code().String(),
+		Name:        v.Name(),
+		Description: v.Description(),
+		Severity:    string(docs.EffectiveSeverity()),
+		Envs:        envs,
+		Tags:        docs.Tags,
+		Fixable:     fixable,
+	}
+}
+
+func printJSON(out io.Writer, infos []validatorInfo) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(infos); err != nil {
+		return fmt.Errorf("encoding validators: %w", err)
+	}
+
+	return nil
+}
+
+func printYAML(out io.Writer, infos []validatorInfo) error {
+	enc := yaml.NewEncoder(out)
+	enc.SetIndent(2)
+
+	if err := enc.Encode(infos); err != nil {
+		return fmt.Errorf("encoding validators: %w", err)
+	}
+
+	return enc.Close()
+}
+
+func printTable(out io.Writer, infos []validatorInfo) error {
 	table, err := cli.NewTable(
-		cli.WithHeaders{"CODE", "NAME", "DESCRIPTION"},
+		cli.WithHeaders{"CODE", "NAME", "SEVERITY", "DESCRIPTION"},
 	)
 	if err != nil {
 		return fmt.Errorf("initializing table: %w", err)
 	}
 
-	for _, v := range runner.GetValidators() {
+	for _, info := range infos {
 		table.WriteRow(cli.TableRow{
-			cli.Field{Value: v.Code().String()},
-			cli.Field{Value: v.Name()},
-			cli.Field{Value: v.Description()},
+			cli.Field{Value: info.Code},
+			cli.Field{Value: info.Name},
+			cli.Field{Value: info.Severity},
+			cli.Field{Value: info.Description},
 		})
 	}
 
-	out := cmd.OutOrStdout()
-
 	fmt.Fprintln(out, table.String())
 	fmt.Fprintln(out)
 
@@ -0,0 +1,79 @@
+package validators
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/spf13/pflag"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+type options struct {
+	Output        string
+	CodePrefix    string
+	Tag           string
+	EnabledForEnv string
+}
+
+func (o *options) AddOutputFlag(flags *pflag.FlagSet) {
+	flags.StringVarP(
+		&o.Output,
+		"output",
+		"o",
+		o.Output,
+		"Output format; 'table', 'json' or 'yaml'.",
+	)
+}
+
+func (o *options) AddCodePrefixFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.CodePrefix,
+		"code-prefix",
+		o.CodePrefix,
+		"Only list validators whose code starts with the given prefix, e.g. 'AM001'.",
+	)
+}
+
+func (o *options) AddTagFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Tag,
+		"tag",
+		o.Tag,
+		fmt.Sprintf("Only list validators with the given tag; one of %s.", strings.Join(validator.Tags, ", ")),
+	)
+}
+
+func (o *options) AddEnabledForEnvFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.EnabledForEnv,
+		"enabled-for-env",
+		o.EnabledForEnv,
+		"Only list validators which apply to the given environment; integration, stage or production.",
+	)
+}
+
+func (o *options) VerifyFlags() error {
+	switch o.Output {
+	case outputTable, outputJSON, outputYAML:
+	default:
+		return fmt.Errorf("'%s' is not a valid output format; must be one of '%s', '%s' or '%s'", o.Output, outputTable, outputJSON, outputYAML)
+	}
+
+	if o.Tag != "" && !slices.Contains(validator.Tags, o.Tag) {
+		return fmt.Errorf("'%s' is not a valid tag; must be one of %s", o.Tag, strings.Join(validator.Tags, ", "))
+	}
+
+	if o.EnabledForEnv != "" && !slices.Contains(cli.Envs, o.EnabledForEnv) {
+		return fmt.Errorf("'%s' is not a valid environment; must be one of %s", o.EnabledForEnv, strings.Join(cli.Envs, ", "))
+	}
+
+	return nil
+}
@@ -3,24 +3,70 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/url"
 	"os"
 	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
+	"github.com/go-logr/logr"
+	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/bench"
 	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/bundle"
 	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/completion"
+	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/describe"
+	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/doctor"
+	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/fix"
+	mtclifmt "github.com/mt-sre/addon-metadata-operator/cmd/mtcli/fmt"
+	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/generate"
+	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/imageset"
+	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/lint"
 	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/list"
+	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/render"
+	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/schema"
+	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/selfupdate"
+	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/serve"
 	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/validate"
 	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/version"
-	log "github.com/sirupsen/logrus"
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	"github.com/mt-sre/addon-metadata-operator/internal/logging"
+	"github.com/mt-sre/addon-metadata-operator/pkg/extractor"
+	"github.com/mt-sre/addon-metadata-operator/pkg/httputil"
+	"github.com/mt-sre/addon-metadata-operator/pkg/imagepolicy"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
-var verbose bool
+var (
+	verbose   bool
+	logFormat = string(logging.FormatText)
+	noColor   bool
+
+	httpTimeout time.Duration
+	httpProxy   string
+
+	imagePolicy string
+
+	validatorsPluginDir string
+	validatorsExecDir   string
+	validatorsWasmDir   string
+	validatorsWasmImage []string
+	// wasmValidators are closed once the command finished.
+	wasmValidators validator.WasmValidators
+
+	registryRetries = 3
+	registryBackoff = time.Second
+
+	configFile string
+)
 
 func main() {
 	code := 0
 
-	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, os.Kill)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer func() {
 		stop()
 
@@ -29,22 +75,59 @@ func main() {
 
 	rootCmd := generateRootCmd()
 
-	if err := rootCmd.ExecuteContext(ctx); err != nil {
-		fmt.Fprintln(os.Stdout, err)
+	err := rootCmd.ExecuteContext(ctx)
+
+	// releasing the runtimes of WASM validators must not change the outcome
+	_ = wasmValidators.Close()
+
+	if err != nil {
+		code = cli.ExitCode(err)
 
-		code = 1
+		// errors go to stderr to keep machine readable output on stdout intact
+		printError(os.Stderr, err, code)
 	}
 }
 
+// printError prints the error a command failed with. It is logged as a
+// record if JSON logs are requested so that log aggregators can parse
+// all output on w.
+func printError(w io.Writer, err error, code int) {
+	if format, parseErr := logging.ParseFormat(logFormat); parseErr == nil && format == logging.FormatJSON {
+		logging.New(w, format, false).Error(err, "command failed", "exitCode", code)
+
+		return
+	}
+
+	fmt.Fprintln(w, err)
+}
+
 func generateRootCmd() *cobra.Command {
 	rootCmd := &cobra.Command{
-		Use:   "mtcli",
-		Short: "Managed Tenants CLI swiss army knife.",
+		Use:               "mtcli",
+		Short:             "Managed Tenants CLI swiss army knife.",
+		PersistentPreRunE: setup,
 	}
 
+	// inherited by all subcommands
+	rootCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return cli.UsageError(err)
+	})
+
+	rootCmd.AddCommand(bench.Cmd())
 	rootCmd.AddCommand(bundle.Cmd())
 	rootCmd.AddCommand(completion.Cmd())
+	rootCmd.AddCommand(describe.Cmd())
+	rootCmd.AddCommand(doctor.Cmd())
+	rootCmd.AddCommand(fix.Cmd())
+	rootCmd.AddCommand(mtclifmt.Cmd())
+	rootCmd.AddCommand(generate.Cmd())
+	rootCmd.AddCommand(imageset.Cmd())
+	rootCmd.AddCommand(lint.Cmd())
 	rootCmd.AddCommand(list.Cmd())
+	rootCmd.AddCommand(render.Cmd())
+	rootCmd.AddCommand(schema.Cmd())
+	rootCmd.AddCommand(selfupdate.Cmd())
+	rootCmd.AddCommand(serve.Cmd())
 	rootCmd.AddCommand(validate.Cmd())
 	rootCmd.AddCommand(version.Cmd())
 
@@ -56,24 +139,349 @@ func generateRootCmd() *cobra.Command {
 		verbose,
 		"verbose output",
 	)
-
-	cobra.OnInitialize(setLogFormatter, setLogLevel)
+	flags.StringVar(
+		&logFormat,
+		"log-format",
+		logFormat,
+		"log output format: 'text' or 'json'",
+	)
+	flags.BoolVar(
+		&noColor,
+		"no-color",
+		noColor,
+		"disable colored output; it is also disabled if NO_COLOR is set or stdout is no terminal",
+	)
+	flags.DurationVar(
+		&httpTimeout,
+		"http-timeout",
+		httpTimeout,
+		"timeout of individual HTTP requests (e.g. '30s'); zero means no timeout",
+	)
+	flags.StringVar(
+		&httpProxy,
+		"http-proxy",
+		httpProxy,
+		"URL of a proxy to send all HTTP requests through; defaults to the proxy environment variables",
+	)
+	flags.StringVar(
+		&configFile,
+		"config",
+		configFile,
+		fmt.Sprintf("path of a config file holding default flag values; defaults to '%s' in the working or home directory", cli.ConfigFileName),
+	)
+	flags.StringVar(
+		&imagePolicy,
+		"image-policy",
+		imagePolicy,
+		"path of a policy.json-style image signature policy enforced for all pulled images",
+	)
+	flags.StringVar(
+		&validatorsPluginDir,
+		"validators-plugin-dir",
+		validatorsPluginDir,
+		"directory of Go plugins ('.so' files) exporting 'RegisterValidators' to add organization-specific validators",
+	)
+	flags.StringVar(
+		&validatorsExecDir,
+		"validators-exec-dir",
+		validatorsExecDir,
+		"directory of executables implementing the exec validator protocol (JSON on stdin and stdout) to add as validators",
+	)
+	flags.StringVar(
+		&validatorsWasmDir,
+		"validators-wasm-dir",
+		validatorsWasmDir,
+		"directory of WASI modules ('.wasm' files) implementing the exec validator protocol to run sandboxed as validators",
+	)
+	flags.StringSliceVar(
+		&validatorsWasmImage,
+		"validators-wasm-image",
+		validatorsWasmImage,
+		"images holding WASI modules ('.wasm' files) in their root directory to run sandboxed as validators",
+	)
+	flags.IntVar(
+		&registryRetries,
+		"registry-retries",
+		registryRetries,
+		"number of times pulls of index and bundle images failing with transient registry errors (e.g. 502) are retried",
+	)
+	flags.DurationVar(
+		&registryBackoff,
+		"registry-backoff",
+		registryBackoff,
+		"delay before the first retry of a failed pull which doubles for every subsequent retry (e.g. '2s')",
+	)
 
 	return rootCmd
 }
 
-func setLogFormatter() {
-	formatter := &log.TextFormatter{
-		TimestampFormat:        "02-01-2006 15:04:05",
-		FullTimestamp:          true,
-		DisableLevelTruncation: true,
+// setup applies the global flags; all errors are usage errors.
+func setup(cmd *cobra.Command, args []string) error {
+	if err := applyConfig(cmd); err != nil {
+		return cli.UsageError(err)
+	}
+
+	if err := setLogger(cmd, args); err != nil {
+		return cli.UsageError(err)
+	}
+
+	if noColor {
+		cli.DisableColor()
+	}
+
+	if err := setHTTPDefaults(); err != nil {
+		return cli.UsageError(err)
+	}
+
+	if err := setRegistryRetry(cmd); err != nil {
+		return cli.UsageError(err)
+	}
+
+	if err := loadValidatorPlugins(); err != nil {
+		return cli.UsageError(err)
+	}
+
+	if err := loadExecValidators(cmd); err != nil {
+		return cli.UsageError(err)
+	}
+
+	if err := setImagePolicy(cmd); err != nil {
+		return cli.UsageError(err)
+	}
+
+	// pulled after the image policy is set so that it is enforced
+	return loadWasmValidators(cmd)
+}
+
+// applyConfig sets all flags of cmd which were not given explicitly to
+// the values of the config file given by --config or discovered in the
+// working or home directory. Values for flags of other commands are
+// ignored, but values which are no flag of any command are rejected.
+func applyConfig(cmd *cobra.Command) error {
+	path := configFile
+
+	if path == "" {
+		// a missing home directory only disables discovery there
+		home, _ := os.UserHomeDir()
+
+		found, err := cli.FindConfigFile(".", home)
+		if err != nil {
+			return err
+		}
+
+		if found == "" {
+			return nil
+		}
+
+		path = found
+	}
+
+	cfg, err := cli.LoadConfig(path)
+	if err != nil {
+		return err
+	}
+
+	if unknown := cfg.Unknown(flagNames(cmd.Root())); len(unknown) > 0 {
+		return fmt.Errorf("config file %q sets unknown flags: %s", path, strings.Join(unknown, ", "))
+	}
+
+	if _, err := cli.SetFlags(cmd.Flags(), cfg); err != nil {
+		return fmt.Errorf("applying config file %q: %w", path, err)
 	}
-	log.SetFormatter(formatter)
+
+	return nil
 }
 
-func setLogLevel() {
-	log.SetLevel(log.InfoLevel)
-	if verbose {
-		log.SetLevel(log.DebugLevel)
+// flagNames returns the names of all flags of cmd and its subcommands.
+func flagNames(cmd *cobra.Command) map[string]struct{} {
+	res := make(map[string]struct{})
+
+	var walk func(*cobra.Command)
+
+	walk = func(c *cobra.Command) {
+		add := func(f *pflag.Flag) { res[f.Name] = struct{}{} }
+
+		c.Flags().VisitAll(add)
+		c.PersistentFlags().VisitAll(add)
+
+		for _, sub := range c.Commands() {
+			walk(sub)
+		}
 	}
+
+	walk(cmd)
+
+	return res
+}
+
+// setLogger configures the logger carried by the context of every
+// command and redirects the standard logrus logger used by dependencies.
+func setLogger(cmd *cobra.Command, _ []string) error {
+	format, err := logging.ParseFormat(logFormat)
+	if err != nil {
+		return err
+	}
+
+	logger := logging.New(os.Stderr, format, verbose)
+
+	logging.RedirectLogrus(logrus.StandardLogger(), logger)
+
+	cmd.SetContext(logr.NewContext(cmd.Context(), logger))
+
+	return nil
+}
+
+// setHTTPDefaults applies the global HTTP flags to every client created
+// by httputil. The proxy is also exported to the environment so that
+// clients constructed by dependencies (e.g. registry clients) use it.
+func setHTTPDefaults() error {
+	var opts []httputil.ClientOption
+
+	if httpTimeout > 0 {
+		opts = append(opts, httputil.WithTimeout(httpTimeout))
+	}
+
+	if httpProxy != "" {
+		if _, err := url.Parse(httpProxy); err != nil {
+			return fmt.Errorf("parsing --http-proxy: %w", err)
+		}
+
+		opts = append(opts, httputil.WithProxy(httpProxy))
+
+		for _, env := range []string{"HTTPS_PROXY", "HTTP_PROXY"} {
+			if err := os.Setenv(env, httpProxy); err != nil {
+				return fmt.Errorf("setting %s: %w", env, err)
+			}
+		}
+	}
+
+	httputil.SetDefaults(opts...)
+
+	return nil
+}
+
+// setRegistryRetry attaches the retries given by --registry-retries and
+// --registry-backoff to the context of every command so that they are
+// applied by all extractors pulling images.
+func setRegistryRetry(cmd *cobra.Command) error {
+	if registryRetries < 0 {
+		return fmt.Errorf("--registry-retries must not be negative: %d", registryRetries)
+	}
+
+	if registryBackoff < 0 {
+		return fmt.Errorf("--registry-backoff must not be negative: %s", registryBackoff)
+	}
+
+	cmd.SetContext(extractor.NewRetryContext(cmd.Context(), extractor.Retry{
+		Retries: registryRetries,
+		Backoff: registryBackoff,
+	}))
+
+	return nil
+}
+
+// loadValidatorPlugins registers the validators of the plugins in the
+// directory given by --validators-plugin-dir before any command creates
+// a validator runner.
+func loadValidatorPlugins() error {
+	if validatorsPluginDir == "" {
+		return nil
+	}
+
+	if err := validator.LoadPlugins(validatorsPluginDir); err != nil {
+		return fmt.Errorf("loading --validators-plugin-dir: %w", err)
+	}
+
+	return nil
+}
+
+// loadExecValidators registers the executables in the directory given
+// by --validators-exec-dir as validators. See 'validator.ExecArgRun'
+// for the protocol they implement.
+func loadExecValidators(cmd *cobra.Command) error {
+	if validatorsExecDir == "" {
+		return nil
+	}
+
+	if err := validator.LoadExecValidators(cmd.Context(), validatorsExecDir); err != nil {
+		return fmt.Errorf("loading --validators-exec-dir: %w", err)
+	}
+
+	return nil
+}
+
+// loadWasmValidators loads the WASM modules in the directory given
+// by --validators-wasm-dir and in the images given by
+// --validators-wasm-image as validators of all runners created with
+// the context of cmd. Failed pulls are infrastructure errors, all
+// other errors are usage errors.
+func loadWasmValidators(cmd *cobra.Command) error {
+	ctx := cmd.Context()
+
+	if validatorsWasmDir != "" {
+		vals, err := validator.LoadWasmValidators(ctx, validatorsWasmDir)
+		if err != nil {
+			return cli.UsageError(fmt.Errorf("loading --validators-wasm-dir: %w", err))
+		}
+
+		wasmValidators = append(wasmValidators, vals...)
+	}
+
+	for _, img := range validatorsWasmImage {
+		vals, err := loadWasmImage(ctx, img)
+		if err != nil {
+			return err
+		}
+
+		wasmValidators = append(wasmValidators, vals...)
+	}
+
+	if len(wasmValidators) > 0 {
+		cmd.SetContext(validator.NewInitializersContext(ctx, wasmValidators.Initializers()...))
+	}
+
+	return nil
+}
+
+func loadWasmImage(ctx context.Context, img string) (validator.WasmValidators, error) {
+	dir, err := os.MkdirTemp("", "mtcli-wasm-")
+	if err != nil {
+		return nil, fmt.Errorf("creating temp dir: %w", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	if err := extractor.UnpackImage(ctx, img, dir); err != nil {
+		return nil, cli.InfrastructureError(fmt.Errorf("loading --validators-wasm-image '%s': %w", img, err))
+	}
+
+	vals, err := validator.LoadWasmValidators(ctx, dir)
+	if err != nil {
+		return nil, cli.UsageError(fmt.Errorf("loading --validators-wasm-image '%s': %w", img, err))
+	}
+
+	return vals, nil
+}
+
+// setImagePolicy loads the policy given by --image-policy and attaches
+// it to the context of every command so that it is enforced by all
+// extractors and validators pulling images.
+func setImagePolicy(cmd *cobra.Command) error {
+	if imagePolicy == "" {
+		return nil
+	}
+
+	policy, err := imagepolicy.Load(imagePolicy)
+	if err != nil {
+		return fmt.Errorf("loading --image-policy: %w", err)
+	}
+
+	verifier, err := imagepolicy.NewVerifier(policy)
+	if err != nil {
+		return fmt.Errorf("loading --image-policy: %w", err)
+	}
+
+	cmd.SetContext(imagepolicy.NewContext(cmd.Context(), verifier))
+
+	return nil
 }
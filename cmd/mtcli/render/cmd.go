@@ -0,0 +1,17 @@
+package render
+
+import (
+	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/render/crd"
+	"github.com/spf13/cobra"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "render [command]",
+		Short: "Run a render subcommand.",
+	}
+
+	cmd.AddCommand(crd.Cmd())
+
+	return cmd
+}
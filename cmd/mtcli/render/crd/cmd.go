@@ -0,0 +1,102 @@
+package crd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	"github.com/mt-sre/addon-metadata-operator/pkg/metadata"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const long = `Render the addon metadata files of an environment as ready-to-apply
+custom resources.`
+
+func examples() string {
+	return strings.Join([]string{
+		"  # Render the AddonImageSet for version 1.2.3 of a staging addon.",
+		"  mtcli render crd --env stage --version 1.2.3 <path/to/addon_dir>",
+		"  # Render both the AddonImageSet and AddonMetadata into a namespace and apply them.",
+		"  mtcli render crd --kind AddonImageSet,AddonMetadata -n addons <path/to/addon_dir> | oc apply -f -",
+	}, "\n")
+}
+
+const (
+	kindAddonImageSet = cli.KindAddonImageSet
+	kindAddonMetadata = cli.KindAddonMetadata
+)
+
+func Cmd() *cobra.Command {
+	opts := &options{
+		Env:   "stage",
+		Kinds: []string{kindAddonImageSet},
+	}
+
+	cmd := &cobra.Command{
+		Use:           "crd",
+		Short:         "Render addon metadata and imagesets as custom resources.",
+		Long:          long,
+		Example:       examples(),
+		Args:          cobra.ExactArgs(1),
+		RunE:          run(opts),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	flags := cmd.Flags()
+
+	opts.AddEnvFlag(flags)
+	opts.AddVersionFlag(flags)
+	opts.AddNamespaceFlag(flags)
+	opts.AddKindFlag(flags)
+
+	cli.RegisterFlagCompletions(cmd, cli.FlagCompletions{
+		"env": cli.CompleteEnvs,
+	})
+
+	return cmd
+}
+
+func run(opts *options) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := opts.VerifyFlags(); err != nil {
+			return fmt.Errorf("verifying flags: %w", err)
+		}
+
+		loader := metadata.NewLoader(args[0],
+			metadata.WithEnv(opts.Env),
+			metadata.WithVersion(opts.Version),
+		)
+
+		var objs []runtime.Object
+
+		for _, kind := range opts.Kinds {
+			switch kind {
+			case kindAddonImageSet:
+				spec, err := loader.LoadImageSet(cmd.Context())
+				if err != nil {
+					return fmt.Errorf("loading imageset: %w", err)
+				}
+
+				objs = append(objs, cli.NewAddonImageSet(*spec, opts.Namespace))
+			case kindAddonMetadata:
+				spec, err := loader.LoadMetadata(cmd.Context())
+				if err != nil {
+					return fmt.Errorf("loading metadata: %w", err)
+				}
+
+				objs = append(objs, cli.NewAddonMetadata(*spec, opts.Namespace))
+			}
+		}
+
+		out, err := cli.RenderObjects(objs...)
+		if err != nil {
+			return fmt.Errorf("rendering resources: %w", err)
+		}
+
+		_, err = cmd.OutOrStdout().Write(out)
+
+		return err
+	}
+}
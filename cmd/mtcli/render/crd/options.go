@@ -0,0 +1,72 @@
+package crd
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+	"golang.org/x/mod/semver"
+)
+
+type options struct {
+	Env       string
+	Version   string
+	Namespace string
+	Kinds     []string
+}
+
+func (o *options) AddEnvFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Env,
+		"env",
+		o.Env,
+		"integration, stage or production",
+	)
+}
+
+func (o *options) AddVersionFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Version,
+		"version",
+		o.Version,
+		"addon imageset version",
+	)
+}
+
+func (o *options) AddNamespaceFlag(flags *pflag.FlagSet) {
+	flags.StringVarP(
+		&o.Namespace,
+		"namespace",
+		"n",
+		o.Namespace,
+		"Namespace set on the rendered resources.",
+	)
+}
+
+func (o *options) AddKindFlag(flags *pflag.FlagSet) {
+	flags.StringSliceVar(
+		&o.Kinds,
+		"kind",
+		o.Kinds,
+		fmt.Sprintf("Kinds of resources to render, separated by ','; any of '%s' or '%s'.", kindAddonImageSet, kindAddonMetadata),
+	)
+}
+
+func (o *options) VerifyFlags() error {
+	switch o.Env {
+	case "stage", "integration", "production":
+	default:
+		return fmt.Errorf("'%s' is not a valid environment; must be one of 'integration', 'stage' or 'production'", o.Env)
+	}
+
+	if o.Version != "" && o.Version != "latest" && !semver.IsValid(fmt.Sprintf("v%v", o.Version)) {
+		return fmt.Errorf("'%s' is not a valid version; must be one of 'latest' or match 'MAJOR.MINOR.PATCH'", o.Version)
+	}
+
+	for _, kind := range o.Kinds {
+		if kind != kindAddonImageSet && kind != kindAddonMetadata {
+			return fmt.Errorf("'%s' is not a valid kind; must be one of '%s' or '%s'", kind, kindAddonImageSet, kindAddonMetadata)
+		}
+	}
+
+	return nil
+}
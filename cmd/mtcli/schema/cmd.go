@@ -0,0 +1,17 @@
+package schema
+
+import (
+	"github.com/mt-sre/addon-metadata-operator/cmd/mtcli/schema/export"
+	"github.com/spf13/cobra"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schema [command]",
+		Short: "Run a schema subcommand.",
+	}
+
+	cmd.AddCommand(export.Cmd())
+
+	return cmd
+}
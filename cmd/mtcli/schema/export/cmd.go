@@ -0,0 +1,57 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validate"
+	"github.com/spf13/cobra"
+)
+
+const long = `Print the JSON Schema of a format produced by mtcli. Schemas are
+versioned; within a version fields are only added so consumers should
+ignore unknown fields.`
+
+func examples() string {
+	return strings.Join([]string{
+		"  # Print the schema of the report printed by 'mtcli validate --output json'.",
+		"  mtcli schema export --type report",
+	}, "\n")
+}
+
+func Cmd() *cobra.Command {
+	opts := &options{
+		Type: typeReport,
+	}
+
+	cmd := &cobra.Command{
+		Use:           "export",
+		Short:         "Print the JSON Schema of an mtcli format.",
+		Long:          long,
+		Example:       examples(),
+		Args:          cobra.NoArgs,
+		RunE:          run(opts),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	opts.AddTypeFlag(cmd.Flags())
+
+	return cmd
+}
+
+func run(opts *options) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := opts.VerifyFlags(); err != nil {
+			return fmt.Errorf("verifying flags: %w", err)
+		}
+
+		schemas := map[string]func() []byte{
+			typeReport: validate.ReportSchema,
+		}
+
+		_, err := cmd.OutOrStdout().Write(schemas[opts.Type]())
+
+		return err
+	}
+}
@@ -0,0 +1,31 @@
+package export
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+)
+
+const typeReport = "report"
+
+type options struct {
+	Type string
+}
+
+func (o *options) AddTypeFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Type,
+		"type",
+		o.Type,
+		fmt.Sprintf("Format to print the schema of; one of '%s'.", typeReport),
+	)
+}
+
+func (o *options) VerifyFlags() error {
+	switch o.Type {
+	case typeReport:
+		return nil
+	default:
+		return fmt.Errorf("'%s' is not a valid type; must be '%s'", o.Type, typeReport)
+	}
+}
@@ -0,0 +1,91 @@
+package selfupdate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+const long = `Replace the running mtcli binary with the binary of a published release.
+
+The release archive for the current platform is downloaded from GitHub and
+verified against the sha256 checksums published with the release before
+the binary is replaced. Releases are not signed, so the checksum is the only
+verification performed.
+
+Without '--version' the latest release is installed if it is newer than
+the running version. A pinned version is always installed, which allows
+downgrading.`
+
+func examples() string {
+	return strings.Join([]string{
+		"  # Update mtcli to the latest release.",
+		"  mtcli self-update",
+		"  # Install a specific release.",
+		"  mtcli self-update --version v1.2.3",
+	}, "\n")
+}
+
+func Cmd() *cobra.Command {
+	var opts options
+
+	cmd := &cobra.Command{
+		Use:           "self-update",
+		Short:         "Update mtcli to the latest or a pinned release.",
+		Long:          long,
+		Example:       examples(),
+		Args:          cobra.NoArgs,
+		RunE:          run(&opts),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	flags := cmd.Flags()
+
+	opts.AddVersionFlag(flags)
+	opts.AddForceFlag(flags)
+
+	return cmd
+}
+
+func run(opts *options) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		out := cmd.OutOrStdout()
+		current := cli.GetBuildInfo().Version
+
+		var release cli.Release
+
+		if opts.Version == "" {
+			check, err := cli.CheckForUpdate(ctx, nil, cli.LatestReleaseURL, current)
+			if err != nil {
+				return fmt.Errorf("checking for updates: %w", err)
+			}
+
+			if !check.UpdateAvailable && !opts.Force {
+				fmt.Fprintf(out, "mtcli %s is up to date (latest release: %s).\n", current, check.Latest.Version)
+
+				return nil
+			}
+
+			release = check.Latest
+		} else {
+			var err error
+
+			release, err = cli.FetchRelease(ctx, nil, cli.ReleaseURL(cli.ReleasesURL, opts.Version))
+			if err != nil {
+				return fmt.Errorf("retrieving release '%s': %w", opts.Version, err)
+			}
+		}
+
+		if err := cli.NewUpdater().Update(ctx, release); err != nil {
+			return fmt.Errorf("updating mtcli: %w", err)
+		}
+
+		fmt.Fprintf(out, "Updated mtcli from %s to %s.\n", current, release.Version)
+
+		return nil
+	}
+}
@@ -0,0 +1,28 @@
+package selfupdate
+
+import (
+	"github.com/spf13/pflag"
+)
+
+type options struct {
+	Version string
+	Force   bool
+}
+
+func (o *options) AddVersionFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Version,
+		"version",
+		o.Version,
+		"Release version to install (e.g. 'v1.2.3'); defaults to the latest release.",
+	)
+}
+
+func (o *options) AddForceFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&o.Force,
+		"force",
+		o.Force,
+		"Install the latest release even if it is not newer than the running version.",
+	)
+}
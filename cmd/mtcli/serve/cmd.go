@@ -0,0 +1,202 @@
+package serve
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	"github.com/mt-sre/addon-metadata-operator/pkg/server"
+	pkgvalidate "github.com/mt-sre/addon-metadata-operator/pkg/validate"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/spf13/cobra"
+)
+
+const long = `Run the validators as an HTTP service until interrupted so that bots can
+validate addon metadata without shelling out to mtcli.
+
+Validations are submitted as jobs which run asynchronously:
+
+  POST /v1/validations       submit '{"metadata": "<addon.yaml>", "indexImage": "<ref>"}'
+  GET  /v1/validations/{id}  poll the job; its report is included once it is 'done'
+  GET  /healthz              check that the server is up
+
+Submitting returns '202 Accepted' with the job ID, '400 Bad Request' if
+the metadata can not be loaded or '503 Service Unavailable' while
+'--max-pending-jobs' validations did not finish yet. Bundles are only
+extracted and validated if 'indexImage' is given; it overrides the index
+image of the metadata. Job reports use the JSON encoding of
+'mtcli validate --output json'.
+
+The server only listens on localhost by default. If the environment
+variable '` + tokenEnvVar + `' is set, requests to '/v1/validations' must
+send its value as bearer token or are rejected with '401 Unauthorized'.`
+
+// tokenEnvVar holds the bearer token required by the server.
+const tokenEnvVar = "MTCLI_SERVE_TOKEN"
+
+func examples() string {
+	return strings.Join([]string{
+		"  # Validate staging metadata on port 8080.",
+		"  mtcli serve",
+		"  # Validate production metadata on all interfaces running at most 4 validations at a time.",
+		"  MTCLI_SERVE_TOKEN=<token> mtcli serve --env production --addr :9090 --concurrency 4",
+		"  # Submit the metadata of an addon.",
+		`  jq -Rs '{metadata: .}' metadata/stage/addon.yaml | curl -d @- localhost:8080/v1/validations`,
+	}, "\n")
+}
+
+func Cmd() *cobra.Command {
+	opts := &options{
+		Addr:        "127.0.0.1:8080",
+		Env:         "stage",
+		Concurrency: 2,
+		Timeout:     10 * time.Minute,
+		JobTTL:      time.Hour,
+		MaxPending:  100,
+		Profile:     cli.DefaultProfile,
+	}
+
+	cmd := &cobra.Command{
+		Use:           "serve",
+		Short:         "Run the validators as an HTTP service.",
+		Long:          long,
+		Example:       examples(),
+		Args:          cobra.NoArgs,
+		RunE:          run(opts),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	flags := cmd.Flags()
+
+	opts.AddAddrFlag(flags)
+	opts.AddEnvFlag(flags)
+	opts.AddConcurrencyFlag(flags)
+	opts.AddTimeoutFlag(flags)
+	opts.AddJobTTLFlag(flags)
+	opts.AddMaxPendingFlag(flags)
+	opts.AddDisabledFlag(flags)
+	opts.AddEnabledFlag(flags)
+	opts.AddEnabledTagsFlag(flags)
+	opts.AddDisabledTagsFlag(flags)
+	opts.AddProfileFlag(flags)
+
+	cli.RegisterFlagCompletions(cmd, cli.FlagCompletions{
+		"env":           cli.CompleteEnvs,
+		"enabled":       cli.CompleteValidatorCodes,
+		"disabled":      cli.CompleteValidatorCodes,
+		"enabled-tags":  cli.CompleteValidatorTags,
+		"disabled-tags": cli.CompleteValidatorTags,
+		"profile":       cli.CompleteProfiles,
+	})
+
+	return cmd
+}
+
+// shutdownTimeout limits the time requests in flight
+// may take to complete once the server is interrupted.
+const shutdownTimeout = 10 * time.Second
+
+func run(opts *options) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		if err := opts.VerifyFlags(); err != nil {
+			return cli.UsageError(fmt.Errorf("verifying flags: %w", err))
+		}
+
+		tagFilter, err := cli.TagFilter(opts.DisabledTags, opts.EnabledTags)
+		if err != nil {
+			return cli.UsageError(fmt.Errorf("generating validator filter: %w", err))
+		}
+
+		profile, err := cli.LookupProfile(opts.Profile)
+		if err != nil {
+			return cli.UsageError(fmt.Errorf("generating validator filter: %w", err))
+		}
+
+		ocm, err := cli.NewOCMClient(opts.Env)
+		if err != nil {
+			return cli.InfrastructureError(fmt.Errorf("initializing ocm client: %w", err))
+		}
+
+		defer func() { _ = ocm.CloseConnection() }()
+
+		// validations run with contexts derived from ctx
+		ctx = validator.NewOCMClientContext(ctx, ocm)
+
+		// shared by all validations
+		runner, err := validator.NewRunner(ctx,
+			validator.WithMiddleware{
+				validator.NewRetryMiddleware(),
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("initializing validators: %w", err)
+		}
+
+		filter, err := cli.ValidatorFilter(ctx, runner, opts.Disabled, opts.Enabled)
+		if err != nil {
+			return cli.UsageError(fmt.Errorf("generating validator filter: %w", err))
+		}
+
+		validateOpts := server.WithValidateOptions{
+			pkgvalidate.WithRunner{Runner: runner},
+			pkgvalidate.WithSeverities(profile.Severities),
+			pkgvalidate.WithFilters{validator.All(filter, tagFilter, profile.Filter)},
+		}
+
+		ln, err := net.Listen("tcp", opts.Addr)
+		if err != nil {
+			return cli.InfrastructureError(fmt.Errorf("listening on '%s': %w", opts.Addr, err))
+		}
+
+		srv := &http.Server{
+			Handler: server.New(ctx,
+				server.WithEnv(opts.Env),
+				server.WithConcurrency(opts.Concurrency),
+				server.WithTimeout(opts.Timeout),
+				server.WithJobTTL(opts.JobTTL),
+				server.WithMaxPendingJobs(opts.MaxPending),
+				server.WithToken(os.Getenv(tokenEnvVar)),
+				validateOpts,
+			),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		errCh := make(chan error, 1)
+
+		go func() { errCh <- srv.Serve(ln) }()
+
+		log := logr.FromContextOrDiscard(ctx)
+		log.Info("serving validations", "addr", ln.Addr().String(), "env", opts.Env)
+
+		if addr, ok := ln.Addr().(*net.TCPAddr); ok && !addr.IP.IsLoopback() && os.Getenv(tokenEnvVar) == "" {
+			log.Info("serving on a non-loopback address without a token; set " + tokenEnvVar + " to require one")
+		}
+
+		select {
+		case err := <-errCh:
+			return cli.InfrastructureError(fmt.Errorf("serving: %w", err))
+		case <-ctx.Done():
+		}
+
+		log.Info("shutting down")
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		if err := srv.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("shutting down: %w", err)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,164 @@
+package serve
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/spf13/pflag"
+)
+
+type options struct {
+	Addr         string
+	Env          string
+	Concurrency  int
+	Timeout      time.Duration
+	JobTTL       time.Duration
+	MaxPending   int
+	Disabled     string
+	Enabled      string
+	EnabledTags  []string
+	DisabledTags []string
+	Profile      string
+}
+
+func (o *options) AddAddrFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Addr,
+		"addr",
+		o.Addr,
+		fmt.Sprintf("Address the server listens on, e.g. '127.0.0.1:8080' or ':8080' for all interfaces. "+
+			"Set '%s' to require a bearer token when listening on other interfaces.", tokenEnvVar),
+	)
+}
+
+func (o *options) AddEnvFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Env,
+		"env",
+		o.Env,
+		"Environment whose metadata is validated; integration, stage or production.",
+	)
+}
+
+func (o *options) AddConcurrencyFlag(flags *pflag.FlagSet) {
+	flags.IntVar(
+		&o.Concurrency,
+		"concurrency",
+		o.Concurrency,
+		"Maximum number of validations running at the same time; further jobs wait.",
+	)
+}
+
+func (o *options) AddTimeoutFlag(flags *pflag.FlagSet) {
+	flags.DurationVar(
+		&o.Timeout,
+		"timeout",
+		o.Timeout,
+		"Maximum duration of each validation including bundle extraction. Zero means no limit.",
+	)
+}
+
+func (o *options) AddJobTTLFlag(flags *pflag.FlagSet) {
+	flags.DurationVar(
+		&o.JobTTL,
+		"job-ttl",
+		o.JobTTL,
+		"Duration finished jobs and their results are kept for.",
+	)
+}
+
+func (o *options) AddMaxPendingFlag(flags *pflag.FlagSet) {
+	flags.IntVar(
+		&o.MaxPending,
+		"max-pending-jobs",
+		o.MaxPending,
+		"Maximum number of submitted validations which did not finish yet; further submissions are rejected.",
+	)
+}
+
+func (o *options) AddDisabledFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Disabled,
+		"disabled",
+		o.Disabled,
+		"Disable specific validators by code or code prefix (e.g. 'OSD'), separated by ','. Can't be combined with --enabled.",
+	)
+}
+
+func (o *options) AddEnabledFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Enabled,
+		"enabled",
+		o.Enabled,
+		"Enable specific validators by code or code prefix (e.g. 'OSD'), separated by ','. Can't be combined with --disabled.",
+	)
+}
+
+func (o *options) AddEnabledTagsFlag(flags *pflag.FlagSet) {
+	flags.StringSliceVar(
+		&o.EnabledTags,
+		"enabled-tags",
+		o.EnabledTags,
+		fmt.Sprintf("Only run validators with any of the given tags, separated by ','; any of %s.", strings.Join(validator.Tags, ", ")),
+	)
+}
+
+func (o *options) AddDisabledTagsFlag(flags *pflag.FlagSet) {
+	flags.StringSliceVar(
+		&o.DisabledTags,
+		"disabled-tags",
+		o.DisabledTags,
+		"Skip validators with any of the given tags, separated by ','. Takes precedence over '--enabled-tags'.",
+	)
+}
+
+func (o *options) AddProfileFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Profile,
+		"profile",
+		o.Profile,
+		fmt.Sprintf("Run the curated set of validators and severities of the given profile; one of %s. "+
+			"Combined with the other validator selection flags.", strings.Join(cli.ProfileNames(), ", ")),
+	)
+}
+
+func (o *options) VerifyFlags() error {
+	if o.Addr == "" {
+		return errors.New("'--addr' must not be empty")
+	}
+
+	if _, err := cli.LookupProfile(o.Profile); err != nil {
+		return err
+	}
+
+	if !slices.Contains(cli.Envs, o.Env) {
+		return fmt.Errorf("'%s' is not a valid environment; must be one of 'integration', 'stage' or 'production'", o.Env)
+	}
+
+	if o.Concurrency < 1 {
+		return fmt.Errorf("'%d' is not a valid concurrency; must be greater than zero", o.Concurrency)
+	}
+
+	if o.Timeout < 0 {
+		return fmt.Errorf("'--timeout' must not be negative: %s", o.Timeout)
+	}
+
+	if o.MaxPending < 1 {
+		return fmt.Errorf("'%d' is not a valid number of pending jobs; must be greater than zero", o.MaxPending)
+	}
+
+	if o.JobTTL <= 0 {
+		return fmt.Errorf("'--job-ttl' must be greater than zero: %s", o.JobTTL)
+	}
+
+	if o.Disabled != "" && o.Enabled != "" {
+		return errors.New("'--disabled' and '--enabled' are mutually exclusive options")
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	pkgvalidate "github.com/mt-sre/addon-metadata-operator/pkg/validate"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/spf13/cobra"
+)
+
+// loadBaseline reads the baseline given by '--baseline'. A missing file
+// is treated as an empty baseline unless it was given explicitly and is
+// not going to be written by '--update-baseline'.
+func loadBaseline(cmd *cobra.Command, opts *options) (*cli.Baseline, error) {
+	baseline, err := cli.LoadBaseline(opts.Baseline)
+	if errors.Is(err, os.ErrNotExist) && (opts.UpdateBaseline || !cmd.Flags().Changed("baseline")) {
+		return &cli.Baseline{}, nil
+	} else if err != nil {
+		return nil, cli.UsageError(err)
+	}
+
+	return baseline, nil
+}
+
+// writeBaseline writes baseline to '--baseline' if '--update-baseline' is given.
+func writeBaseline(opts *options, baseline *cli.Baseline) error {
+	if !opts.UpdateBaseline {
+		return nil
+	}
+
+	return baseline.Write(opts.Baseline)
+}
+
+// applyBaseline records the failures of report if '--update-baseline'
+// is given and returns its results without the known failures which
+// determine the outcome. Known and fixed failures are noted on out.
+func (v *addonValidator) applyBaseline(out io.Writer, report pkgvalidate.Report) validator.ResultList {
+	if v.opts.UpdateBaseline {
+		v.baseline.Update(report.Addon, report.Results)
+	}
+
+	fresh, known := v.baseline.Split(report.Addon, report.Results)
+
+	if len(known) > 0 {
+		codes := make([]validator.Code, 0, len(known))
+		for _, res := range known {
+			codes = append(codes, res.Code)
+		}
+
+		fmt.Fprintf(out, "%s: ignoring %d known failure(s) listed in %q: %s\n",
+			report.Addon, len(known), v.opts.Baseline, joinCodes(codes),
+		)
+	}
+
+	if fixed := v.baseline.Fixed(report.Addon, report.Results); len(fixed) > 0 {
+		fmt.Fprintf(out, "%s: known failure(s) %s passed; remove them from %q using '--update-baseline'\n",
+			report.Addon, joinCodes(fixed), v.opts.Baseline,
+		)
+	}
+
+	return fresh
+}
+
+func joinCodes(codes []validator.Code) string {
+	res := make([]string, 0, len(codes))
+	for _, code := range codes {
+		res = append(res, code.String())
+	}
+
+	return strings.Join(res, ", ")
+}
@@ -0,0 +1,84 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	"github.com/mt-sre/addon-metadata-operator/pkg/metadata"
+)
+
+// changedAddonArgs returns the addon dirs among args with changes since ref.
+// Every addon dir is looked up in the git repository containing it.
+func changedAddonArgs(ctx context.Context, args []string, ref string) ([]string, error) {
+	var res []string
+
+	for _, arg := range args {
+		if metadata.IsRemote(arg) {
+			return nil, cli.UsageError(fmt.Errorf("'--changed-since' requires local addon dirs; %q is remote", arg))
+		}
+
+		changed, err := changedAddonDirs(ctx, arg, []string{arg}, ref)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, changed...)
+	}
+
+	return res, nil
+}
+
+// changedAddonDirs returns the dirs containing files changed since ref
+// according to the git repository containing root.
+func changedAddonDirs(ctx context.Context, root string, dirs []string, ref string) ([]string, error) {
+	files, err := cli.ChangedFiles(ctx, root, ref)
+	if err != nil {
+		return nil, fmt.Errorf("listing changes since %q: %w", ref, err)
+	}
+
+	var res []string
+
+	for _, dir := range dirs {
+		prefix, err := realPath(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		prefix += string(filepath.Separator)
+
+		for _, file := range files {
+			if strings.HasPrefix(file, prefix) {
+				res = append(res, dir)
+
+				break
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// realPath returns the absolute path of dir with symlinks resolved
+// as paths reported by git are.
+func realPath(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("resolving %q: %w", dir, err)
+	}
+
+	res, err := filepath.EvalSymlinks(abs)
+	if err != nil {
+		return "", fmt.Errorf("resolving %q: %w", dir, err)
+	}
+
+	return res, nil
+}
+
+// printNoChanges notes that nothing was validated as no addon changed.
+func printNoChanges(out io.Writer, ref string) {
+	fmt.Fprintf(out, "no addons changed since %q\n", ref)
+}
@@ -2,24 +2,43 @@ package validate
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/go-logr/logr"
 	"github.com/mt-sre/addon-metadata-operator/internal/cli"
 	"github.com/mt-sre/addon-metadata-operator/pkg/extractor"
+	"github.com/mt-sre/addon-metadata-operator/pkg/metadata"
+	"github.com/mt-sre/addon-metadata-operator/pkg/notify"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
 	"github.com/mt-sre/addon-metadata-operator/pkg/types"
-	"github.com/mt-sre/addon-metadata-operator/pkg/utils"
+	pkgvalidate "github.com/mt-sre/addon-metadata-operator/pkg/validate"
 	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
-	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/register"
 	"github.com/spf13/cobra"
 )
 
-const long = "Validate an addon metadata and it's bundles against custom validators."
+const long = `Validate an addon metadata and it's bundles against custom validators.
+
+The metadata of an environment may be split across 'addon.yaml' and fragments
+named 'addon.<name>.yaml' (e.g. 'addon.parameters.yaml') next to it which are
+merged into 'addon.yaml'. Each top-level key may only be defined once. Other
+files in the metadata directory are ignored.
+
+Exit codes:
+  0  all validators passed
+  1  at least one validator failed
+  2  invalid flags or arguments
+  3  infrastructure errors such as failed extractions, unreachable APIs or exceeded timeouts; retrying may succeed`
 
 func examples() string {
 	return strings.Join([]string{
@@ -27,18 +46,88 @@ func examples() string {
 		"  mtcli validate --env stage --version latest internal/testdata/addons-imageset/reference-addon",
 		"  # Validate a version 1.0.0 of a production addon using imageset.",
 		"  mtcli validate --env production --version 1.0.0 <path/to/addon_dir>",
+		"  # Validate all addons of a directory; patterns are also expanded if the shell does not.",
+		"  mtcli validate --env stage 'addons/*'",
 		"  # Validate a staging addon that is not using imageset, but a static indexImage.",
 		"  mtcli validate --env stage <path/to/addon_dir>",
 		"  # Validate an integration addon using imageset, disabling validators 001_foo and 002_bar.",
 		"  mtcli validate --env integration --disabled AM0001,AM0002 <path/to/addon_dir>",
 		"  # Validate an integration addon using imageset, enabled only 001_foo.",
 		"  mtcli validate --env integration --enabled AM0001 <path/to/addon_dir>",
+		"  # Validate the imageset with the highest semantic version rather than the last one by name.",
+		"  mtcli validate --env stage --version latest --version-strategy semver <path/to/addon_dir>",
+		"  # Validate a remote addon using a raw file URL, verifying the checksum of its metadata.",
+		"  mtcli validate --env stage --version 1.0.0 --checksum metadata/stage/addon.yaml=<sha256> https://<host>/<path/to/addon_dir>",
+		"  # Validate addon metadata read from stdin without a local checkout; only addons with an 'indexImage' are supported.",
+		"  cat addon.yaml | mtcli validate --env stage -",
+		"  # Validate the addon metadata at a raw file URL.",
+		"  mtcli validate --env stage --metadata-url https://<host>/<path/to/addon_dir>/metadata/stage/addon.yaml",
+		"  # Only print failures and a summary, e.g. when validating many addons in CI.",
+		"  mtcli validate --env stage --quiet 'addons/*'",
+		"  # Print a versioned JSON report instead of a table.",
+		"  mtcli validate --env stage --output json <path/to/addon_dir>",
+		"  # Additionally dry-run apply all bundle manifests against a live cluster.",
+		"  mtcli validate --env stage --kubeconfig ~/.kube/config --dry-run-namespace <namespace> <path/to/addon_dir>",
+		"  # Additionally run the preflight certification checks for a partner-certified addon.",
+		"  mtcli validate --env stage --preflight --docker-config ~/.docker/config.json <path/to/addon_dir>",
+		"  # Require all pulled and referenced images to satisfy an image signature policy.",
+		"  mtcli validate --env stage --image-policy /etc/containers/policy.json <path/to/addon_dir>",
+		"  # Post a summary of the outcome to a Slack channel and e-mail the report.",
+		"  mtcli validate --env stage --notify slack=https://hooks.slack.com/services/<id> --notify 'email=smtp://<user>:<password>@<host>:587?from=<address>&to=<address>' <path/to/addon_dir>",
+		"  # Additionally write a JUnit XML report for CI test tabs.",
+		"  mtcli validate --env stage --report junit --report-file junit.xml <path/to/addon_dir>",
+		"  # Additionally write a SARIF report for GitHub code scanning; run from the repository root.",
+		"  mtcli validate --env stage --report sarif --report-file results.sarif <path/to/addon_dir>",
+		"  # Additionally render a Markdown summary to post as merge request comment.",
+		"  mtcli validate --env stage --report markdown --report-file summary.md <path/to/addon_dir>",
+		"  # Additionally write a browsable HTML report with a per-bundle breakdown.",
+		"  mtcli validate --env stage --report html --report-dir ./report <path/to/addon_dir>",
+		"  # Keep defaults such as the environment or disabled validators in a config file.",
+		"  printf 'env: stage\\ndisabled: [AM0005]\\n' > .mtcli.yaml && mtcli validate <path/to/addon_dir>",
+		"  # Record a run manifest alongside the report, e.g. in CI.",
+		"  mtcli validate --env stage --output json --run-manifest run-manifest.json <path/to/addon_dir> > report.json",
+		"  # Quickly check the addon metadata without pulling the index image.",
+		"  mtcli validate --env stage --metadata-only <path/to/addon_dir>",
+		"  # Only run the validators of imageset fields and bundles, e.g. when bumping an imageset.",
+		"  mtcli validate --env stage --scope bundles,imageset <path/to/addon_dir>",
+		"  # Validate against bundles extracted beforehand, e.g. without network access.",
+		"  mtcli bundle extract --to-dir ./bundles <index_image> && mtcli validate --env stage --bundles-dir ./bundles <path/to/addon_dir>",
+		"  # Re-run validators not depending on remote services whenever the addon changes.",
+		"  mtcli validate --env stage --watch <path/to/addon_dir>",
+		"  # Abort after 10 minutes and report validators taking longer than 2 minutes as timed out.",
+		"  mtcli validate --env stage --timeout 10m --validator-timeout 2m <path/to/addon_dir>",
+		"  # Stop at the first failing validator while fixing a known-broken addon.",
+		"  mtcli validate --env stage --metadata-only --fail-fast <path/to/addon_dir>",
+		"  # Browse the results in a terminal UI and re-run individual validators after fixing them.",
+		"  mtcli validate --env stage --interactive <path/to/addon_dir>",
+		"  # Validate every addon and environment of a managed-tenants checkout.",
+		"  mtcli validate repo <path/to/managed-tenants>",
+		"  # Validate every addon of a managed-tenants checkout in staging only.",
+		"  mtcli validate repo --env stage <path/to/managed-tenants>",
+		"  # Only validate the addons changed on the current branch, e.g. in merge request pipelines.",
+		"  mtcli validate repo --changed-since origin/main <path/to/managed-tenants>",
+		"  # Record the current failures of all addons so that only new failures fail later runs.",
+		"  mtcli validate repo --update-baseline <path/to/managed-tenants>",
+		"  # Validate the bundles of an operator in an index image before its addon metadata exists.",
+		"  mtcli validate index --package <operator> quay.io/<org>/<operator>-index:<tag>",
+		"  # Print which validators a profile and filters select, in which order they run and with which severities.",
+		"  mtcli validate --env production --profile ocm-publish --disabled-tags service --dry-run",
+		"  # Re-execute a recorded run against the same image digests and flags.",
+		"  mtcli validate --replay run-manifest.json",
 	}, "\n")
 }
 
 func Cmd() *cobra.Command {
 	opts := &options{
-		Env: "stage",
+		Env:             "stage",
+		VersionStrategy: "lexical",
+		Output:          outputTable,
+		DryRunNamespace: "default",
+		PreflightBinary: "preflight",
+		Baseline:        cli.BaselineFileName,
+		Profile:         cli.DefaultProfile,
+		FailOn:          string(validator.SeverityError),
+		Strict:          true,
 	}
 
 	cmd := &cobra.Command{
@@ -46,7 +135,7 @@ func Cmd() *cobra.Command {
 		Short:         "Validate addon metadata, bundles and imagesets.",
 		Long:          long,
 		Example:       examples(),
-		Args:          cobra.ExactArgs(1),
+		Args:          cobra.ArbitraryArgs,
 		RunE:          run(opts),
 		SilenceErrors: true,
 		SilenceUsage:  true,
@@ -58,194 +147,1241 @@ func Cmd() *cobra.Command {
 	opts.AddVersionFlag(flags)
 	opts.AddDisabledFlag(flags)
 	opts.AddEnabledFlag(flags)
+	opts.AddEnabledTagsFlag(flags)
+	opts.AddDisabledTagsFlag(flags)
+	opts.AddProfileFlag(flags)
 	opts.AddExcludedNamespacesFlag(flags)
+	opts.AddRBACAllowlistFlag(flags)
+	opts.AddChecksumFlag(flags)
+	opts.AddStrictFlag(flags)
+	opts.AddVersionStrategyFlag(flags)
+	opts.AddOutputFlag(flags)
+	opts.AddKubeconfigFlag(flags)
+	opts.AddDryRunNamespaceFlag(flags)
+	opts.AddPreflightFlag(flags)
+	opts.AddPreflightBinaryFlag(flags)
+	opts.AddDockerConfigFlag(flags)
+	opts.AddNotifyFlag(flags)
+	opts.AddReportURLFlag(flags)
+	opts.AddRunManifestFlag(flags)
+	opts.AddReplayFlag(flags)
+	opts.AddReportFlag(flags)
+	opts.AddReportFileFlag(flags)
+	opts.AddReportDirFlag(flags)
+	opts.AddConcurrencyFlag(flags)
+	opts.AddChangedSinceFlag(flags)
+	opts.AddWatchFlag(flags)
+	opts.AddTimeoutFlag(flags)
+	opts.AddValidatorTimeoutFlag(flags)
+	opts.AddInteractiveFlag(flags)
+	opts.AddBaselineFlag(flags)
+	opts.AddUpdateBaselineFlag(flags)
+	opts.AddMetadataOnlyFlag(flags)
+	opts.AddBundlesDirFlag(flags)
+	opts.AddScopeFlag(flags)
+	opts.AddMetadataURLFlag(flags)
+	opts.AddQuietFlag(flags)
+	opts.AddFailFastFlag(flags)
+	opts.AddFailOnFlag(flags)
+	opts.AddCacheFileFlag(flags)
+	opts.AddDryRunFlag(flags)
+
+	cmd.AddCommand(repoCmd(opts))
+	cmd.AddCommand(indexCmd(opts))
+
+	cli.RegisterFlagCompletions(cmd, cli.FlagCompletions{
+		"env":           cli.CompleteEnvs,
+		"enabled":       cli.CompleteValidatorCodes,
+		"disabled":      cli.CompleteValidatorCodes,
+		"enabled-tags":  cli.CompleteValidatorTags,
+		"disabled-tags": cli.CompleteValidatorTags,
+		"profile":       cli.CompleteProfiles,
+		"fail-on": func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return failOnSeverities, cobra.ShellCompDirectiveNoFileComp
+		},
+		"scope": func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return scopes, cobra.ShellCompDirectiveNoFileComp
+		},
+	})
 
 	return cmd
 }
 
-const (
-	ocmTokenEnvVar        = "OCM_TOKEN"
-	ocmClientIDEnvVar     = "OCM_CLIENT_ID"
-	ocmClientSecretEnvVar = "OCM_CLIENT_SECRET"
-)
-
 var (
 	ErrValidationFailed  = errors.New("validation failed")
 	ErrValidationErrored = errors.New("validators encountered errors")
+	ErrNoAddonDir        = errors.New("at least one addon dir is required unless '--replay' or '--metadata-url' is given")
+	ErrMetadataURLAndDir = errors.New("'--metadata-url' cannot be combined with addon dirs")
 )
 
+// unrecordedFlags are not recorded in run manifests as they
+// only control what happens with the outcome of a run.
+var unrecordedFlags = []string{"run-manifest", "replay", "changed-since", "notify", "report-url", "report", "report-file", "report-dir", "update-baseline"}
+
 func run(opts *options) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 		ctx, cancel := context.WithCancel(cmd.Context())
 		defer cancel()
 
-		if err := opts.VerifyFlags(); err != nil {
-			return fmt.Errorf("verifying flags: %w", err)
+		var replay *cli.RunManifest
+
+		if opts.Replay != "" {
+			recorded, err := replayRun(ctx, cmd, opts.Replay)
+			if err != nil {
+				return cli.UsageError(fmt.Errorf("replaying run: %w", err))
+			}
+
+			replay = &recorded
 		}
 
-		addonDir, err := parseAddonDir(args[0])
+		if opts.DryRun {
+			if err := opts.VerifyFlags(); err != nil {
+				return cli.UsageError(fmt.Errorf("verifying flags: %w", err))
+			}
+
+			return printPlan(ctx, cmd.OutOrStdout(), opts)
+		}
+
+		addonArgs, err := expandAddonArgs(args)
 		if err != nil {
-			return fmt.Errorf("parsing addon dir %q: %w", args[0], err)
+			return cli.UsageError(err)
 		}
 
-		if err := verifyAddonDir(addonDir); err != nil {
-			return fmt.Errorf("verifying addon dir %q: %w", addonDir, err)
+		if opts.MetadataURL != "" {
+			if len(addonArgs) > 0 {
+				return cli.UsageError(ErrMetadataURLAndDir)
+			}
+
+			addonArgs = []string{opts.MetadataURL}
+		}
+
+		if len(addonArgs) == 0 {
+			if replay == nil {
+				return cli.UsageError(ErrNoAddonDir)
+			}
+
+			addonArgs = []string{replay.Addon.Dir}
+		}
+
+		if err := opts.VerifyFlags(); err != nil {
+			return cli.UsageError(fmt.Errorf("verifying flags: %w", err))
+		}
+
+		bulk := len(addonArgs) > 1
+
+		if bulk {
+			if err := opts.VerifyBulkFlags(); err != nil {
+				return cli.UsageError(fmt.Errorf("verifying flags: %w", err))
+			}
+		}
+
+		if opts.Watch {
+			if err := opts.VerifyWatchFlags(); err != nil {
+				return cli.UsageError(fmt.Errorf("verifying flags: %w", err))
+			}
+		}
+
+		if err := verifyMetadataArgs(opts, addonArgs); err != nil {
+			return cli.UsageError(fmt.Errorf("verifying flags: %w", err))
 		}
 
-		meta, err := utils.NewMetaLoader(addonDir, opts.Env, opts.Version).Load()
+		if opts.ChangedSince != "" {
+			addonArgs, err = changedAddonArgs(ctx, addonArgs, opts.ChangedSince)
+			if err != nil {
+				return err
+			}
+
+			if len(addonArgs) == 0 {
+				printNoChanges(summaryWriter(cmd, opts), opts.ChangedSince)
+
+				return nil
+			}
+		}
+
+		baseline, err := loadBaseline(cmd, opts)
 		if err != nil {
-			return fmt.Errorf("loading addon metadata from '%s': %w", addonDir, err)
+			return err
 		}
 
-		extractor := extractor.New()
-		bundles, err := extractor.ExtractBundles(ctx, *meta.IndexImage, meta.OperatorName)
+		v, closeValidator, err := newAddonValidator(ctx, cmd, opts, opts.Env, extractor.New())
 		if err != nil {
-			return fmt.Errorf("extracting and parsing addon bundles: %w", err)
+			return err
+		}
+
+		defer closeValidator()
+
+		v.replay = replay
+		v.bulk = bulk
+		v.baseline = baseline
+
+		if opts.Watch {
+			return v.Watch(ctx, addonArgs[0])
+		}
+
+		if opts.Interactive {
+			return v.Browse(ctx, addonArgs[0])
 		}
 
-		filter, err := generateFilter(opts.Disabled, opts.Enabled)
+		ctx, cancel = withTimeout(ctx, opts.Timeout)
+		defer cancel()
+
+		if !v.bulk {
+			report, err := v.Validate(ctx, addonArgs[0])
+			if err != nil {
+				return timeoutError(ctx, opts, err)
+			}
+
+			results := v.applyBaseline(cmd.ErrOrStderr(), report)
+
+			if err := writeBaseline(opts, baseline); err != nil {
+				return err
+			}
+
+			if opts.Quiet {
+				printSummary(summaryWriter(cmd, opts), []addonOutcome{{
+					Env:     v.env,
+					Addon:   addonArgs[0],
+					Report:  report,
+					Results: results,
+					FailOn:  opts.failOnSeverity(),
+				}}, false)
+			}
+
+			return outcomeError(results, opts.failOnSeverity())
+		}
+
+		outcomes, err := v.ValidateAll(ctx, addonArgs)
 		if err != nil {
-			return fmt.Errorf("generating validator filter: %w", err)
+			return timeoutError(ctx, opts, err)
 		}
 
-		ocm, err := validator.NewOCMClient(
-			validator.WithConnectOptions{
-				validator.WithAPIURL(envToOCMURL(opts.Env)),
-				validator.WithAccessToken(os.Getenv(ocmTokenEnvVar)),
-				validator.WithClientID(os.Getenv(ocmClientIDEnvVar)),
-				validator.WithClientSecret(os.Getenv(ocmClientSecretEnvVar)),
-			},
-		)
+		if err := writeBaseline(opts, baseline); err != nil {
+			return err
+		}
+
+		printSummary(summaryWriter(cmd, opts), outcomes, false)
+
+		return summaryError(outcomes)
+	}
+}
+
+// newAddonValidator returns an addonValidator for the given environment
+// using the given extractor. The returned function releases its clients.
+func newAddonValidator(ctx context.Context, cmd *cobra.Command, opts *options, env string, ext *extractor.MainExtractor) (*addonValidator, func(), error) {
+	notifiers, err := parseNotifiers(opts.Notify)
+	if err != nil {
+		return nil, nil, cli.UsageError(fmt.Errorf("parsing notifiers: %w", err))
+	}
+
+	strategy, err := metadata.ParseVersionStrategy(opts.VersionStrategy)
+	if err != nil {
+		return nil, nil, cli.UsageError(fmt.Errorf("parsing version strategy: %w", err))
+	}
+
+	ocm, err := cli.NewOCMClient(env)
+	if err != nil {
+		return nil, nil, cli.InfrastructureError(fmt.Errorf("initializing ocm client: %w", err))
+	}
+
+	runner, err := newRunner(ctx, opts, ocm)
+	if err != nil {
+		_ = ocm.CloseConnection()
+
+		return nil, nil, err
+	}
+
+	filter, profile, err := selectValidators(ctx, runner, opts)
+	if err != nil {
+		_ = ocm.CloseConnection()
+
+		return nil, nil, err
+	}
+
+	v := &addonValidator{
+		cmd:        cmd,
+		opts:       opts,
+		env:        env,
+		extractor:  ext,
+		filter:     filter,
+		severities: profile.Severities,
+		runner:     runner,
+		notifiers:  notifiers,
+		strategy:   strategy,
+	}
+
+	if opts.CacheFile != "" {
+		if v.cache, err = pkgvalidate.OpenBoltCache(opts.CacheFile); err != nil {
+			_ = ocm.CloseConnection()
+
+			return nil, nil, cli.UsageError(fmt.Errorf("opening '--cache-file': %w", err))
+		}
+	}
+
+	return v, func() {
+		_ = ocm.CloseConnection()
+
+		if v.cache != nil {
+			_ = v.cache.Close()
+		}
+	}, nil
+}
+
+// selectValidators returns the filter selecting the validators of runner
+// given by the '--enabled', '--disabled', tag and '--profile' flags of
+// opts together with the profile mapping their severities.
+func selectValidators(ctx context.Context, runner *validator.Runner, opts *options) (validator.Filter, cli.Profile, error) {
+	filter, err := cli.ValidatorFilter(ctx, runner, opts.Disabled, opts.Enabled)
+	if err != nil {
+		return nil, cli.Profile{}, cli.UsageError(fmt.Errorf("generating validator filter: %w", err))
+	}
+
+	tagFilter, err := cli.TagFilter(opts.DisabledTags, opts.EnabledTags)
+	if err != nil {
+		return nil, cli.Profile{}, cli.UsageError(fmt.Errorf("generating validator filter: %w", err))
+	}
+
+	profile, err := cli.LookupProfile(opts.Profile)
+	if err != nil {
+		return nil, cli.Profile{}, cli.UsageError(fmt.Errorf("generating validator filter: %w", err))
+	}
+
+	return validator.All(filter, tagFilter, profile.Filter), profile, nil
+}
+
+// newRunner returns the runner shared by all validations of a single
+// environment using the given OCM client and the clients given by opts.
+func newRunner(ctx context.Context, opts *options, ocm validator.OCMClient) (*validator.Runner, error) {
+	runnerOpts := []validator.RunnerOption{
+		validator.WithConcurrency(opts.Concurrency),
+		validator.WithTimeout(opts.ValidatorTimeout),
+		validator.WithMiddleware{
+			validator.NewRetryMiddleware(),
+		},
+		validator.WithOCMClient{OCMClient: ocm},
+		validator.WithValidatorOptions{
+			validator.WithExcludedNamespaces(opts.ExcludedNamespaces),
+			validator.WithRBACAllowlist(opts.RBACAllowlist),
+		},
+	}
+
+	if opts.Kubeconfig != "" {
+		cluster, err := cli.NewClusterClient(opts.Kubeconfig, opts.DryRunNamespace)
 		if err != nil {
-			return fmt.Errorf("initializing ocm client: %w", err)
+			return nil, cli.InfrastructureError(fmt.Errorf("initializing cluster client: %w", err))
 		}
 
-		defer func() { _ = ocm.CloseConnection() }()
+		runnerOpts = append(runnerOpts, validator.WithClusterClient{ClusterClient: cluster})
+	}
 
-		runner, err := validator.NewRunner(
-			validator.WithMiddleware{
-				validator.NewRetryMiddleware(),
-			},
-			validator.WithOCMClient{OCMClient: ocm},
-			validator.WithValidatorOptions{
-				validator.WithExcludedNamespaces(opts.ExcludedNamespaces),
-			},
-		)
+	if opts.Preflight {
+		runnerOpts = append(runnerOpts, validator.WithPreflightRunner{
+			PreflightRunner: validator.NewPreflightRunner(
+				validator.WithPreflightBinary(opts.PreflightBinary),
+				validator.WithDockerConfig(opts.DockerConfig),
+			),
+		})
+	}
+
+	runner, err := validator.NewRunner(ctx, runnerOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("initializing validators: %w", err)
+	}
+
+	return runner, nil
+}
+
+// withTimeout returns a context which is cancelled after
+// timeout unless timeout is less than one.
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+// timeoutError returns err noting the exceeded '--timeout' if the
+// deadline of ctx passed. Errors of image pulls do not always wrap
+// the context error so only ctx is inspected.
+func timeoutError(ctx context.Context, opts *options, err error) error {
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+
+	return cli.InfrastructureError(fmt.Errorf("validation exceeded '--timeout' of %s: %w", opts.Timeout, err))
+}
+
+// outcomeError returns the error matching the outcome of results.
+// Validator errors are infrastructure errors unless validators
+// also failed or encountered internal errors as retrying does
+// not resolve either. Failures are blocking from severity failOn.
+func outcomeError(results validator.ResultList, failOn validator.Severity) error {
+	var failed, internal bool
+
+	for _, res := range results {
+		if res.IsBlockingAt(failOn) && !res.IsError() {
+			failed = true
+		}
+
+		if res.IsInternalError() {
+			internal = true
+		}
+	}
+
+	if len(results.Errors()) > 0 {
+		if failed || internal {
+			return cli.WithExitCode(cli.ExitFailure, ErrValidationErrored)
+		}
+
+		return cli.InfrastructureError(ErrValidationErrored)
+	}
+
+	if failed {
+		return ErrValidationFailed
+	}
+
+	return nil
+}
+
+// stdinArg is given instead of an addon dir to read the addon metadata from stdin.
+const stdinArg = "-"
+
+// verifyMetadataArgs verifies that addon metadata read from stdin or
+// '--metadata-url' is validated once as there is no addon dir to watch
+// or compare with a git revision.
+func verifyMetadataArgs(opts *options, addonArgs []string) error {
+	if opts.MetadataURL == "" && !slices.Contains(addonArgs, stdinArg) {
+		return nil
+	}
+
+	if len(addonArgs) > 1 {
+		return fmt.Errorf("'%s' requires a single addon dir", stdinArg)
+	}
+
+	for _, f := range []struct {
+		name string
+		set  bool
+	}{
+		{"watch", opts.Watch},
+		{"interactive", opts.Interactive},
+		{"changed-since", opts.ChangedSince != ""},
+	} {
+		if f.set {
+			return fmt.Errorf("'--%s' requires an addon dir", f.name)
+		}
+	}
+
+	return nil
+}
+
+// expandAddonArgs expands glob patterns among the given addon dirs. Only
+// directories matched by patterns are returned; other arguments are
+// returned unchanged and verified when they are validated.
+func expandAddonArgs(args []string) ([]string, error) {
+	res := make([]string, 0, len(args))
+
+	for _, arg := range args {
+		if metadata.IsRemote(arg) || !strings.ContainsAny(arg, "*?[") {
+			res = append(res, arg)
+
+			continue
+		}
+
+		matches, err := filepath.Glob(arg)
 		if err != nil {
-			return fmt.Errorf("initializing validators: %w", err)
+			return nil, fmt.Errorf("expanding %q: %w", arg, err)
+		}
+
+		var dirs []string
+
+		for _, m := range matches {
+			if info, err := os.Stat(m); err == nil && info.IsDir() {
+				dirs = append(dirs, m)
+			}
 		}
 
-		mb := types.MetaBundle{
-			AddonMeta: meta,
-			Bundles:   bundles,
+		if len(dirs) == 0 {
+			return nil, fmt.Errorf("no addon dirs match %q", arg)
+		}
+
+		res = append(res, dirs...)
+	}
+
+	return res, nil
+}
+
+// addonValidator validates addons of a single environment sharing
+// the extractor, clients and notifiers of a single invocation.
+type addonValidator struct {
+	cmd        *cobra.Command
+	opts       *options
+	env        string
+	extractor  *extractor.MainExtractor
+	filter     validator.Filter
+	severities validator.SeverityFunc
+	runner     *validator.Runner
+	notifiers  []notify.Notifier
+	strategy   metadata.VersionStrategy
+	replay     *cli.RunManifest
+	// bulk is true if more than one addon is validated.
+	bulk bool
+	// watch is true if the addon is validated repeatedly on changes.
+	watch bool
+	// interactive is true if results are shown in a browser.
+	interactive bool
+	// metaBundle is the last validated addon including its bundles.
+	metaBundle *types.MetaBundle
+	// baseline holds the known failures of all addons.
+	baseline *cli.Baseline
+	// cache holds the results of earlier runs if '--cache-file' is given.
+	cache *pkgvalidate.BoltCache
+}
+
+// addonOutcome is the outcome of validating a single addon.
+type addonOutcome struct {
+	Env   string
+	Addon string
+	// Err is set if the addon could not be validated.
+	Err    error
+	Report pkgvalidate.Report
+	// Results are the results of Report without
+	// known failures; they determine the outcome.
+	Results validator.ResultList
+	// FailOn is the lowest severity of blocking failures.
+	FailOn validator.Severity
+}
+
+// ValidateAll validates every addon even if others could not be
+// validated unless '--fail-fast' is given. An error is only
+// returned if ctx is cancelled.
+func (v *addonValidator) ValidateAll(ctx context.Context, addonArgs []string) ([]addonOutcome, error) {
+	outcomes := make([]addonOutcome, 0, len(addonArgs))
+
+	for _, arg := range addonArgs {
+		report, err := v.Validate(ctx, arg)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+
+			fmt.Fprintf(v.cmd.ErrOrStderr(), "validating %q (%s): %v\n", arg, v.env, err)
 		}
 
 		var results validator.ResultList
+		if err == nil {
+			results = v.applyBaseline(v.cmd.ErrOrStderr(), report)
+		}
+
+		outcomes = append(outcomes, addonOutcome{
+			Env:     v.env,
+			Addon:   arg,
+			Err:     err,
+			Report:  report,
+			Results: results,
+			FailOn:  v.opts.failOnSeverity(),
+		})
+
+		if v.opts.FailFast && (err != nil || results.HasBlockingAt(v.opts.failOnSeverity())) {
+			if skipped := len(addonArgs) - len(outcomes); skipped > 0 {
+				fmt.Fprintf(v.cmd.ErrOrStderr(), "skipping %d remaining addon(s) ('--fail-fast')\n", skipped)
+			}
 
-		for res := range runner.Run(ctx, mb, filter) {
-			results = append(results, res)
+			break
 		}
+	}
 
-		sort.Sort(results)
+	return outcomes, nil
+}
 
-		table, err := cli.NewTable(
-			cli.WithHeaders{"STATUS", "CODE", "NAME", "DESCRIPTION", "FAILURE MESSAGE"},
-		)
-		if err != nil {
-			return fmt.Errorf("initializing table: %w", err)
+// summaryWriter returns the writer summaries are printed to;
+// they must not interfere with JSON reports on stdout.
+func summaryWriter(cmd *cobra.Command, opts *options) io.Writer {
+	if opts.Output == outputJSON {
+		return cmd.ErrOrStderr()
+	}
+
+	return cmd.OutOrStdout()
+}
+
+type outcomeCounts struct {
+	Passed, Failed, Errored, Invalid int
+}
+
+func (c *outcomeCounts) add(o addonOutcome) {
+	err := outcomeError(o.Results, o.FailOn)
+
+	switch {
+	case o.Err != nil:
+		c.Invalid++
+	case errors.Is(err, ErrValidationErrored):
+		c.Errored++
+	case errors.Is(err, ErrValidationFailed):
+		c.Failed++
+	default:
+		c.Passed++
+	}
+}
+
+func (c outcomeCounts) String() string {
+	return fmt.Sprintf("%d addons: %d passed, %d failed, %d errored, %d could not be validated",
+		c.Passed+c.Failed+c.Errored+c.Invalid, c.Passed, c.Failed, c.Errored, c.Invalid,
+	)
+}
+
+// printSummary prints a table of all outcomes followed by their totals.
+// If withEnv is set the table includes the environment of each outcome
+// and totals are also printed per environment.
+func printSummary(out io.Writer, outcomes []addonOutcome, withEnv bool) {
+	headers := []string{"ADDON", "STATUS", "PASSED", "WARNED", "FAILED", "ERRORED"}
+	if withEnv {
+		headers = append([]string{"ENV"}, headers...)
+	}
+
+	table, err := cli.NewTable(cli.WithHeaders(headers))
+	if err != nil {
+		// headers are static so this is a programming error
+		panic(err)
+	}
+
+	var (
+		total outcomeCounts
+		envs  []string
+	)
+
+	perEnv := make(map[string]*outcomeCounts)
+
+	for _, o := range outcomes {
+		total.add(o)
+
+		if _, ok := perEnv[o.Env]; !ok {
+			envs = append(envs, o.Env)
+			perEnv[o.Env] = &outcomeCounts{}
 		}
-		for _, res := range results {
-			writeResult(table, res)
+
+		perEnv[o.Env].add(o)
+
+		var row cli.TableRow
+
+		if withEnv {
+			row = append(row, cli.Field{Value: o.Env})
 		}
 
-		out := cmd.OutOrStdout()
+		table.WriteRow(append(row, outcomeFields(o)...))
+	}
+
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, table.String())
+
+	if withEnv {
+		for _, env := range envs {
+			fmt.Fprintf(out, "%s: %s\n", env, perEnv[env])
+		}
+	}
 
-		fmt.Fprintln(out, table.String())
-		fmt.Fprintln(out)
-		fmt.Fprintln(out, "Please consult corresponding validator wikis: https://github.com/mt-sre/addon-metadata-operator/wiki/<code>.")
+	fmt.Fprintln(out, total)
+}
 
-		if errs := results.Errors(); len(errs) > 0 {
-			cli.PrintValidationErrors(errs)
-			return ErrValidationErrored
+func outcomeFields(o addonOutcome) cli.TableRow {
+	if o.Err != nil {
+		return cli.TableRow{
+			cli.Field{Value: o.Addon},
+			cli.Field{Value: "Invalid", Color: cli.FieldColorIntenselyBoldRed},
+			cli.Field{Value: "-"}, cli.Field{Value: "-"}, cli.Field{Value: "-"}, cli.Field{Value: "-"},
 		}
+	}
 
-		if results.HasFailure() {
-			return ErrValidationFailed
+	var passed, warned, failed, errored int
+
+	for _, res := range o.Results {
+		switch {
+		case res.IsSkipped():
+			continue
+		case res.IsSuccess():
+			passed++
+		case res.IsError():
+			errored++
+		case res.IsBlockingAt(o.FailOn):
+			failed++
+		default:
+			warned++
 		}
+	}
 
-		return nil
+	status := cli.Field{Value: "Success", Color: cli.FieldColorGreen}
+
+	switch err := outcomeError(o.Results, o.FailOn); {
+	case errors.Is(err, ErrValidationErrored):
+		status = cli.Field{Value: "Error", Color: cli.FieldColorIntenselyBoldRed}
+	case errors.Is(err, ErrValidationFailed):
+		status = cli.Field{Value: "Failed", Color: cli.FieldColorRed}
+	case warned > 0:
+		status = cli.Field{Value: "Warning", Color: cli.FieldColorYellow}
+	}
+
+	return cli.TableRow{
+		cli.Field{Value: o.Addon},
+		status,
+		cli.Field{Value: strconv.Itoa(passed)},
+		cli.Field{Value: strconv.Itoa(warned)},
+		cli.Field{Value: strconv.Itoa(failed)},
+		cli.Field{Value: strconv.Itoa(errored)},
 	}
 }
 
-func parseAddonDir(dir string) (string, error) {
-	if !path.IsAbs(dir) {
-		return filepath.Abs(dir)
+// summaryError returns an error reflecting the worst of all outcomes.
+// It only has the infrastructure exit code if all problems of all
+// addons were infrastructure errors as retrying does not help otherwise.
+func summaryError(outcomes []addonOutcome) error {
+	var (
+		counts outcomeCounts
+		code   = cli.ExitInfrastructure
+	)
+
+	for _, o := range outcomes {
+		counts.add(o)
+
+		err := o.Err
+		if err == nil {
+			err = outcomeError(o.Results, o.FailOn)
+		}
+
+		if err != nil && cli.ExitCode(err) != cli.ExitInfrastructure {
+			code = cli.ExitFailure
+		}
 	}
 
-	return dir, nil
+	var err error
+
+	switch {
+	case counts.Invalid > 0:
+		err = fmt.Errorf("%d of %d addons could not be validated", counts.Invalid, len(outcomes))
+	case counts.Errored > 0:
+		err = ErrValidationErrored
+	case counts.Failed > 0:
+		err = ErrValidationFailed
+	default:
+		return nil
+	}
+
+	return cli.WithExitCode(code, err)
 }
 
-// addonDir is an absolute path at this point
-func verifyAddonDir(addonDir string) error {
-	dir, err := os.Stat(addonDir)
+// runValidators runs all selected validators against mb which also
+// satisfy the given filters.
+func (v *addonValidator) runValidators(ctx context.Context, mb types.MetaBundle, filters ...validator.Filter) (pkgvalidate.Report, error) {
+	all := append(pkgvalidate.WithFilters{v.filter, validator.EnabledForEnv(v.env)}, filters...)
+
+	// validators depending on remote services are too slow to
+	// rerun on every change
+	if v.watch {
+		all = append(all, validator.Not(validator.MatchesTags(validator.TagService)))
+	}
+
+	all = append(all, v.opts.ScopeFilter())
+
+	report, err := pkgvalidate.Run(ctx, mb,
+		all,
+		pkgvalidate.WithRunner{Runner: v.runner},
+		pkgvalidate.WithFailFast(v.opts.FailFast),
+		pkgvalidate.WithFailOn(v.opts.failOnSeverity()),
+		pkgvalidate.WithSeverities(v.severities),
+		v.cacheFor(ctx, mb),
+	)
 	if err != nil {
-		return fmt.Errorf("error while reading directory: %w", err)
+		return report, err
 	}
 
-	if !dir.IsDir() {
-		return fmt.Errorf("%q is not a directory", addonDir)
+	if report.Incomplete {
+		fmt.Fprintf(v.cmd.ErrOrStderr(), "%s: stopped at the first unsuccessful validator ('--fail-fast'); the remaining ones were not run\n", report.Addon)
 	}
 
-	return nil
+	return report, nil
+}
+
+// cacheFor returns the option reusing the results of earlier runs for mb.
+// Caching is disabled if no cache is configured or the digest of the
+// index image the bundles of mb were extracted from cannot be resolved.
+func (v *addonValidator) cacheFor(ctx context.Context, mb types.MetaBundle) pkgvalidate.WithCache {
+	if v.cache == nil {
+		return pkgvalidate.WithCache{}
+	}
+
+	info := cli.GetBuildInfo()
+
+	cache := pkgvalidate.WithCache{
+		Cache:   v.cache,
+		Version: info.Version + "+" + info.Commit,
+	}
+
+	if len(mb.Bundles) == 0 {
+		return cache
+	}
+
+	pinned := *mb.AddonMeta.IndexImage
+	if !strings.Contains(pinned, "@sha256:") {
+		var err error
+
+		if pinned, err = cli.ResolveImageDigest(ctx, pinned); err != nil {
+			logr.FromContextOrDiscard(ctx).Info("not caching results", "error", err.Error())
+
+			return pkgvalidate.WithCache{}
+		}
+	}
+
+	cache.IndexDigest = pinned[strings.LastIndex(pinned, "@")+1:]
+
+	return cache
 }
 
-func generateFilter(disabled, enabled string) (validator.Filter, error) {
-	if disabled == "" && enabled == "" {
-		return nil, nil
+// Validate validates the addon at addonArg, prints its report and
+// performs all configured side effects (report files, run manifests
+// and notifications). An error is only returned if the addon could
+// not be validated.
+func (v *addonValidator) Validate(ctx context.Context, addonArg string) (pkgvalidate.Report, error) {
+	opts := v.opts
+
+	manifest := cli.RunManifest{StartedAt: time.Now().UTC()}
+
+	addonDir := addonArg
+
+	loaderOpts := []metadata.LoaderOption{
+		metadata.WithEnv(v.env),
+		metadata.WithVersion(opts.Version),
+		metadata.WithChecksums(opts.Checksums),
+		metadata.WithStrict(opts.Strict),
+		metadata.WithVersionStrategy{VersionStrategy: v.strategy},
 	}
 
-	if disabled != "" {
-		codes, err := parseCodeList(disabled)
+	switch {
+	case addonArg == stdinArg:
+		data, err := io.ReadAll(v.cmd.InOrStdin())
 		if err != nil {
-			return nil, fmt.Errorf("unable to process '--disabled' option argument: %w", err)
+			return pkgvalidate.Report{}, fmt.Errorf("reading addon metadata from stdin: %w", err)
+		}
+
+		loaderOpts = append(loaderOpts, metadata.WithMetadata(data))
+	case addonArg == opts.MetadataURL:
+		data, err := metadata.FetchFile(ctx, addonArg, nil)
+		if err != nil {
+			return pkgvalidate.Report{}, cli.InfrastructureError(fmt.Errorf("fetching addon metadata: %w", err))
+		}
+
+		loaderOpts = append(loaderOpts, metadata.WithMetadata(data))
+	case metadata.IsRemote(addonDir):
+	default:
+		var err error
+
+		addonDir, err = parseAddonDir(addonArg)
+		if err != nil {
+			return pkgvalidate.Report{}, cli.UsageError(fmt.Errorf("parsing addon dir %q: %w", addonArg, err))
+		}
+
+		if err := verifyAddonDir(addonDir); err != nil {
+			return pkgvalidate.Report{}, cli.UsageError(fmt.Errorf("verifying addon dir %q: %w", addonDir, err))
+		}
+	}
+
+	loader := metadata.NewLoader(addonDir, loaderOpts...)
+
+	mb, err := loader.LoadMetaBundle(ctx)
+	if err != nil {
+		return pkgvalidate.Report{}, fmt.Errorf("loading addon metadata from '%s': %w", addonDir, err)
+	}
+
+	if v.replay != nil {
+		pinIndexImage(ctx, mb, v.replay.Addon)
+	}
+
+	if opts.RunManifest != "" {
+		manifest.Addon = recordAddon(ctx, addonArg, mb)
+	}
+
+	switch {
+	case opts.SkipsBundles():
+	case opts.BundlesDir != "":
+		bundlesDir := filepath.Join(opts.BundlesDir, mb.AddonMeta.OperatorName)
+
+		bundles, err := operator.NewBundlesFromDirectory(bundlesDir)
+		if err != nil {
+			return pkgvalidate.Report{}, cli.UsageError(fmt.Errorf("loading addon bundles from '%s': %w", bundlesDir, err))
+		}
+
+		mb.Bundles = bundles
+	default:
+		bundles, err := v.extractor.ExtractBundles(ctx, *mb.AddonMeta.IndexImage, mb.AddonMeta.OperatorName)
+		if err != nil {
+			return pkgvalidate.Report{}, cli.InfrastructureError(fmt.Errorf("extracting and parsing addon bundles: %w", err))
+		}
+
+		mb.Bundles = bundles
+	}
+
+	bundleImages := bundleImages(mb.Bundles)
+
+	if v.replay != nil && !slices.Equal(bundleImages, v.replay.Addon.BundleImages) {
+		logr.FromContextOrDiscard(ctx).Info("extracted bundle images differ from recorded run",
+			"recorded", v.replay.Addon.BundleImages,
+			"extracted", bundleImages,
+		)
+	}
+
+	if excluded := validator.ExcludedCodes(mb.AddonMeta); len(excluded) > 0 {
+		fmt.Fprintf(v.cmd.ErrOrStderr(), "%s: skipping validator(s) excluded by the addon metadata: %s\n",
+			mb.AddonMeta.ID, joinCodes(excluded),
+		)
+	}
+
+	report, err := v.runValidators(ctx, *mb)
+	if err != nil {
+		return pkgvalidate.Report{}, cli.InfrastructureError(err)
+	}
+
+	v.metaBundle = mb
+
+	out := v.cmd.OutOrStdout()
+
+	// interactive results are shown by the browser instead
+	switch {
+	case v.interactive:
+	case opts.Output == outputJSON:
+		if err := printJSONReport(out, report); err != nil {
+			return pkgvalidate.Report{}, err
+		}
+	case opts.Quiet:
+		if err := v.printQuietReport(out, mb.AddonMeta.ID, addonArg, report.Results); err != nil {
+			return pkgvalidate.Report{}, err
+		}
+	default:
+		if v.bulk {
+			fmt.Fprintf(out, "\n%s (%s, %s):\n", mb.AddonMeta.ID, addonArg, v.env)
+		}
+
+		if err := printTableReport(out, report.Results); err != nil {
+			return pkgvalidate.Report{}, err
+		}
+	}
+
+	switch opts.Report {
+	case reportJUnit:
+		err = writeReportFile(opts.ReportFile, func(w io.Writer) error {
+			return report.WriteJUnit(w, mb.AddonMeta.ID)
+		})
+	case reportSARIF:
+		sarifOpts := []pkgvalidate.SARIFOption{
+			pkgvalidate.WithToolVersion(cli.GetBuildInfo().Version),
+			pkgvalidate.WithDefaultFile(loader.MetadataPath()),
+		}
+
+		// locations of remote addons and metadata read from stdin
+		// cannot be resolved to local files
+		if !metadata.IsRemote(addonArg) && addonArg != stdinArg {
+			sarifOpts = append(sarifOpts, pkgvalidate.WithBaseDir(filepath.ToSlash(filepath.Clean(addonArg))))
+		}
+
+		err = writeReportFile(opts.ReportFile, func(w io.Writer) error {
+			return report.WriteSARIF(w, sarifOpts...)
+		})
+	case reportMarkdown:
+		err = writeReportFile(opts.ReportFile, func(w io.Writer) error {
+			return report.WriteMarkdown(w, reportTitle(mb.AddonMeta.ID, v.env))
+		})
+	case reportHTML:
+		err = writeHTMLReport(opts.ReportDir, reportTitle(mb.AddonMeta.ID, v.env), report, *mb)
+	}
+
+	if err != nil {
+		return pkgvalidate.Report{}, err
+	}
+
+	if opts.RunManifest != "" {
+		manifest.Mtcli = cli.GetBuildInfo()
+		manifest.Addon.BundleImages = bundleImages
+		manifest.Flags = cli.RecordFlags(v.cmd.Flags(), unrecordedFlags...)
+		manifest.FinishedAt = time.Now().UTC()
+
+		if manifest.Validators, err = cli.GetValidatorRegistry(ctx); err != nil {
+			return pkgvalidate.Report{}, err
+		}
+
+		if err := cli.WriteRunManifest(opts.RunManifest, manifest); err != nil {
+			return pkgvalidate.Report{}, err
+		}
+	}
+
+	summary := notify.Summary{
+		Addon:     mb.AddonMeta.ID,
+		Env:       v.env,
+		Version:   opts.Version,
+		Report:    report,
+		ReportURL: opts.ReportURL,
+	}
+
+	// failing to notify does not change the outcome of the validation
+	if err := notify.NotifyAll(ctx, summary, v.notifiers...); err != nil {
+		fmt.Fprintf(v.cmd.ErrOrStderr(), "sending notifications: %v\n", err)
+	}
+
+	return report, nil
+}
+
+// replayRun loads the run manifest at path and applies the recorded
+// flags which were not given explicitly. Discrepancies between the
+// recorded and the current run which cannot be replayed are logged.
+func replayRun(ctx context.Context, cmd *cobra.Command, path string) (cli.RunManifest, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	recorded, err := cli.LoadRunManifest(path)
+	if err != nil {
+		return cli.RunManifest{}, err
+	}
+
+	vals, err := cli.GetValidatorRegistry(ctx)
+	if err != nil {
+		return cli.RunManifest{}, err
+	}
+
+	for _, msg := range recorded.Discrepancies(cli.GetBuildInfo(), vals) {
+		log.Info(msg)
+	}
+
+	unknown, err := cli.SetFlags(cmd.LocalFlags(), recorded.Flags)
+	if err != nil {
+		return cli.RunManifest{}, err
+	}
+
+	// global flags have been applied before the run started
+	// so they can only be compared with the recorded ones
+	for _, name := range unknown {
+		f := cmd.InheritedFlags().Lookup(name)
+		if f == nil {
+			log.Info("ignoring unknown recorded flag", "flag", name)
+
+			continue
+		}
+
+		if current := cli.FlagValues(f); !slices.Equal(current, recorded.Flags[name]) {
+			log.Info("global flag differs from recorded run; pass it explicitly to replay it",
+				"flag", name,
+				"recorded", recorded.Flags[name],
+				"current", current,
+			)
 		}
+	}
+
+	return recorded, nil
+}
+
+// pinIndexImage replaces the index image of mb with
+// the digest reference recorded in addon if available.
+func pinIndexImage(ctx context.Context, mb *types.MetaBundle, addon cli.RunManifestAddon) {
+	pinned := addon.PinnedIndexImage()
+	if pinned == "" {
+		return
+	}
 
-		return validator.Not(validator.MatchesCodes(codes...)), nil
+	if current := *mb.AddonMeta.IndexImage; current != addon.IndexImage {
+		logr.FromContextOrDiscard(ctx).Info("index image differs from recorded run; using recorded image",
+			"recorded", addon.IndexImage,
+			"current", current,
+		)
 	}
 
-	codes, err := parseCodeList(enabled)
+	mb.AddonMeta.IndexImage = &pinned
+}
+
+// recordAddon describes the addon of mb for a run manifest resolving the
+// digest of its index image. Failing to resolve the digest is logged
+// but does not fail the run.
+func recordAddon(ctx context.Context, dir string, mb *types.MetaBundle) cli.RunManifestAddon {
+	addon := cli.RunManifestAddon{
+		Dir:        dir,
+		ID:         mb.AddonMeta.ID,
+		IndexImage: *mb.AddonMeta.IndexImage,
+	}
+
+	pinned, err := cli.ResolveImageDigest(ctx, addon.IndexImage)
 	if err != nil {
-		return nil, fmt.Errorf("unable to process '--enabled' option argument: %w", err)
+		logr.FromContextOrDiscard(ctx).Info("recording index image without digest", "error", err.Error())
+
+		return addon
 	}
 
-	return validator.MatchesCodes(codes...), nil
+	addon.IndexImageDigest = pinned
+
+	return addon
 }
 
-func parseCodeList(maybeList string) ([]validator.Code, error) {
-	rawStrings := strings.Split(maybeList, ",")
+func bundleImages(bundles []operator.Bundle) []string {
+	res := make([]string, 0, len(bundles))
+
+	for _, b := range bundles {
+		// bundles read from disk have no bundle image
+		if b.BundleImage == "" {
+			continue
+		}
+
+		res = append(res, b.BundleImage)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
 
-	res := make([]validator.Code, 0, len(rawStrings))
+func parseNotifiers(specs []string) ([]notify.Notifier, error) {
+	notifiers := make([]notify.Notifier, 0, len(specs))
 
-	for _, s := range rawStrings {
-		c, err := validator.ParseCode(s)
+	for _, spec := range specs {
+		n, err := notify.Parse(spec)
 		if err != nil {
-			return nil, fmt.Errorf("invalid code list '%s': %w", maybeList, err)
+			return nil, err
 		}
 
-		res = append(res, c)
+		notifiers = append(notifiers, n)
 	}
 
-	return res, nil
+	return notifiers, nil
 }
 
-func envToOCMURL(env string) string {
-	envToUrl := map[string]string{
-		"stage":       "https://api.stage.openshift.com",
-		"integration": "https://api.integration.openshift.com",
-		"production":  "https://api.openshift.com",
+func printJSONReport(out io.Writer, report pkgvalidate.Report) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(report); err != nil {
+		return fmt.Errorf("encoding report: %w", err)
 	}
 
-	return envToUrl[env]
+	return nil
+}
+
+func reportTitle(addonID, env string) string {
+	return fmt.Sprintf("Validation of %s (%s)", addonID, env)
+}
+
+// writeHTMLReport writes the HTML report as 'index.html' to dir
+// along with the JSON report it was rendered from.
+func writeHTMLReport(dir, title string, report pkgvalidate.Report, mb types.MetaBundle) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating report dir: %w", err)
+	}
+
+	if err := writeReportFile(filepath.Join(dir, "index.html"), func(w io.Writer) error {
+		return report.WriteHTML(w, title, mb)
+	}); err != nil {
+		return err
+	}
+
+	return writeReportFile(filepath.Join(dir, "report.json"), func(w io.Writer) error {
+		return printJSONReport(w, report)
+	})
+}
+
+func writeReportFile(path string, write func(io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating report file: %w", err)
+	}
+
+	if err := write(f); err != nil {
+		f.Close()
+
+		return fmt.Errorf("writing report file: %w", err)
+	}
+
+	return f.Close()
+}
+
+// printQuietReport prints only the results of validators which did not
+// succeed. Nothing is printed if all validators succeeded.
+func (v *addonValidator) printQuietReport(out io.Writer, addonID, addonArg string, results validator.ResultList) error {
+	var unsuccessful validator.ResultList
+
+	for _, res := range results {
+		if !res.IsSuccess() && !res.IsSkipped() {
+			unsuccessful = append(unsuccessful, res)
+		}
+	}
+
+	if len(unsuccessful) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(out, "\n%s (%s, %s):\n", addonID, addonArg, v.env)
+
+	return printTableReport(out, unsuccessful)
+}
+
+func printTableReport(out io.Writer, results validator.ResultList) error {
+	table, err := cli.NewTable(
+		cli.WithHeaders{"STATUS", "CODE", "NAME", "DESCRIPTION", "FAILURE MESSAGE"},
+	)
+	if err != nil {
+		return fmt.Errorf("initializing table: %w", err)
+	}
+	for _, res := range results {
+		writeResult(table, res)
+	}
+
+	fmt.Fprintln(out, table.String())
+
+	printRemediations(out, results)
+
+	if errs := results.Errors(); len(errs) > 0 {
+		cli.PrintValidationErrors(errs)
+	}
+
+	return nil
+}
+
+// printRemediations prints how to resolve each failure
+// together with a link to the validator's documentation.
+func printRemediations(out io.Writer, results validator.ResultList) {
+	var failed validator.ResultList
+
+	for _, res := range results {
+		if !res.IsSuccess() && !res.IsError() && !res.IsSkipped() {
+			failed = append(failed, res)
+		}
+	}
+
+	if len(failed) == 0 {
+		return
+	}
+
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "How to fix:")
+
+	for _, res := range failed {
+		remediation := res.Remediation
+		if remediation == "" {
+			remediation = "See the documentation."
+		}
+
+		fmt.Fprintf(out, "  %s %s: %s\n", res.Code, res.Name, remediation)
+		fmt.Fprintf(out, "    %s\n", validator.Docs{DocsURL: res.DocsURL}.EffectiveDocsURL(res.Code))
+	}
+}
+
+func parseAddonDir(dir string) (string, error) {
+	if !path.IsAbs(dir) {
+		return filepath.Abs(dir)
+	}
+
+	return dir, nil
+}
+
+// addonDir is an absolute path at this point
+func verifyAddonDir(addonDir string) error {
+	dir, err := os.Stat(addonDir)
+	if err != nil {
+		return fmt.Errorf("error while reading directory: %w", err)
+	}
+
+	if !dir.IsDir() {
+		return fmt.Errorf("%q is not a directory", addonDir)
+	}
+
+	return nil
 }
 
 func writeResult(t *cli.Table, res validator.Result) {
@@ -255,13 +1391,31 @@ func writeResult(t *cli.Table, res validator.Result) {
 		t.WriteRow(append(row, cli.Field{Value: "None"}))
 	} else if res.IsError() {
 		t.WriteRow(append(row, cli.Field{Value: res.Error.Error()}))
+	} else if res.IsSkipped() {
+		t.WriteRow(append(row, cli.Field{Value: res.SkipReason}))
 	} else {
-		for _, msg := range res.FailureMsgs {
+		msgs := res.Messages()
+		// failures must be listed even if the validator did not report why
+		if len(msgs) == 0 {
+			msgs = []string{"None reported"}
+		}
+
+		for _, msg := range msgs {
 			t.WriteRow(append(row, cli.Field{Value: msg}))
 		}
 	}
 }
 
+// severityStatus returns the status shown for
+// failures of a non-blocking severity.
+func severityStatus(sev validator.Severity) string {
+	if sev == validator.SeverityInfo {
+		return "Info"
+	}
+
+	return "Warning"
+}
+
 func resultToRow(res validator.Result) cli.TableRow {
 	var status cli.Field
 
@@ -270,11 +1424,28 @@ func resultToRow(res validator.Result) cli.TableRow {
 			Value: "Success",
 			Color: cli.FieldColorGreen,
 		}
+	} else if res.IsSkipped() {
+		status = cli.Field{Value: "Skipped"}
+	} else if res.IsTimeout() {
+		status = cli.Field{
+			Value: "Timeout",
+			Color: cli.FieldColorYellow,
+		}
+	} else if res.IsInternalError() {
+		status = cli.Field{
+			Value: "Internal Error",
+			Color: cli.FieldColorIntenselyBoldRed,
+		}
 	} else if res.IsError() {
 		status = cli.Field{
 			Value: "Error",
 			Color: cli.FieldColorIntenselyBoldRed,
 		}
+	} else if !res.IsBlocking() {
+		status = cli.Field{
+			Value: severityStatus(res.Severity),
+			Color: cli.FieldColorYellow,
+		}
 	} else {
 		status = cli.Field{
 			Value: "Failed",
@@ -0,0 +1,119 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	"github.com/mt-sre/addon-metadata-operator/pkg/extractor"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/spf13/cobra"
+)
+
+const indexLong = `Validate the bundles of an operator package in an index image without addon
+metadata, e.g. before an addon is onboarded. Only validators checking bundles
+alone are run; validators comparing bundles with the addon metadata (tagged
+'consistency') are skipped.`
+
+var ErrNoBundles = errors.New("no bundles found")
+
+func indexCmd(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "index <index_image>",
+		Short:         "Validate the bundles of an index image without addon metadata.",
+		Long:          indexLong,
+		Args:          usageArgs(cobra.ExactArgs(1)),
+		RunE:          runIndex(opts),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	opts.AddPackageFlag(cmd.Flags())
+
+	return cmd
+}
+
+func runIndex(opts *options) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithCancel(cmd.Context())
+		defer cancel()
+
+		if err := opts.VerifyFlags(); err != nil {
+			return cli.UsageError(fmt.Errorf("verifying flags: %w", err))
+		}
+
+		if err := opts.VerifyIndexFlags(); err != nil {
+			return cli.UsageError(fmt.Errorf("verifying flags: %w", err))
+		}
+
+		indexImage := args[0]
+
+		v, closeValidator, err := newAddonValidator(ctx, cmd, opts, opts.Env, extractor.New())
+		if err != nil {
+			return err
+		}
+
+		defer closeValidator()
+
+		ctx, cancel = withTimeout(ctx, opts.Timeout)
+		defer cancel()
+
+		bundles, err := v.extractor.ExtractBundles(ctx, indexImage, opts.Package)
+		if err != nil {
+			return timeoutError(ctx, opts, cli.InfrastructureError(fmt.Errorf("extracting and parsing bundles: %w", err)))
+		}
+
+		if len(bundles) == 0 {
+			return cli.UsageError(fmt.Errorf("%w for package %q in %q", ErrNoBundles, opts.Package, indexImage))
+		}
+
+		// validators tagged 'consistency' are skipped, so the
+		// metadata only needs to identify the package
+		mb := types.MetaBundle{
+			AddonMeta: &v1alpha1.AddonMetadataSpec{
+				ID:           opts.Package,
+				OperatorName: opts.Package,
+				IndexImage:   &indexImage,
+			},
+			Bundles: bundles,
+		}
+
+		report, err := v.runValidators(ctx, mb,
+			validator.MatchesTags(validator.TagBundle),
+			validator.Not(validator.MatchesTags(validator.TagConsistency)),
+		)
+		if err != nil {
+			return timeoutError(ctx, opts, cli.InfrastructureError(err))
+		}
+
+		out := cmd.OutOrStdout()
+
+		switch {
+		case opts.Output == outputJSON:
+			if err := printJSONReport(out, report); err != nil {
+				return err
+			}
+		case opts.Quiet:
+			if err := v.printQuietReport(out, opts.Package, indexImage, report.Results); err != nil {
+				return err
+			}
+
+			printSummary(summaryWriter(cmd, opts), []addonOutcome{{
+				Env:     v.env,
+				Addon:   indexImage,
+				Report:  report,
+				Results: report.Results,
+				FailOn:  opts.failOnSeverity(),
+			}}, false)
+		default:
+			if err := printTableReport(out, report.Results); err != nil {
+				return err
+			}
+		}
+
+		return outcomeError(report.Results, opts.failOnSeverity())
+	}
+}
@@ -0,0 +1,59 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	"github.com/mt-sre/addon-metadata-operator/internal/tui"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+// Browse validates the addon at addonArg and shows the results in a
+// terminal UI. Validators are re-run against the bundles extracted
+// initially. The results shown last are printed once the UI is quit.
+func (v *addonValidator) Browse(ctx context.Context, addonArg string) error {
+	if !tui.IsTerminal(os.Stdin, os.Stdout) {
+		return cli.UsageError(errors.New("'--interactive' requires a terminal"))
+	}
+
+	v.interactive = true
+
+	// '--timeout' only applies to the initial run
+	runCtx, cancel := withTimeout(ctx, v.opts.Timeout)
+	defer cancel()
+
+	report, err := v.Validate(runCtx, addonArg)
+	if err != nil {
+		return timeoutError(runCtx, v.opts, err)
+	}
+
+	mb := *v.metaBundle
+
+	rerun := func(ctx context.Context, codes ...validator.Code) (validator.ResultList, error) {
+		var filters []validator.Filter
+		if len(codes) > 0 {
+			filters = append(filters, validator.MatchesCodes(codes...))
+		}
+
+		report, err := v.runValidators(ctx, mb, filters...)
+
+		return report.Results, err
+	}
+
+	browser := tui.NewBrowser(reportTitle(mb.AddonMeta.ID, v.env), report.Results, rerun)
+
+	if err := tui.Run(ctx, os.Stdin, os.Stdout, browser); err != nil {
+		return fmt.Errorf("running terminal UI: %w", err)
+	}
+
+	report.Results = browser.Results()
+
+	if err := printTableReport(v.cmd.OutOrStdout(), report.Results); err != nil {
+		return err
+	}
+
+	return outcomeError(v.applyBaseline(v.cmd.ErrOrStderr(), report), v.opts.failOnSeverity())
+}
@@ -3,7 +3,14 @@ package validate
 import (
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	"github.com/mt-sre/addon-metadata-operator/pkg/metadata"
+	"github.com/mt-sre/addon-metadata-operator/pkg/notify"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
 	"github.com/spf13/pflag"
 	"golang.org/x/mod/semver"
 )
@@ -13,7 +20,45 @@ type options struct {
 	Version            string
 	Disabled           string
 	Enabled            string
+	EnabledTags        []string
+	DisabledTags       []string
+	Profile            string
 	ExcludedNamespaces []string
+	RBACAllowlist      []string
+	Checksums          map[string]string
+	Strict             bool
+	VersionStrategy    string
+	Output             string
+	Kubeconfig         string
+	DryRunNamespace    string
+	Preflight          bool
+	PreflightBinary    string
+	DockerConfig       string
+	Notify             []string
+	ReportURL          string
+	RunManifest        string
+	Replay             string
+	Report             string
+	ReportFile         string
+	ReportDir          string
+	Concurrency        int
+	ChangedSince       string
+	Watch              bool
+	Interactive        bool
+	Timeout            time.Duration
+	ValidatorTimeout   time.Duration
+	Baseline           string
+	UpdateBaseline     bool
+	MetadataOnly       bool
+	BundlesDir         string
+	MetadataURL        string
+	Quiet              bool
+	Scopes             []string
+	Package            string
+	FailFast           bool
+	FailOn             string
+	CacheFile          string
+	DryRun             bool
 }
 
 func (o *options) AddEnvFlag(flags *pflag.FlagSet) {
@@ -39,7 +84,7 @@ func (o *options) AddDisabledFlag(flags *pflag.FlagSet) {
 		&o.Disabled,
 		"disabled",
 		o.Disabled,
-		"Disable specific validators, separated by ','. Can't be combined with --enabled.",
+		"Disable specific validators by code or code prefix (e.g. 'OSD'), separated by ','. Can't be combined with --enabled.",
 	)
 }
 
@@ -48,7 +93,35 @@ func (o *options) AddEnabledFlag(flags *pflag.FlagSet) {
 		&o.Enabled,
 		"enabled",
 		o.Enabled,
-		"Enable specific validators, separated by ','. Can't be combined with --disabled.",
+		"Enable specific validators by code or code prefix (e.g. 'OSD'), separated by ','. Can't be combined with --disabled.",
+	)
+}
+
+func (o *options) AddEnabledTagsFlag(flags *pflag.FlagSet) {
+	flags.StringSliceVar(
+		&o.EnabledTags,
+		"enabled-tags",
+		o.EnabledTags,
+		fmt.Sprintf("Only run validators with any of the given tags, separated by ','; any of %s.", strings.Join(validator.Tags, ", ")),
+	)
+}
+
+func (o *options) AddDisabledTagsFlag(flags *pflag.FlagSet) {
+	flags.StringSliceVar(
+		&o.DisabledTags,
+		"disabled-tags",
+		o.DisabledTags,
+		"Skip validators with any of the given tags, separated by ','. Takes precedence over '--enabled-tags'.",
+	)
+}
+
+func (o *options) AddProfileFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Profile,
+		"profile",
+		o.Profile,
+		fmt.Sprintf("Run the curated set of validators and severities of the given profile; one of %s. "+
+			"Combined with the other validator selection flags.", strings.Join(cli.ProfileNames(), ", ")),
 	)
 }
 
@@ -61,11 +134,408 @@ func (o *options) AddExcludedNamespacesFlag(flags *pflag.FlagSet) {
 	)
 }
 
+func (o *options) AddRBACAllowlistFlag(flags *pflag.FlagSet) {
+	flags.StringSliceVar(
+		&o.RBACAllowlist,
+		"rbac-allowlist",
+		o.RBACAllowlist,
+		"Resources given as 'resource[.group]' (e.g. 'secrets' or '*.example.com') operators may be granted privileged access to.",
+	)
+}
+
+func (o *options) AddChecksumFlag(flags *pflag.FlagSet) {
+	flags.StringToStringVar(
+		&o.Checksums,
+		"checksum",
+		o.Checksums,
+		"Expected sha256 sums of metadata files given as '<path relative to addon dir>=<sum>'.",
+	)
+}
+
+func (o *options) AddStrictFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&o.Strict,
+		"strict",
+		o.Strict,
+		"Fail when metadata files contain unknown fields. Use '--strict=false' to log a warning for them instead.",
+	)
+}
+
+func (o *options) AddVersionStrategyFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.VersionStrategy,
+		"version-strategy",
+		o.VersionStrategy,
+		fmt.Sprintf("How '--version latest' is resolved; one of %s.", strings.Join(metadata.VersionStrategyNames(), ", ")),
+	)
+}
+
+func (o *options) AddOutputFlag(flags *pflag.FlagSet) {
+	flags.StringVarP(
+		&o.Output,
+		"output",
+		"o",
+		o.Output,
+		"Output format; 'table' or 'json'. See 'mtcli schema export --type report' for the JSON format.",
+	)
+}
+
+func (o *options) AddKubeconfigFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Kubeconfig,
+		"kubeconfig",
+		o.Kubeconfig,
+		"Path to a kubeconfig; enables server-side dry-run applies of all bundle manifests against that cluster.",
+	)
+}
+
+func (o *options) AddDryRunNamespaceFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.DryRunNamespace,
+		"dry-run-namespace",
+		o.DryRunNamespace,
+		"Namespace namespaced bundle manifests are dry-run applied to if they do not specify one.",
+	)
+}
+
+func (o *options) AddPreflightFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&o.Preflight,
+		"preflight",
+		o.Preflight,
+		"Run the Red Hat preflight container certification checks against operator and operand images.",
+	)
+}
+
+func (o *options) AddPreflightBinaryFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.PreflightBinary,
+		"preflight-bin",
+		o.PreflightBinary,
+		"Name or path of the preflight binary used by '--preflight'.",
+	)
+}
+
+func (o *options) AddDockerConfigFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.DockerConfig,
+		"docker-config",
+		o.DockerConfig,
+		"Path to a docker config with credentials used by '--preflight' to pull images.",
+	)
+}
+
+func (o *options) AddNotifyFlag(flags *pflag.FlagSet) {
+	flags.StringArrayVar(
+		&o.Notify,
+		"notify",
+		o.Notify,
+		fmt.Sprintf("Send a summary of the outcome to '<kind>=<target>' once validation completed; kind is one of %s. Can be repeated.",
+			strings.Join(notify.Kinds, ", "),
+		),
+	)
+}
+
+func (o *options) AddReportURLFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.ReportURL,
+		"report-url",
+		o.ReportURL,
+		"URL the report is published at; linked in notifications sent by '--notify'.",
+	)
+}
+
+func (o *options) AddRunManifestFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.RunManifest,
+		"run-manifest",
+		o.RunManifest,
+		"Path a run manifest recording versions, image digests and flags of this run is written to.",
+	)
+}
+
+func (o *options) AddReplayFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Replay,
+		"replay",
+		o.Replay,
+		"Path of a run manifest written by '--run-manifest'; re-executes the recorded run against the recorded image digests.",
+	)
+}
+
+func (o *options) AddReportFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Report,
+		"report",
+		o.Report,
+		fmt.Sprintf("Additionally write the results to '--report-file' ('--report-dir' for '%s') in the given format; one of %s.",
+			reportHTML, strings.Join(reportFormats, ", "),
+		),
+	)
+}
+
+func (o *options) AddReportFileFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.ReportFile,
+		"report-file",
+		o.ReportFile,
+		"Path the report requested by '--report' is written to.",
+	)
+}
+
+func (o *options) AddReportDirFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.ReportDir,
+		"report-dir",
+		o.ReportDir,
+		fmt.Sprintf("Directory the report requested by '--report %s' is written to.", reportHTML),
+	)
+}
+
+func (o *options) AddConcurrencyFlag(flags *pflag.FlagSet) {
+	flags.IntVar(
+		&o.Concurrency,
+		"concurrency",
+		o.Concurrency,
+		"Maximum number of validators running at the same time; zero means no limit.",
+	)
+}
+
+func (o *options) AddChangedSinceFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.ChangedSince,
+		"changed-since",
+		o.ChangedSince,
+		"Only validate addons whose files changed since the given git ref, e.g. 'origin/main'.",
+	)
+}
+
+func (o *options) AddWatchFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&o.Watch,
+		"watch",
+		o.Watch,
+		"Re-run validators which do not depend on remote services each time files of the addon dir change.",
+	)
+}
+
+func (o *options) AddInteractiveFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&o.Interactive,
+		"interactive",
+		o.Interactive,
+		"Browse results in a terminal UI which can re-run validators without extracting bundles again.",
+	)
+}
+
+func (o *options) AddTimeoutFlag(flags *pflag.FlagSet) {
+	flags.DurationVar(
+		&o.Timeout,
+		"timeout",
+		o.Timeout,
+		"Maximum duration of the whole run including image pulls, e.g. '10m'; zero means no limit.",
+	)
+}
+
+func (o *options) AddValidatorTimeoutFlag(flags *pflag.FlagSet) {
+	flags.DurationVar(
+		&o.ValidatorTimeout,
+		"validator-timeout",
+		o.ValidatorTimeout,
+		"Maximum duration of each validator including retries; validators exceeding it are reported as timed out. Zero means no limit.",
+	)
+}
+
+func (o *options) AddBaselineFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Baseline,
+		"baseline",
+		o.Baseline,
+		"Path of a baseline file listing known failures per addon; known failures are reported but do not fail the run.",
+	)
+}
+
+func (o *options) AddUpdateBaselineFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&o.UpdateBaseline,
+		"update-baseline",
+		o.UpdateBaseline,
+		"Record the failures of all validated addons as known failures in '--baseline'.",
+	)
+}
+
+func (o *options) AddMetadataOnlyFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&o.MetadataOnly,
+		"metadata-only",
+		o.MetadataOnly,
+		"Skip extracting bundles from the index image and only run validators which check the addon metadata; same as '--scope metadata'.",
+	)
+}
+
+func (o *options) AddScopeFlag(flags *pflag.FlagSet) {
+	flags.StringSliceVar(
+		&o.Scopes,
+		"scope",
+		o.Scopes,
+		fmt.Sprintf("Only run validators checking the given layers, separated by ','; any of %s. Bundles are not extracted for '%s' alone. Defaults to all layers.",
+			strings.Join(scopes, ", "), scopeMetadata,
+		),
+	)
+}
+
+func (o *options) AddBundlesDirFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.BundlesDir,
+		"bundles-dir",
+		o.BundlesDir,
+		"Read the bundles of each addon from '<dir>/<operatorName>/' as written by 'mtcli bundle extract' instead of pulling the index image.",
+	)
+}
+
+func (o *options) AddMetadataURLFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.MetadataURL,
+		"metadata-url",
+		o.MetadataURL,
+		"Validate the 'addon.yaml' at the given 'https://' URL (e.g. a raw GitLab file URL) instead of an addon dir. Only addons with an 'indexImage' are supported.",
+	)
+}
+
+func (o *options) AddPackageFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Package,
+		"package",
+		o.Package,
+		"Name of the operator package whose bundles are validated.",
+	)
+}
+
+func (o *options) AddQuietFlag(flags *pflag.FlagSet) {
+	flags.BoolVarP(
+		&o.Quiet,
+		"quiet",
+		"q",
+		o.Quiet,
+		"Only print the failed and errored validators of each addon followed by a summary of all addons.",
+	)
+}
+
+func (o *options) AddFailFastFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&o.FailFast,
+		"fail-fast",
+		o.FailFast,
+		"Stop at the first failed or errored validator, including known failures, and skip the remaining addons.",
+	)
+}
+
+func (o *options) AddFailOnFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.FailOn,
+		"fail-on",
+		o.FailOn,
+		fmt.Sprintf("Lowest severity of failures which fail a validation; one of %s.", strings.Join(failOnSeverities, ", ")),
+	)
+}
+
+// failOnSeverity returns the lowest severity of blocking failures.
+func (o *options) failOnSeverity() validator.Severity {
+	return validator.Severity(o.FailOn)
+}
+
+// failOnSeverities are the valid values of '--fail-on'.
+var failOnSeverities = []string{string(validator.SeverityError), string(validator.SeverityWarning)}
+
+func (o *options) AddDryRunFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&o.DryRun,
+		"dry-run",
+		o.DryRun,
+		"Print which validators would run, in which order and with which severities, without loading addons or pulling images. "+
+			"Validators excluded by the addon metadata are still listed.",
+	)
+}
+
+func (o *options) AddCacheFileFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.CacheFile,
+		"cache-file",
+		o.CacheFile,
+		"Path of a local result cache; validators are not run again for addons whose metadata and index image digest did not change since the last run of this mtcli version. Validators depending on remote services are always run.",
+	)
+}
+
 func (o *options) VerifyFlags() error {
 	if !isValidEnv(o.Env) {
 		return fmt.Errorf("'%s' is not a valid environment; must be one of 'integration', 'stage' or 'production'", o.Env)
 	}
 
+	if o.Output != outputTable && o.Output != outputJSON {
+		return fmt.Errorf("'%s' is not a valid output format; must be one of '%s' or '%s'", o.Output, outputTable, outputJSON)
+	}
+
+	if _, err := metadata.ParseVersionStrategy(o.VersionStrategy); err != nil {
+		return err
+	}
+
+	if _, err := cli.LookupProfile(o.Profile); err != nil {
+		return err
+	}
+
+	if !slices.Contains(failOnSeverities, o.FailOn) {
+		return fmt.Errorf("'%s' is not a valid '--fail-on' severity; must be one of %s", o.FailOn, strings.Join(failOnSeverities, ", "))
+	}
+
+	if o.Report != "" && !slices.Contains(reportFormats, o.Report) {
+		return fmt.Errorf("'%s' is not a valid report format; must be one of %s", o.Report, strings.Join(reportFormats, ", "))
+	}
+
+	if err := o.verifyReportFlags(); err != nil {
+		return err
+	}
+
+	if o.Timeout < 0 || o.ValidatorTimeout < 0 {
+		return errors.New("'--timeout' and '--validator-timeout' must not be negative")
+	}
+
+	if o.Interactive && (o.Watch || o.Output == outputJSON) {
+		return fmt.Errorf("'--interactive' cannot be combined with '--watch' or '--output %s'", outputJSON)
+	}
+
+	if o.Quiet && (o.Interactive || o.Output == outputJSON) {
+		return fmt.Errorf("'--quiet' cannot be combined with '--interactive' or '--output %s'", outputJSON)
+	}
+
+	if o.UpdateBaseline && (o.Watch || o.Interactive) {
+		return errors.New("'--update-baseline' cannot be combined with '--watch' or '--interactive'")
+	}
+
+	if o.FailFast && (o.UpdateBaseline || o.Interactive) {
+		return errors.New("'--fail-fast' cannot be combined with '--update-baseline' or '--interactive'")
+	}
+
+	if o.MetadataOnly && o.BundlesDir != "" {
+		return errors.New("'--metadata-only' and '--bundles-dir' are mutually exclusive options")
+	}
+
+	if err := o.verifyScopes(); err != nil {
+		return err
+	}
+
+	if o.MetadataURL != "" && !metadata.IsRemote(o.MetadataURL) {
+		return fmt.Errorf("'--metadata-url' must be an 'https://' URL not %q", o.MetadataURL)
+	}
+
+	if o.ChangedSince != "" && o.Replay != "" {
+		return errors.New("'--changed-since' and '--replay' are mutually exclusive options")
+	}
+
+	// bundles read from disk are not identified by a digest
+	if o.CacheFile != "" && o.BundlesDir != "" {
+		return errors.New("'--cache-file' and '--bundles-dir' are mutually exclusive options")
+	}
+
 	// unset version is OK, will fallback to meta.addonImageSetVersion
 	if o.Version == "" {
 		return nil
@@ -83,6 +553,201 @@ func (o *options) VerifyFlags() error {
 	return nil
 }
 
+// VerifyBulkFlags verifies that no flags referring
+// to a single addon are given for multiple addons.
+func (o *options) VerifyBulkFlags() error {
+	for _, f := range []struct{ name, value string }{
+		{"replay", o.Replay},
+		{"run-manifest", o.RunManifest},
+		{"report", o.Report},
+		{"metadata-url", o.MetadataURL},
+	} {
+		if f.value != "" {
+			return fmt.Errorf("'--%s' requires a single addon dir", f.name)
+		}
+	}
+
+	if o.Watch {
+		return errors.New("'--watch' requires a single addon dir")
+	}
+
+	if o.Interactive {
+		return errors.New("'--interactive' requires a single addon dir")
+	}
+
+	return nil
+}
+
+// VerifyWatchFlags verifies that no flags conflicting with
+// repeated runs are given together with '--watch'.
+func (o *options) VerifyWatchFlags() error {
+	for _, f := range []struct{ name, value string }{
+		{"replay", o.Replay},
+		{"run-manifest", o.RunManifest},
+		{"changed-since", o.ChangedSince},
+	} {
+		if f.value != "" {
+			return fmt.Errorf("'--watch' and '--%s' are mutually exclusive options", f.name)
+		}
+	}
+
+	if len(o.Notify) > 0 {
+		return errors.New("'--watch' and '--notify' are mutually exclusive options")
+	}
+
+	return nil
+}
+
+// VerifyRepoFlags verifies flags when validating a whole repository.
+func (o *options) VerifyRepoFlags() error {
+	if err := o.VerifyBulkFlags(); err != nil {
+		return err
+	}
+
+	if o.Version != "" && o.Version != "latest" {
+		return errors.New("'--version' only supports 'latest' when validating a repository")
+	}
+
+	if len(o.Checksums) > 0 {
+		return errors.New("'--checksum' requires a single addon dir")
+	}
+
+	if o.DryRun {
+		return errors.New("'--dry-run' cannot be combined with validating a repository")
+	}
+
+	return nil
+}
+
+// VerifyIndexFlags verifies that no flags depending on addon
+// metadata are given when validating an index image.
+func (o *options) VerifyIndexFlags() error {
+	if o.Package == "" {
+		return errors.New("'--package' is required")
+	}
+
+	for _, f := range []struct {
+		name string
+		set  bool
+	}{
+		{"version", o.Version != ""},
+		{"checksum", len(o.Checksums) > 0},
+		{"replay", o.Replay != ""},
+		{"run-manifest", o.RunManifest != ""},
+		{"report", o.Report != ""},
+		{"metadata-url", o.MetadataURL != ""},
+		{"changed-since", o.ChangedSince != ""},
+		{"notify", len(o.Notify) > 0},
+		{"watch", o.Watch},
+		{"interactive", o.Interactive},
+		{"update-baseline", o.UpdateBaseline},
+		{"metadata-only", o.MetadataOnly},
+		{"scope", len(o.Scopes) > 0},
+		{"bundles-dir", o.BundlesDir != ""},
+		{"dry-run", o.DryRun},
+	} {
+		if f.set {
+			return fmt.Errorf("'--%s' cannot be combined with validating an index image", f.name)
+		}
+	}
+
+	return nil
+}
+
+func (o *options) verifyScopes() error {
+	for _, s := range o.Scopes {
+		if !slices.Contains(scopes, s) {
+			return fmt.Errorf("'%s' is not a valid scope; must be one of %s", s, strings.Join(scopes, ", "))
+		}
+	}
+
+	if len(o.Scopes) > 0 && o.MetadataOnly {
+		return fmt.Errorf("'--metadata-only' and '--scope' are mutually exclusive options; use '--scope %s' instead", scopeMetadata)
+	}
+
+	if o.SkipsBundles() && o.BundlesDir != "" {
+		return fmt.Errorf("'--bundles-dir' cannot be combined with '--scope %s'", scopeMetadata)
+	}
+
+	return nil
+}
+
+const (
+	// scopeMetadata selects validators which only check the addon metadata.
+	scopeMetadata = "metadata"
+	// scopeBundles selects validators checking the bundles of the index image.
+	scopeBundles = "bundles"
+	// scopeImageSet selects validators checking fields imagesets may set.
+	scopeImageSet = "imageset"
+)
+
+var scopes = []string{scopeMetadata, scopeBundles, scopeImageSet}
+
+// ScopeFilter returns a filter matching the validators of the selected
+// scopes or nil if all validators are selected.
+func (o *options) ScopeFilter() validator.Filter {
+	selected := o.Scopes
+	if o.MetadataOnly {
+		selected = []string{scopeMetadata}
+	}
+
+	if len(selected) == 0 {
+		return nil
+	}
+
+	filters := make([]validator.Filter, 0, len(selected))
+
+	for _, s := range selected {
+		switch s {
+		case scopeMetadata:
+			filters = append(filters, validator.Not(validator.MatchesTags(validator.TagBundle)))
+		case scopeBundles:
+			filters = append(filters, validator.MatchesTags(validator.TagBundle))
+		case scopeImageSet:
+			filters = append(filters, validator.MatchesTags(validator.TagImageSet))
+		}
+	}
+
+	return validator.Any(filters...)
+}
+
+// SkipsBundles reports whether bundles are not needed as only
+// validators checking the addon metadata are selected.
+func (o *options) SkipsBundles() bool {
+	if o.MetadataOnly {
+		return true
+	}
+
+	return len(o.Scopes) > 0 && !slices.ContainsFunc(o.Scopes, func(s string) bool {
+		return s != scopeMetadata
+	})
+}
+
+func (o *options) verifyReportFlags() error {
+	switch {
+	case o.Report == "" && (o.ReportFile != "" || o.ReportDir != ""):
+		return errors.New("'--report-file' and '--report-dir' require '--report'")
+	case o.Report == reportHTML && (o.ReportDir == "" || o.ReportFile != ""):
+		return fmt.Errorf("'--report %s' must be given together with '--report-dir' only", reportHTML)
+	case o.Report != "" && o.Report != reportHTML && (o.ReportFile == "" || o.ReportDir != ""):
+		return fmt.Errorf("'--report %s' must be given together with '--report-file' only", o.Report)
+	}
+
+	return nil
+}
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+
+	reportJUnit    = "junit"
+	reportSARIF    = "sarif"
+	reportMarkdown = "markdown"
+	reportHTML     = "html"
+)
+
+var reportFormats = []string{reportJUnit, reportSARIF, reportMarkdown, reportHTML}
+
 func isValidEnv(env string) bool {
 	switch env {
 	case "stage", "integration", "production":
@@ -0,0 +1,127 @@
+package validate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+// plannedValidator is a validator which would be run by '--dry-run'.
+type plannedValidator struct {
+	// Stage is the position of the validator in the order validators
+	// are run in; validators of the same stage may run concurrently.
+	Stage     int      `json:"stage"`
+	Code      string   `json:"code"`
+	Name      string   `json:"name"`
+	Severity  string   `json:"severity"`
+	DependsOn []string `json:"dependsOn,omitempty"`
+}
+
+type plan struct {
+	Env        string             `json:"env"`
+	Profile    string             `json:"profile"`
+	Validators []plannedValidator `json:"validators"`
+}
+
+// newPlan returns the validators which would be run for the flags of opts
+// ordered by stage and code. No addon is loaded so validators excluded by
+// its metadata or skipped as their prerequisites fail are still included.
+func newPlan(ctx context.Context, opts *options) (plan, error) {
+	runner, err := validator.NewRunner(ctx)
+	if err != nil {
+		return plan{}, fmt.Errorf("initializing validators: %w", err)
+	}
+
+	filter, profile, err := selectValidators(ctx, runner, opts)
+	if err != nil {
+		return plan{}, err
+	}
+
+	res := plan{
+		Env:     opts.Env,
+		Profile: profile.Name,
+		// encoded as empty list rather than null
+		Validators: []plannedValidator{},
+	}
+
+	stages := runner.Stages(filter, validator.EnabledForEnv(opts.Env), opts.ScopeFilter())
+
+	for i, stage := range stages {
+		for _, v := range stage {
+			docs := validator.DocsOf(v)
+
+			severity := docs.EffectiveSeverity()
+			if profile.Severities != nil {
+				severity = profile.Severities(v.Code(), severity)
+			}
+
+			planned := plannedValidator{
+				Stage:    i + 1,
+				Code:     v.Code().String(),
+				Name:     v.Name(),
+				Severity: string(severity),
+			}
+
+			for _, code := range docs.DependsOn {
+				planned.DependsOn = append(planned.DependsOn, code.String())
+			}
+
+			res.Validators = append(res.Validators, planned)
+		}
+	}
+
+	return res, nil
+}
+
+// printPlan prints the validators which would be
+// run for the flags of opts without running them.
+func printPlan(ctx context.Context, out io.Writer, opts *options) error {
+	p, err := newPlan(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	if opts.Output == outputJSON {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+
+		if err := enc.Encode(p); err != nil {
+			return fmt.Errorf("encoding plan: %w", err)
+		}
+
+		return nil
+	}
+
+	fmt.Fprintf(out, "%d validators would run for env '%s' with profile '%s'.\n\n", len(p.Validators), p.Env, p.Profile)
+
+	if len(p.Validators) == 0 {
+		return nil
+	}
+
+	table, err := cli.NewTable(
+		cli.WithHeaders{"STAGE", "CODE", "NAME", "SEVERITY", "DEPENDS ON"},
+	)
+	if err != nil {
+		return fmt.Errorf("initializing table: %w", err)
+	}
+
+	for _, v := range p.Validators {
+		table.WriteRow(cli.TableRow{
+			cli.Field{Value: strconv.Itoa(v.Stage)},
+			cli.Field{Value: v.Code},
+			cli.Field{Value: v.Name},
+			cli.Field{Value: v.Severity},
+			cli.Field{Value: strings.Join(v.DependsOn, ", ")},
+		})
+	}
+
+	fmt.Fprintln(out, table.String())
+
+	return nil
+}
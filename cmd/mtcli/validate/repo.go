@@ -0,0 +1,186 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	"github.com/mt-sre/addon-metadata-operator/pkg/extractor"
+	"github.com/mt-sre/addon-metadata-operator/pkg/metadata"
+	"github.com/spf13/cobra"
+)
+
+const repoLong = "Discover every addon of a managed-tenants checkout and validate it in each environment it has metadata for."
+
+var ErrNoAddons = errors.New("no addons found")
+
+func repoCmd(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:           "repo <path/to/managed-tenants>",
+		Short:         "Validate all addons and environments of a managed-tenants repository.",
+		Long:          repoLong,
+		Args:          usageArgs(cobra.ExactArgs(1)),
+		RunE:          runRepo(opts),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+}
+
+func runRepo(opts *options) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithCancel(cmd.Context())
+		defer cancel()
+
+		if err := opts.VerifyFlags(); err != nil {
+			return cli.UsageError(fmt.Errorf("verifying flags: %w", err))
+		}
+
+		if err := opts.VerifyRepoFlags(); err != nil {
+			return cli.UsageError(fmt.Errorf("verifying flags: %w", err))
+		}
+
+		root := args[0]
+
+		addons, err := metadata.DiscoverAddons(root)
+		if err != nil {
+			return cli.UsageError(err)
+		}
+
+		if opts.ChangedSince != "" {
+			addons, err = changedAddons(ctx, root, addons, opts.ChangedSince)
+			if err != nil {
+				return err
+			}
+
+			if len(addons) == 0 {
+				printNoChanges(summaryWriter(cmd, opts), opts.ChangedSince)
+
+				return nil
+			}
+		}
+
+		// only a single environment is validated if one was requested
+		var onlyEnv string
+		if cmd.Flags().Changed("env") {
+			onlyEnv = opts.Env
+		}
+
+		addonDirs := addonDirsByEnv(addons, onlyEnv)
+		if len(addonDirs) == 0 {
+			return cli.UsageError(fmt.Errorf("%w in %q", ErrNoAddons, root))
+		}
+
+		baseline, err := loadBaseline(cmd, opts)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel = withTimeout(ctx, opts.Timeout)
+		defer cancel()
+
+		var (
+			ext      = extractor.New()
+			outcomes []addonOutcome
+		)
+
+		for _, env := range cli.Envs {
+			dirs, ok := addonDirs[env]
+			if !ok {
+				continue
+			}
+
+			envOutcomes, err := validateEnv(ctx, cmd, opts, env, ext, baseline, dirs)
+			if err != nil {
+				return timeoutError(ctx, opts, err)
+			}
+
+			for i := range envOutcomes {
+				envOutcomes[i].Addon = relativeTo(root, envOutcomes[i].Addon)
+			}
+
+			outcomes = append(outcomes, envOutcomes...)
+		}
+
+		if err := writeBaseline(opts, baseline); err != nil {
+			return err
+		}
+
+		printSummary(summaryWriter(cmd, opts), outcomes, true)
+
+		return summaryError(outcomes)
+	}
+}
+
+func validateEnv(ctx context.Context, cmd *cobra.Command, opts *options, env string, ext *extractor.MainExtractor, baseline *cli.Baseline, dirs []string) ([]addonOutcome, error) {
+	v, closeValidator, err := newAddonValidator(ctx, cmd, opts, env, ext)
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeValidator()
+
+	v.bulk = true
+	v.baseline = baseline
+
+	return v.ValidateAll(ctx, dirs)
+}
+
+// addonDirsByEnv groups the discovered addon dirs by environment.
+// If onlyEnv is not empty all other environments are omitted.
+func addonDirsByEnv(addons []metadata.DiscoveredAddon, onlyEnv string) map[string][]string {
+	res := make(map[string][]string)
+
+	for _, addon := range addons {
+		for _, env := range addon.Envs {
+			if onlyEnv != "" && env != onlyEnv {
+				continue
+			}
+
+			res[env] = append(res[env], addon.Dir)
+		}
+	}
+
+	return res
+}
+
+func relativeTo(root, path string) string {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return path
+	}
+
+	return rel
+}
+
+// changedAddons returns the discovered addons with changes since ref.
+func changedAddons(ctx context.Context, root string, addons []metadata.DiscoveredAddon, ref string) ([]metadata.DiscoveredAddon, error) {
+	dirs := make([]string, 0, len(addons))
+	for _, addon := range addons {
+		dirs = append(dirs, addon.Dir)
+	}
+
+	changed, err := changedAddonDirs(ctx, root, dirs, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]metadata.DiscoveredAddon, 0, len(changed))
+
+	for _, addon := range addons {
+		if slices.Contains(changed, addon.Dir) {
+			res = append(res, addon)
+		}
+	}
+
+	return res, nil
+}
+
+// usageArgs returns args reporting errors as usage errors.
+func usageArgs(args cobra.PositionalArgs) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, a []string) error {
+		return cli.UsageError(args(cmd, a))
+	}
+}
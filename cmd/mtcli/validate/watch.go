@@ -0,0 +1,44 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	"github.com/mt-sre/addon-metadata-operator/pkg/metadata"
+)
+
+// watchInterval is how often a watched addon dir is checked for changes.
+const watchInterval = 500 * time.Millisecond
+
+// Watch validates the addon at addonArg and validates it again each time
+// its files change until ctx is cancelled. Extracted bundles are cached
+// across runs so that only the addon metadata is loaded again.
+func (v *addonValidator) Watch(ctx context.Context, addonArg string) error {
+	if metadata.IsRemote(addonArg) {
+		return cli.UsageError(fmt.Errorf("'--watch' requires a local addon dir; %q is remote", addonArg))
+	}
+
+	v.watch = true
+
+	out := v.cmd.ErrOrStderr()
+
+	// '--timeout' applies to each run rather than to watching
+	validate := func(ctx context.Context) {
+		fmt.Fprintf(out, "\nvalidating %q at %s\n", addonArg, time.Now().Format(time.TimeOnly))
+
+		runCtx, cancel := withTimeout(ctx, v.opts.Timeout)
+		defer cancel()
+
+		if _, err := v.Validate(runCtx, addonArg); err != nil && ctx.Err() == nil {
+			fmt.Fprintf(out, "validating %q: %v\n", addonArg, timeoutError(runCtx, v.opts, err))
+		}
+
+		fmt.Fprintln(out, "watching for changes; press Ctrl+C to stop")
+	}
+
+	validate(ctx)
+
+	return cli.WatchDir(ctx, addonArg, watchInterval, validate)
+}
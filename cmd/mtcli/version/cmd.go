@@ -1,23 +1,121 @@
 package version
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"strings"
 
 	"github.com/mt-sre/addon-metadata-operator/internal/cli"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/register"
 	"github.com/spf13/cobra"
 )
 
+func examples() string {
+	return strings.Join([]string{
+		"  # Show mtcli version information.",
+		"  mtcli version",
+		"  # Show version information as JSON.",
+		"  mtcli version --output json",
+		"  # Check whether a newer mtcli release is available.",
+		"  mtcli version --check",
+	}, "\n")
+}
+
 func Cmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "version",
-		Short: "Show mtcli version information.",
-		Args:  cobra.NoArgs,
-		RunE:  run,
+	opts := &options{
+		Output: outputText,
+	}
+
+	cmd := &cobra.Command{
+		Use:           "version",
+		Short:         "Show mtcli version information.",
+		Example:       examples(),
+		Args:          cobra.NoArgs,
+		RunE:          run(opts),
+		SilenceErrors: true,
+		SilenceUsage:  true,
 	}
+
+	flags := cmd.Flags()
+
+	opts.AddOutputFlag(flags)
+	opts.AddCheckUpdateFlag(flags)
+
+	return cmd
 }
 
-func run(cmd *cobra.Command, args []string) error {
-	fmt.Fprintln(cmd.OutOrStdout(), cli.Version())
+type versionInfo struct {
+	cli.BuildInfo
+	Validators int              `json:"validators"`
+	Update     *cli.UpdateCheck `json:"update,omitempty"`
+}
+
+func run(opts *options) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := opts.VerifyFlags(); err != nil {
+			return fmt.Errorf("verifying flags: %w", err)
+		}
+
+		runner, err := validator.NewRunner(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("initializing validators: %w", err)
+		}
+
+		info := versionInfo{
+			BuildInfo:  cli.GetBuildInfo(),
+			Validators: len(runner.GetValidators()),
+		}
+
+		if opts.CheckUpdate {
+			check, err := cli.CheckForUpdate(cmd.Context(), nil, cli.LatestReleaseURL, info.Version)
+			if err != nil {
+				return fmt.Errorf("checking for updates: %w", err)
+			}
+
+			info.Update = &check
+		}
+
+		if opts.Output == outputJSON {
+			return printJSON(cmd.OutOrStdout(), info)
+		}
+
+		printText(cmd.OutOrStdout(), info)
+
+		return nil
+	}
+}
+
+func printJSON(out io.Writer, info versionInfo) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(info); err != nil {
+		return fmt.Errorf("encoding version information: %w", err)
+	}
 
 	return nil
 }
+
+func printText(out io.Writer, info versionInfo) {
+	fmt.Fprintf(out, "mtcli version: %s\n", info.Version)
+	fmt.Fprintf(out, "commit: %s\n", info.Commit)
+	fmt.Fprintf(out, "built by: %s (%s)\n", info.BuiltBy, info.Date)
+	fmt.Fprintf(out, "go version: %s (%s)\n", info.GoVersion, info.Platform)
+	fmt.Fprintf(out, "validators: %d\n", info.Validators)
+
+	if info.Update == nil {
+		return
+	}
+
+	if !info.Update.UpdateAvailable {
+		fmt.Fprintf(out, "\nmtcli is up to date (latest release: %s).\n", info.Update.Latest.Version)
+
+		return
+	}
+
+	fmt.Fprintf(out, "\nA newer mtcli version is available: %s\n", info.Update.Latest.Version)
+	fmt.Fprintf(out, "Download it from %s or run 'mtcli selfupdate';\n", info.Update.Latest.URL)
+	fmt.Fprintln(out, "validators referred to by newer addon metadata or documentation may be missing until then.")
+}
@@ -0,0 +1,52 @@
+package version
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+)
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+type options struct {
+	Output      string
+	CheckUpdate bool
+}
+
+func (o *options) AddOutputFlag(flags *pflag.FlagSet) {
+	flags.StringVarP(
+		&o.Output,
+		"output",
+		"o",
+		o.Output,
+		"Output format; 'text' or 'json'.",
+	)
+}
+
+func (o *options) AddCheckUpdateFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&o.CheckUpdate,
+		"check-update",
+		o.CheckUpdate,
+		"Check GitHub releases for a newer mtcli version; may be shortened to '--check'.",
+	)
+
+	flags.SetNormalizeFunc(func(_ *pflag.FlagSet, name string) pflag.NormalizedName {
+		if name == "check" {
+			name = "check-update"
+		}
+
+		return pflag.NormalizedName(name)
+	})
+}
+
+func (o *options) VerifyFlags() error {
+	if o.Output != outputText && o.Output != outputJSON {
+		return fmt.Errorf("'%s' is not a valid output format; must be one of '%s' or '%s'", o.Output, outputText, outputJSON)
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("validate --baseline", func() {
+	addonDir := filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon")
+
+	DescribeTable("invalid baselines",
+		func(content string, args []string, expectedErr string) {
+			path := filepath.Join(GinkgoT().TempDir(), ".mtcli-baseline.yaml")
+
+			if content != "" {
+				Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+			}
+
+			args = append(append([]string{"validate", "--baseline", path}, args...), addonDir)
+
+			session, err := Start(exec.Command(_binPath, args...), GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(session, "30s").Should(Exit(2))
+			Expect(session.Err).To(Say(expectedErr))
+		},
+		Entry("missing", "", nil, "reading baseline file"),
+		Entry("malformed", "addons: [", nil, "parsing baseline file"),
+		Entry("invalid code", "addons:\n  reference-addon: [AM5]\n", nil, `invalid code "AM5"`),
+		Entry("update while watching", "", []string{"--update-baseline", "--watch"}, "'--update-baseline' cannot be combined"),
+	)
+})
@@ -0,0 +1,31 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os/exec"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("bench subcommand", func() {
+	DescribeTable("fixture addon",
+		func(args []string, expectedCode int, expectedOut string) {
+			cmd := exec.Command(_binPath, append([]string{"bench"}, args...)...)
+
+			session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(session, "30s").Should(Exit(expectedCode))
+			Expect(session.Out).To(Say(expectedOut))
+		},
+		Entry("table", []string{"-n", "2", "--enabled", "AM0001"}, 0, `AM0001\s+default_channel`),
+		Entry("json", []string{"-n", "2", "--enabled", "AM0001", "-o", "json"}, 0, `"iterations": 2`),
+		Entry("invalid iterations", []string{"-n", "0"}, 1, ""),
+		Entry("invalid output", []string{"-o", "yaml"}, 1, ""),
+	)
+})
@@ -0,0 +1,46 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("validate with multiple addon dirs", func() {
+	It("validates every addon and summarizes the outcome", func() {
+		dir := GinkgoT().TempDir()
+
+		cmd := exec.Command(_binPath, "validate",
+			filepath.Join(dir, "missing-a"),
+			filepath.Join(dir, "missing-b"),
+		)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(1))
+		Expect(session.Err).To(Say("missing-a"))
+		Expect(session.Err).To(Say("missing-b"))
+		Expect(session.Out).To(Say("2 addons: 0 passed, 0 failed, 0 errored, 2 could not be validated"))
+		Expect(session.Err).To(Say("2 of 2 addons could not be validated"))
+	})
+
+	DescribeTable("invalid arguments",
+		func(args []string, expectedErr string) {
+			session, err := Start(exec.Command(_binPath, append([]string{"validate"}, args...)...), GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(session, "30s").Should(Exit(2))
+			Expect(session.Err).To(Say(expectedErr))
+		},
+		Entry("unmatched pattern", []string{filepath.Join("does-not-exist", "*")}, "no addon dirs match"),
+		Entry("single addon flag", []string{"--report", "junit", "--report-file", "out.xml", "a", "b"}, "'--report' requires a single addon dir"),
+	)
+})
@@ -0,0 +1,37 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os/exec"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("bundle diff subcommand", func() {
+	const referenceIndex = "quay.io/osd-addons/reference-addon-index@sha256:b9e87a598e7fd6afb4bfedb31e4098435c2105cc8ebe33231c341e515ba9054d"
+
+	It("reports no differences for the same index image", func() {
+		cmd := exec.Command(_binPath, "bundle", "diff", "--package", "reference-addon", referenceIndex, referenceIndex)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "60s").Should(Exit(0))
+		Expect(session.Out).To(Say("No differences."))
+	})
+
+	It("fails for unreachable index images", func() {
+		cmd := exec.Command(_binPath, "bundle", "diff", referenceIndex, "localhost:1/missing-index:v1")
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "60s").Should(Exit(3))
+		Expect(session.Err).To(Say("extracting and parsing bundles from index image"))
+	})
+})
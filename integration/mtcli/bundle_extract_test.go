@@ -0,0 +1,48 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("bundle extract subcommand", func() {
+	It("writes valid bundles to disk", func() {
+		dir := GinkgoT().TempDir()
+
+		cmd := exec.Command(_binPath, "bundle", "extract",
+			"--to-dir", dir,
+			"--package", "reference-addon",
+			"quay.io/osd-addons/reference-addon-index@sha256:b9e87a598e7fd6afb4bfedb31e4098435c2105cc8ebe33231c341e515ba9054d",
+		)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "60s").Should(Exit(0))
+
+		bundleDir := filepath.Join(dir, "reference-addon", "reference-addon.v0.1.0")
+		Expect(session.Out).To(Say(bundleDir))
+		Expect(filepath.Join(bundleDir, "metadata", "annotations.yaml")).To(BeAnExistingFile())
+
+		session, err = Start(exec.Command(_binPath, "bundle", "validate", bundleDir), GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(0))
+	})
+
+	It("requires --to-dir", func() {
+		session, err := Start(exec.Command(_binPath, "bundle", "extract", "quay.io/osd-addons/reference-addon-index:v1"), GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(2))
+		Expect(session.Err).To(Say("'--to-dir' is required"))
+	})
+})
@@ -0,0 +1,65 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("validate --bundles-dir", func() {
+	var (
+		addonDir   = filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon")
+		bundlesDir string
+	)
+
+	BeforeEach(func() {
+		bundlesDir = GinkgoT().TempDir()
+
+		Expect(os.Symlink(
+			filepath.Join(testutils.RootDir().TestData().Bundles(), "reference-addon", "main"),
+			filepath.Join(bundlesDir, "reference-addon"),
+		)).To(Succeed())
+	})
+
+	It("validates bundles read from disk", func() {
+		cmd := exec.Command(_binPath, "validate",
+			"--bundles-dir", bundlesDir,
+			// AM0005 and AM0011 depend on quay and OCM
+			// and the bundle lacks probes checked by AM0015
+			"--disabled", "AM0005,AM0011,AM0015",
+			addonDir,
+		)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(0))
+		Expect(session.Out).To(Say("AM0003"))
+		Expect(session.Out).To(Say("AM0012"))
+	})
+
+	It("fails for missing bundles", func() {
+		session, err := Start(exec.Command(_binPath, "validate", "--bundles-dir", GinkgoT().TempDir(), addonDir), GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(2))
+		Expect(session.Err).To(Say("loading addon bundles"))
+	})
+
+	It("can't be combined with --metadata-only", func() {
+		session, err := Start(exec.Command(_binPath, "validate", "--bundles-dir", bundlesDir, "--metadata-only", addonDir), GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(2))
+		Expect(session.Err).To(Say("mutually exclusive"))
+	})
+})
@@ -0,0 +1,89 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"encoding/json"
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("validate --cache-file", func() {
+	var cacheFile string
+
+	BeforeEach(func() {
+		cacheFile = filepath.Join(GinkgoT().TempDir(), "mtcli", "results.db")
+	})
+
+	validate := func(args ...string) *Session {
+		cmd := exec.Command(_binPath, append([]string{"validate",
+			"--cache-file", cacheFile,
+			"--metadata-only",
+			// AM0005 and AM0011 depend on quay and OCM
+			"--disabled", "AM0005,AM0011",
+		}, args...)...)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		return session
+	}
+
+	type report struct {
+		Passed  bool `json:"passed"`
+		Results []struct {
+			Code   string `json:"code"`
+			Status string `json:"status"`
+			Cached bool   `json:"cached"`
+		} `json:"results"`
+	}
+
+	It("reuses the results of unchanged addons", func() {
+		session := validate("--output", "json", failingAddon)
+		Eventually(session, "30s").Should(Exit(1))
+
+		var first report
+
+		Expect(json.Unmarshal(session.Out.Contents(), &first)).To(Succeed())
+		Expect(first.Results).ToNot(BeEmpty())
+
+		for _, res := range first.Results {
+			Expect(res.Cached).To(BeFalse(), res.Code)
+		}
+
+		session = validate("--output", "json", failingAddon)
+		Eventually(session, "30s").Should(Exit(1))
+
+		var second report
+
+		Expect(json.Unmarshal(session.Out.Contents(), &second)).To(Succeed())
+		Expect(second.Passed).To(BeFalse())
+		Expect(second.Results).To(HaveLen(len(first.Results)))
+
+		for i, res := range second.Results {
+			Expect(res.Code).To(Equal(first.Results[i].Code))
+			Expect(res.Status).To(Equal(first.Results[i].Status), res.Code)
+			Expect(res.Cached).To(BeTrue(), res.Code)
+		}
+	})
+
+	It("cannot be combined with --bundles-dir", func() {
+		cmd := exec.Command(_binPath, "validate",
+			"--cache-file", cacheFile,
+			"--bundles-dir", GinkgoT().TempDir(),
+			failingAddon,
+		)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(2))
+		Expect(session.Err).To(Say("'--cache-file' and '--bundles-dir' are mutually exclusive"))
+	})
+})
@@ -0,0 +1,59 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os/exec"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("completion subcommand", func() {
+	DescribeTable("shells",
+		func(args []string, expectedCode int, expectedOut string) {
+			cmd := exec.Command(_binPath, append([]string{"completion"}, args...)...)
+
+			session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(session, "30s").Should(Exit(expectedCode))
+			Expect(session.Out).To(Say(expectedOut))
+		},
+		Entry("bash", []string{"bash"}, 0, "bash completion V2 for mtcli"),
+		Entry("zsh", []string{"zsh"}, 0, "zsh completion for mtcli"),
+		Entry("fish", []string{"fish"}, 0, "fish completion for mtcli"),
+		Entry("powershell", []string{"powershell"}, 0, "powershell completion for mtcli"),
+		Entry("unknown shell", []string{"tcsh"}, 1, ""),
+	)
+
+	DescribeTable("dynamic completions",
+		func(args []string, expectedOut string) {
+			cmd := exec.Command(_binPath, append([]string{"__complete"}, args...)...)
+
+			session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(session, "30s").Should(Exit(0))
+			Expect(session.Out).To(Say(expectedOut))
+		},
+		Entry("environments", []string{"validate", "--env", ""}, "integration\nstage\nproduction"),
+		Entry("validator codes", []string{"validate", "--enabled", "AM0001,"}, "AM0001,AM0002\t"),
+		Entry("environments of subcommands", []string{"validate", "repo", "--env", ""}, "integration\nstage\nproduction"),
+		Entry("disabled validator codes of subcommands", []string{"validate", "repo", "--disabled", ""}, "AM0001\t"),
+		Entry("fix environments", []string{"fix", "--env", ""}, "integration\nstage\nproduction"),
+		Entry("fix validator codes", []string{"fix", "--disabled", "AM0002,"}, "AM0002,AM0001\t"),
+		Entry("serve environments", []string{"serve", "--env", ""}, "integration\nstage\nproduction"),
+		Entry("doctor environments", []string{"doctor", "--env", ""}, "integration\nstage\nproduction"),
+		Entry("validator tags", []string{"validate", "--enabled-tags", "security,"}, "security,bundle\n"),
+		Entry("profiles", []string{"validate", "--profile", ""}, "default\t"),
+		Entry("serve profiles", []string{"serve", "--profile", ""}, "default\t"),
+		Entry("fix disabled validator tags", []string{"fix", "--disabled-tags", ""}, "bundle\n"),
+		Entry("bench validator codes", []string{"bench", "--enabled", ""}, "AM0001\t"),
+		Entry("list validators environments", []string{"list", "validators", "--enabled-for-env", ""}, "integration\nstage\nproduction"),
+		Entry("describe validator codes", []string{"describe", "validator", ""}, "AM0001\t"),
+	)
+})
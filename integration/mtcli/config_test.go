@@ -0,0 +1,70 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("config file", func() {
+	addonDir := filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon")
+
+	run := func(dir string, args ...string) *Session {
+		cmd := exec.Command(_binPath, args...)
+		cmd.Dir = dir
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		return session
+	}
+
+	writeConfig := func(content string) string {
+		dir := GinkgoT().TempDir()
+
+		Expect(os.WriteFile(filepath.Join(dir, ".mtcli.yaml"), []byte(content), 0o600)).To(Succeed())
+
+		return dir
+	}
+
+	It("is discovered in the working directory", func() {
+		dir := writeConfig("env: bogus\n")
+
+		session := run(dir, "validate", addonDir)
+
+		Eventually(session, "30s").Should(Exit(2))
+		Expect(session.Err).To(Say("'bogus' is not a valid environment"))
+	})
+
+	It("is overridden by flags", func() {
+		dir := writeConfig("env: bogus\noutput: bogus\n")
+
+		session := run(dir, "validate", "--env", "stage", addonDir)
+
+		Eventually(session, "30s").Should(Exit(2))
+		Expect(session.Err).To(Say("'bogus' is not a valid output format"))
+	})
+
+	DescribeTable("invalid config files",
+		func(content string, expectedErr string) {
+			dir := writeConfig(content)
+
+			session := run(dir, "validate", "--config", filepath.Join(dir, ".mtcli.yaml"), addonDir)
+
+			Eventually(session, "30s").Should(Exit(2))
+			Expect(session.Err).To(Say(expectedErr))
+		},
+		Entry("unknown flag", "envirnoment: stage\n", "sets unknown flags: envirnoment"),
+		Entry("invalid value", "concurrency: many\n", "setting flag '--concurrency'"),
+		Entry("malformed", "env: [\n", "parsing config file"),
+	)
+})
@@ -0,0 +1,41 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os/exec"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("describe validator subcommand", func() {
+	It("describes a registered validator", func() {
+		session, err := Start(exec.Command(_binPath, "describe", "validator", "am0002"), GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(0))
+		Expect(session.Out).To(Say(`Code:\s+AM0002`))
+		Expect(session.Out).To(Say(`Name:\s+label_format`))
+		Expect(session.Out).To(Say(`Severity:\s+error`))
+		Expect(session.Out).To(Say(`Environments:\s+integration, stage, production`))
+		Expect(session.Out).To(Say(`Fixable:\s+true`))
+		Expect(session.Out).To(Say("Example failures:"))
+		Expect(session.Out).To(Say("Remediation:"))
+		Expect(session.Out).To(Say("https://github.com/mt-sre/addon-metadata-operator/wiki/AM0002"))
+	})
+
+	DescribeTable("invalid codes",
+		func(code string) {
+			session, err := Start(exec.Command(_binPath, "describe", "validator", code), GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(session, "30s").Should(Exit(2))
+		},
+		Entry("malformed code", "label_format"),
+		Entry("unregistered code", "AM0014"),
+	)
+})
@@ -0,0 +1,78 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("doctor subcommand", func() {
+	var dockerConfigDir string
+
+	BeforeEach(func() {
+		dockerConfigDir = GinkgoT().TempDir()
+
+		Expect(os.WriteFile(filepath.Join(dockerConfigDir, "config.json"),
+			[]byte(`{"auths":{"127.0.0.1:1":{"auth":"dXNlcjpwYXNz"}}}`), 0o600,
+		)).To(Succeed())
+	})
+
+	It("reports failed checks with actionable details", func() {
+		cmd := exec.Command(_binPath, "doctor", "--registry", "127.0.0.1:1", "--output", "json")
+		cmd.Env = append(withoutOCMCredentials(os.Environ()), "DOCKER_CONFIG="+dockerConfigDir)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(1))
+		Expect(session.Err).To(Say("checks failed: 1"))
+
+		var results []struct {
+			Check  string `json:"check"`
+			Status string `json:"status"`
+			Detail string `json:"detail"`
+		}
+
+		Expect(json.Unmarshal(session.Out.Contents(), &results)).To(Succeed())
+
+		statuses := make(map[string]string)
+		for _, r := range results {
+			statuses[r.Check] = r.Status
+		}
+
+		Expect(statuses).To(HaveKeyWithValue("registry", "failed"))
+		Expect(statuses).To(HaveKeyWithValue("registry credentials", "ok"))
+		Expect(statuses).To(HaveKeyWithValue("temp dir", "ok"))
+		Expect(statuses).To(HaveKeyWithValue("ocm credentials", "warning"))
+	})
+
+	It("rejects unknown environments", func() {
+		session, err := Start(exec.Command(_binPath, "doctor", "--env", "dev"), GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(2))
+		Expect(session.Err).To(Say("not a valid environment"))
+	})
+})
+
+func withoutOCMCredentials(env []string) []string {
+	res := make([]string, 0, len(env))
+
+	for _, e := range env {
+		if !strings.HasPrefix(e, "OCM_") {
+			res = append(res, e)
+		}
+	}
+
+	return res
+}
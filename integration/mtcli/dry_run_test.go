@@ -0,0 +1,76 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"encoding/json"
+	"os/exec"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("validate --dry-run", func() {
+	validate := func(args ...string) *Session {
+		cmd := exec.Command(_binPath, append([]string{"validate", "--dry-run"}, args...)...)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		return session
+	}
+
+	It("prints the selected validators without an addon dir", func() {
+		session := validate("--profile", "minimal", "--disabled", "AM0009")
+		Eventually(session, "30s").Should(Exit(0))
+
+		Expect(session.Out).To(Say("10 validators would run for env 'stage' with profile 'minimal'"))
+		Expect(session.Out).To(Say(`STAGE +CODE +NAME +SEVERITY +DEPENDS ON`))
+		Expect(session.Out).To(Say(`1 +AM0002 +label_format +error`))
+		Expect(session.Out).To(Say(`1 +AM0031 +description +warning`))
+
+		out := string(session.Out.Contents())
+		Expect(out).ToNot(ContainSubstring("AM0001"))
+		Expect(out).ToNot(ContainSubstring("AM0009"))
+	})
+
+	It("prints the severities of the profile as JSON", func() {
+		session := validate("--profile", "ocm-publish", "--enabled", "AM0015,AM0018", "--output", "json", "path/to/ignored_addon_dir")
+		Eventually(session, "30s").Should(Exit(0))
+
+		var plan struct {
+			Profile    string `json:"profile"`
+			Validators []struct {
+				Stage    int    `json:"stage"`
+				Code     string `json:"code"`
+				Severity string `json:"severity"`
+			} `json:"validators"`
+		}
+
+		Expect(json.Unmarshal(session.Out.Contents(), &plan)).To(Succeed())
+		Expect(plan.Profile).To(Equal("ocm-publish"))
+		Expect(plan.Validators).To(HaveLen(1))
+		Expect(plan.Validators[0].Code).To(Equal("AM0015"))
+		Expect(plan.Validators[0].Severity).To(Equal("warning"))
+		Expect(plan.Validators[0].Stage).To(Equal(1))
+	})
+
+	It("rejects invalid flags", func() {
+		session := validate("--profile", "lenient")
+		Eventually(session, "30s").Should(Exit(2))
+		Expect(session.Err).To(Say("unknown profile 'lenient'"))
+	})
+
+	It("cannot be combined with validating a repository", func() {
+		cmd := exec.Command(_binPath, "validate", "repo", "--dry-run", GinkgoT().TempDir())
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(2))
+		Expect(session.Err).To(Say("'--dry-run' cannot be combined with validating a repository"))
+	})
+})
@@ -0,0 +1,74 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("validate with excludedValidators", func() {
+	var addonDir, metaPath string
+
+	BeforeEach(func() {
+		data, err := os.ReadFile(failingAddonMetadata)
+		Expect(err).ToNot(HaveOccurred())
+
+		addonDir = GinkgoT().TempDir()
+		metaPath = filepath.Join(addonDir, "metadata", "stage", "addon.yaml")
+
+		Expect(os.MkdirAll(filepath.Dir(metaPath), 0o755)).To(Succeed())
+		Expect(os.WriteFile(metaPath, data, 0o600)).To(Succeed())
+	})
+
+	exclude := func(entries string) {
+		f, err := os.OpenFile(metaPath, os.O_APPEND|os.O_WRONLY, 0o600)
+		Expect(err).ToNot(HaveOccurred())
+
+		defer f.Close()
+
+		_, err = f.WriteString("excludedValidators:\n" + entries)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	validate := func() *Session {
+		cmd := exec.Command(_binPath, "validate",
+			"--metadata-only",
+			// AM0005 and AM0011 depend on quay and OCM
+			"--disabled", "AM0005,AM0011",
+			addonDir,
+		)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		return session
+	}
+
+	It("skips justified exclusions", func() {
+		exclude("- code: AM0008\n  justification: the default channel is managed by the operator\n")
+
+		session := validate()
+
+		Eventually(session, "30s").Should(Exit(0))
+		Expect(session.Err).To(Say("skipping validator\\(s\\) excluded by the addon metadata: AM0008"))
+	})
+
+	It("runs validators excluded without a justification", func() {
+		exclude("- code: AM0008\n")
+
+		session := validate()
+
+		Eventually(session, "30s").Should(Exit(1))
+		Expect(string(session.Err.Contents())).ToNot(ContainSubstring("excluded by the addon metadata"))
+		Expect(session.Out).To(Say("AM0008"))
+		Expect(session.Out).To(Say("AM0020"))
+	})
+})
@@ -0,0 +1,74 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"encoding/json"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("validate --fail-fast", func() {
+	var (
+		passingAddon = filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon")
+	)
+
+	validate := func(args ...string) *Session {
+		cmd := exec.Command(_binPath, append([]string{"validate",
+			"--fail-fast",
+			"--metadata-only",
+			// AM0005 and AM0011 depend on quay and OCM
+			"--disabled", "AM0005,AM0011",
+		}, args...)...)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		return session
+	}
+
+	It("runs all validators of a passing addon", func() {
+		session := validate(passingAddon)
+
+		Eventually(session, "30s").Should(Exit(0))
+		Expect(string(session.Err.Contents())).ToNot(ContainSubstring("--fail-fast"))
+	})
+
+	It("marks the report of a failing addon as incomplete", func() {
+		session := validate("--output", "json", failingAddon)
+
+		Eventually(session, "30s").Should(Exit(1))
+		Expect(session.Err).To(Say("stopped at the first unsuccessful validator"))
+
+		var report struct {
+			Passed     bool `json:"passed"`
+			Incomplete bool `json:"incomplete"`
+		}
+
+		Expect(json.Unmarshal(session.Out.Contents(), &report)).To(Succeed())
+		Expect(report.Passed).To(BeFalse())
+		Expect(report.Incomplete).To(BeTrue())
+	})
+
+	It("skips the remaining addons after a failing one", func() {
+		session := validate("--quiet", failingAddon, passingAddon)
+
+		Eventually(session, "30s").Should(Exit(1))
+		Expect(session.Err).To(Say("skipping 1 remaining addon"))
+		Expect(session.Out).To(Say("1 addons: 0 passed, 1 failed"))
+	})
+
+	It("cannot be combined with --interactive", func() {
+		session := validate("--interactive", passingAddon)
+
+		Eventually(session, "30s").Should(Exit(2))
+		Expect(session.Err).To(Say("'--fail-fast' cannot be combined"))
+	})
+})
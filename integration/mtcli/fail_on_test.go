@@ -0,0 +1,68 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("validate --fail-on", func() {
+	var (
+		addonDir   = filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon")
+		bundlesDir string
+	)
+
+	BeforeEach(func() {
+		bundlesDir = GinkgoT().TempDir()
+
+		Expect(os.Symlink(
+			filepath.Join(testutils.RootDir().TestData().Bundles(), "reference-addon", "main"),
+			filepath.Join(bundlesDir, "reference-addon"),
+		)).To(Succeed())
+	})
+
+	// the bundle lacks probes checked by AM0015 which
+	// the 'ocm-publish' profile reports as warnings
+	validate := func(args ...string) *Session {
+		cmd := exec.Command(_binPath, append([]string{"validate",
+			"--bundles-dir", bundlesDir,
+			"--profile", "ocm-publish",
+			"--enabled", "AM0015",
+		}, args...)...)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		return session
+	}
+
+	It("passes with warnings by default", func() {
+		session := validate(addonDir)
+
+		Eventually(session, "30s").Should(Exit(0))
+		Expect(session.Out).To(Say("AM0015"))
+	})
+
+	It("fails with warnings for '--fail-on warning'", func() {
+		session := validate("--fail-on", "warning", addonDir)
+
+		Eventually(session, "30s").Should(Exit(1))
+		Expect(session.Out).To(Say("AM0015"))
+	})
+
+	It("rejects other severities", func() {
+		session := validate("--fail-on", "info", addonDir)
+
+		Eventually(session, "30s").Should(Exit(2))
+		Expect(session.Err).To(Say("'info' is not a valid '--fail-on' severity"))
+	})
+})
@@ -0,0 +1,67 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("fix subcommand", func() {
+	var addonDir, metaPath string
+
+	BeforeEach(func() {
+		addonDir = GinkgoT().TempDir()
+		metaPath = filepath.Join(addonDir, "metadata", "stage", "addon.yaml")
+
+		Expect(os.MkdirAll(filepath.Dir(metaPath), 0o755)).To(Succeed())
+		Expect(os.WriteFile(metaPath, []byte(
+			"id: fix-addon\n# keep in sync with the id\nlabel: api.openshift.com/addon-Fix-Addon\nindexImage: quay.io/osd-addons/fix-addon-index:v0.1.0\n",
+		), 0o600)).To(Succeed())
+	})
+
+	It("applies fixes keeping comments", func() {
+		session, err := Start(exec.Command(_binPath, "fix", addonDir), GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(0))
+		Expect(session.Out).To(Say("AM0002 label_format: metadata/stage/addon.yaml: set 'label'"))
+		Expect(session.Out).To(Say(`Applied 1 fix\(es\)`))
+
+		Expect(os.ReadFile(metaPath)).To(BeEquivalentTo(
+			"id: fix-addon\n# keep in sync with the id\nlabel: api.openshift.com/addon-fix-addon\nindexImage: quay.io/osd-addons/fix-addon-index:v0.1.0\n",
+		))
+
+		session, err = Start(exec.Command(_binPath, "fix", "--check", addonDir), GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(0))
+		Expect(session.Out).To(Say("Nothing to fix."))
+	})
+
+	It("only lists fixes with --check", func() {
+		session, err := Start(exec.Command(_binPath, "fix", "--check", addonDir), GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(1))
+		Expect(session.Out).To(Say("AM0002 label_format"))
+		Expect(session.Err).To(Say("fixable failures found: 1"))
+
+		Expect(os.ReadFile(metaPath)).To(ContainSubstring("addon-Fix-Addon"))
+	})
+
+	It("skips disabled validators", func() {
+		session, err := Start(exec.Command(_binPath, "fix", "--disabled", "AM0002", addonDir), GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(0))
+		Expect(session.Out).To(Say("Nothing to fix."))
+	})
+})
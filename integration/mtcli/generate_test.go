@@ -0,0 +1,79 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os/exec"
+	"path/filepath"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("generate imageset subcommand", func() {
+	var (
+		legacyAddon   = filepath.Join(testutils.RootDir().TestData().MetadataV1().Legacy(), "reference-addon")
+		imageSetAddon = filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon")
+	)
+
+	DescribeTable("generating imagesets",
+		func(addonDir string, args []string, expected ...string) {
+			cmd := exec.Command(_binPath, append(append([]string{"generate", "imageset"}, args...), addonDir)...)
+
+			session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(session, "30s").Should(Exit(0))
+
+			for _, exp := range expected {
+				Expect(session.Out).To(Say(exp))
+			}
+		},
+		Entry("legacy addon",
+			legacyAddon,
+			[]string{"--version", "1.2.3"},
+			"kind: AddonImageSet", "name: reference-addon.v1.2.3", "indexImage: quay.io/osd-addons/reference-addon-index@sha256:",
+		),
+		Entry("addon using imagesets with an index image",
+			imageSetAddon,
+			[]string{"--version", "0.2.0", "--index-image", "quay.io/osd-addons/reference-addon-index:v0.2.0", "-n", "addons"},
+			"namespace: addons", "indexImage: quay.io/osd-addons/reference-addon-index:v0.2.0", "name: reference-addon.v0.2.0",
+		),
+	)
+
+	DescribeTable("invalid usage",
+		func(addonDir string, args []string, expectedErr string) {
+			cmd := exec.Command(_binPath, append(append([]string{"generate", "imageset"}, args...), addonDir)...)
+
+			session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(session, "30s").Should(Exit(2))
+			Expect(session.Err).To(Say(expectedErr))
+		},
+		Entry("missing version", legacyAddon, nil, "'--version' must be provided"),
+		Entry("invalid version", legacyAddon, []string{"--version", "latest"}, "not a valid version"),
+		Entry("addon using imagesets without an index image", imageSetAddon, []string{"--version", "0.2.0"}, "provide '--index-image'"),
+	)
+})
+
+var _ = Describe("generate schema subcommand", func() {
+	DescribeTable("generating schemas",
+		func(args []string, expectedCode int, expectedOut string) {
+			cmd := exec.Command(_binPath, append([]string{"generate", "schema"}, args...)...)
+
+			session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(session, "30s").Should(Exit(expectedCode))
+			Expect(session.Out).To(Say(expectedOut))
+		},
+		Entry("metadata by default", nil, 0, `"title": "Addon metadata"`),
+		Entry("imageset", []string{"--type", "imageset"}, 0, `"title": "Addon imageset"`),
+		Entry("unknown type", []string{"--type", "unknown"}, 2, ""),
+	)
+})
@@ -0,0 +1,46 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("image-policy flag", func() {
+	DescribeTable("enforcement",
+		func(policy string, exitCode int, expectedErr string) {
+			path := filepath.Join(GinkgoT().TempDir(), "policy.json")
+			Expect(os.WriteFile(path, []byte(policy), 0o600)).To(Succeed())
+
+			cmd := exec.Command(_binPath,
+				"--image-policy", path,
+				"list", "bundles",
+				"quay.io/osd-addons/reference-addon-index@sha256:b9e87a598e7fd6afb4bfedb31e4098435c2105cc8ebe33231c341e515ba9054d",
+			)
+
+			session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(session, "30s").Should(Exit(exitCode))
+			Expect(session.Err).To(Say(expectedErr))
+		},
+		Entry("rejected registry",
+			`{"default":[{"type":"insecureAcceptAnything"}],"transports":{"docker":{"quay.io/osd-addons":[{"type":"reject"}]}}}`,
+			1,
+			"image rejected by policy",
+		),
+		Entry("invalid policy",
+			`{"default":[{"type":"signedBy"}]}`,
+			2,
+			`loading --image-policy: .*unsupported requirement type "signedBy"`,
+		),
+	)
+})
@@ -0,0 +1,28 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os/exec"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("validate --interactive", func() {
+	DescribeTable("invalid usage",
+		func(args []string, expectedErr string) {
+			session, err := Start(exec.Command(_binPath, append([]string{"validate", "--interactive"}, args...)...), GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(session, "30s").Should(Exit(2))
+			Expect(session.Err).To(Say(expectedErr))
+		},
+		Entry("no terminal", []string{"a"}, "'--interactive' requires a terminal"),
+		Entry("json output", []string{"--output", "json", "a"}, "'--interactive' cannot be combined"),
+		Entry("multiple addon dirs", []string{"a", "b"}, "'--interactive' requires a single addon dir"),
+	)
+})
@@ -56,4 +56,66 @@ var _ = Describe("list subcommand", func() {
 			},
 		),
 	)
+	DescribeTable("validators subcommand",
+		func(args []string, expectedCodes []string, unexpectedCodes []string) {
+			cmd := exec.Command(_binPath, append([]string{"list", "validators"}, args...)...)
+
+			session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+			Eventually(session, "30s").Should(Exit(0))
+
+			out := string(session.Out.Contents())
+			for _, code := range expectedCodes {
+				Expect(out).To(ContainSubstring(code))
+			}
+
+			for _, code := range unexpectedCodes {
+				Expect(out).ToNot(ContainSubstring(code))
+			}
+		},
+		Entry("json filtered by tag",
+			[]string{"--output", "json", "--tag", "service"},
+			[]string{`"code": "AM0005"`, `"code": "AM0011"`, `"code": "AM0018"`, `"code": "AM0019"`},
+			[]string{"AM0001", "AM0002"},
+		),
+		Entry("table filtered by imageset tag",
+			[]string{"--tag", "imageset"},
+			[]string{"AM0009", "AM0013", "AM0017", "AM0019", "AM0022"},
+			[]string{"AM0001", "AM0002"},
+		),
+		Entry("table filtered by consistency tag",
+			[]string{"--tag", "consistency"},
+			[]string{"AM0001", "AM0003", "AM0007"},
+			[]string{"AM0002", "AM0012"},
+		),
+		Entry("yaml filtered by code prefix",
+			[]string{"--output", "yaml", "--code-prefix", "am001"},
+			[]string{"code: AM0010", "code: AM0019", "severity: error"},
+			[]string{"AM0009"},
+		),
+		Entry("table filtered by environment",
+			[]string{"--enabled-for-env", "production"},
+			[]string{"AM0001", "AM0019", "AM0021"},
+			nil,
+		),
+		Entry("table filtered by non-production environment",
+			[]string{"--enabled-for-env", "stage"},
+			[]string{"AM0001", "AM0019"},
+			[]string{"AM0021"},
+		),
+	)
+
+	DescribeTable("validators subcommand with invalid flags",
+		func(args ...string) {
+			cmd := exec.Command(_binPath, append([]string{"list", "validators"}, args...)...)
+
+			session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+			Eventually(session, "30s").Should(Exit(2))
+			Expect(session.Err).To(Say("verifying flags"))
+		},
+		Entry("invalid output", "--output", "xml"),
+		Entry("invalid tag", "--tag", "unknown"),
+		Entry("invalid environment", "--enabled-for-env", "dev"),
+	)
 })
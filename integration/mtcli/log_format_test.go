@@ -0,0 +1,38 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"encoding/json"
+	"os/exec"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("--log-format", func() {
+	It("logs the error a command failed with as JSON record", func() {
+		cmd := exec.Command(_binPath, "--log-format", "json", "validate", "--env", "unknown", "addon")
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(2))
+
+		var record map[string]interface{}
+		Expect(json.Unmarshal(session.Err.Contents(), &record)).To(Succeed())
+		Expect(record).To(HaveKeyWithValue("level", "ERROR"))
+		Expect(record).To(HaveKeyWithValue("exitCode", BeNumerically("==", 2)))
+		Expect(record["err"]).To(ContainSubstring("not a valid environment"))
+	})
+
+	It("rejects unknown formats", func() {
+		session, err := Start(exec.Command(_binPath, "--log-format", "xml", "version"), GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(2))
+		Expect(string(session.Err.Contents())).To(ContainSubstring("unknown log format"))
+	})
+})
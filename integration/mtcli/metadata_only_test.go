@@ -0,0 +1,35 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os/exec"
+	"path/filepath"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("validate --metadata-only", func() {
+	It("skips bundle extraction and validators requiring bundles", func() {
+		cmd := exec.Command(_binPath, "validate",
+			"--metadata-only",
+			// AM0005 and AM0011 depend on quay and OCM
+			"--disabled", "AM0005,AM0011",
+			filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon"),
+		)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(0))
+
+		out := string(session.Out.Contents())
+		Expect(out).To(ContainSubstring("AM0002"))
+		Expect(out).ToNot(ContainSubstring("AM0003"))
+		Expect(out).ToNot(ContainSubstring("AM0015"))
+	})
+})
@@ -0,0 +1,56 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os"
+	"os/exec"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("validate addon metadata without an addon dir", func() {
+	It("reads the addon metadata from stdin", func() {
+		stdin, err := os.Open(failingAddonMetadata)
+		Expect(err).ToNot(HaveOccurred())
+
+		defer stdin.Close()
+
+		cmd := exec.Command(_binPath, "validate",
+			"--metadata-only",
+			// AM0005 and AM0011 depend on quay and OCM
+			"--disabled", "AM0005,AM0011",
+			"-",
+		)
+		cmd.Stdin = stdin
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(1))
+
+		out := string(session.Out.Contents())
+		Expect(out).To(ContainSubstring("AM0002"))
+		Expect(out).To(ContainSubstring("AM0008"))
+		Expect(out).ToNot(ContainSubstring("AM0003"))
+	})
+
+	DescribeTable("invalid usage",
+		func(args []string, expectedErr string) {
+			session, err := Start(exec.Command(_binPath, append([]string{"validate"}, args...)...), GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(session, "30s").Should(Exit(2))
+			Expect(session.Err).To(Say(expectedErr))
+		},
+		Entry("metadata url without https", []string{"--metadata-url", "http://example.com/addon.yaml"}, "must be an 'https://' URL"),
+		Entry("metadata url with addon dirs", []string{"--metadata-url", "https://example.com/addon.yaml", "addon"}, "cannot be combined with addon dirs"),
+		Entry("stdin with other addon dirs", []string{"-", "addon"}, "'-' requires a single addon dir"),
+		Entry("stdin with watch", []string{"--watch", "-"}, "'--watch' requires an addon dir"),
+		Entry("metadata url with watch", []string{"--watch", "--metadata-url", "https://example.com/addon.yaml"}, "'--watch' requires an addon dir"),
+	)
+})
@@ -13,6 +13,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gexec"
@@ -20,6 +21,14 @@ import (
 
 var (
 	_binPath string
+
+	// failingAddon is the legacy reference addon. Its namespace
+	// 'reference-addon' lacks the 'redhat-' prefix so it always fails
+	// AM0008 while passing the other validators run without bundles
+	// and remote services (AM0005 and AM0011).
+	failingAddon = filepath.Join(testutils.RootDir().TestData().MetadataV1().Legacy(), "reference-addon")
+	// failingAddonMetadata is the stage addon metadata of failingAddon.
+	failingAddonMetadata = filepath.Join(failingAddon, "metadata", "stage", "addon.yaml")
 )
 
 func TestMTCLI(t *testing.T) {
@@ -0,0 +1,35 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os/exec"
+	"path/filepath"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("validate --notify", func() {
+	DescribeTable("invalid notifiers",
+		func(spec string, expectedErr string) {
+			cmd := exec.Command(_binPath, "validate",
+				"--notify", spec,
+				filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon"),
+			)
+
+			session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(session, "30s").Should(Exit(2))
+			Expect(session.Err).To(Say(expectedErr))
+		},
+		Entry("unknown kind", "pager=https://example.com", "unknown notifier kind"),
+		Entry("missing target", "slack", "must have the format '<kind>=<target>'"),
+		Entry("missing recipients", "email=smtp://localhost?from=mtcli@example.com", "requires at least one 'to' address"),
+	)
+})
@@ -0,0 +1,56 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os/exec"
+	"path/filepath"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("validate --profile", func() {
+	addon := filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon")
+
+	validate := func(args ...string) *Session {
+		cmd := exec.Command(_binPath, append([]string{"validate",
+			// AM0005 and AM0011 depend on quay and OCM
+			"--disabled", "AM0005,AM0011",
+		}, args...)...)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		return session
+	}
+
+	It("only runs the validators of the profile", func() {
+		session := validate("--metadata-only", "--profile", "minimal", addon)
+		Eventually(session, "30s").Should(Exit(0))
+
+		out := string(session.Out.Contents())
+		Expect(out).To(ContainSubstring("AM0009"))
+		Expect(out).ToNot(ContainSubstring("AM0001"))
+		Expect(out).ToNot(ContainSubstring("AM0018"))
+	})
+
+	It("is combined with the other validator selection flags", func() {
+		session := validate("--metadata-only", "--profile", "minimal", "--enabled-tags", "imageset", addon)
+		Eventually(session, "30s").Should(Exit(0))
+
+		out := string(session.Out.Contents())
+		Expect(out).To(ContainSubstring("AM0009"))
+		Expect(out).ToNot(ContainSubstring("AM0002"))
+	})
+
+	It("rejects unknown profiles", func() {
+		session := validate("--profile", "lenient", addon)
+		Eventually(session, "30s").Should(Exit(2))
+		Expect(session.Err).To(Say("unknown profile 'lenient'"))
+	})
+})
@@ -0,0 +1,64 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os/exec"
+	"path/filepath"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("validate --quiet", func() {
+	DescribeTable("only printing failures and a summary",
+		func(addonDir string, expectedCode int, expected, unexpected []string) {
+			cmd := exec.Command(_binPath, "validate",
+				"--quiet",
+				"--metadata-only",
+				// AM0005 and AM0011 depend on quay and OCM
+				"--disabled", "AM0005,AM0011",
+				addonDir,
+			)
+
+			session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(session, "30s").Should(Exit(expectedCode))
+
+			out := string(session.Out.Contents())
+
+			for _, exp := range expected {
+				Expect(out).To(ContainSubstring(exp))
+			}
+
+			for _, unexp := range unexpected {
+				Expect(out).ToNot(ContainSubstring(unexp))
+			}
+		},
+		Entry("passing addon",
+			filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon"),
+			0,
+			[]string{"1 addons: 1 passed, 0 failed"},
+			[]string{"AM0002", "FAILURE MESSAGE"},
+		),
+		Entry("failing addon",
+			failingAddon,
+			1,
+			[]string{"AM0008", "How to fix:", "/wiki/AM0008", "1 addons: 0 passed, 1 failed"},
+			[]string{"AM0002"},
+		),
+	)
+
+	It("cannot be combined with JSON output", func() {
+		session, err := Start(exec.Command(_binPath, "validate", "--quiet", "--output", "json", "addon"), GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(2))
+		Expect(session.Err).To(Say("'--quiet' cannot be combined"))
+	})
+})
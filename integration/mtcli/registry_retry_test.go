@@ -0,0 +1,36 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os/exec"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("registry retry flags", func() {
+	It("are accepted by all commands", func() {
+		cmd := exec.Command(_binPath, "--registry-retries", "0", "--registry-backoff", "2s", "list", "validators")
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(0))
+	})
+
+	DescribeTable("invalid usage",
+		func(args []string, expectedErr string) {
+			session, err := Start(exec.Command(_binPath, append(args, "list", "validators")...), GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(session, "30s").Should(Exit(2))
+			Expect(session.Err).To(Say(expectedErr))
+		},
+		Entry("negative retries", []string{"--registry-retries", "-1"}, "--registry-retries must not be negative"),
+		Entry("negative backoff", []string{"--registry-backoff", "-1s"}, "--registry-backoff must not be negative"),
+	)
+})
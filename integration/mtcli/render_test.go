@@ -0,0 +1,65 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os/exec"
+	"path/filepath"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("render subcommand", func() {
+	type crdTestCase struct {
+		Args          []string
+		ExpectedNames []string
+		ShouldSucceed bool
+	}
+
+	addonDir := filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon")
+
+	DescribeTable("crd subcommand",
+		func(tc crdTestCase) {
+			cmd := exec.Command(_binPath, append([]string{"render", "crd"}, tc.Args...)...)
+
+			session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+
+			if !tc.ShouldSucceed {
+				Eventually(session, "30s").Should(Exit(1))
+
+				return
+			}
+
+			Eventually(session, "30s").Should(Exit(0))
+
+			for _, name := range tc.ExpectedNames {
+				Expect(session.Out).To(Say(name))
+			}
+		},
+		Entry("pinned imageset",
+			crdTestCase{
+				Args:          []string{"--env", "stage", "--version", "0.0.2", addonDir},
+				ExpectedNames: []string{"kind: AddonImageSet", "name: reference-addon.v0.0.2"},
+				ShouldSucceed: true,
+			},
+		),
+		Entry("imageset and metadata",
+			crdTestCase{
+				Args:          []string{"--kind", "AddonImageSet,AddonMetadata", "-n", "addons", addonDir},
+				ExpectedNames: []string{"kind: AddonImageSet", "namespace: addons", "kind: AddonMetadata", "name: reference-addon"},
+				ShouldSucceed: true,
+			},
+		),
+		Entry("legacy addon",
+			crdTestCase{
+				Args: []string{filepath.Join(testutils.RootDir().TestData().MetadataV1().Legacy(), "reference-addon")},
+			},
+		),
+	)
+})
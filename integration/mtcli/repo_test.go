@@ -0,0 +1,101 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("validate repo", func() {
+	var root string
+
+	BeforeEach(func() {
+		root = GinkgoT().TempDir()
+
+		for _, env := range []string{"stage", "production"} {
+			path := filepath.Join(root, "addons", "broken-addon", "metadata", env, "addon.yaml")
+
+			Expect(os.MkdirAll(filepath.Dir(path), 0o755)).To(Succeed())
+			Expect(os.WriteFile(path, []byte("id: [\n"), 0o600)).To(Succeed())
+		}
+	})
+
+	It("validates every discovered addon and environment", func() {
+		session, err := Start(exec.Command(_binPath, "validate", "repo", root), GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(1))
+		Expect(session.Out).To(Say("stage: 1 addons: 0 passed, 0 failed, 0 errored, 1 could not be validated"))
+		Expect(session.Out).To(Say("production: 1 addons: 0 passed, 0 failed, 0 errored, 1 could not be validated"))
+		Expect(session.Out).To(Say("2 addons: 0 passed, 0 failed, 0 errored, 2 could not be validated"))
+		Expect(session.Err).To(Say("2 of 2 addons could not be validated"))
+	})
+
+	It("only validates the environment given explicitly", func() {
+		session, err := Start(exec.Command(_binPath, "validate", "repo", "--env", "production", root), GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(1))
+		Expect(session.Out).ToNot(Say("stage:"))
+		Expect(session.Err).To(Say("1 of 1 addons could not be validated"))
+	})
+
+	It("only validates addons changed since the given ref", func() {
+		git := func(args ...string) {
+			cmd := exec.Command("git", append([]string{"-C", root}, args...)...)
+			cmd.Env = append(os.Environ(),
+				"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+				"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+			)
+
+			Expect(cmd.Run()).To(Succeed())
+		}
+
+		git("init", "--quiet")
+		git("add", "-A")
+		git("commit", "--quiet", "-m", "initial")
+
+		session, err := Start(exec.Command(_binPath, "validate", "repo", "--changed-since", "HEAD", root), GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(0))
+		Expect(session.Out).To(Say(`no addons changed since "HEAD"`))
+
+		path := filepath.Join(root, "addons", "broken-addon", "metadata", "stage", "addon.yaml")
+		Expect(os.WriteFile(path, []byte("id: [[\n"), 0o600)).To(Succeed())
+
+		session, err = Start(exec.Command(_binPath, "validate", "--changed-since", "HEAD", filepath.Join(root, "addons", "broken-addon")), GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(1))
+		Expect(session.Err).To(Say("loading addon metadata"))
+	})
+
+	It("fails if no addons are found", func() {
+		session, err := Start(exec.Command(_binPath, "validate", "repo", GinkgoT().TempDir()), GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(2))
+		Expect(session.Err).To(Say("no addons found"))
+	})
+
+	DescribeTable("invalid arguments",
+		func(args []string, expectedErr string) {
+			session, err := Start(exec.Command(_binPath, append([]string{"validate", "repo"}, args...)...), GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(session, "30s").Should(Exit(2))
+			Expect(session.Err).To(Say(expectedErr))
+		},
+		Entry("missing repository", []string{"does-not-exist"}, "reading repository"),
+		Entry("pinned version", []string{"--version", "1.0.0", "."}, "'--version' only supports 'latest'"),
+	)
+})
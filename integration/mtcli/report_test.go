@@ -0,0 +1,34 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os/exec"
+	"path/filepath"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("validate --report", func() {
+	DescribeTable("invalid report flags",
+		func(args []string, expectedErr string) {
+			args = append([]string{"validate"}, args...)
+			args = append(args, filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon"))
+
+			session, err := Start(exec.Command(_binPath, args...), GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(session, "30s").Should(Exit(2))
+			Expect(session.Err).To(Say(expectedErr))
+		},
+		Entry("unknown format", []string{"--report", "xunit", "--report-file", "out.xml"}, "not a valid report format"),
+		Entry("missing file", []string{"--report", "junit"}, "must be given together with '--report-file' only"),
+		Entry("missing format", []string{"--report-file", "out.xml"}, "require '--report'"),
+		Entry("html without dir", []string{"--report", "html", "--report-file", "out.html"}, "must be given together with '--report-dir' only"),
+	)
+})
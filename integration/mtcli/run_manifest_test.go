@@ -0,0 +1,49 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("validate --replay", func() {
+	DescribeTable("invalid run manifests",
+		func(content string, expectedErr string) {
+			path := filepath.Join(GinkgoT().TempDir(), "run-manifest.json")
+
+			if content != "" {
+				Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+			}
+
+			cmd := exec.Command(_binPath, "validate", "--replay", path)
+
+			session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(session, "30s").Should(Exit(2))
+			Expect(session.Err).To(Say(expectedErr))
+		},
+		Entry("missing", "", "reading run manifest"),
+		Entry("malformed", "{", "decoding run manifest"),
+		Entry("unsupported version", `{"version":"v0"}`, "unsupported run manifest version"),
+		Entry("invalid flag value", `{"version":"v1","flags":{"strict":["maybe"]}}`, "setting flag '--strict'"),
+	)
+
+	It("requires an addon dir without --replay", func() {
+		cmd := exec.Command(_binPath, "validate")
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(2))
+		Expect(session.Err).To(Say("at least one addon dir is required"))
+	})
+})
@@ -0,0 +1,29 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os/exec"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("schema subcommand", func() {
+	DescribeTable("export subcommand",
+		func(args []string, expectedCode int, expectedOut string) {
+			cmd := exec.Command(_binPath, append([]string{"schema", "export"}, args...)...)
+
+			session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(session, "30s").Should(Exit(expectedCode))
+			Expect(session.Out).To(Say(expectedOut))
+		},
+		Entry("report", []string{"--type", "report"}, 0, `"title": "mtcli validation report"`),
+		Entry("unknown type", []string{"--type", "unknown"}, 1, ""),
+	)
+})
@@ -0,0 +1,90 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("validate --scope", func() {
+	var (
+		addonDir   = filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon")
+		bundlesDir string
+	)
+
+	BeforeEach(func() {
+		bundlesDir = GinkgoT().TempDir()
+
+		Expect(os.Symlink(
+			filepath.Join(testutils.RootDir().TestData().Bundles(), "reference-addon", "main"),
+			filepath.Join(bundlesDir, "reference-addon"),
+		)).To(Succeed())
+	})
+
+	DescribeTable("selecting validators",
+		func(scope string, withBundles bool, expected, unexpected []string) {
+			args := []string{"--scope", scope}
+			if withBundles {
+				args = append(args, "--bundles-dir", bundlesDir)
+			}
+
+			cmd := exec.Command(_binPath, append(append([]string{"validate",
+				// AM0005 and AM0011 depend on quay and OCM
+				// and the bundle lacks probes checked by AM0015
+				"--disabled", "AM0005,AM0011,AM0015",
+			}, args...), addonDir)...)
+
+			session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(session, "30s").Should(Exit(0))
+
+			out := string(session.Out.Contents())
+
+			for _, exp := range expected {
+				Expect(out).To(ContainSubstring(exp))
+			}
+
+			for _, unexp := range unexpected {
+				Expect(out).ToNot(ContainSubstring(unexp))
+			}
+		},
+		Entry("metadata without extracting bundles",
+			"metadata", false,
+			[]string{"AM0002", "AM0009"},
+			[]string{"AM0003", "AM0012"},
+		),
+		Entry("bundles",
+			"bundles", true,
+			[]string{"AM0003", "AM0012"},
+			[]string{"AM0002", "AM0009"},
+		),
+		Entry("imageset and bundles",
+			"imageset,bundles", true,
+			[]string{"AM0003", "AM0009", "AM0017"},
+			[]string{"AM0002", "AM0004"},
+		),
+	)
+
+	DescribeTable("invalid usage",
+		func(args []string, expectedErr string) {
+			session, err := Start(exec.Command(_binPath, append(append([]string{"validate"}, args...), addonDir)...), GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(session, "30s").Should(Exit(2))
+			Expect(session.Err).To(Say(expectedErr))
+		},
+		Entry("unknown scope", []string{"--scope", "docs"}, "not a valid scope"),
+		Entry("with --metadata-only", []string{"--scope", "bundles", "--metadata-only"}, "mutually exclusive"),
+		Entry("metadata with --bundles-dir", []string{"--scope", "metadata", "--bundles-dir", "bundles"}, "cannot be combined"),
+	)
+})
@@ -0,0 +1,89 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("serve subcommand", func() {
+	It("validates submitted metadata", func() {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ToNot(HaveOccurred())
+
+		addr := ln.Addr().String()
+		Expect(ln.Close()).To(Succeed())
+
+		session, err := Start(exec.Command(_binPath, "serve", "--addr", addr, "--enabled", "AM0002"), GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		DeferCleanup(func() { session.Kill() })
+
+		Eventually(session.Err, "30s").Should(Say("serving validations"))
+
+		meta, err := os.ReadFile(filepath.Join(testutils.RootDir().TestData().MetadataV1().Legacy(),
+			"reference-addon", "metadata", "stage", "addon.yaml"))
+		Expect(err).ToNot(HaveOccurred())
+
+		body, err := json.Marshal(map[string]string{"metadata": string(meta)})
+		Expect(err).ToNot(HaveOccurred())
+
+		res, err := http.Post("http://"+addr+"/v1/validations", "application/json", bytes.NewReader(body))
+		Expect(err).ToNot(HaveOccurred())
+
+		var job struct {
+			ID     string `json:"id"`
+			Status string `json:"status"`
+			Report struct {
+				Passed bool `json:"passed"`
+			} `json:"report"`
+		}
+
+		Expect(json.NewDecoder(res.Body).Decode(&job)).To(Succeed())
+		Expect(res.Body.Close()).To(Succeed())
+		Expect(res.StatusCode).To(Equal(http.StatusAccepted))
+
+		Eventually(func() string {
+			res, err := http.Get("http://" + addr + "/v1/validations/" + job.ID)
+			Expect(err).ToNot(HaveOccurred())
+
+			defer res.Body.Close()
+
+			Expect(json.NewDecoder(res.Body).Decode(&job)).To(Succeed())
+
+			return job.Status
+		}, "30s").Should(Equal("done"))
+
+		Expect(job.Report.Passed).To(BeTrue())
+
+		session.Interrupt()
+		Eventually(session, "30s").Should(Exit(0))
+	})
+
+	DescribeTable("invalid usage",
+		func(args []string, expectedErr string) {
+			session, err := Start(exec.Command(_binPath, append([]string{"serve"}, args...)...), GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(session, "30s").Should(Exit(2))
+			Expect(session.Err).To(Say(expectedErr))
+		},
+		Entry("unknown env", []string{"--env", "dev"}, "not a valid environment"),
+		Entry("zero concurrency", []string{"--concurrency", "0"}, "not a valid concurrency"),
+		Entry("zero pending jobs", []string{"--max-pending-jobs", "0"}, "not a valid number of pending jobs"),
+		Entry("enabled and disabled", []string{"--enabled", "AM0001", "--disabled", "AM0002"}, "mutually exclusive"),
+	)
+})
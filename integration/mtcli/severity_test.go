@@ -0,0 +1,32 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os/exec"
+	"path/filepath"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("validate severities", func() {
+	// the icon is not square and the description does
+	// not end with a full stop which are only warnings
+	addonDir := filepath.Join(testutils.RootDir().TestData().MetadataV1().Legacy(), "ocm-addon-test-operator")
+
+	It("passes if only warnings fail", func() {
+		cmd := exec.Command(_binPath, "validate", "--metadata-only", "--enabled", "AM0004,AM0031", addonDir)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(0))
+		Expect(session.Out).To(Say(`Warning\s+AM0004`))
+		Expect(session.Out).To(Say(`Warning\s+AM0031`))
+	})
+})
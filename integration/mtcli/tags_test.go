@@ -0,0 +1,52 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os/exec"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("validate --enabled-tags/--disabled-tags", func() {
+	validate := func(args ...string) *Session {
+		cmd := exec.Command(_binPath, append([]string{"validate",
+			"--metadata-only",
+			// AM0005 and AM0011 depend on quay and OCM
+			"--disabled", "AM0005,AM0011",
+		}, args...)...)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		return session
+	}
+
+	It("only runs validators with any of the enabled tags", func() {
+		session := validate("--enabled-tags", "imageset,security", failingAddon)
+		Eventually(session, "30s").Should(Exit(0))
+
+		out := string(session.Out.Contents())
+		Expect(out).To(ContainSubstring("AM0009"))
+		Expect(out).ToNot(ContainSubstring("AM0008"))
+	})
+
+	It("skips validators with any of the disabled tags", func() {
+		session := validate("--disabled-tags", "imageset", failingAddon)
+		Eventually(session, "30s").Should(Exit(1))
+
+		out := string(session.Out.Contents())
+		Expect(out).To(ContainSubstring("AM0008"))
+		Expect(out).ToNot(ContainSubstring("AM0009"))
+	})
+
+	It("rejects unknown tags", func() {
+		session := validate("--disabled-tags", "network", failingAddon)
+		Eventually(session, "30s").Should(Exit(2))
+		Expect(session.Err).To(Say("'network' is not a valid tag"))
+	})
+})
@@ -0,0 +1,34 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os/exec"
+	"path/filepath"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("validate --timeout", func() {
+	addonDir := filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon")
+
+	DescribeTable("timeouts",
+		func(args []string, exitCode int, expectedErr string) {
+			args = append(append([]string{"validate"}, args...), addonDir)
+
+			session, err := Start(exec.Command(_binPath, args...), GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(session, "30s").Should(Exit(exitCode))
+			Expect(session.Err).To(Say(expectedErr))
+		},
+		Entry("exceeded", []string{"--timeout", "1ns"}, 3, "validation exceeded '--timeout' of 1ns"),
+		Entry("negative", []string{"--timeout", "-1s"}, 2, "must not be negative"),
+		Entry("negative per validator", []string{"--validator-timeout", "-1s"}, 2, "must not be negative"),
+	)
+})
@@ -0,0 +1,50 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os/exec"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("validate index subcommand", func() {
+	const indexImage = "quay.io/osd-addons/reference-addon-index@sha256:b9e87a598e7fd6afb4bfedb31e4098435c2105cc8ebe33231c341e515ba9054d"
+
+	It("only runs validators checking bundles alone", func() {
+		cmd := exec.Command(_binPath, "validate", "index",
+			"--package", "reference-addon",
+			"--output", "json",
+			indexImage,
+		)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "60s").Should(Exit())
+		Expect(session.ExitCode()).To(BeNumerically("<", 2))
+
+		out := string(session.Out.Contents())
+		Expect(out).To(ContainSubstring(`"code": "AM0012"`))
+		Expect(out).ToNot(ContainSubstring(`"code": "AM0001"`))
+		Expect(out).ToNot(ContainSubstring(`"code": "AM0002"`))
+	})
+
+	DescribeTable("invalid usage",
+		func(args []string, expectedErr string) {
+			session, err := Start(exec.Command(_binPath, append([]string{"validate", "index"}, args...)...), GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(session, "30s").Should(Exit(2))
+			Expect(session.Err).To(Say(expectedErr))
+		},
+		Entry("without package", []string{indexImage}, "'--package' is required"),
+		Entry("without image", []string{"--package", "reference-addon"}, "accepts 1 arg"),
+		Entry("with --scope", []string{"--package", "reference-addon", "--scope", "metadata", indexImage}, "'--scope' cannot be combined"),
+		Entry("with --version", []string{"--package", "reference-addon", "--version", "1.0.0", indexImage}, "'--version' cannot be combined"),
+	)
+})
@@ -0,0 +1,184 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+const ownerOrgExecValidator = `#!/bin/sh
+if [ "$1" = describe ]; then
+  echo '{"code": "AM9002", "name": "owner_org_exec", "description": "Ensure that the addon is owned by the example org"}'
+  exit 0
+fi
+
+if grep -q '"addonOwner":"[^"]*@example.com>"'; then
+  echo '{"success": true}'
+else
+  echo '{"failureMsgs": ["addonOwner must have an @example.com address"]}'
+fi
+`
+
+var _ = Describe("--validators-exec-dir", func() {
+	var execDir string
+
+	BeforeEach(func() {
+		execDir = GinkgoT().TempDir()
+
+		Expect(os.WriteFile(filepath.Join(execDir, "owner-org"), []byte(ownerOrgExecValidator), 0o700)).To(Succeed())
+		// files which are not executable are ignored
+		Expect(os.WriteFile(filepath.Join(execDir, "README.md"), []byte("# validators"), 0o600)).To(Succeed())
+	})
+
+	It("lists exec validators", func() {
+		cmd := exec.Command(_binPath, "--validators-exec-dir", execDir, "list", "validators")
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(0))
+		Expect(session.Out).To(Say("AM9002 +owner_org_exec"))
+	})
+
+	It("runs exec validators", func() {
+		cmd := exec.Command(_binPath, "--validators-exec-dir", execDir, "validate",
+			"--metadata-only",
+			// AM0005 and AM0011 depend on quay and OCM
+			"--disabled", "AM0005,AM0011",
+			filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon"),
+		)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(1))
+		Expect(session.Out).To(Say("AM9002 +owner_org_exec .* addonOwner must have an @example.com address"))
+	})
+
+	It("skips exec validators whose prerequisites failed", func() {
+		dependent := strings.Replace(ownerOrgExecValidator, `"name": "owner_org_exec",`, `"name": "owner_org_exec", "dependsOn": ["AM0008"],`, 1)
+		Expect(os.WriteFile(filepath.Join(execDir, "owner-org"), []byte(dependent), 0o700)).To(Succeed())
+
+		cmd := exec.Command(_binPath, "--validators-exec-dir", execDir, "validate",
+			"--metadata-only",
+			// AM0005 and AM0011 depend on quay and OCM
+			"--disabled", "AM0005,AM0011",
+			failingAddon,
+		)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(1))
+		Expect(session.Out).To(Say("Skipped +AM9002 +owner_org_exec .* prerequisite failed: AM0008"))
+	})
+
+	It("selects exec validators by the codes they replace", func() {
+		replacing := strings.Replace(ownerOrgExecValidator, `"name": "owner_org_exec",`, `"name": "owner_org_exec", "replaces": ["AM9001"],`, 1)
+		Expect(os.WriteFile(filepath.Join(execDir, "owner-org"), []byte(replacing), 0o700)).To(Succeed())
+
+		cmd := exec.Command(_binPath, "--validators-exec-dir", execDir, "validate",
+			"--metadata-only",
+			"--enabled", "AM9001",
+			filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon"),
+		)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(1))
+		Expect(session.Err).To(Say("validator is deprecated; selecting its replacement.*code=AM9001 replacement=AM9002"))
+		Expect(session.Out).To(Say("AM9002 +owner_org_exec .* addonOwner must have an @example.com address"))
+
+		cmd = exec.Command(_binPath, "--validators-exec-dir", execDir, "describe", "validator", "AM9001")
+
+		session, err = Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(0))
+		Expect(session.Out).To(Say("AM9001 is deprecated and replaced by AM9002"))
+		Expect(session.Out).To(Say("Replaces: +AM9001"))
+	})
+
+	It("selects exec validators by the prefix of their family", func() {
+		downstream := strings.Replace(ownerOrgExecValidator, `"code": "AM9002"`, `"code": "OSD0001"`, 1)
+		Expect(os.WriteFile(filepath.Join(execDir, "owner-org"), []byte(downstream), 0o700)).To(Succeed())
+
+		cmd := exec.Command(_binPath, "--validators-exec-dir", execDir, "validate",
+			"--metadata-only",
+			"--enabled", "OSD",
+			filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon"),
+		)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(1))
+		Expect(session.Out).To(Say("OSD0001 +owner_org_exec .* addonOwner must have an @example.com address"))
+		Expect(session.Out).ToNot(Say("AM0001"))
+
+		cmd = exec.Command(_binPath, "--validators-exec-dir", execDir, "validate",
+			"--metadata-only",
+			"--disabled", "OSD,AM0005,AM0011",
+			filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon"),
+		)
+
+		session, err = Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(0))
+		Expect(session.Out).ToNot(Say("OSD0001"))
+	})
+
+	DescribeTable("exits depending on whether exec validator errors are retryable",
+		func(output, status string, code int) {
+			erroring := strings.Join([]string{
+				"#!/bin/sh",
+				`if [ "$1" = describe ]; then`,
+				`  echo '{"code": "AM9003", "name": "erroring_exec", "description": "Always errors"}'`,
+				"  exit 0",
+				"fi",
+				"echo '" + output + "'",
+				"",
+			}, "\n")
+			Expect(os.WriteFile(filepath.Join(execDir, "erroring"), []byte(erroring), 0o700)).To(Succeed())
+
+			cmd := exec.Command(_binPath, "--validators-exec-dir", execDir, "validate",
+				"--metadata-only",
+				"--enabled", "AM9003",
+				filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon"),
+			)
+
+			session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+
+			// retryable errors are retried before giving up
+			Eventually(session, "60s").Should(Exit(code))
+			Expect(session.Out).To(Say(status + " +AM9003 +erroring_exec .* validator internal error"))
+		},
+		Entry("retryable error", `{"error": "registry unavailable", "retryable": true}`, "Error", 3),
+		Entry("internal error", `{"error": "unexpected manifest"}`, "Internal Error", 1),
+	)
+
+	It("rejects executables with invalid descriptions", func() {
+		Expect(os.WriteFile(filepath.Join(execDir, "invalid"), []byte("#!/bin/sh\necho '{}'\n"), 0o700)).To(Succeed())
+
+		cmd := exec.Command(_binPath, "--validators-exec-dir", execDir, "list", "validators")
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(2))
+		Expect(session.Err).To(Say("loading --validators-exec-dir: loading exec validator"))
+	})
+})
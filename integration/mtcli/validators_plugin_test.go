@@ -0,0 +1,77 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("--validators-plugin-dir", Ordered, func() {
+	var pluginDir string
+
+	BeforeAll(func() {
+		pluginDir = GinkgoT().TempDir()
+
+		// plugins must be built with the same flags as the binary loading them
+		cmd := exec.Command("go", "build", "-buildmode=plugin",
+			"-o", filepath.Join(pluginDir, "example.so"),
+			filepath.Join(testutils.RootDir().TestData().Plugins(), "example"),
+		)
+		cmd.Env = append(os.Environ(),
+			"CGO_ENABLED=1",
+			"CGO_CFLAGS=-DSQLITE_ENABLE_JSON1",
+		)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "10m").Should(Exit(0))
+	})
+
+	It("lists the validators of plugins", func() {
+		cmd := exec.Command(_binPath, "--validators-plugin-dir", pluginDir, "list", "validators")
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(0))
+		Expect(session.Out).To(Say("AM9001 +owner_org"))
+	})
+
+	It("runs the validators of plugins", func() {
+		cmd := exec.Command(_binPath, "--validators-plugin-dir", pluginDir, "validate",
+			"--metadata-only",
+			// AM0005 and AM0011 depend on quay and OCM
+			"--disabled", "AM0005,AM0011",
+			filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon"),
+		)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(1))
+		Expect(session.Out).To(Say("AM9001 +owner_org .* addonOwner must have an '@example.com' address"))
+	})
+
+	It("rejects invalid plugins", func() {
+		dir := GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(dir, "invalid.so"), []byte("not an ELF file"), 0o600)).To(Succeed())
+
+		cmd := exec.Command(_binPath, "--validators-plugin-dir", dir, "list", "validators")
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(2))
+		Expect(session.Err).To(Say("loading --validators-plugin-dir: loading plugin"))
+	})
+})
@@ -0,0 +1,100 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("--validators-wasm-dir", Ordered, func() {
+	var wasmDir string
+
+	BeforeAll(func() {
+		wasmDir = GinkgoT().TempDir()
+
+		cmd := exec.Command("go", "build", "-o", filepath.Join(wasmDir, "owner-org.wasm"), ".")
+		cmd.Dir = filepath.Join(testutils.RootDir().TestData().Wasm(), "example")
+		cmd.Env = append(os.Environ(), "GOOS=wasip1", "GOARCH=wasm")
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "5m").Should(Exit(0))
+	})
+
+	It("runs WASM validators", func() {
+		cmd := exec.Command(_binPath, "--validators-wasm-dir", wasmDir, "validate",
+			"--metadata-only",
+			// AM0005 and AM0011 depend on quay and OCM
+			"--disabled", "AM0005,AM0011",
+			filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon"),
+		)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(1))
+		Expect(session.Out).To(Say("AM9003 +owner_org_wasm .* addonOwner must have an '@example.com' address"))
+	})
+
+	It("lists WASM validators", func() {
+		cmd := exec.Command(_binPath, "--validators-wasm-dir", wasmDir, "list", "validators")
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(0))
+		Expect(session.Out).To(Say("AM9003 +owner_org_wasm"))
+	})
+
+	It("records WASM validators in run manifests", func() {
+		manifest := filepath.Join(GinkgoT().TempDir(), "run-manifest.json")
+
+		cmd := exec.Command(_binPath, "--validators-wasm-dir", wasmDir, "validate",
+			"--metadata-only",
+			"--enabled", "AM9003",
+			"--run-manifest", manifest,
+			filepath.Join(testutils.RootDir().TestData().MetadataV1().ImageSets(), "reference-addon"),
+		)
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(1))
+
+		data, err := os.ReadFile(manifest)
+		Expect(err).ToNot(HaveOccurred())
+
+		var recorded struct {
+			Validators struct {
+				Codes []string `json:"codes"`
+			} `json:"validators"`
+		}
+
+		Expect(json.Unmarshal(data, &recorded)).To(Succeed())
+		Expect(recorded.Validators.Codes).To(ContainElement("AM9003"))
+	})
+
+	It("rejects invalid modules", func() {
+		dir := GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(dir, "invalid.wasm"), []byte("invalid"), 0o600)).To(Succeed())
+
+		cmd := exec.Command(_binPath, "--validators-wasm-dir", dir, "list", "validators")
+
+		session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session, "30s").Should(Exit(2))
+		Expect(session.Err).To(Say("loading --validators-wasm-dir: loading wasm validator"))
+	})
+})
@@ -0,0 +1,33 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os/exec"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("version subcommand", func() {
+	DescribeTable("output",
+		func(args []string, expectedCode int, expectedOut string) {
+			cmd := exec.Command(_binPath, append([]string{"version"}, args...)...)
+
+			session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(session, "30s").Should(Exit(expectedCode))
+			Expect(session.Out).To(Say(expectedOut))
+		},
+		Entry("text", []string{}, 0, `mtcli version: .+\n(?s:.*)validators: \d+`),
+		Entry("json", []string{"-o", "json"}, 0, `"goVersion": "go.+"(?s:.*)"validators": \d+`),
+		Entry("invalid output", []string{"-o", "yaml"}, 1, ""),
+		// flags are verified before checking for updates
+		Entry("check shorthand", []string{"--check", "-o", "yaml"}, 1, ""),
+		Entry("unknown flag", []string{"--checks"}, 2, ""),
+	)
+})
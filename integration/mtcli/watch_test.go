@@ -0,0 +1,70 @@
+//go:build !unit
+// +build !unit
+
+package mtcli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("validate --watch", func() {
+	It("validates the addon again after changes", func() {
+		dir := GinkgoT().TempDir()
+		path := filepath.Join(dir, "metadata", "stage", "addon.yaml")
+
+		Expect(os.MkdirAll(filepath.Dir(path), 0o755)).To(Succeed())
+		Expect(os.WriteFile(path, []byte("id: [\n"), 0o600)).To(Succeed())
+
+		session, err := Start(exec.Command(_binPath, "validate", "--watch", dir), GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session.Err, "30s").Should(Say("loading addon metadata"))
+		Eventually(session.Err, "30s").Should(Say("watching for changes"))
+
+		Expect(os.WriteFile(path, []byte("id: [[\n"), 0o600)).To(Succeed())
+
+		Eventually(session.Err, "30s").Should(Say("loading addon metadata"))
+		Eventually(session.Err, "30s").Should(Say("watching for changes"))
+
+		session.Interrupt()
+
+		Eventually(session, "30s").Should(Exit(0))
+	})
+
+	It("stops gracefully on SIGTERM", func() {
+		dir := GinkgoT().TempDir()
+		path := filepath.Join(dir, "metadata", "stage", "addon.yaml")
+
+		Expect(os.MkdirAll(filepath.Dir(path), 0o755)).To(Succeed())
+		Expect(os.WriteFile(path, []byte("id: [\n"), 0o600)).To(Succeed())
+
+		session, err := Start(exec.Command(_binPath, "validate", "--watch", dir), GinkgoWriter, GinkgoWriter)
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(session.Err, "30s").Should(Say("watching for changes"))
+
+		session.Terminate()
+
+		Eventually(session, "30s").Should(Exit(0))
+	})
+
+	DescribeTable("invalid arguments",
+		func(args []string, exitCode int, expectedErr string) {
+			session, err := Start(exec.Command(_binPath, append([]string{"validate", "--watch"}, args...)...), GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(session, "30s").Should(Exit(exitCode))
+			Expect(session.Err).To(Say(expectedErr))
+		},
+		Entry("missing addon dir", []string{"does-not-exist"}, 1, "watching"),
+		Entry("multiple addon dirs", []string{"a", "b"}, 2, "'--watch' requires a single addon dir"),
+		Entry("notifications", []string{"--notify", "slack=https://hooks.slack.com/x", "a"}, 2, "'--watch' and '--notify' are mutually exclusive"),
+	)
+})
@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"gopkg.in/yaml.v3"
+)
+
+// BaselineFileName is the name of the baseline file
+// used if no other baseline file is given.
+const BaselineFileName = ".mtcli-baseline.yaml"
+
+// Baseline records the validators known to fail for each addon so that
+// only new failures fail a run. Addons are identified by their ID; e.g.
+// the following file accepts two failing validators of 'reference-addon':
+//
+//	addons:
+//	  reference-addon: [AM0005, AM0011]
+type Baseline struct {
+	Addons map[string][]validator.Code
+	// updated holds the addons recorded by Update so that
+	// results of further environments are added to them.
+	updated map[string]bool
+}
+
+type baselineFile struct {
+	Addons map[string][]string `yaml:"addons"`
+}
+
+// LoadBaseline reads the baseline file at path.
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading baseline file: %w", err)
+	}
+
+	var raw baselineFile
+
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parsing baseline file %q: %w", path, err)
+	}
+
+	b := &Baseline{Addons: make(map[string][]validator.Code, len(raw.Addons))}
+
+	for addon, codes := range raw.Addons {
+		for _, c := range codes {
+			code, err := validator.ParseCode(c)
+			if err != nil {
+				return nil, fmt.Errorf("parsing baseline file %q: invalid code %q of addon %q: %w", path, c, addon, err)
+			}
+
+			b.Addons[addon] = append(b.Addons[addon], code)
+		}
+	}
+
+	return b, nil
+}
+
+// Write encodes b as YAML to path. Addons without known failures are omitted.
+func (b *Baseline) Write(path string) error {
+	raw := baselineFile{Addons: make(map[string][]string, len(b.Addons))}
+
+	for addon, codes := range b.Addons {
+		if len(codes) == 0 {
+			continue
+		}
+
+		sorted := make([]string, 0, len(codes))
+		for _, code := range codes {
+			sorted = append(sorted, code.String())
+		}
+
+		sort.Strings(sorted)
+
+		raw.Addons[addon] = sorted
+	}
+
+	var buf bytes.Buffer
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+
+	if err := enc.Encode(raw); err != nil {
+		return fmt.Errorf("encoding baseline: %w", err)
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("writing baseline file: %w", err)
+	}
+
+	return nil
+}
+
+// Known returns 'true' if code is known to fail for addon.
+func (b *Baseline) Known(addon string, code validator.Code) bool {
+	if b == nil {
+		return false
+	}
+
+	for _, c := range b.Addons[addon] {
+		if c == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Update replaces the known failures of addon by the failed results.
+// Results of validators which encountered errors are not recorded.
+// Further calls for the same addon, e.g. of other environments, add
+// their failures to those recorded before.
+func (b *Baseline) Update(addon string, results validator.ResultList) {
+	if b.Addons == nil {
+		b.Addons = make(map[string][]validator.Code)
+	}
+
+	if b.updated == nil {
+		b.updated = make(map[string]bool)
+	}
+
+	if !b.updated[addon] {
+		b.Addons[addon] = nil
+		b.updated[addon] = true
+	}
+
+	for _, res := range results {
+		if res.IsSuccess() || res.IsError() || res.IsSkipped() || b.Known(addon, res.Code) {
+			continue
+		}
+
+		b.Addons[addon] = append(b.Addons[addon], res.Code)
+	}
+}
+
+// Split separates the results of addon into new and known failures.
+// All other results are returned as new.
+func (b *Baseline) Split(addon string, results validator.ResultList) (validator.ResultList, validator.ResultList) {
+	var fresh, known validator.ResultList
+
+	for _, res := range results {
+		if !res.IsSuccess() && !res.IsError() && b.Known(addon, res.Code) {
+			known = append(known, res)
+
+			continue
+		}
+
+		fresh = append(fresh, res)
+	}
+
+	return fresh, known
+}
+
+// Fixed returns the known failures of addon which succeeded in results.
+func (b *Baseline) Fixed(addon string, results validator.ResultList) []validator.Code {
+	var res []validator.Code
+
+	for _, r := range results {
+		if r.IsSuccess() && b.Known(addon, r.Code) {
+			res = append(res, r.Code)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaseline(t *testing.T) {
+	t.Parallel()
+
+	newBase := func(code validator.Code) *validator.Base {
+		base, err := validator.NewBase(code, validator.BaseName("test"), validator.BaseDesc("test validator"))
+		require.NoError(t, err)
+
+		return base
+	}
+
+	am1, am2, am3, am4 := newBase(1), newBase(2), newBase(3), newBase(4)
+
+	path := filepath.Join(t.TempDir(), BaselineFileName)
+	require.NoError(t, os.WriteFile(path, []byte("addons:\n  reference-addon: [AM0002, AM0003]\n"), 0o600))
+
+	b, err := LoadBaseline(path)
+	require.NoError(t, err)
+
+	results := validator.ResultList{
+		am1.Fail("new failure"),
+		am2.Fail("known failure"),
+		am3.Success(),
+		am4.Error(errors.New("unreachable")),
+	}
+
+	fresh, known := b.Split("reference-addon", results)
+	assert.Equal(t, validator.ResultList{results[0], results[2], results[3]}, fresh)
+	assert.Equal(t, validator.ResultList{results[1]}, known)
+	assert.Equal(t, []validator.Code{3}, b.Fixed("reference-addon", results))
+
+	fresh, known = b.Split("other-addon", results)
+	assert.Equal(t, results, fresh)
+	assert.Empty(t, known)
+
+	b.Update("reference-addon", results)
+	// failures of further environments are added
+	b.Update("reference-addon", validator.ResultList{am3.Fail("production only")})
+	b.Update("other-addon", validator.ResultList{am1.Success()})
+
+	require.NoError(t, b.Write(path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "addons:\n  reference-addon:\n    - AM0001\n    - AM0002\n    - AM0003\n", string(data))
+}
+
+func TestLoadBaselineInvalidCode(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), BaselineFileName)
+	require.NoError(t, os.WriteFile(path, []byte("addons:\n  reference-addon: [AM5]\n"), 0o600))
+
+	_, err := LoadBaseline(path)
+	assert.ErrorContains(t, err, `invalid code "AM5" of addon "reference-addon"`)
+}
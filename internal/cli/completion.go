@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"context"
+
+	"fmt"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/spf13/cobra"
+)
+
+// Envs are the environments addon metadata can be loaded for.
+var Envs = []string{"integration", "stage", "production"}
+
+// CompletionFunc returns the dynamic completions of a flag value.
+type CompletionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// FlagCompletions maps flag names to their CompletionFunc.
+type FlagCompletions map[string]CompletionFunc
+
+// RegisterFlagCompletions registers the given completions with cmd. It
+// panics if cmd has no such flag as that is a programming error.
+func RegisterFlagCompletions(cmd *cobra.Command, completions FlagCompletions) {
+	for flag, fn := range completions {
+		if err := cmd.RegisterFlagCompletionFunc(flag, fn); err != nil {
+			panic(fmt.Sprintf("registering completion for flag '%s': %v", flag, err))
+		}
+	}
+}
+
+// CompleteEnvs completes environment names.
+func CompleteEnvs(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return Envs, cobra.ShellCompDirectiveNoFileComp
+}
+
+// CompleteValidatorCodes completes the codes of registered validators
+// for flags accepting a ',' separated list of codes. Codes which are
+// already part of the list are not suggested again.
+func CompleteValidatorCodes(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	runner, err := validator.NewRunner(context.Background())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	prefix, listed := splitListed(toComplete)
+
+	var res []string
+
+	for _, v := range runner.GetValidators() {
+		code := v.Code().String()
+		if listed[code] {
+			continue
+		}
+
+		res = append(res, fmt.Sprintf("%s%s\t%s", prefix, code, v.Name()))
+	}
+
+	return res, cobra.ShellCompDirectiveNoFileComp
+}
+
+// CompleteValidatorTags completes comma separated lists of validator tags.
+func CompleteValidatorTags(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	prefix, listed := splitListed(toComplete)
+
+	var res []string
+
+	for _, tag := range validator.Tags {
+		if listed[tag] {
+			continue
+		}
+
+		res = append(res, prefix+tag)
+	}
+
+	return res, cobra.ShellCompDirectiveNoFileComp
+}
+
+// splitListed splits a partially completed comma separated list into
+// the completed items, which prefix the completions, and the set of
+// those items so that they are not completed again.
+func splitListed(toComplete string) (string, map[string]bool) {
+	listed := make(map[string]bool)
+
+	i := strings.LastIndex(toComplete, ",")
+	if i < 0 {
+		return "", listed
+	}
+
+	for _, item := range strings.Split(toComplete[:i], ",") {
+		listed[strings.TrimSpace(item)] = true
+	}
+
+	return toComplete[:i+1], listed
+}
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"context"
+
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/register"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompleteValidatorCodes(t *testing.T) {
+	t.Parallel()
+
+	runner, err := validator.NewRunner(context.Background())
+	require.NoError(t, err)
+
+	vals := runner.GetValidators()
+	require.Greater(t, len(vals), 1)
+
+	first := vals[0].Code().String()
+
+	all, _ := CompleteValidatorCodes(nil, nil, "")
+	assert.Len(t, all, len(vals))
+	assert.Contains(t, all, first+"\t"+vals[0].Name())
+
+	rest, _ := CompleteValidatorCodes(nil, nil, first+",")
+	assert.Len(t, rest, len(vals)-1)
+
+	for _, c := range rest {
+		assert.Regexp(t, "^"+first+",AM", c)
+	}
+}
+
+func TestCompleteValidatorTags(t *testing.T) {
+	t.Parallel()
+
+	all, _ := CompleteValidatorTags(nil, nil, "")
+	assert.Len(t, all, len(validator.Tags))
+
+	rest, _ := CompleteValidatorTags(nil, nil, validator.TagSecurity+",")
+	assert.Len(t, rest, len(validator.Tags)-1)
+	assert.NotContains(t, rest, validator.TagSecurity+","+validator.TagSecurity)
+	assert.Contains(t, rest, validator.TagSecurity+","+validator.TagBundle)
+}
@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileName is the name of the configuration
+// file discovered by FindConfigFile.
+const ConfigFileName = ".mtcli.yaml"
+
+// FindConfigFile returns the path of the first configuration file found
+// in the given directories. Empty directories are skipped. An empty path
+// is returned if no directory contains a configuration file.
+func FindConfigFile(dirs ...string) (string, error) {
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+
+		path := filepath.Join(dir, ConfigFileName)
+
+		info, err := os.Stat(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return "", fmt.Errorf("checking for config file: %w", err)
+		}
+
+		if !info.IsDir() {
+			return path, nil
+		}
+	}
+
+	return "", nil
+}
+
+// Config holds default flag values read from a configuration file. It
+// maps flag names to their values; e.g. the following file sets the
+// '--env', '--disabled' and '--excluded-namespaces' flags:
+//
+//	env: stage
+//	disabled: [AM0005, AM0011]
+//	excluded-namespaces:
+//	- openshift-monitoring
+//
+// Lists given for flags which are not slices are joined by ','.
+type Config map[string][]string
+
+// LoadConfig reads the configuration file at path.
+func LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+
+	var raw map[string]yaml.Node
+
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parsing config file %q: %w", path, err)
+	}
+
+	cfg := make(Config, len(raw))
+
+	for name, node := range raw {
+		values, err := configValues(&node)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid value of %q: %w", path, node.Line, name, err)
+		}
+
+		cfg[name] = values
+	}
+
+	return cfg, nil
+}
+
+func configValues(node *yaml.Node) ([]string, error) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return []string{node.Value}, nil
+	case yaml.SequenceNode:
+		res := make([]string, 0, len(node.Content))
+
+		for _, item := range node.Content {
+			if item.Kind != yaml.ScalarNode {
+				return nil, errors.New("list items must be scalars")
+			}
+
+			res = append(res, item.Value)
+		}
+
+		return res, nil
+	case yaml.MappingNode:
+		// maps are given to 'stringToString' flags as 'k=v' pairs
+		pairs := make([]string, 0, len(node.Content)/2)
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, val := node.Content[i], node.Content[i+1]
+			if val.Kind != yaml.ScalarNode {
+				return nil, errors.New("map values must be scalars")
+			}
+
+			pairs = append(pairs, key.Value+"="+val.Value)
+		}
+
+		return []string{strings.Join(pairs, ",")}, nil
+	default:
+		return nil, errors.New("unsupported value")
+	}
+}
+
+// Unknown returns the sorted names of all configured
+// values which are not part of the given flag names.
+func (c Config) Unknown(known map[string]struct{}) []string {
+	var res []string
+
+	for name := range c {
+		if _, ok := known[name]; !ok {
+			res = append(res, name)
+		}
+	}
+
+	sort.Strings(res)
+
+	return res
+}
@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindConfigFile(t *testing.T) {
+	t.Parallel()
+
+	empty, withConfig := t.TempDir(), t.TempDir()
+
+	expected := filepath.Join(withConfig, ConfigFileName)
+	require.NoError(t, os.WriteFile(expected, []byte("env: stage\n"), 0o600))
+
+	path, err := FindConfigFile("", empty, withConfig)
+	require.NoError(t, err)
+	assert.Equal(t, expected, path)
+
+	path, err = FindConfigFile(empty)
+	require.NoError(t, err)
+	assert.Empty(t, path)
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), ConfigFileName)
+	require.NoError(t, os.WriteFile(path, []byte(`
+env: production
+strict: true
+concurrency: 4
+disabled: [AM0005, AM0011]
+excluded-namespaces:
+- a
+- b
+checksum:
+  addon.yaml: abc
+`), 0o600))
+
+	cfg, err := LoadConfig(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, Config{
+		"env":                 {"production"},
+		"strict":              {"true"},
+		"concurrency":         {"4"},
+		"disabled":            {"AM0005", "AM0011"},
+		"excluded-namespaces": {"a", "b"},
+		"checksum":            {"addon.yaml=abc"},
+	}, cfg)
+
+	assert.Equal(t, []string{"checksum", "strict"}, cfg.Unknown(map[string]struct{}{
+		"env": {}, "concurrency": {}, "disabled": {}, "excluded-namespaces": {},
+	}))
+
+	var (
+		env, disabled string
+		strict        bool
+		concurrency   int
+		namespaces    []string
+		checksums     map[string]string
+	)
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.StringVar(&env, "env", "stage", "")
+	flags.StringVar(&disabled, "disabled", "", "")
+	flags.BoolVar(&strict, "strict", false, "")
+	flags.IntVar(&concurrency, "concurrency", 0, "")
+	flags.StringSliceVar(&namespaces, "excluded-namespaces", nil, "")
+	flags.StringToStringVar(&checksums, "checksum", nil, "")
+
+	require.NoError(t, flags.Parse([]string{"--concurrency", "2"}))
+
+	unknown, err := SetFlags(flags, cfg)
+	require.NoError(t, err)
+	assert.Empty(t, unknown)
+
+	assert.Equal(t, "production", env)
+	assert.Equal(t, "AM0005,AM0011", disabled)
+	assert.True(t, strict)
+	assert.Equal(t, 2, concurrency, "flags given explicitly take precedence")
+	assert.Equal(t, []string{"a", "b"}, namespaces)
+	assert.Equal(t, map[string]string{"addon.yaml": "abc"}, checksums)
+
+	// values set from a config file can be overridden by later calls
+	_, err = SetFlags(flags, map[string][]string{"env": {"integration"}, "concurrency": {"8"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, "integration", env)
+	assert.Equal(t, 2, concurrency)
+}
+
+func TestLoadConfigInvalid(t *testing.T) {
+	t.Parallel()
+
+	for name, content := range map[string]string{
+		"not a mapping": "- env\n",
+		"nested list":   "disabled: [[AM0001]]\n",
+		"nested map":    "checksum:\n  addon.yaml:\n    sum: abc\n",
+	} {
+		content := content
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), ConfigFileName)
+			require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+			_, err := LoadConfig(path)
+			assert.Error(t, err)
+		})
+	}
+}
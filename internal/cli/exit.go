@@ -0,0 +1,57 @@
+package cli
+
+import "errors"
+
+// Exit codes of mtcli. Pipelines may retry runs which exited with
+// ExitInfrastructure while ExitFailure denotes problems of the input.
+const (
+	// ExitFailure is used for validation failures and unclassified errors.
+	ExitFailure = 1
+	// ExitUsage is used for invalid flags and arguments.
+	ExitUsage = 2
+	// ExitInfrastructure is used for errors of external systems such as
+	// failed image pulls, unreachable APIs or exceeded timeouts.
+	ExitInfrastructure = 3
+)
+
+// ExitError assigns an exit code to an error.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string { return e.Err.Error() }
+
+func (e *ExitError) Unwrap() error { return e.Err }
+
+// WithExitCode returns err annotated with the given exit code
+// or nil if err is nil.
+func WithExitCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &ExitError{Code: code, Err: err}
+}
+
+// UsageError annotates err with ExitUsage.
+func UsageError(err error) error { return WithExitCode(ExitUsage, err) }
+
+// InfrastructureError annotates err with ExitInfrastructure.
+func InfrastructureError(err error) error { return WithExitCode(ExitInfrastructure, err) }
+
+// ExitCode returns the exit code for err. The outermost code found in
+// the chain of err is used so that callers can reclassify errors.
+// ExitFailure is returned for errors without exit code.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+
+	return ExitFailure
+}
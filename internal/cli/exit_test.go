@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExitCode(t *testing.T) {
+	t.Parallel()
+
+	base := errors.New("boom")
+
+	for name, tc := range map[string]struct {
+		Err      error
+		Expected int
+	}{
+		"nil":            {Err: nil, Expected: 0},
+		"unclassified":   {Err: base, Expected: ExitFailure},
+		"usage":          {Err: UsageError(base), Expected: ExitUsage},
+		"infrastructure": {Err: fmt.Errorf("wrapped: %w", InfrastructureError(base)), Expected: ExitInfrastructure},
+		"reclassified":   {Err: UsageError(InfrastructureError(base)), Expected: ExitUsage},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.Expected, ExitCode(tc.Err))
+
+			if tc.Err != nil {
+				assert.ErrorIs(t, tc.Err, base)
+			}
+		})
+	}
+
+	assert.NoError(t, UsageError(nil))
+}
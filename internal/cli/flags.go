@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// RecordFlags returns the names and values of all flags in flags which
+// were set explicitly, excluding the named flags.
+func RecordFlags(flags *pflag.FlagSet, exclude ...string) map[string][]string {
+	excluded := make(map[string]struct{}, len(exclude))
+	for _, name := range exclude {
+		excluded[name] = struct{}{}
+	}
+
+	res := make(map[string][]string)
+
+	flags.Visit(func(f *pflag.Flag) {
+		if _, ok := excluded[f.Name]; ok {
+			return
+		}
+
+		res[f.Name] = FlagValues(f)
+	})
+
+	return res
+}
+
+// FlagValues returns the current values of f. The entries of map
+// flags are sorted by key so that the result is deterministic.
+func FlagValues(f *pflag.Flag) []string {
+	if sv, ok := f.Value.(pflag.SliceValue); ok {
+		return sv.GetSlice()
+	}
+
+	if f.Value.Type() == "stringToString" {
+		return []string{sortedStringToString(f.Value.String())}
+	}
+
+	return []string{f.Value.String()}
+}
+
+// sortedStringToString sorts the 'key=value' pairs of a stringToString
+// flag value which pflag formats as a single bracketed CSV record in
+// map iteration order. The value is returned as is if it can't be parsed.
+func sortedStringToString(val string) string {
+	trimmed := strings.TrimSuffix(strings.TrimPrefix(val, "["), "]")
+	if trimmed == "" {
+		return val
+	}
+
+	pairs, err := csv.NewReader(strings.NewReader(trimmed)).Read()
+	if err != nil {
+		return val
+	}
+
+	sort.Strings(pairs)
+
+	var buf bytes.Buffer
+
+	w := csv.NewWriter(&buf)
+	if err := w.Write(pairs); err != nil {
+		return val
+	}
+
+	w.Flush()
+
+	return "[" + strings.TrimSpace(buf.String()) + "]"
+}
+
+// setAnnotation marks flags set by SetFlags. Such flags are treated as
+// not set explicitly by later calls so that e.g. a replayed run overrides
+// the values of a configuration file but not the flags given explicitly.
+const setAnnotation = "mtcli/set-flag"
+
+// SetFlags sets the given values of all flags which are defined by flags
+// and not set explicitly already. The names of values which do not
+// belong to a flag defined by flags are returned.
+func SetFlags(flags *pflag.FlagSet, values map[string][]string) ([]string, error) {
+	var unknown []string
+
+	for name, vals := range values {
+		f := flags.Lookup(name)
+		if f == nil {
+			unknown = append(unknown, name)
+
+			continue
+		}
+
+		// flags given on the command line take precedence
+		if _, ok := f.Annotations[setAnnotation]; f.Changed && !ok {
+			continue
+		}
+
+		if err := setFlag(f, vals); err != nil {
+			return nil, fmt.Errorf("setting flag '--%s': %w", name, err)
+		}
+	}
+
+	sort.Strings(unknown)
+
+	return unknown, nil
+}
+
+func setFlag(f *pflag.Flag, values []string) error {
+	if sv, ok := f.Value.(pflag.SliceValue); ok {
+		if err := sv.Replace(values); err != nil {
+			return err
+		}
+	} else if len(values) > 0 {
+		val := strings.Join(values, ",")
+
+		if f.Value.Type() == "stringToString" {
+			// values formatted by FlagValues are enclosed in brackets
+			val = strings.TrimSuffix(strings.TrimPrefix(val, "["), "]")
+		}
+
+		if err := f.Value.Set(val); err != nil {
+			return err
+		}
+	}
+
+	f.Changed = true
+
+	if f.Annotations == nil {
+		f.Annotations = make(map[string][]string)
+	}
+
+	f.Annotations[setAnnotation] = []string{"true"}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAndSetFlags(t *testing.T) {
+	t.Parallel()
+
+	type values struct {
+		Env        string
+		Strict     bool
+		Namespaces []string
+		Checksums  map[string]string
+		Notify     []string
+	}
+
+	newFlags := func(v *values) *pflag.FlagSet {
+		flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		flags.StringVar(&v.Env, "env", "stage", "")
+		flags.BoolVar(&v.Strict, "strict", false, "")
+		flags.StringSliceVar(&v.Namespaces, "excluded-namespaces", nil, "")
+		flags.StringToStringVar(&v.Checksums, "checksum", nil, "")
+		flags.StringArrayVar(&v.Notify, "notify", nil, "")
+
+		return flags
+	}
+
+	var recorded values
+
+	flags := newFlags(&recorded)
+	require.NoError(t, flags.Parse([]string{
+		"--env", "production",
+		"--strict",
+		"--excluded-namespaces", "a,b",
+		"--checksum", "imageset.yaml=def,addon.yaml=abc",
+		"--notify", "slack=https://example.com",
+	}))
+
+	record := RecordFlags(flags, "notify")
+	assert.Equal(t, map[string][]string{
+		"env":                 {"production"},
+		"strict":              {"true"},
+		"excluded-namespaces": {"a", "b"},
+		"checksum":            {"[addon.yaml=abc,imageset.yaml=def]"},
+	}, record)
+
+	record["unknown"] = []string{"value"}
+
+	var set values
+
+	flags = newFlags(&set)
+	require.NoError(t, flags.Parse([]string{"--env", "integration"}))
+
+	unknown, err := SetFlags(flags, record)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"unknown"}, unknown)
+
+	assert.Equal(t, values{
+		// explicitly given flags take precedence
+		Env:        "integration",
+		Strict:     true,
+		Namespaces: []string{"a", "b"},
+		Checksums:  map[string]string{"addon.yaml": "abc", "imageset.yaml": "def"},
+	}, set)
+}
+
+func TestFlagValuesSortsStringToString(t *testing.T) {
+	t.Parallel()
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.StringToString("labels", nil, "")
+	require.NoError(t, flags.Parse([]string{"--labels", `c=3,"b=2,two",a=1`}))
+
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, []string{`[a=1,"b=2,two",c=3]`}, FlagValues(flags.Lookup("labels")))
+	}
+
+	var set map[string]string
+
+	flags = pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.StringToStringVar(&set, "labels", nil, "")
+
+	_, err := SetFlags(flags, map[string][]string{"labels": {`[a=1,"b=2,two",c=3]`}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "1", "b": "2,two", "c": "3"}, set)
+}
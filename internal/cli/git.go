@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ChangedFiles returns the absolute paths of all files below dir which
+// changed since the merge base of ref and HEAD in the git repository
+// containing dir. Committed, uncommitted and untracked changes as well as
+// deleted files are included.
+func ChangedFiles(ctx context.Context, dir, ref string) ([]string, error) {
+	toplevel, err := git(ctx, dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := git(ctx, dir, "merge-base", ref, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := git(ctx, dir, "diff", "--name-only", "--no-renames", strings.TrimSpace(base), "--", ".")
+	if err != nil {
+		return nil, err
+	}
+
+	// untracked files are listed relative to dir rather than the toplevel
+	untracked, err := git(ctx, dir, "ls-files", "--others", "--exclude-standard", "--full-name", "--", ".")
+	if err != nil {
+		return nil, err
+	}
+
+	root := strings.TrimSpace(toplevel)
+	seen := make(map[string]struct{})
+
+	var res []string
+
+	for _, name := range strings.Split(diff+untracked, "\n") {
+		if name == "" {
+			continue
+		}
+
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if _, ok := seen[path]; ok {
+			continue
+		}
+
+		seen[path] = struct{}{}
+		res = append(res, path)
+	}
+
+	sort.Strings(res)
+
+	return res, nil
+}
+
+func git(ctx context.Context, dir string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir, "-c", "core.quotePath=false"}, args...)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("running 'git %s': %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangedFiles(t *testing.T) {
+	t.Parallel()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+
+	run := func(args ...string) {
+		t.Helper()
+
+		cmd := exec.Command("git", append([]string{"-C", root}, args...)...)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+		)
+
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	write := func(name string) {
+		t.Helper()
+
+		path := filepath.Join(root, name)
+
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(name), 0o600))
+	}
+
+	run("init", "--quiet")
+	write("addons/a/metadata/stage/addon.yaml")
+	write("addons/b/metadata/stage/addon.yaml")
+	write("addons/c/metadata/stage/addon.yaml")
+	run("add", "-A")
+	run("commit", "--quiet", "-m", "initial")
+	run("tag", "base")
+
+	write("addons/a/addonimagesets/stage/a.v1.0.0.yaml")
+	run("rm", "--quiet", "addons/c/metadata/stage/addon.yaml")
+	run("add", "-A")
+	run("commit", "--quiet", "-m", "change")
+	write("addons/b/metadata/stage/addon.yaml.new")
+
+	changed, err := ChangedFiles(context.Background(), root, "base")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		filepath.Join(root, "addons/a/addonimagesets/stage/a.v1.0.0.yaml"),
+		filepath.Join(root, "addons/b/metadata/stage/addon.yaml.new"),
+		filepath.Join(root, "addons/c/metadata/stage/addon.yaml"),
+	}, changed)
+
+	changed, err = ChangedFiles(context.Background(), filepath.Join(root, "addons", "a"), "base")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		filepath.Join(root, "addons/a/addonimagesets/stage/a.v1.0.0.yaml"),
+	}, changed)
+
+	_, err = ChangedFiles(context.Background(), root, "does-not-exist")
+	assert.Error(t, err)
+}
@@ -103,6 +103,8 @@ func (fc FieldColor) Apply(s string) string {
 		return green(s)
 	case FieldColorRed:
 		return red(s)
+	case FieldColorYellow:
+		return yellow(s)
 	case FieldColorIntenselyBoldRed:
 		return intenselyBoldRed(s)
 	default:
@@ -113,15 +115,23 @@ func (fc FieldColor) Apply(s string) string {
 const (
 	FieldColorGreen            FieldColor = "green"
 	FieldColorRed              FieldColor = "red"
+	FieldColorYellow           FieldColor = "yellow"
 	FieldColorIntenselyBoldRed FieldColor = "intenselyBoldRed"
 )
 
 var (
 	green            = color.New(color.FgGreen).SprintFunc()
 	red              = color.New(color.FgRed).SprintFunc()
+	yellow           = color.New(color.FgYellow).SprintFunc()
 	intenselyBoldRed = color.New(color.Bold, color.FgHiRed).SprintFunc()
 )
 
+// DisableColor disables colored output. Output is colored by default
+// only if stdout is a terminal and NO_COLOR is not set.
+func DisableColor() {
+	color.NoColor = true
+}
+
 type TableConfig struct {
 	Header *simpletable.Header
 	Style  *simpletable.Style
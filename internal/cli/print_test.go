@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldColorApply(t *testing.T) {
+	color.NoColor = false
+	t.Cleanup(func() { color.NoColor = true })
+
+	for name, tc := range map[string]struct {
+		Color    FieldColor
+		Expected string
+	}{
+		"green":  {Color: FieldColorGreen, Expected: "\x1b[32mok\x1b[0m"},
+		"red":    {Color: FieldColorRed, Expected: "\x1b[31mok\x1b[0m"},
+		"yellow": {Color: FieldColorYellow, Expected: "\x1b[33mok\x1b[0m"},
+		"none":   {Expected: "ok"},
+	} {
+		assert.Equal(t, tc.Expected, tc.Color.Apply("ok"), name)
+	}
+
+	DisableColor()
+
+	assert.Equal(t, "ok", FieldColorYellow.Apply("ok"))
+}
@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/spf13/cobra"
+)
+
+// Profile is a curated set of validators and severities selected with
+// '--profile' so that pipeline stages do not need to maintain long
+// '--enabled' lists.
+type Profile struct {
+	Name        string
+	Description string
+	// Filter selects the validators of the profile; nil selects all.
+	Filter validator.Filter
+	// Severities maps the severities of results; nil keeps the
+	// documented severities.
+	Severities validator.SeverityFunc
+}
+
+// DefaultProfile is the name of the profile used unless another is given.
+const DefaultProfile = "default"
+
+// Profiles lists the built-in profiles.
+var Profiles = []Profile{
+	{
+		Name:        DefaultProfile,
+		Description: "All validators with their documented severities.",
+	},
+	{
+		Name:        "strict",
+		Description: "All validators; failures of severity warning are reported as errors.",
+		Severities: func(_ validator.Code, sev validator.Severity) validator.Severity {
+			if sev == validator.SeverityWarning {
+				return validator.SeverityError
+			}
+
+			return sev
+		},
+	},
+	{
+		Name:        "minimal",
+		Description: "Only validators inspecting the addon metadata itself; bundles and remote services are not checked.",
+		Filter:      validator.Not(validator.MatchesTags(validator.TagBundle, validator.TagService)),
+	},
+	{
+		Name: "ocm-publish",
+		Description: "Validators required to publish the addon to OCM; live cluster dry-runs and preflight checks are not run " +
+			"and missing resource requests or probes of deployments are reported as warnings.",
+		Filter: validator.Not(validator.MatchesCodes(
+			validator.PrefixAM.Code(18), // bundle_dry_run
+			validator.PrefixAM.Code(19), // preflight_certification
+		)),
+		Severities: func(code validator.Code, sev validator.Severity) validator.Severity {
+			// csv_deployments
+			if code == validator.PrefixAM.Code(15) && sev == validator.SeverityError {
+				return validator.SeverityWarning
+			}
+
+			return sev
+		},
+	},
+}
+
+// LookupProfile returns the built-in profile with the given name.
+func LookupProfile(name string) (Profile, error) {
+	for _, p := range Profiles {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+
+	return Profile{}, fmt.Errorf("unknown profile '%s'; must be one of %s", name, strings.Join(ProfileNames(), ", "))
+}
+
+// ProfileNames returns the names of all built-in profiles.
+func ProfileNames() []string {
+	names := make([]string, 0, len(Profiles))
+
+	for _, p := range Profiles {
+		names = append(names, p.Name)
+	}
+
+	return names
+}
+
+// CompleteProfiles completes the names of the built-in profiles.
+func CompleteProfiles(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	res := make([]string, 0, len(Profiles))
+
+	for _, p := range Profiles {
+		res = append(res, fmt.Sprintf("%s\t%s", p.Name, p.Description))
+	}
+
+	return res, cobra.ShellCompDirectiveNoFileComp
+}
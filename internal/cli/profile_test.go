@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupProfile(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range ProfileNames() {
+		p, err := LookupProfile(name)
+		require.NoError(t, err)
+
+		assert.Equal(t, name, p.Name)
+		assert.NotEmpty(t, p.Description)
+	}
+
+	_, err := LookupProfile("lenient")
+	assert.ErrorContains(t, err, "unknown profile 'lenient'; must be one of default, strict, minimal, ocm-publish")
+}
+
+func TestProfiles(t *testing.T) {
+	t.Parallel()
+
+	newDocumented := func(code validator.Code, tags ...string) validator.Validator {
+		base, err := validator.NewBase(code, validator.BaseDocs(validator.Docs{Tags: tags}))
+		require.NoError(t, err)
+
+		return taggedValidator{Base: base}
+	}
+
+	var (
+		metadata = newDocumented(2)
+		bundle   = newDocumented(15, validator.TagBundle)
+		dryRun   = newDocumented(18, validator.TagBundle, validator.TagService)
+	)
+
+	def, err := LookupProfile(DefaultProfile)
+	require.NoError(t, err)
+	assert.Nil(t, def.Filter)
+	assert.Nil(t, def.Severities)
+
+	strict, err := LookupProfile("strict")
+	require.NoError(t, err)
+	assert.Equal(t, validator.SeverityError, strict.Severities(2, validator.SeverityWarning))
+	assert.Equal(t, validator.SeverityInfo, strict.Severities(2, validator.SeverityInfo))
+
+	minimal, err := LookupProfile("minimal")
+	require.NoError(t, err)
+	assert.True(t, minimal.Filter(metadata))
+	assert.False(t, minimal.Filter(bundle))
+	assert.False(t, minimal.Filter(dryRun))
+
+	publish, err := LookupProfile("ocm-publish")
+	require.NoError(t, err)
+	assert.True(t, publish.Filter(metadata))
+	assert.True(t, publish.Filter(bundle))
+	assert.False(t, publish.Filter(dryRun))
+	assert.Equal(t, validator.SeverityWarning, publish.Severities(15, validator.SeverityError))
+	assert.Equal(t, validator.SeverityError, publish.Severities(2, validator.SeverityError))
+}
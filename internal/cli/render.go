@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	KindAddonImageSet = "AddonImageSet"
+	KindAddonMetadata = "AddonMetadata"
+)
+
+// NewAddonImageSet returns an AddonImageSet resource holding spec.
+func NewAddonImageSet(spec addonsv1alpha1.AddonImageSetSpec, namespace string) *addonsv1alpha1.AddonImageSet {
+	return &addonsv1alpha1.AddonImageSet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: addonsv1alpha1.GroupVersion.String(),
+			Kind:       KindAddonImageSet,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      spec.Name,
+			Namespace: namespace,
+		},
+		Spec: spec,
+	}
+}
+
+// NewAddonMetadata returns an AddonMetadata resource holding spec.
+func NewAddonMetadata(spec addonsv1alpha1.AddonMetadataSpec, namespace string) *addonsv1alpha1.AddonMetadata {
+	return &addonsv1alpha1.AddonMetadata{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: addonsv1alpha1.GroupVersion.String(),
+			Kind:       KindAddonMetadata,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      spec.ID,
+			Namespace: namespace,
+		},
+		Spec: spec,
+	}
+}
+
+// RenderObjects encodes objs as a multi-document YAML stream omitting
+// unset fields and the fields which are only populated by the API server.
+func RenderObjects(objs ...runtime.Object) ([]byte, error) {
+	var buf bytes.Buffer
+
+	for i, obj := range objs {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return nil, fmt.Errorf("converting %T: %w", obj, err)
+		}
+
+		unstructured.RemoveNestedField(content, "metadata", "creationTimestamp")
+		unstructured.RemoveNestedField(content, "status")
+		pruneNulls(content)
+
+		data, err := yaml.Marshal(content)
+		if err != nil {
+			return nil, fmt.Errorf("encoding %T: %w", obj, err)
+		}
+
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+
+		buf.Write(data)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func pruneNulls(obj map[string]interface{}) {
+	for key, val := range obj {
+		switch v := val.(type) {
+		case nil:
+			delete(obj, key)
+		case map[string]interface{}:
+			pruneNulls(v)
+		case []interface{}:
+			for _, item := range v {
+				if m, ok := item.(map[string]interface{}); ok {
+					pruneNulls(m)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/httputil"
+	"github.com/mt-sre/addon-metadata-operator/pkg/registry"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+// RunManifestVersion is the version of the JSON encoding of a RunManifest.
+const RunManifestVersion = "v1"
+
+// RunManifest records everything required to reproduce a validation
+// run: the binary and validators used, the addon and the digests of
+// the images it resolved to and the flags the run was started with.
+type RunManifest struct {
+	Version    string            `json:"version"`
+	Mtcli      BuildInfo         `json:"mtcli"`
+	Validators ValidatorRegistry `json:"validators"`
+	Addon      RunManifestAddon  `json:"addon"`
+	// Flags maps the names of all flags set
+	// explicitly for the run to their values.
+	Flags      map[string][]string `json:"flags,omitempty"`
+	StartedAt  time.Time           `json:"startedAt"`
+	FinishedAt time.Time           `json:"finishedAt"`
+}
+
+type RunManifestAddon struct {
+	// Dir is the addon directory or URL as given to the run.
+	Dir string `json:"dir"`
+	ID  string `json:"id,omitempty"`
+	// IndexImage is the index image referenced by the metadata.
+	IndexImage string `json:"indexImage,omitempty"`
+	// IndexImageDigest is IndexImage pinned to the digest it resolved
+	// to. It is empty if the digest could not be resolved.
+	IndexImageDigest string `json:"indexImageDigest,omitempty"`
+	// BundleImages are the bundle images extracted from the index.
+	BundleImages []string `json:"bundleImages,omitempty"`
+}
+
+// PinnedIndexImage returns the index image pinned by digest
+// if available and the index image as referenced otherwise.
+func (a RunManifestAddon) PinnedIndexImage() string {
+	if a.IndexImageDigest != "" {
+		return a.IndexImageDigest
+	}
+
+	return a.IndexImage
+}
+
+// ValidatorRegistry identifies the set of registered validators.
+type ValidatorRegistry struct {
+	// Hash is the sha256 sum of the codes, names and
+	// descriptions of all registered validators.
+	Hash  string   `json:"hash"`
+	Codes []string `json:"codes"`
+}
+
+// GetValidatorRegistry returns the ValidatorRegistry of the validators
+// of the default runner created with ctx.
+func GetValidatorRegistry(ctx context.Context) (ValidatorRegistry, error) {
+	runner, err := validator.NewRunner(ctx)
+	if err != nil {
+		return ValidatorRegistry{}, fmt.Errorf("initializing validators: %w", err)
+	}
+
+	vals := runner.GetValidators()
+
+	sort.Slice(vals, func(i, j int) bool { return vals[i].Code() < vals[j].Code() })
+
+	h := sha256.New()
+	codes := make([]string, 0, len(vals))
+
+	for _, v := range vals {
+		fmt.Fprintf(h, "%s\x00%s\x00%s\n", v.Code(), v.Name(), v.Description())
+
+		codes = append(codes, v.Code().String())
+	}
+
+	return ValidatorRegistry{
+		Hash:  hex.EncodeToString(h.Sum(nil)),
+		Codes: codes,
+	}, nil
+}
+
+// ResolveImageDigest returns image pinned to the digest of its
+// manifest as '<repository>@<digest>'.
+func ResolveImageDigest(ctx context.Context, image string) (string, error) {
+	repo, err := registry.NewRepository(httputil.NewClient(), image)
+	if err != nil {
+		return "", err
+	}
+
+	dgst, err := repo.Resolve(ctx, repo.Reference())
+	if err != nil {
+		return "", fmt.Errorf("resolving digest of %q: %w", image, err)
+	}
+
+	return repo.Name() + "@" + dgst.String(), nil
+}
+
+// WriteRunManifest encodes m as indented JSON to path.
+func WriteRunManifest(path string, m RunManifest) error {
+	m.Version = RunManifestVersion
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding run manifest: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing run manifest: %w", err)
+	}
+
+	return nil
+}
+
+// LoadRunManifest reads the run manifest at path.
+func LoadRunManifest(path string) (RunManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return RunManifest{}, fmt.Errorf("reading run manifest: %w", err)
+	}
+
+	var m RunManifest
+
+	if err := json.Unmarshal(data, &m); err != nil {
+		return RunManifest{}, fmt.Errorf("decoding run manifest %q: %w", path, err)
+	}
+
+	if m.Version != RunManifestVersion {
+		return RunManifest{}, fmt.Errorf("unsupported run manifest version %q; expected %q", m.Version, RunManifestVersion)
+	}
+
+	return m, nil
+}
+
+// Discrepancies returns human readable differences between the
+// binary and validators which recorded m and the given ones.
+func (m RunManifest) Discrepancies(build BuildInfo, vals ValidatorRegistry) []string {
+	var res []string
+
+	if m.Mtcli.Version != build.Version || m.Mtcli.Commit != build.Commit {
+		res = append(res, fmt.Sprintf("run was recorded by mtcli %s (%s), replaying with %s (%s)",
+			m.Mtcli.Version, m.Mtcli.Commit, build.Version, build.Commit,
+		))
+	}
+
+	if m.Validators.Hash != vals.Hash {
+		res = append(res, fmt.Sprintf("validator registry differs: recorded %s, current %s",
+			shortHash(m.Validators.Hash), shortHash(vals.Hash),
+		))
+	}
+
+	return res
+}
+
+func shortHash(h string) string {
+	const length = 12
+
+	if len(h) > length {
+		return h[:length]
+	}
+
+	return h
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunManifestRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	vals, err := GetValidatorRegistry(context.Background())
+	require.NoError(t, err)
+	require.NotEmpty(t, vals.Codes)
+
+	again, err := GetValidatorRegistry(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, vals, again)
+
+	path := filepath.Join(t.TempDir(), "run-manifest.json")
+
+	m := RunManifest{
+		Mtcli:      GetBuildInfo(),
+		Validators: vals,
+		Addon: RunManifestAddon{
+			Dir:              "addons/reference-addon",
+			ID:               "reference-addon",
+			IndexImage:       "quay.io/osd-addons/reference-addon-index:v1",
+			IndexImageDigest: "quay.io/osd-addons/reference-addon-index@sha256:" + vals.Hash,
+			BundleImages:     []string{"quay.io/osd-addons/reference-addon-bundle:v1"},
+		},
+		Flags:      map[string][]string{"env": {"stage"}},
+		StartedAt:  time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+		FinishedAt: time.Date(2022, 1, 1, 0, 1, 0, 0, time.UTC),
+	}
+
+	require.NoError(t, WriteRunManifest(path, m))
+
+	loaded, err := LoadRunManifest(path)
+	require.NoError(t, err)
+
+	m.Version = RunManifestVersion
+	assert.Equal(t, m, loaded)
+	assert.Equal(t, m.Addon.IndexImageDigest, loaded.Addon.PinnedIndexImage())
+	assert.Empty(t, loaded.Discrepancies(GetBuildInfo(), vals))
+
+	build := GetBuildInfo()
+	build.Version = "99.0.0"
+	vals.Hash = "changed"
+
+	assert.Len(t, loaded.Discrepancies(build, vals), 2)
+}
+
+func TestLoadRunManifestRejectsUnknownVersion(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "run-manifest.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"version":"v0"}`), 0o600))
+
+	_, err := LoadRunManifest(path)
+	assert.ErrorContains(t, err, "unsupported run manifest version")
+}
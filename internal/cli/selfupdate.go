@@ -0,0 +1,281 @@
+package cli
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/httputil"
+)
+
+var (
+	ErrNoReleaseArchive = errors.New("no release archive for platform")
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+)
+
+const (
+	checksumsAsset = "checksums.txt"
+	binaryName     = "mtcli"
+)
+
+// ArchiveName returns the name of the release archive containing the
+// mtcli binary for the given platform as produced by goreleaser
+// (see .goreleaser.yml).
+func ArchiveName(version, goos, goarch string) string {
+	title := goos
+	if title != "" {
+		title = strings.ToUpper(title[:1]) + title[1:]
+	}
+
+	arch := goarch
+	switch goarch {
+	case "amd64":
+		arch = "x86_64"
+	case "386":
+		arch = "i386"
+	}
+
+	return fmt.Sprintf("%s_%s_%s_%s.tar.gz", binaryName, strings.TrimPrefix(version, "v"), title, arch)
+}
+
+// NewUpdater returns an Updater configured with a variadic
+// slice of options.
+func NewUpdater(opts ...UpdaterOption) *Updater {
+	var cfg UpdaterConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	return &Updater{cfg: cfg}
+}
+
+// Updater replaces an mtcli binary with the binary of a published release.
+type Updater struct {
+	cfg UpdaterConfig
+}
+
+// Update downloads the release archive for the configured platform from
+// the given release, verifies it against the checksums published with
+// the release and replaces the configured executable with the contained
+// binary. The executable is left untouched if any step fails.
+func (u *Updater) Update(ctx context.Context, release Release) error {
+	name := ArchiveName(release.Version, u.cfg.GOOS, u.cfg.GOARCH)
+
+	archive, ok := release.Asset(name)
+	if !ok {
+		return fmt.Errorf("%w %s/%s in release '%s'", ErrNoReleaseArchive, u.cfg.GOOS, u.cfg.GOARCH, release.Version)
+	}
+
+	checksums, ok := release.Asset(checksumsAsset)
+	if !ok {
+		return fmt.Errorf("release '%s' does not publish '%s'", release.Version, checksumsAsset)
+	}
+
+	expected, err := u.checksum(ctx, checksums.DownloadURL, name)
+	if err != nil {
+		return fmt.Errorf("retrieving checksum: %w", err)
+	}
+
+	data, err := u.download(ctx, archive.DownloadURL)
+	if err != nil {
+		return fmt.Errorf("downloading '%s': %w", name, err)
+	}
+
+	sum := sha256.Sum256(data)
+	if actual := hex.EncodeToString(sum[:]); actual != expected {
+		return fmt.Errorf("verifying '%s': %w: expected '%s', got '%s'", name, ErrChecksumMismatch, expected, actual)
+	}
+
+	bin, err := extractBinary(data)
+	if err != nil {
+		return fmt.Errorf("extracting '%s': %w", name, err)
+	}
+
+	if err := replaceFile(u.cfg.Executable, bin); err != nil {
+		return fmt.Errorf("replacing '%s': %w", u.cfg.Executable, err)
+	}
+
+	return nil
+}
+
+func (u *Updater) checksum(ctx context.Context, url, name string) (string, error) {
+	data, err := u.download(ctx, url)
+	if err != nil {
+		return "", err
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[1] == name {
+			return fields[0], nil
+		}
+	}
+
+	return "", fmt.Errorf("no checksum listed for '%s'", name)
+}
+
+func (u *Updater) download(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	res, err := u.cfg.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status '%s'", res.Status)
+	}
+
+	return io.ReadAll(res.Body)
+}
+
+func extractBinary(archive []byte) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("archive does not contain '%s'", binaryName)
+		} else if err != nil {
+			return nil, err
+		}
+
+		if hdr.Typeflag != tar.TypeReg || filepath.Base(hdr.Name) != binaryName {
+			continue
+		}
+
+		return io.ReadAll(tr)
+	}
+}
+
+// replaceFile atomically replaces the file at path with data
+// keeping the permissions of the existing file.
+func replaceFile(path string, data []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-update-")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+type UpdaterConfig struct {
+	Client *http.Client
+	// Executable is the path of the binary to replace.
+	// Defaults to the running executable.
+	Executable string
+	GOOS       string
+	GOARCH     string
+}
+
+func (c *UpdaterConfig) Option(opts ...UpdaterOption) {
+	for _, opt := range opts {
+		opt.ConfigureUpdater(c)
+	}
+}
+
+func (c *UpdaterConfig) Default() {
+	if c.Client == nil {
+		c.Client = httputil.NewClient()
+	}
+
+	if c.Executable == "" {
+		c.Executable = currentExecutable()
+	}
+
+	if c.GOOS == "" {
+		c.GOOS = runtime.GOOS
+	}
+
+	if c.GOARCH == "" {
+		c.GOARCH = runtime.GOARCH
+	}
+}
+
+func currentExecutable() string {
+	exe, err := os.Executable()
+	if err != nil {
+		return ""
+	}
+
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		return resolved
+	}
+
+	return exe
+}
+
+type UpdaterOption interface {
+	ConfigureUpdater(*UpdaterConfig)
+}
+
+// WithHTTPClient applies the client used to download release assets.
+type WithHTTPClient struct{ *http.Client }
+
+func (w WithHTTPClient) ConfigureUpdater(c *UpdaterConfig) {
+	c.Client = w.Client
+}
+
+// WithExecutable replaces the binary at the given path
+// instead of the running executable.
+type WithExecutable string
+
+func (w WithExecutable) ConfigureUpdater(c *UpdaterConfig) {
+	c.Executable = string(w)
+}
+
+// WithPlatform selects the release archive for the given
+// platform instead of the platform mtcli is running on.
+type WithPlatform struct {
+	OS   string
+	Arch string
+}
+
+func (w WithPlatform) ConfigureUpdater(c *UpdaterConfig) {
+	c.GOOS = w.OS
+	c.GOARCH = w.Arch
+}
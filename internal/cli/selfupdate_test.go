@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "mtcli_1.2.3_Linux_x86_64.tar.gz", ArchiveName("v1.2.3", "linux", "amd64"))
+	assert.Equal(t, "mtcli_1.2.3_Darwin_arm64.tar.gz", ArchiveName("1.2.3", "darwin", "arm64"))
+}
+
+func TestUpdaterUpdate(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Checksum    func(archive []byte) string
+		ExpectedErr error
+		Expected    string
+	}{
+		"valid checksum": {
+			Checksum: sha256Hex,
+			Expected: "new binary",
+		},
+		"invalid checksum": {
+			Checksum:    func([]byte) string { return sha256Hex([]byte("other")) },
+			ExpectedErr: ErrChecksumMismatch,
+			Expected:    "old binary",
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			archive := newTestArchive(t, "new binary")
+			archiveName := ArchiveName("v1.0.0", "linux", "amd64")
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("/"+archiveName, func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write(archive)
+			})
+			mux.HandleFunc("/checksums.txt", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, "%s  %s\n", tc.Checksum(archive), archiveName)
+			})
+
+			srv := httptest.NewServer(mux)
+			t.Cleanup(srv.Close)
+
+			exe := filepath.Join(t.TempDir(), "mtcli")
+			require.NoError(t, os.WriteFile(exe, []byte("old binary"), 0o755))
+
+			updater := NewUpdater(
+				WithHTTPClient{Client: srv.Client()},
+				WithExecutable(exe),
+				WithPlatform{OS: "linux", Arch: "amd64"},
+			)
+
+			err := updater.Update(context.Background(), Release{
+				Version: "v1.0.0",
+				Assets: []ReleaseAsset{
+					{Name: archiveName, DownloadURL: srv.URL + "/" + archiveName},
+					{Name: "checksums.txt", DownloadURL: srv.URL + "/checksums.txt"},
+				},
+			})
+			if tc.ExpectedErr != nil {
+				require.ErrorIs(t, err, tc.ExpectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			data, err := os.ReadFile(exe)
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expected, string(data))
+
+			info, err := os.Stat(exe)
+			require.NoError(t, err)
+			assert.Equal(t, os.FileMode(0o755), info.Mode().Perm())
+		})
+	}
+}
+
+func TestUpdaterUpdateMissingArchive(t *testing.T) {
+	t.Parallel()
+
+	updater := NewUpdater(
+		WithExecutable(filepath.Join(t.TempDir(), "mtcli")),
+		WithPlatform{OS: "windows", Arch: "amd64"},
+	)
+
+	err := updater.Update(context.Background(), Release{Version: "v1.0.0"})
+	require.ErrorIs(t, err, ErrNoReleaseArchive)
+}
+
+func newTestArchive(t *testing.T, content string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	for name, data := range map[string]string{
+		"README.md": "readme",
+		"mtcli":     content,
+	} {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0o755,
+			Size:     int64(len(data)),
+			Typeflag: tar.TypeReg,
+		}))
+
+		_, err := tw.Write([]byte(data))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	return buf.Bytes()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/mt-sre/addon-metadata-operator/pkg/httputil"
+)
+
+const (
+	// ReleasesURL is the GitHub API endpoint listing mtcli releases.
+	ReleasesURL = "https://api.github.com/repos/mt-sre/addon-metadata-operator/releases"
+	// LatestReleaseURL is the GitHub API endpoint returning the latest mtcli release.
+	LatestReleaseURL = ReleasesURL + "/latest"
+)
+
+// ReleaseURL returns the GitHub API endpoint of the release
+// tagged with the given version below releasesURL.
+func ReleaseURL(releasesURL, version string) string {
+	if version == "" || version == "latest" {
+		return releasesURL + "/latest"
+	}
+
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+
+	return releasesURL + "/tags/" + version
+}
+
+// Release is a published mtcli release.
+type Release struct {
+	Version string         `json:"version"`
+	URL     string         `json:"url"`
+	Assets  []ReleaseAsset `json:"-"`
+}
+
+// Asset returns the asset with the given file name.
+func (r Release) Asset(name string) (ReleaseAsset, bool) {
+	for _, a := range r.Assets {
+		if a.Name == name {
+			return a, true
+		}
+	}
+
+	return ReleaseAsset{}, false
+}
+
+// ReleaseAsset is a file attached to a Release.
+type ReleaseAsset struct {
+	Name        string
+	DownloadURL string
+}
+
+// UpdateCheck is the result of comparing the running
+// mtcli version against the latest release.
+type UpdateCheck struct {
+	Current         string  `json:"current"`
+	Latest          Release `json:"latest"`
+	UpdateAvailable bool    `json:"updateAvailable"`
+}
+
+// CheckForUpdate fetches the latest release from the GitHub releases API
+// at the given URL and reports whether it is newer than the current version.
+// If client is nil a client created by httputil.NewClient is used.
+func CheckForUpdate(ctx context.Context, client *http.Client, url, current string) (UpdateCheck, error) {
+	currentVer, err := semver.ParseTolerant(current)
+	if err != nil {
+		return UpdateCheck{}, fmt.Errorf("parsing current version '%s': %w", current, err)
+	}
+
+	latest, err := FetchRelease(ctx, client, url)
+	if err != nil {
+		return UpdateCheck{}, err
+	}
+
+	latestVer, err := semver.ParseTolerant(latest.Version)
+	if err != nil {
+		return UpdateCheck{}, fmt.Errorf("parsing latest version '%s': %w", latest.Version, err)
+	}
+
+	return UpdateCheck{
+		Current:         current,
+		Latest:          latest,
+		UpdateAvailable: latestVer.GT(currentVer),
+	}, nil
+}
+
+// FetchRelease fetches the release served by the GitHub releases API at the given URL.
+// If client is nil a client created by httputil.NewClient is used.
+func FetchRelease(ctx context.Context, client *http.Client, url string) (Release, error) {
+	if client == nil {
+		client = httputil.NewClient()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return Release{}, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return Release{}, fmt.Errorf("requesting release: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return Release{}, fmt.Errorf("requesting release: unexpected status '%s'", res.Status)
+	}
+
+	var body struct {
+		TagName string `json:"tag_name"`
+		HTMLURL string `json:"html_url"`
+		Assets  []struct {
+			Name               string `json:"name"`
+			BrowserDownloadURL string `json:"browser_download_url"`
+		} `json:"assets"`
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return Release{}, fmt.Errorf("decoding release: %w", err)
+	}
+
+	release := Release{
+		Version: body.TagName,
+		URL:     body.HTMLURL,
+	}
+
+	for _, a := range body.Assets {
+		release.Assets = append(release.Assets, ReleaseAsset{
+			Name:        a.Name,
+			DownloadURL: a.BrowserDownloadURL,
+		})
+	}
+
+	return release, nil
+}
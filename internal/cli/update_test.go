@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckForUpdate(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"tag_name":"v1.2.0","html_url":"https://example.com/releases/v1.2.0"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	for name, tc := range map[string]struct {
+		Current         string
+		UpdateAvailable bool
+	}{
+		"older":  {Current: "1.1.9", UpdateAvailable: true},
+		"same":   {Current: "1.2.0", UpdateAvailable: false},
+		"newer":  {Current: "v1.3.0", UpdateAvailable: false},
+		"prerel": {Current: "1.2.0-rc.1", UpdateAvailable: true},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			check, err := CheckForUpdate(context.Background(), srv.Client(), srv.URL, tc.Current)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.UpdateAvailable, check.UpdateAvailable)
+			assert.Equal(t, "v1.2.0", check.Latest.Version)
+			assert.Equal(t, "https://example.com/releases/v1.2.0", check.Latest.URL)
+		})
+	}
+}
+
+func TestCheckForUpdateBadStatus(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(srv.Close)
+
+	_, err := CheckForUpdate(context.Background(), srv.Client(), srv.URL, "1.0.0")
+	require.Error(t, err)
+}
+
+func TestReleaseURL(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "https://example.com/releases/latest", ReleaseURL("https://example.com/releases", ""))
+	assert.Equal(t, "https://example.com/releases/latest", ReleaseURL("https://example.com/releases", "latest"))
+	assert.Equal(t, "https://example.com/releases/tags/v1.2.3", ReleaseURL("https://example.com/releases", "1.2.3"))
+	assert.Equal(t, "https://example.com/releases/tags/v1.2.3", ReleaseURL("https://example.com/releases", "v1.2.3"))
+}
@@ -0,0 +1,211 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/mt-sre/addon-metadata-operator/pkg/httputil"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// ValidatorFilter returns a filter excluding the validators listed in
+// disabled or selecting only the validators listed in enabled. Both are
+// comma separated lists of codes or code prefixes selecting whole families
+// of validators, e.g. 'OSD'. A nil filter is returned if both are empty
+// and an error if both are given. Codes of deprecated validators match the
+// validators replacing them; a warning is logged to the logger of ctx for
+// them as well as for codes and prefixes not matching any validator of
+// runner.
+func ValidatorFilter(ctx context.Context, runner *validator.Runner, disabled, enabled string) (validator.Filter, error) {
+	if disabled == "" && enabled == "" {
+		return nil, nil
+	}
+
+	if disabled != "" && enabled != "" {
+		return nil, errors.New("'--disabled' and '--enabled' are mutually exclusive options")
+	}
+
+	flag, list := "--enabled", enabled
+	if disabled != "" {
+		flag, list = "--disabled", disabled
+	}
+
+	codes, prefixes, err := parseSelection(list)
+	if err != nil {
+		return nil, fmt.Errorf("unable to process '%s' option argument: %w", flag, err)
+	}
+
+	warnUnknownCodes(ctx, runner, flag, codes, prefixes)
+
+	filter := validator.Any(validator.MatchesCodes(codes...), validator.MatchesPrefixes(prefixes...))
+	if disabled != "" {
+		return validator.Not(filter), nil
+	}
+
+	return filter, nil
+}
+
+// warnUnknownCodes logs a warning for each of the given codes which
+// belongs to a deprecated validator or does not match any validator
+// of runner and for each of the given prefixes without validators.
+func warnUnknownCodes(ctx context.Context, runner *validator.Runner, flag string, codes []validator.Code, prefixes []validator.Prefix) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	for _, code := range codes {
+		if v, ok := runner.ReplacementOf(code); ok {
+			log.Info("validator is deprecated; selecting its replacement",
+				"flag", flag, "code", code.String(), "replacement", v.Code().String(),
+			)
+		} else if len(runner.GetValidators(validator.MatchesCodes(code))) == 0 {
+			log.Info("no validator matches code", "flag", flag, "code", code.String())
+		}
+	}
+
+	for _, prefix := range prefixes {
+		if len(runner.GetValidators(validator.MatchesPrefixes(prefix))) == 0 {
+			log.Info("no validator matches prefix", "flag", flag, "prefix", string(prefix))
+		}
+	}
+}
+
+// TagFilter returns a filter excluding the validators with any of the
+// disabled tags and, unless enabled is empty, selecting only validators
+// with any of the enabled tags. A nil filter is returned if both are
+// empty. An error is returned for tags not listed in validator.Tags.
+func TagFilter(disabled, enabled []string) (validator.Filter, error) {
+	for _, tags := range []struct {
+		flag string
+		tags []string
+	}{
+		{"--disabled-tags", disabled},
+		{"--enabled-tags", enabled},
+	} {
+		for _, tag := range tags.tags {
+			if !slices.Contains(validator.Tags, tag) {
+				return nil, fmt.Errorf("unable to process '%s' option argument: '%s' is not a valid tag; must be one of %s",
+					tags.flag, tag, strings.Join(validator.Tags, ", "),
+				)
+			}
+		}
+	}
+
+	var filters []validator.Filter
+
+	if len(disabled) > 0 {
+		filters = append(filters, validator.Not(validator.MatchesTags(disabled...)))
+	}
+
+	if len(enabled) > 0 {
+		filters = append(filters, validator.MatchesTags(enabled...))
+	}
+
+	if len(filters) == 0 {
+		return nil, nil
+	}
+
+	return validator.All(filters...), nil
+}
+
+// parseSelection parses a comma separated list of
+// validator codes and registered code prefixes.
+func parseSelection(maybeList string) ([]validator.Code, []validator.Prefix, error) {
+	var (
+		codes    []validator.Code
+		prefixes []validator.Prefix
+	)
+
+	for _, s := range strings.Split(maybeList, ",") {
+		if p, err := validator.ParsePrefix(s); err == nil {
+			prefixes = append(prefixes, p)
+
+			continue
+		}
+
+		c, err := validator.ParseCode(s)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid code list '%s': %w", maybeList, err)
+		}
+
+		codes = append(codes, c)
+	}
+
+	return codes, prefixes, nil
+}
+
+// ParseCodeList parses a comma separated list of validator codes.
+func ParseCodeList(maybeList string) ([]validator.Code, error) {
+	rawStrings := strings.Split(maybeList, ",")
+
+	res := make([]validator.Code, 0, len(rawStrings))
+
+	for _, s := range rawStrings {
+		c, err := validator.ParseCode(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid code list '%s': %w", maybeList, err)
+		}
+
+		res = append(res, c)
+	}
+
+	return res, nil
+}
+
+const (
+	ocmTokenEnvVar        = "OCM_TOKEN"
+	ocmClientIDEnvVar     = "OCM_CLIENT_ID"
+	ocmClientSecretEnvVar = "OCM_CLIENT_SECRET"
+)
+
+// NewOCMClient returns an OCM client for the given environment using
+// the credentials from the OCM_TOKEN or OCM_CLIENT_ID and
+// OCM_CLIENT_SECRET environment variables.
+func NewOCMClient(env string) (*validator.OCMClientImpl, error) {
+	return validator.NewOCMClient(
+		validator.WithConnectOptions{
+			validator.WithAPIURL(envToOCMURL(env)),
+			validator.WithAccessToken(os.Getenv(ocmTokenEnvVar)),
+			validator.WithClientID(os.Getenv(ocmClientIDEnvVar)),
+			validator.WithClientSecret(os.Getenv(ocmClientSecretEnvVar)),
+		},
+	)
+}
+
+// HasOCMCredentials reports whether credentials for OCM are set
+// in the environment variables read by NewOCMClient.
+func HasOCMCredentials() bool {
+	return os.Getenv(ocmTokenEnvVar) != "" ||
+		(os.Getenv(ocmClientIDEnvVar) != "" && os.Getenv(ocmClientSecretEnvVar) != "")
+}
+
+// NewClusterClient returns a ClusterClient for the cluster described by
+// the kubeconfig at the given path. Namespaced objects without a
+// namespace are applied to the given namespace.
+func NewClusterClient(kubeconfig, namespace string) (*validator.ClusterClientImpl, error) {
+	cfg, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("loading kubeconfig '%s': %w", kubeconfig, err)
+	}
+
+	// client-go retries requests itself
+	cfg.Wrap(httputil.Wrap(httputil.WithMaxRetries(-1)))
+
+	return validator.NewClusterClient(cfg,
+		validator.WithDefaultNamespace(namespace),
+	)
+}
+
+func envToOCMURL(env string) string {
+	envToUrl := map[string]string{
+		"stage":       "https://api.stage.openshift.com",
+		"integration": "https://api.integration.openshift.com",
+		"production":  "https://api.openshift.com",
+	}
+
+	return envToUrl[env]
+}
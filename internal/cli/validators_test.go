@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagFilter(t *testing.T) {
+	t.Parallel()
+
+	newDocumented := func(tags ...string) validator.Validator {
+		base, err := validator.NewBase(1, validator.BaseDocs(validator.Docs{Tags: tags}))
+		require.NoError(t, err)
+
+		return taggedValidator{Base: base}
+	}
+
+	var (
+		bundle   = newDocumented(validator.TagBundle)
+		security = newDocumented(validator.TagBundle, validator.TagSecurity)
+		service  = newDocumented(validator.TagService)
+	)
+
+	filter, err := TagFilter(nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, filter)
+
+	filter, err = TagFilter(nil, []string{validator.TagSecurity})
+	require.NoError(t, err)
+	assert.False(t, filter(bundle))
+	assert.True(t, filter(security))
+	assert.False(t, filter(service))
+
+	filter, err = TagFilter([]string{validator.TagService}, nil)
+	require.NoError(t, err)
+	assert.True(t, filter(bundle))
+	assert.True(t, filter(security))
+	assert.False(t, filter(service))
+
+	// disabled tags take precedence
+	filter, err = TagFilter([]string{validator.TagSecurity}, []string{validator.TagBundle})
+	require.NoError(t, err)
+	assert.True(t, filter(bundle))
+	assert.False(t, filter(security))
+	assert.False(t, filter(service))
+
+	_, err = TagFilter(nil, []string{"network"})
+	assert.ErrorContains(t, err, "unable to process '--enabled-tags' option argument: 'network' is not a valid tag")
+}
+
+type taggedValidator struct {
+	*validator.Base
+}
+
+func (v taggedValidator) Run(context.Context, types.MetaBundle) validator.Result {
+	return v.Success()
+}
+
+func TestValidatorFilterWarnsUnknownCodes(t *testing.T) {
+	t.Parallel()
+
+	var logs []string
+
+	ctx := logr.NewContext(context.Background(), funcr.New(func(_, args string) {
+		logs = append(logs, args)
+	}, funcr.Options{}))
+
+	runner, err := validator.NewRunner(ctx)
+	require.NoError(t, err)
+
+	filter, err := ValidatorFilter(ctx, runner, "", "AM0001,AM9999")
+	require.NoError(t, err)
+	require.NotNil(t, filter)
+
+	require.Len(t, logs, 1)
+	assert.Contains(t, logs[0], `"msg"="no validator matches code"`)
+	assert.Contains(t, logs[0], `"code"="AM9999"`)
+}
+
+func TestValidatorFilterPrefixes(t *testing.T) {
+	t.Parallel()
+
+	newCoded := func(code validator.Code) validator.Validator {
+		base, err := validator.NewBase(code)
+		require.NoError(t, err)
+
+		return taggedValidator{Base: base}
+	}
+
+	var (
+		am1  = newCoded(validator.PrefixAM.Code(1))
+		am2  = newCoded(validator.PrefixAM.Code(2))
+		osd1 = newCoded(validator.PrefixOSD.Code(1))
+	)
+
+	runner, err := validator.NewRunner(context.Background())
+	require.NoError(t, err)
+
+	filter, err := ValidatorFilter(context.Background(), runner, "", "osd,AM0002")
+	require.NoError(t, err)
+	assert.False(t, filter(am1))
+	assert.True(t, filter(am2))
+	assert.True(t, filter(osd1))
+
+	filter, err = ValidatorFilter(context.Background(), runner, "OSD", "")
+	require.NoError(t, err)
+	assert.True(t, filter(am1))
+	assert.True(t, filter(am2))
+	assert.False(t, filter(osd1))
+
+	_, err = ValidatorFilter(context.Background(), runner, "XYZ", "")
+	assert.ErrorContains(t, err, "unable to process '--disabled' option argument: invalid code list 'XYZ'")
+
+	_, err = ValidatorFilter(context.Background(), runner, "OSD", "AM0002")
+	assert.ErrorContains(t, err, "'--disabled' and '--enabled' are mutually exclusive options")
+}
+
+func TestValidatorFilterWarnsUnknownPrefixes(t *testing.T) {
+	t.Parallel()
+
+	var logs []string
+
+	ctx := logr.NewContext(context.Background(), funcr.New(func(_, args string) {
+		logs = append(logs, args)
+	}, funcr.Options{}))
+
+	runner, err := validator.NewRunner(ctx)
+	require.NoError(t, err)
+
+	_, err = ValidatorFilter(ctx, runner, "AM,SEC", "")
+	require.NoError(t, err)
+
+	require.Len(t, logs, 1)
+	assert.Contains(t, logs[0], `"msg"="no validator matches prefix"`)
+	assert.Contains(t, logs[0], `"prefix"="SEC"`)
+}
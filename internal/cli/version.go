@@ -1,6 +1,9 @@
 package cli
 
-import "fmt"
+import (
+	"fmt"
+	"runtime"
+)
 
 // Injected by goreleaser through ldflags (see .goreleaser.yml)
 var (
@@ -10,6 +13,23 @@ var (
 	date    = "local"
 )
 
-func Version() string {
-	return fmt.Sprintf("mtcli version: %v, commit: %v, builtBy: %v (%v)", version, commit, builtBy, date)
+// BuildInfo describes the running mtcli binary.
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuiltBy   string `json:"builtBy"`
+	Date      string `json:"date"`
+	GoVersion string `json:"goVersion"`
+	Platform  string `json:"platform"`
+}
+
+func GetBuildInfo() BuildInfo {
+	return BuildInfo{
+		Version:   version,
+		Commit:    commit,
+		BuiltBy:   builtBy,
+		Date:      date,
+		GoVersion: runtime.Version(),
+		Platform:  fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH),
+	}
 }
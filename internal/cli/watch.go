@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path/filepath"
+	"time"
+)
+
+// WatchDir calls onChange each time files below dir are created, modified
+// or removed until ctx is cancelled. Changes are detected by polling every
+// interval so that all changes made within one interval, e.g. by an editor
+// saving a file, result in a single call. An error is only returned if dir
+// cannot be read.
+func WatchDir(ctx context.Context, dir string, interval time.Duration, onChange func(context.Context)) error {
+	last, err := snapshotDir(dir)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		current, err := snapshotDir(dir)
+		if err != nil {
+			return err
+		}
+
+		if maps.Equal(last, current) {
+			continue
+		}
+
+		last = current
+
+		onChange(ctx)
+	}
+}
+
+type fileState struct {
+	ModTime time.Time
+	Size    int64
+}
+
+// snapshotDir returns the state of all regular files below dir.
+// Hidden files and directories are skipped as editors commonly
+// keep swap and backup files there.
+func snapshotDir(dir string) (map[string]fileState, error) {
+	res := make(map[string]fileState)
+
+	walk := func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if path != dir && d.Name()[0] == '.' {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		res[path] = fileState{ModTime: info.ModTime(), Size: info.Size()}
+
+		return nil
+	}
+
+	if err := filepath.WalkDir(dir, walk); err != nil {
+		return nil, fmt.Errorf("watching %q: %w", dir, err)
+	}
+
+	return res, nil
+}
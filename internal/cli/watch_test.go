@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchDir(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "addon.yaml")
+
+	require.NoError(t, os.WriteFile(path, []byte("id: a\n"), 0o600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := make(chan struct{}, 10)
+	done := make(chan error, 1)
+
+	go func() {
+		done <- WatchDir(ctx, dir, 10*time.Millisecond, func(context.Context) {
+			changes <- struct{}{}
+		})
+	}()
+
+	// the watcher may take its initial snapshot after the first write
+	for content := "id: changed\n"; ; content += "\n" {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+		select {
+		case <-changes:
+		case <-time.After(50 * time.Millisecond):
+			continue
+		}
+
+		break
+	}
+
+	// changes to hidden files are ignored
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".addon.yaml.swp"), []byte("x"), 0o600))
+
+	select {
+	case <-changes:
+		t.Fatal("change to hidden file was detected")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	cancel()
+
+	require.NoError(t, <-done)
+}
+
+func TestWatchDirMissing(t *testing.T) {
+	t.Parallel()
+
+	err := WatchDir(context.Background(), filepath.Join(t.TempDir(), "missing"), time.Millisecond, func(context.Context) {})
+	assert.Error(t, err)
+}
@@ -100,7 +100,7 @@ type DeploymentLinterImplOption interface {
 
 type WithDeploymentChecks []DeploymentCheck
 
-func (w WithDeploymentChecks) ConfigureDeploymentLinter(c *DeploymentLinterImplConfig) {
+func (w WithDeploymentChecks) ConfigureDeploymentValidator(c *DeploymentLinterImplConfig) {
 	c.checks = []DeploymentCheck(w)
 }
 
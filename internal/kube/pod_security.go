@@ -0,0 +1,127 @@
+package kube
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PodSecurityChecks check deployments against the controls of the
+// 'restricted' Pod Security Standard concerning privileges, host
+// namespaces, the user containers run as and seccomp profiles.
+var PodSecurityChecks = []DeploymentCheck{
+	IsNotPrivileged,
+	HasNoHostNamespaces,
+	RunsAsNonRoot,
+	HasSeccompProfile,
+}
+
+func IsNotPrivileged(d appsv1.Deployment) DeploymentCheckResult {
+	var reasons []string
+
+	for _, c := range allContainers(d) {
+		if sc := c.SecurityContext; sc != nil && isTrue(sc.Privileged) {
+			reasons = append(reasons, reportContainerLintReason(c, "privileged"))
+		}
+	}
+
+	return NewDeploymentCheckResult(reasons...)
+}
+
+func HasNoHostNamespaces(d appsv1.Deployment) DeploymentCheckResult {
+	var (
+		reasons []string
+		spec    = d.Spec.Template.Spec
+	)
+
+	if spec.HostNetwork {
+		reasons = append(reasons, "pod is sharing the host network namespace")
+	}
+
+	if spec.HostPID {
+		reasons = append(reasons, "pod is sharing the host PID namespace")
+	}
+
+	if spec.HostIPC {
+		reasons = append(reasons, "pod is sharing the host IPC namespace")
+	}
+
+	return NewDeploymentCheckResult(reasons...)
+}
+
+// RunsAsNonRoot requires 'runAsNonRoot' to be set for every container
+// either directly or through the pod and forbids running as UID 0.
+func RunsAsNonRoot(d appsv1.Deployment) DeploymentCheckResult {
+	var (
+		reasons []string
+		podSC   = d.Spec.Template.Spec.SecurityContext
+	)
+
+	if podSC == nil {
+		podSC = &corev1.PodSecurityContext{}
+	}
+
+	if podSC.RunAsUser != nil && *podSC.RunAsUser == 0 {
+		reasons = append(reasons, "pod is running as root user (runAsUser 0)")
+	}
+
+	for _, c := range allContainers(d) {
+		nonRoot := podSC.RunAsNonRoot
+
+		if sc := c.SecurityContext; sc != nil {
+			if sc.RunAsNonRoot != nil {
+				nonRoot = sc.RunAsNonRoot
+			}
+
+			if sc.RunAsUser != nil && *sc.RunAsUser == 0 {
+				reasons = append(reasons, reportContainerLintReason(c, "running as root user (runAsUser 0)"))
+			}
+		}
+
+		if !isTrue(nonRoot) {
+			reasons = append(reasons, reportContainerLintReason(c, "missing runAsNonRoot"))
+		}
+	}
+
+	return NewDeploymentCheckResult(reasons...)
+}
+
+// HasSeccompProfile requires the 'RuntimeDefault' or a 'Localhost'
+// seccomp profile for every container either directly or through
+// the pod.
+func HasSeccompProfile(d appsv1.Deployment) DeploymentCheckResult {
+	var (
+		reasons []string
+		profile *corev1.SeccompProfile
+	)
+
+	if podSC := d.Spec.Template.Spec.SecurityContext; podSC != nil {
+		profile = podSC.SeccompProfile
+	}
+
+	for _, c := range allContainers(d) {
+		p := profile
+		if sc := c.SecurityContext; sc != nil && sc.SeccompProfile != nil {
+			p = sc.SeccompProfile
+		}
+
+		switch {
+		case p == nil:
+			reasons = append(reasons, reportContainerLintReason(c, "missing a seccomp profile"))
+		case p.Type != corev1.SeccompProfileTypeRuntimeDefault && p.Type != corev1.SeccompProfileTypeLocalhost:
+			reasons = append(reasons, reportContainerLintReason(c, fmt.Sprintf("using seccomp profile %q", p.Type)))
+		}
+	}
+
+	return NewDeploymentCheckResult(reasons...)
+}
+
+// allContainers returns the init containers and containers of d.
+func allContainers(d appsv1.Deployment) []corev1.Container {
+	spec := d.Spec.Template.Spec
+
+	return append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+}
+
+func isTrue(b *bool) bool { return b != nil && *b }
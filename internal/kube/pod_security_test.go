@@ -0,0 +1,70 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestDeploymentLinterImplPodSecurity(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Container       corev1.Container
+		SecurityContext *corev1.PodSecurityContext
+		ExpectedReasons []string
+	}{
+		"restricted pod": {
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsNonRoot:   ptr.To(true),
+				SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+			},
+		},
+		"restricted container": {
+			Container: corev1.Container{
+				SecurityContext: &corev1.SecurityContext{
+					RunAsNonRoot:   ptr.To(true),
+					SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeLocalhost},
+				},
+			},
+		},
+		"no security context": {
+			ExpectedReasons: []string{
+				`container "" is missing runAsNonRoot`,
+				`container "" is missing a seccomp profile`,
+			},
+		},
+		"privileged root container": {
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsNonRoot:   ptr.To(true),
+				RunAsUser:      ptr.To[int64](0),
+				SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+			},
+			Container: corev1.Container{
+				SecurityContext: &corev1.SecurityContext{Privileged: ptr.To(true)},
+			},
+			ExpectedReasons: []string{
+				`container "" is privileged`,
+				"pod is running as root user (runAsUser 0)",
+			},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			d := newTestDeployment(tc.Container)
+			d.Spec.Template.Spec.SecurityContext = tc.SecurityContext
+
+			val := NewDeploymentLinterImpl(WithDeploymentChecks(PodSecurityChecks))
+
+			res := val.Lint(d)
+
+			assert.Equal(t, len(tc.ExpectedReasons) == 0, res.Success, res)
+			assert.ElementsMatch(t, tc.ExpectedReasons, res.Reasons)
+		})
+	}
+}
@@ -63,6 +63,17 @@ func IsValidk8sSecretName(name string) string {
 	return ""
 }
 
+// IsValidk8sEnvVarName validates the given name against the kubernetes format for
+// environment variable names and returns a validation failure message if an issue
+// is found. Otherwise an empty string is returned.
+func IsValidk8sEnvVarName(name string) string {
+	if valid, failureReasons := reasonsToResult(utilvalidation.IsEnvVarName(name)); !valid {
+		return fmt.Sprintf("\"%s\" is not a valid kubernetes environment variable name: %s", name, failureReasons)
+	}
+
+	return ""
+}
+
 // IsValidk8sAnnotationName validates the given name against the kubernetes format for
 // annotation names and returns a validation failure message if an issue is found.
 // Otherwise an empty string is returned.
@@ -0,0 +1,136 @@
+// Package logging constructs the logr.Logger shared by mtcli and its
+// libraries and bridges it to dependencies which require logrus.
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/sirupsen/logrus"
+)
+
+// Format is the encoding of log records.
+type Format string
+
+const (
+	FormatText Format = "text"
+	FormatJSON Format = "json"
+)
+
+var ErrUnknownFormat = errors.New("unknown log format")
+
+// ParseFormat returns the Format with the given name.
+func ParseFormat(name string) (Format, error) {
+	switch f := Format(strings.ToLower(name)); f {
+	case FormatText, FormatJSON:
+		return f, nil
+	default:
+		return "", fmt.Errorf("%w %q: must be one of %s or %s", ErrUnknownFormat, name, FormatText, FormatJSON)
+	}
+}
+
+// New returns a logger writing records to w in the given format.
+// Messages logged with a verbosity of one ('V(1)') or higher are
+// only written if verbose is 'true'.
+func New(w io.Writer, format Format, verbose bool) logr.Logger {
+	opts := &slog.HandlerOptions{
+		Level:       slog.LevelInfo,
+		ReplaceAttr: normalizeLevel,
+	}
+	if verbose {
+		opts.Level = slog.LevelDebug
+	}
+
+	var handler slog.Handler
+
+	switch format {
+	case FormatJSON:
+		handler = slog.NewJSONHandler(w, opts)
+	default:
+		handler = slog.NewTextHandler(w, opts)
+	}
+
+	return logr.FromSlogHandler(handler)
+}
+
+// normalizeLevel reports the levels of messages logged with a verbosity
+// of one or higher (e.g. 'DEBUG+3' for 'V(1)') as 'DEBUG' so that log
+// aggregators recognize them.
+func normalizeLevel(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) > 0 || a.Key != slog.LevelKey {
+		return a
+	}
+
+	if level, ok := a.Value.Any().(slog.Level); ok && level < slog.LevelInfo {
+		a.Value = slog.StringValue(slog.LevelDebug.String())
+	}
+
+	return a
+}
+
+// Logrus returns a logrus entry forwarding all records to log for
+// use with dependencies which only accept logrus (e.g. opm).
+func Logrus(log logr.Logger) *logrus.Entry {
+	l := logrus.New()
+
+	RedirectLogrus(l, log)
+
+	return logrus.NewEntry(l)
+}
+
+// RedirectLogrus makes l forward all records to log instead
+// of writing them itself (e.g. for 'logrus.StandardLogger()').
+func RedirectLogrus(l *logrus.Logger, log logr.Logger) {
+	l.SetOutput(io.Discard)
+	l.SetFormatter(discardFormatter{})
+	// filtering by verbosity is left to log
+	l.SetLevel(logrus.TraceLevel)
+	l.ReplaceHooks(logrus.LevelHooks{})
+	l.AddHook(logrHook{log: log})
+}
+
+type discardFormatter struct{}
+
+func (discardFormatter) Format(*logrus.Entry) ([]byte, error) { return nil, nil }
+
+type logrHook struct {
+	log logr.Logger
+}
+
+func (h logrHook) Levels() []logrus.Level { return logrus.AllLevels }
+
+func (h logrHook) Fire(entry *logrus.Entry) error {
+	keys := make([]string, 0, len(entry.Data))
+	for k := range entry.Data {
+		if k != logrus.ErrorKey {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+
+	kvs := make([]interface{}, 0, 2*len(keys))
+	for _, k := range keys {
+		kvs = append(kvs, k, entry.Data[k])
+	}
+
+	switch {
+	case entry.Level <= logrus.ErrorLevel:
+		err, _ := entry.Data[logrus.ErrorKey].(error)
+
+		h.log.Error(err, entry.Message, kvs...)
+	case entry.Level <= logrus.InfoLevel:
+		h.log.Info(entry.Message, kvs...)
+	case entry.Level == logrus.DebugLevel:
+		h.log.V(1).Info(entry.Message, kvs...)
+	default:
+		h.log.V(2).Info(entry.Message, kvs...)
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+
+	format, err := ParseFormat("JSON")
+	require.NoError(t, err)
+	assert.Equal(t, FormatJSON, format)
+
+	_, err = ParseFormat("xml")
+	require.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestNewJSON(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Verbose       bool
+		ExpectedCount int
+	}{
+		"not verbose": {ExpectedCount: 1},
+		"verbose":     {Verbose: true, ExpectedCount: 2},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+
+			log := New(&buf, FormatJSON, tc.Verbose)
+			log.Info("info message", "addon", "reference-addon")
+			log.V(1).Info("debug message")
+
+			lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+			require.Len(t, lines, tc.ExpectedCount)
+
+			var record map[string]interface{}
+			require.NoError(t, json.Unmarshal(lines[0], &record))
+			assert.Equal(t, "info message", record["msg"])
+			assert.Equal(t, "reference-addon", record["addon"])
+
+			if tc.Verbose {
+				require.NoError(t, json.Unmarshal(lines[1], &record))
+				assert.Equal(t, "DEBUG", record["level"])
+			}
+		})
+	}
+}
+
+func TestLogrus(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	entry := Logrus(New(&buf, FormatJSON, false))
+	entry.WithField("image", "quay.io/bundle").Info("pulling")
+	entry.WithError(errors.New("boom")).Error("failed")
+	entry.Debug("hidden")
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+
+	var info, failed map[string]interface{}
+	require.NoError(t, json.Unmarshal(lines[0], &info))
+	require.NoError(t, json.Unmarshal(lines[1], &failed))
+
+	assert.Equal(t, "pulling", info["msg"])
+	assert.Equal(t, "quay.io/bundle", info["image"])
+	assert.Equal(t, "ERROR", failed["level"])
+	assert.Equal(t, "boom", failed["err"])
+}
@@ -0,0 +1,35 @@
+// Package testdata embeds a fixture addon for commands which
+// need an addon to work with when none is given (e.g. 'mtcli bench').
+package testdata
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed metadata_v1/legacy/reference-addon bundles/reference-addon/main/0.1.6
+var fixtures embed.FS
+
+// FixtureAddonName is the name of the fixture addon.
+const FixtureAddonName = "reference-addon"
+
+// FixtureAddon returns the directory of the fixture addon.
+// Only the 'stage' environment is available.
+func FixtureAddon() fs.FS {
+	return mustSub("metadata_v1/legacy/reference-addon")
+}
+
+// FixtureBundle returns the directory of the single bundle
+// of the fixture addon as it would be unpacked from its image.
+func FixtureBundle() fs.FS {
+	return mustSub("bundles/reference-addon/main/0.1.6")
+}
+
+func mustSub(dir string) fs.FS {
+	sub, err := fs.Sub(fixtures, dir)
+	if err != nil {
+		panic(err)
+	}
+
+	return sub
+}
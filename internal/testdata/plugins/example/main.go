@@ -0,0 +1,49 @@
+// Package main is an example of a Go plugin providing an out-of-tree
+// validator which fails unless the addon is owned by the example org.
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+// RegisterValidators is looked up by 'validator.LoadPlugins'.
+func RegisterValidators(register func(validator.Initializer)) {
+	register(NewOwnerOrg)
+}
+
+const (
+	code = 9001
+	name = "owner_org"
+	desc = "Ensure that the addon is owned by the example org"
+)
+
+func NewOwnerOrg(deps validator.Dependencies) (validator.Validator, error) {
+	base, err := validator.NewBase(
+		code,
+		validator.BaseName(name),
+		validator.BaseDesc(desc),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OwnerOrg{
+		Base: base,
+	}, nil
+}
+
+type OwnerOrg struct {
+	*validator.Base
+}
+
+func (o *OwnerOrg) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
+	if !strings.HasSuffix(mb.AddonMeta.AddonOwner, "@example.com>") {
+		return o.Fail("addonOwner must have an '@example.com' address")
+	}
+
+	return o.Success()
+}
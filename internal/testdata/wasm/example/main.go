@@ -0,0 +1,64 @@
+// Package main is an example of a WASM validator which fails unless
+// the addon is owned by the example org. Build it with:
+//
+//	GOOS=wasip1 GOARCH=wasm go build -o owner-org.wasm .
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+type output struct {
+	Success     bool     `json:"success"`
+	FailureMsgs []string `json:"failureMsgs,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: owner-org (describe|run)")
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "describe":
+		write(map[string]string{
+			"code":        "AM9003",
+			"name":        "owner_org_wasm",
+			"description": "Ensure that the addon is owned by the example org",
+		})
+	case "run":
+		write(run())
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", os.Args[1])
+		os.Exit(2)
+	}
+}
+
+func run() output {
+	var in struct {
+		Metadata struct {
+			AddonOwner string `json:"addonOwner"`
+		} `json:"metadata"`
+	}
+
+	if err := json.NewDecoder(os.Stdin).Decode(&in); err != nil {
+		return output{Error: fmt.Sprintf("decoding input: %v", err)}
+	}
+
+	if !strings.HasSuffix(in.Metadata.AddonOwner, "@example.com>") {
+		return output{FailureMsgs: []string{"addonOwner must have an '@example.com' address"}}
+	}
+
+	return output{Success: true}
+}
+
+func write(v interface{}) {
+	if err := json.NewEncoder(os.Stdout).Encode(v); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -130,5 +130,8 @@ func (r *singleton) GetMetaBundle(version string) (*types.MetaBundle, error) {
 		return nil, fmt.Errorf("Could not combine metadata with imageset, got %v.", err)
 	}
 
-	return types.NewMetaBundle(combinedMeta, bundles), nil
+	return types.NewMetaBundleBuilder().
+		WithMeta(combinedMeta).
+		WithBundles(bundles...).
+		Build(), nil
 }
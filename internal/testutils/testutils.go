@@ -55,6 +55,14 @@ func (t TestDataTree) Validators() string {
 	return filepath.Join(string(t), "validators")
 }
 
+func (t TestDataTree) Plugins() string {
+	return filepath.Join(string(t), "plugins")
+}
+
+func (t TestDataTree) Wasm() string {
+	return filepath.Join(string(t), "wasm")
+}
+
 func (t TestDataTree) MetadataV1() MetadataV1Tree {
 	return MetadataV1Tree(filepath.Join(string(t), "metadata_v1"))
 }
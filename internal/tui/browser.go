@@ -0,0 +1,316 @@
+// Package tui implements a terminal user interface
+// for browsing and re-running validation results.
+package tui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+// RerunFunc runs the validators with the given codes again
+// and returns their results. All validators are run if no
+// codes are given.
+type RerunFunc func(ctx context.Context, codes ...validator.Code) (validator.ResultList, error)
+
+// NewBrowser returns a Browser listing results under the given title
+// which re-runs validators using rerun.
+func NewBrowser(title string, results validator.ResultList, rerun RerunFunc) *Browser {
+	return &Browser{
+		title:   title,
+		results: results,
+		rerun:   rerun,
+	}
+}
+
+// Browser lists validation results and shows the details of
+// the selected one. It holds no terminal state so that it can
+// be driven by Run or directly through HandleKey and Render.
+type Browser struct {
+	title    string
+	results  validator.ResultList
+	rerun    RerunFunc
+	selected int
+	// detail is true while the details of the selected result are shown.
+	detail bool
+	// offset is the first line shown of the list or details.
+	offset int
+	status string
+}
+
+// Results returns the current results including those of re-runs.
+func (b *Browser) Results() validator.ResultList { return b.results }
+
+// Action is what a key asks the caller of HandleKey to do.
+type Action int
+
+const (
+	ActionNone Action = iota
+	ActionQuit
+	// ActionRerun asks to re-run the selected validator.
+	ActionRerun
+	// ActionRerunAll asks to re-run all validators.
+	ActionRerunAll
+)
+
+// HandleKey updates the browser for key and returns the action
+// which has to be performed by the caller.
+func (b *Browser) HandleKey(key Key) Action {
+	switch key {
+	case KeyQuit:
+		return ActionQuit
+	case KeyUp:
+		if b.detail {
+			b.offset = max(b.offset-1, 0)
+		} else if b.selected > 0 {
+			b.selected--
+		}
+	case KeyDown:
+		if b.detail {
+			b.offset++
+		} else if b.selected < len(b.results)-1 {
+			b.selected++
+		}
+	case KeyEnter:
+		if len(b.results) > 0 {
+			b.detail, b.offset = true, 0
+		}
+	case KeyBack:
+		b.detail, b.offset = false, 0
+	case KeyRerun:
+		if len(b.results) > 0 {
+			return ActionRerun
+		}
+	case KeyRerunAll:
+		return ActionRerunAll
+	}
+
+	return ActionNone
+}
+
+// Rerun performs ActionRerun or ActionRerunAll replacing
+// the affected results. Errors are shown as status.
+func (b *Browser) Rerun(ctx context.Context, action Action) {
+	var codes []validator.Code
+
+	if action == ActionRerun {
+		codes = append(codes, b.results[b.selected].Code)
+	}
+
+	start := time.Now()
+
+	results, err := b.rerun(ctx, codes...)
+	if err != nil {
+		b.status = fmt.Sprintf("re-running failed: %v", err)
+
+		return
+	}
+
+	for _, res := range results {
+		b.replace(res)
+	}
+
+	b.status = fmt.Sprintf("re-ran %d validator(s) in %s", len(results), time.Since(start).Round(time.Millisecond))
+}
+
+// SetStatus sets the message shown below the results.
+func (b *Browser) SetStatus(status string) { b.status = status }
+
+func (b *Browser) replace(res validator.Result) {
+	for i := range b.results {
+		if b.results[i].Code == res.Code {
+			b.results[i] = res
+
+			return
+		}
+	}
+
+	b.results = append(b.results, res)
+}
+
+// Render writes the current view sized to the given
+// terminal dimensions using '\r\n' line endings.
+func (b *Browser) Render(w io.Writer, width, height int) error {
+	var lines []string
+
+	if b.detail {
+		lines = b.detailLines(width)
+	} else {
+		lines = b.listLines(width)
+	}
+
+	// title and summary, status and help are always shown
+	visible := max(height-5, 1)
+
+	b.offset = min(b.offset, max(len(lines)-visible, 0))
+
+	if !b.detail {
+		// keep the selected result visible
+		if b.selected < b.offset {
+			b.offset = b.selected
+		} else if b.selected >= b.offset+visible {
+			b.offset = b.selected - visible + 1
+		}
+	}
+
+	end := min(b.offset+visible, len(lines))
+
+	out := []string{
+		color.New(color.Bold).Sprint(truncate(b.title, width)),
+		b.summary(),
+	}
+	out = append(out, lines[b.offset:end]...)
+	out = append(out, "", truncate(b.status, width), truncate(b.help(), width))
+
+	_, err := io.WriteString(w, strings.Join(out, "\r\n"))
+
+	return err
+}
+
+func (b *Browser) summary() string {
+	var passed, warned, failed, errored, skipped int
+
+	for _, res := range b.results {
+		switch {
+		case res.IsSuccess():
+			passed++
+		case res.IsSkipped():
+			skipped++
+		case res.IsError():
+			errored++
+		case res.IsBlocking():
+			failed++
+		default:
+			warned++
+		}
+	}
+
+	summary := fmt.Sprintf("%d passed, %d failed, %d errored", passed, failed, errored)
+	if warned > 0 {
+		summary += fmt.Sprintf(", %d warned", warned)
+	}
+
+	if skipped > 0 {
+		summary += fmt.Sprintf(", %d skipped", skipped)
+	}
+
+	return summary
+}
+
+func (b *Browser) help() string {
+	if b.detail {
+		return "↑/↓ scroll  esc back  r re-run  a re-run all  q quit"
+	}
+
+	return "↑/↓ select  enter details  r re-run  a re-run all  q quit"
+}
+
+func (b *Browser) listLines(width int) []string {
+	lines := make([]string, 0, len(b.results))
+
+	for i, res := range b.results {
+		cursor := "  "
+		if i == b.selected {
+			cursor = "> "
+		}
+
+		// cursor and status take up 11 columns
+		rest := fmt.Sprintf("%s  %s (%s)", res.Code, res.Name, res.Duration.Round(time.Millisecond))
+
+		lines = append(lines, cursor+status(res)+"  "+truncate(rest, width-11))
+	}
+
+	return lines
+}
+
+func (b *Browser) detailLines(width int) []string {
+	res := b.results[b.selected]
+
+	lines := []string{
+		fmt.Sprintf("%s %s: %s", res.Code, res.Name, status(res)),
+		res.Description,
+		"",
+	}
+
+	switch {
+	case res.IsSuccess():
+		lines = append(lines, "No findings.")
+	case res.IsSkipped():
+		lines = append(lines, "Skipped: "+res.SkipReason)
+	case res.IsError():
+		lines = append(lines, res.Error.Error())
+	default:
+		for _, msg := range res.Messages() {
+			lines = append(lines, "- "+msg)
+		}
+
+		if res.Remediation != "" {
+			lines = append(lines, "", "How to fix: "+res.Remediation)
+		}
+
+		lines = append(lines, "", "Docs: "+validator.Docs{DocsURL: res.DocsURL}.EffectiveDocsURL(res.Code))
+	}
+
+	wrapped := make([]string, 0, len(lines))
+	for _, line := range lines {
+		wrapped = append(wrapped, wrap(line, width)...)
+	}
+
+	return wrapped
+}
+
+// status returns the padded and colored status of res.
+func status(res validator.Result) string {
+	switch {
+	case res.IsSuccess():
+		return color.GreenString("%-7s", "Success")
+	case res.IsSkipped():
+		return color.New(color.Faint).Sprintf("%-7s", "Skipped")
+	case res.IsTimeout():
+		return color.YellowString("%-7s", "Timeout")
+	case res.IsError():
+		return color.New(color.FgHiRed, color.Bold).Sprintf("%-7s", "Error")
+	case res.IsBlocking():
+		return color.RedString("%-7s", "Failed")
+	case res.Severity == validator.SeverityInfo:
+		return color.YellowString("%-7s", "Info")
+	default:
+		return color.YellowString("%-7s", "Warning")
+	}
+}
+
+// truncate shortens line to width runes.
+func truncate(line string, width int) string {
+	if width <= 0 {
+		return line
+	}
+
+	runes := []rune(line)
+	if len(runes) <= width {
+		return line
+	}
+
+	return string(runes[:width])
+}
+
+// wrap splits line into lines of at most width runes.
+func wrap(line string, width int) []string {
+	runes := []rune(line)
+	if width <= 0 || len(runes) <= width {
+		return []string{line}
+	}
+
+	var res []string
+
+	for len(runes) > width {
+		res = append(res, string(runes[:width]))
+		runes = runes[width:]
+	}
+
+	return append(res, string(runes))
+}
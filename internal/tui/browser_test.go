@@ -0,0 +1,144 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBrowser(t *testing.T) {
+	color.NoColor = true
+
+	first, err := validator.NewBase(1, validator.BaseName("first"), validator.BaseDesc("first validator"))
+	require.NoError(t, err)
+
+	second, err := validator.NewBase(2, validator.BaseName("second"), validator.BaseDesc("second validator"))
+	require.NoError(t, err)
+
+	var rerunCodes []validator.Code
+
+	rerun := func(_ context.Context, codes ...validator.Code) (validator.ResultList, error) {
+		rerunCodes = codes
+
+		return validator.ResultList{second.Success()}, nil
+	}
+
+	b := NewBrowser("Validation of reference-addon", validator.ResultList{
+		first.Success(),
+		second.Fail("missing icon", "invalid label"),
+	}, rerun)
+
+	render := func() string {
+		var sb strings.Builder
+
+		require.NoError(t, b.Render(&sb, 80, 24))
+
+		return sb.String()
+	}
+
+	view := render()
+	assert.Contains(t, view, "1 passed, 1 failed, 0 errored")
+	assert.Contains(t, view, "> Success  AM0001  first")
+	assert.Contains(t, view, "  Failed   AM0002  second")
+
+	assert.Equal(t, ActionNone, b.HandleKey(KeyDown))
+	assert.Equal(t, ActionNone, b.HandleKey(KeyDown), "selection stops at the last result")
+	assert.Equal(t, ActionNone, b.HandleKey(KeyEnter))
+
+	view = render()
+	assert.Contains(t, view, "AM0002 second: Failed")
+	assert.Contains(t, view, "- missing icon\r\n- invalid label")
+	assert.Contains(t, view, "Docs: https://github.com/mt-sre/addon-metadata-operator/wiki/AM0002")
+
+	assert.Equal(t, ActionRerun, b.HandleKey(KeyRerun))
+	b.Rerun(context.Background(), ActionRerun)
+
+	assert.Equal(t, []validator.Code{2}, rerunCodes)
+	assert.True(t, b.Results()[1].IsSuccess())
+	assert.Contains(t, render(), "re-ran 1 validator(s)")
+
+	assert.Equal(t, ActionNone, b.HandleKey(KeyBack))
+	assert.Contains(t, render(), "2 passed, 0 failed, 0 errored")
+
+	assert.Equal(t, ActionRerunAll, b.HandleKey(KeyRerunAll))
+	b.Rerun(context.Background(), ActionRerunAll)
+	assert.Empty(t, rerunCodes)
+
+	b.rerun = func(context.Context, ...validator.Code) (validator.ResultList, error) {
+		return nil, errors.New("boom")
+	}
+
+	b.Rerun(context.Background(), ActionRerunAll)
+	assert.Contains(t, render(), "re-running failed: boom")
+
+	assert.Equal(t, ActionQuit, b.HandleKey(KeyQuit))
+}
+
+func TestBrowserWarnings(t *testing.T) {
+	color.NoColor = true
+
+	base, err := validator.NewBase(3,
+		validator.BaseName("third"),
+		validator.BaseDocs(validator.Docs{Severity: validator.SeverityWarning}),
+	)
+	require.NoError(t, err)
+
+	b := NewBrowser("Validation of reference-addon", validator.ResultList{base.Fail("small icon")}, nil)
+
+	var sb strings.Builder
+	require.NoError(t, b.Render(&sb, 80, 24))
+
+	assert.Contains(t, sb.String(), "0 passed, 0 failed, 0 errored, 1 warned")
+	assert.Contains(t, sb.String(), "> Warning  AM0003  third")
+}
+
+func TestBrowserScrollsToSelection(t *testing.T) {
+	color.NoColor = true
+
+	var results validator.ResultList
+
+	for i := 1; i <= 20; i++ {
+		base, err := validator.NewBase(validator.Code(i))
+		require.NoError(t, err)
+
+		results = append(results, base.Success())
+	}
+
+	b := NewBrowser("title", results, nil)
+
+	for i := 0; i < 19; i++ {
+		b.HandleKey(KeyDown)
+	}
+
+	var sb strings.Builder
+
+	require.NoError(t, b.Render(&sb, 80, 10))
+
+	assert.Contains(t, sb.String(), "> Success  AM0020")
+	assert.NotContains(t, sb.String(), "AM0001")
+	assert.Len(t, strings.Split(sb.String(), "\r\n"), 10)
+}
+
+func TestParseKey(t *testing.T) {
+	t.Parallel()
+
+	for in, expected := range map[string]Key{
+		"\x1b[A": KeyUp,
+		"k":      KeyUp,
+		"\x1b[B": KeyDown,
+		"\r":     KeyEnter,
+		"\x1b":   KeyBack,
+		"r":      KeyRerun,
+		"a":      KeyRerunAll,
+		"\x03":   KeyQuit,
+		"x":      KeyUnknown,
+	} {
+		assert.Equal(t, expected, ParseKey([]byte(in)), "%q", in)
+	}
+}
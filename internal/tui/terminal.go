@@ -0,0 +1,128 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// Key is a key press understood by the Browser.
+type Key int
+
+const (
+	KeyUnknown Key = iota
+	KeyUp
+	KeyDown
+	KeyEnter
+	KeyBack
+	KeyRerun
+	KeyRerunAll
+	KeyQuit
+)
+
+// ParseKey returns the key encoded by the input read from a
+// terminal in raw mode. Arrow keys and vi style keys are supported.
+func ParseKey(in []byte) Key {
+	switch string(in) {
+	case "\x1b[A", "k":
+		return KeyUp
+	case "\x1b[B", "j":
+		return KeyDown
+	case "\r", "\n", "\x1b[C", "l":
+		return KeyEnter
+	case "\x1b", "\x1b[D", "h", "\x7f":
+		return KeyBack
+	case "r":
+		return KeyRerun
+	case "a":
+		return KeyRerunAll
+	// ctrl+c does not raise SIGINT in raw mode
+	case "q", "\x03":
+		return KeyQuit
+	default:
+		return KeyUnknown
+	}
+}
+
+// ErrNotTerminal is returned by Run if the given files are no terminals.
+var ErrNotTerminal = errors.New("not a terminal")
+
+// IsTerminal returns 'true' if all files are terminals.
+func IsTerminal(files ...*os.File) bool {
+	for _, f := range files {
+		if !term.IsTerminal(int(f.Fd())) {
+			return false
+		}
+	}
+
+	return true
+}
+
+const (
+	enterAltScreen = "\x1b[?1049h\x1b[?25l"
+	leaveAltScreen = "\x1b[?25h\x1b[?1049l"
+	clearScreen    = "\x1b[H\x1b[2J"
+)
+
+// Run shows b on the terminal of in and out until it is quit or
+// reading in fails. The terminal is restored before returning.
+func Run(ctx context.Context, in, out *os.File, b *Browser) error {
+	if !IsTerminal(in, out) {
+		return ErrNotTerminal
+	}
+
+	inFd, outFd := int(in.Fd()), int(out.Fd())
+
+	state, err := term.MakeRaw(inFd)
+	if err != nil {
+		return fmt.Errorf("entering raw mode: %w", err)
+	}
+
+	defer func() { _ = term.Restore(inFd, state) }()
+
+	fmt.Fprint(out, enterAltScreen)
+	defer fmt.Fprint(out, leaveAltScreen)
+
+	render := func() error {
+		width, height, err := term.GetSize(outFd)
+		if err != nil {
+			width, height = 80, 24
+		}
+
+		fmt.Fprint(out, clearScreen)
+
+		return b.Render(out, width, height)
+	}
+
+	buf := make([]byte, 16)
+
+	for {
+		if err := render(); err != nil {
+			return err
+		}
+
+		n, err := in.Read(buf)
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("reading input: %w", err)
+		}
+
+		switch action := b.HandleKey(ParseKey(buf[:n])); action {
+		case ActionQuit:
+			return nil
+		case ActionRerun, ActionRerunAll:
+			b.SetStatus("re-running...")
+
+			if err := render(); err != nil {
+				return err
+			}
+
+			b.Rerun(ctx, action)
+		}
+	}
+}
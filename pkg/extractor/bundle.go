@@ -9,11 +9,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/go-logr/logr"
+	"github.com/mt-sre/addon-metadata-operator/internal/logging"
+	"github.com/mt-sre/addon-metadata-operator/pkg/imagepolicy"
 	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
 	"github.com/operator-framework/operator-registry/pkg/image"
 	"github.com/operator-framework/operator-registry/pkg/image/containerdregistry"
 	opmbundle "github.com/operator-framework/operator-registry/pkg/lib/bundle"
-	"github.com/sirupsen/logrus"
 )
 
 // BundleCache provides a cache of OPM bundles which are referenced by
@@ -29,9 +31,20 @@ type BundleCache interface {
 }
 
 type DefaultBundleExtractor struct {
-	Log     logrus.FieldLogger
+	Log     logr.Logger
 	Cache   BundleCache
 	Timeout time.Duration
+	// RegistryOptions are applied to the registry used to pull
+	// bundle images in addition to the default options.
+	RegistryOptions []containerdregistry.RegistryOption
+	// Policy is verified for every bundle image before it is pulled
+	// and disables TLS verification for insecure registries. If unset
+	// the image policy carried by the context of each call is enforced.
+	Policy *imagepolicy.Verifier
+	// Retry configures retries of pulls failing with transient
+	// registry errors. If unset the Retry carried by the context
+	// of each call is applied.
+	Retry *Retry
 }
 
 func NewBundleExtractor(opts ...BundleExtractorOpt) *DefaultBundleExtractor {
@@ -44,15 +57,10 @@ func NewBundleExtractor(opts ...BundleExtractorOpt) *DefaultBundleExtractor {
 		opt(&extractor)
 	}
 
-	if extractor.Log == nil {
-		extractor.Log = logrus.New()
-	}
-
 	if extractor.Cache == nil {
 		extractor.Cache = NewBundleCacheImpl()
 	}
 
-	extractor.Log = extractor.Log.WithField("source", "bundleExtractor")
 	return &extractor
 }
 
@@ -64,7 +72,7 @@ func WithBundleCache(cache BundleCache) BundleExtractorOpt {
 	}
 }
 
-func WithBundleLog(log logrus.FieldLogger) BundleExtractorOpt {
+func WithBundleLog(log logr.Logger) BundleExtractorOpt {
 	return func(e *DefaultBundleExtractor) {
 		e.Log = log
 	}
@@ -76,62 +84,104 @@ func WithBundleTimeout(timeout time.Duration) BundleExtractorOpt {
 	}
 }
 
+// WithBundleRegistryOptions applies additional options to the registry
+// used to pull bundle images, e.g. to trust a test registry.
+func WithBundleRegistryOptions(opts ...containerdregistry.RegistryOption) BundleExtractorOpt {
+	return func(e *DefaultBundleExtractor) {
+		e.RegistryOptions = append(e.RegistryOptions, opts...)
+	}
+}
+
+// WithBundlePolicy enforces the given image policy for all bundle images.
+func WithBundlePolicy(policy *imagepolicy.Verifier) BundleExtractorOpt {
+	return func(e *DefaultBundleExtractor) {
+		e.Policy = policy
+	}
+}
+
+// WithBundleRetry retries pulls of bundle images failing
+// with transient registry errors as configured by retry.
+func WithBundleRetry(retry *Retry) BundleExtractorOpt {
+	return func(e *DefaultBundleExtractor) {
+		e.Retry = retry
+	}
+}
+
 func (e *DefaultBundleExtractor) Extract(ctx context.Context, bundleImage string) (operator.Bundle, error) {
+	log := e.logger(ctx)
+
+	// verified ahead of the cache as the image may have
+	// been cached while a different policy was in effect
+	if policy := policyFor(ctx, e.Policy); policy != nil {
+		if err := policy.Verify(ctx, bundleImage); err != nil {
+			return operator.Bundle{}, extractionError(fmt.Errorf("verifying image policy: %w", err))
+		}
+	}
+
 	cachedBundle, err := e.Cache.GetBundle(bundleImage)
 	if err != nil {
-		e.Log.Warnf("retrieving bundle %q from cache: %w", bundleImage, err)
+		log.Error(err, "retrieving bundle from cache", "bundleImage", bundleImage)
 	}
 
 	if cachedBundle != nil {
-		e.Log.Debugf("cache hit for %q", bundleImage)
+		log.V(1).Info("cache hit", "bundleImage", bundleImage)
 		return *cachedBundle, nil
 	}
 
-	e.Log.Debugf("cache miss for '%s'", bundleImage)
+	log.V(1).Info("cache miss", "bundleImage", bundleImage)
 	tmpDirs, err := createTempDirs()
 	if err != nil {
 		return operator.Bundle{}, err
 	}
 	defer func() {
 		if err := tmpDirs.CleanUp(); err != nil {
-			e.Log.Errorf("cleaning up tmpDirs: %w", err)
+			log.Error(err, "cleaning up tmpDirs")
 		}
 	}()
 
 	if err := e.unpackAndValidateBundle(ctx, bundleImage, tmpDirs); err != nil {
-		return operator.Bundle{}, fmt.Errorf("unpacking and validating bundle: %w", err)
+		return operator.Bundle{}, extractionError(fmt.Errorf("unpacking and validating bundle: %w", err))
 	}
 
 	bundle, err := operator.NewBundleFromDirectory(tmpDirs["bundle"])
 	if err != nil {
-		return operator.Bundle{}, err
+		return operator.Bundle{}, extractionError(err)
 	}
 
 	bundle.BundleImage = bundleImage // not set by OPM
 
 	if err := e.Cache.SetBundle(bundleImage, bundle); err != nil {
-		e.Log.Warnf("caching bundle %q: %w", bundleImage, err)
+		log.Error(err, "caching bundle", "bundleImage", bundleImage)
 	}
 
 	return bundle, nil
 }
 
+func (e *DefaultBundleExtractor) logger(ctx context.Context) logr.Logger {
+	return loggerFor(ctx, e.Log).WithValues("source", "bundleExtractor")
+}
+
 // unpackAndValidateBundle - Unpacks the content of an operator bundle into a temp directory
 // and validates the extracted bundle.
 // Reference: https://github.com/operator-framework/operator-registry/blob/master/cmd/opm/alpha/bundle/unpack.go
 func (e *DefaultBundleExtractor) unpackAndValidateBundle(ctx context.Context, bundleImage string, tmpDirs tempDirs) error {
-	e.Log.Debugf("unpacking bundleImage '%s' to '%s'", bundleImage, tmpDirs["bundle"])
+	log := e.logger(ctx)
+
+	log.V(1).Info("unpacking bundle", "bundleImage", bundleImage, "dir", tmpDirs["bundle"])
 
 	ctx, cancel := context.WithTimeout(ctx, e.Timeout)
 	defer cancel()
 
-	registry, err := containerdregistry.NewRegistry(
-		containerdregistry.SkipTLSVerify(false),
-		containerdregistry.WithLog(e.Log.(*logrus.Entry)),
+	policy := policyFor(ctx, e.Policy)
+	insecure := policy != nil && policy.Insecure(bundleImage)
+
+	registry, err := containerdregistry.NewRegistry(append([]containerdregistry.RegistryOption{
+		containerdregistry.SkipTLSVerify(insecure),
+		containerdregistry.WithLog(logging.Logrus(log)),
 		// need a new cache dir for each registry to avoid data races and
 		// having the default "cache/ingest" dir removed from under our feet
 		containerdregistry.WithCacheDir(tmpDirs["containerd"]),
-	)
+	}, e.RegistryOptions...)...)
 	if err != nil {
 		return err
 	}
@@ -139,12 +189,14 @@ func (e *DefaultBundleExtractor) unpackAndValidateBundle(ctx context.Context, bu
 		// ensure cleanup of registry resources, we don't need extra caching
 		// as we implemented our own caching solution, log unreturned err
 		if err := registry.Destroy(); err != nil {
-			e.Log.Errorf("failed to destroy registry: %w", err)
+			log.Error(err, "failed to destroy registry")
 		}
 	}()
 
 	ref := image.SimpleReference(bundleImage)
-	if err := registry.Pull(ctx, ref); err != nil {
+	pull := func() error { return registry.Pull(ctx, ref) }
+
+	if err := retryFor(ctx, e.Retry).do(ctx, log, bundleImage, pull); err != nil {
 		return err
 	}
 
@@ -158,10 +210,12 @@ func (e *DefaultBundleExtractor) unpackAndValidateBundle(ctx context.Context, bu
 func (e *DefaultBundleExtractor) ValidateBundle(ctx context.Context, registry *containerdregistry.Registry, tmpDir string) error {
 	errCh := make(chan error)
 
+	log := e.logger(ctx)
+
 	go func() {
-		e.Log.Debugf("validating the unpacked bundle from %s", tmpDir)
+		log.V(1).Info("validating the unpacked bundle", "dir", tmpDir)
 
-		validator := opmbundle.NewImageValidator(registry, e.Log.(*logrus.Entry))
+		validator := opmbundle.NewImageValidator(registry, logging.Logrus(log))
 		if err := validator.ValidateBundleFormat(tmpDir); err != nil {
 			errCh <- fmt.Errorf("bundle format validation failed: %w", err)
 		}
@@ -4,8 +4,8 @@ import (
 	"context"
 	"testing"
 
+	"github.com/go-logr/logr/testr"
 	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
-	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -19,23 +19,28 @@ func TestDefaultBundleExtractorImplements(t *testing.T) {
 func TestDefaultBundleExtractor(t *testing.T) {
 	t.Parallel()
 
+	reg := newTestRegistry(t)
+
 	cache := NewBundleCacheImpl()
 
 	// adding extra logging for easier test debugging
-	log := logrus.New()
-	log.SetLevel(logrus.DebugLevel)
+	log := testr.NewWithOptions(t, testr.Options{Verbosity: 1})
 
-	extractor := NewBundleExtractor(WithBundleCache(cache), WithBundleLog(log))
+	extractor := NewBundleExtractor(
+		WithBundleCache(cache),
+		WithBundleLog(log),
+		WithBundleRegistryOptions(reg.RegistryOptions()...),
+	)
 
 	for name, tc := range map[string]testCase{
 		"reference-addon:0.1.6": {
-			BundleImage:         "quay.io/osd-addons/reference-addon-bundle@sha256:a62fd3f3b55aa58c587f0b7630f5e70b123d036a1a04a1bd5a866b5c576a04f4",
+			BundleImage:         reg.ReferenceAddonBundles[6],
 			ExpectedPackageName: "reference-addon",
 			ExpectedCSVName:     "reference-addon.v0.1.6",
 			ExpectedCSVVersion:  "0.1.6",
 		},
 		"reference-addon:0.1.5": {
-			BundleImage:         "quay.io/osd-addons/reference-addon-bundle@sha256:29879d193bd8da42e7b6500252b4d21bef733666bd893de2a3f9b250e591658e",
+			BundleImage:         reg.ReferenceAddonBundles[5],
 			ExpectedPackageName: "reference-addon",
 			ExpectedCSVName:     "reference-addon.v0.1.5",
 			ExpectedCSVVersion:  "0.1.5",
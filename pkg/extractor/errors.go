@@ -0,0 +1,101 @@
+package extractor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	remoteserrors "github.com/containerd/containerd/remotes/errors"
+	"github.com/containerd/errdefs"
+)
+
+var (
+	// ErrExtractionFailed is wrapped by all errors caused by pulling or
+	// unpacking index and bundle images.
+	ErrExtractionFailed = errors.New("extraction failed")
+	// ErrRegistryAuth is additionally wrapped if the registry
+	// rejected the request due to missing or invalid credentials.
+	ErrRegistryAuth = errors.New("registry authentication failed")
+)
+
+// extractionError wraps err with ErrExtractionFailed and
+// ErrRegistryAuth if it was caused by an authentication issue.
+func extractionError(err error) error {
+	if isRegistryAuthError(err) {
+		return fmt.Errorf("%w: %w: %w", ErrExtractionFailed, ErrRegistryAuth, err)
+	}
+
+	return fmt.Errorf("%w: %w", ErrExtractionFailed, err)
+}
+
+// registryAuthMessages are matched against errors which opm and
+// containerd only pass on as formatted strings.
+var registryAuthMessages = []string{
+	"401 unauthorized",
+	"403 forbidden",
+	"unauthorized: ",
+	"denied: ",
+}
+
+func isRegistryAuthError(err error) bool {
+	var status remoteserrors.ErrUnexpectedStatus
+	if errors.As(err, &status) {
+		return status.StatusCode == http.StatusUnauthorized || status.StatusCode == http.StatusForbidden
+	}
+
+	if errdefs.IsUnauthorized(err) || errdefs.IsPermissionDenied(err) {
+		return true
+	}
+
+	msg := strings.ToLower(err.Error())
+
+	for _, m := range registryAuthMessages {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// transientMessages are matched against errors which opm and
+// containerd only pass on as formatted strings.
+var transientMessages = []string{
+	"429 too many requests",
+	"500 internal server error",
+	"502 bad gateway",
+	"503 service unavailable",
+	"504 gateway timeout",
+	"connection reset by peer",
+}
+
+// isTransientError reports whether err was caused by a registry
+// failure which may not occur again if the request is retried.
+func isTransientError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || isRegistryAuthError(err) {
+		return false
+	}
+
+	var status remoteserrors.ErrUnexpectedStatus
+	if errors.As(err, &status) {
+		return status.StatusCode == http.StatusTooManyRequests || status.StatusCode >= http.StatusInternalServerError
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	msg := strings.ToLower(err.Error())
+
+	for _, m := range transientMessages {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,87 @@
+package extractor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	remoteserrors "github.com/containerd/containerd/remotes/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractionError(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Err          error
+		ExpectedAuth bool
+	}{
+		"unexpected status unauthorized": {
+			Err:          fmt.Errorf("pulling: %w", remoteserrors.ErrUnexpectedStatus{StatusCode: http.StatusUnauthorized}),
+			ExpectedAuth: true,
+		},
+		"unexpected status not found": {
+			Err: fmt.Errorf("pulling: %w", remoteserrors.ErrUnexpectedStatus{StatusCode: http.StatusNotFound}),
+		},
+		"formatted registry message": {
+			Err:          errors.New("failed to resolve reference: pulling from host quay.io failed with status code [manifests latest]: 401 Unauthorized"),
+			ExpectedAuth: true,
+		},
+		"network failure": {
+			Err: errors.New("dial tcp: lookup quay.io: no such host"),
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := extractionError(tc.Err)
+
+			assert.ErrorIs(t, err, ErrExtractionFailed)
+			assert.ErrorIs(t, err, tc.Err)
+			assert.Equal(t, tc.ExpectedAuth, errors.Is(err, ErrRegistryAuth))
+		})
+	}
+}
+
+func TestIsTransientError(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Err       error
+		Transient bool
+	}{
+		"unexpected status bad gateway": {
+			Err:       fmt.Errorf("pulling: %w", remoteserrors.ErrUnexpectedStatus{StatusCode: http.StatusBadGateway}),
+			Transient: true,
+		},
+		"unexpected status too many requests": {
+			Err:       fmt.Errorf("pulling: %w", remoteserrors.ErrUnexpectedStatus{StatusCode: http.StatusTooManyRequests}),
+			Transient: true,
+		},
+		"unexpected status not found": {
+			Err: fmt.Errorf("pulling: %w", remoteserrors.ErrUnexpectedStatus{StatusCode: http.StatusNotFound}),
+		},
+		"formatted registry message": {
+			Err:       errors.New("failed to resolve reference: pulling from host quay.io failed with status code [manifests latest]: 502 Bad Gateway"),
+			Transient: true,
+		},
+		"authentication failure": {
+			Err: errors.New("failed to resolve reference: pulling from host quay.io failed with status code [manifests latest]: 401 Unauthorized"),
+		},
+		"cancelled": {
+			Err: fmt.Errorf("pulling: %w", context.Canceled),
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.Transient, isTransientError(tc.Err))
+		})
+	}
+}
@@ -6,16 +6,27 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/go-logr/logr"
+	"github.com/mt-sre/addon-metadata-operator/pkg/imagepolicy"
 	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
 	imageparser "github.com/novln/docker-parser"
-	"github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
 )
 
 type MainExtractor struct {
-	Log    logrus.FieldLogger
+	// Log is used for all messages. If unset the logger
+	// carried by the context of each call is used.
+	Log    logr.Logger
 	Index  IndexExtractor
 	Bundle BundleExtractor
+	// Policy is enforced by the default index and bundle extractors
+	// if they are not provided explicitly. If unset the image policy
+	// carried by the context of each call is enforced.
+	Policy *imagepolicy.Verifier
+	// Retry is applied by the default index and bundle extractors
+	// if they are not provided explicitly. If unset the Retry
+	// carried by the context of each call is applied.
+	Retry *Retry
 }
 
 // New - creates a new mainExtractor, with the provided options. Order of provided
@@ -30,18 +41,12 @@ func New(opts ...MainExtractorOpt) *MainExtractor {
 }
 
 func (e *MainExtractor) ApplyDefaults() {
-	if e.Log == nil {
-		log := logrus.New()
-		log.SetLevel(logrus.InfoLevel)
-		e.Log = log
-	}
-
 	if e.Index == nil {
-		e.Index = NewIndexExtractor(WithIndexLog(e.Log))
+		e.Index = NewIndexExtractor(WithIndexLog(e.Log), WithIndexPolicy(e.Policy), WithIndexRetry(e.Retry))
 	}
 
 	if e.Bundle == nil {
-		e.Bundle = NewBundleExtractor(WithBundleLog(e.Log))
+		e.Bundle = NewBundleExtractor(WithBundleLog(e.Log), WithBundlePolicy(e.Policy), WithBundleRetry(e.Retry))
 	}
 }
 
@@ -59,32 +64,50 @@ func WithBundleExtractor(bundleExtractor BundleExtractor) MainExtractorOpt {
 	}
 }
 
-func WithLog(log logrus.FieldLogger) MainExtractorOpt {
+func WithLog(log logr.Logger) MainExtractorOpt {
 	return func(e *MainExtractor) {
 		e.Log = log
 	}
 }
 
+// WithPolicy enforces the given image policy for all index and bundle
+// images pulled by the default extractors.
+func WithPolicy(policy *imagepolicy.Verifier) MainExtractorOpt {
+	return func(e *MainExtractor) {
+		e.Policy = policy
+	}
+}
+
+// WithRetry retries pulls of index and bundle images failing with
+// transient registry errors for the default extractors.
+func WithRetry(retry Retry) MainExtractorOpt {
+	return func(e *MainExtractor) {
+		e.Retry = &retry
+	}
+}
+
 // ExtractBundles - extract bundles from indexImage matching pkgName
 func (e *MainExtractor) ExtractBundles(ctx context.Context, indexImage string, pkgName string) ([]operator.Bundle, error) {
+	log := loggerFor(ctx, e.Log)
+
 	if err := validateIndexImage(indexImage); err != nil {
 		if errors.Is(err, ErrTaglessImage) {
-			e.Log.Info("skipping tagless image, nothing to extract")
+			log.Info("skipping tagless image, nothing to extract")
 			return nil, nil
 		}
-		e.Log.Errorf("failed to validate indexImage: %w", err)
+		log.Error(err, "failed to validate indexImage")
 		return nil, err
 	}
 
 	if pkgName == "" {
 		err := errors.New("invalid empty pkgName")
-		e.Log.Error(err)
+		log.Error(err, "failed to extract bundles")
 		return nil, err
 	}
 
 	bundleImages, err := e.Index.ExtractBundleImages(ctx, indexImage, pkgName)
 	if err != nil {
-		e.Log.Errorf("failed to extract bundles: %w", err)
+		log.Error(err, "failed to extract bundles")
 		return nil, err
 	}
 
@@ -93,18 +116,20 @@ func (e *MainExtractor) ExtractBundles(ctx context.Context, indexImage string, p
 
 // ExtractAllBundles - extract bundles for all packages from indexImage
 func (e *MainExtractor) ExtractAllBundles(ctx context.Context, indexImage string) ([]operator.Bundle, error) {
+	log := loggerFor(ctx, e.Log)
+
 	if err := validateIndexImage(indexImage); err != nil {
 		if errors.Is(err, ErrTaglessImage) {
-			e.Log.Info("skipping tagless image, nothing to extract")
+			log.Info("skipping tagless image, nothing to extract")
 			return nil, nil
 		}
-		e.Log.Errorf("failed to validate indexImage: %w", err)
+		log.Error(err, "failed to validate indexImage")
 		return nil, err
 	}
 
 	bundleImages, err := e.Index.ExtractAllBundleImages(ctx, indexImage)
 	if err != nil {
-		e.Log.Errorf("failed to extract all bundles: %w", err)
+		log.Error(err, "failed to extract all bundles")
 		return nil, err
 	}
 
@@ -115,8 +140,9 @@ func (e *MainExtractor) extractBundlesConcurrent(ctx context.Context, bundleImag
 	res := make([]operator.Bundle, len(bundleImages))
 	g := new(errgroup.Group)
 
-	// we need the global context to be able to cancel all goroutines
-	ctx, cancel := context.WithCancel(context.Background())
+	// derived context is cancelled once any extraction fails while
+	// keeping the logger and image policy carried by the caller's context
+	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
 	for i, bundleImage := range bundleImages {
@@ -138,6 +164,26 @@ func (e *MainExtractor) extractBundlesConcurrent(ctx context.Context, bundleImag
 	return res, nil
 }
 
+// loggerFor returns log if it was configured and the
+// logger carried by ctx otherwise.
+func loggerFor(ctx context.Context, log logr.Logger) logr.Logger {
+	if log.GetSink() != nil {
+		return log
+	}
+
+	return logr.FromContextOrDiscard(ctx)
+}
+
+// policyFor returns policy if it was configured and the
+// image policy carried by ctx otherwise.
+func policyFor(ctx context.Context, policy *imagepolicy.Verifier) *imagepolicy.Verifier {
+	if policy != nil {
+		return policy
+	}
+
+	return imagepolicy.FromContext(ctx)
+}
+
 func validateIndexImage(indexImage string) error {
 	if indexImage == "" {
 		return errors.New("invalid empty indexImage")
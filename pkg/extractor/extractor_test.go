@@ -2,9 +2,21 @@ package extractor
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/mt-sre/addon-metadata-operator/pkg/imagepolicy"
 	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/registrytest"
 	"github.com/stretchr/testify/require"
 )
 
@@ -13,34 +25,30 @@ func TestMainExtractorImplements(t *testing.T) {
 }
 
 func TestMainExtractorWithDefaultValues(t *testing.T) {
+	reg := newTestRegistry(t)
+
 	cases := []struct {
 		indexImage           string
 		pkgName              string
-		expectedBundleImages map[string]bool
+		expectedBundleImages []string
 	}{
 		{
 			// sql-based catalog image
-			indexImage: "quay.io/osd-addons/reference-addon-index@sha256:b9e87a598e7fd6afb4bfedb31e4098435c2105cc8ebe33231c341e515ba9054d",
-			pkgName:    "",
-			expectedBundleImages: map[string]bool{
-				"quay.io/osd-addons/reference-addon-bundle:0.1.0-c15cedb": true,
-				"quay.io/osd-addons/reference-addon-bundle:0.1.1-c15cedb": true,
-				"quay.io/osd-addons/reference-addon-bundle:0.1.2-c15cedb": true,
-				"quay.io/osd-addons/reference-addon-bundle:0.1.3-c15cedb": true,
-				"quay.io/osd-addons/reference-addon-bundle:0.1.4-c15cedb": true,
-				"quay.io/osd-addons/reference-addon-bundle:0.1.5-c15cedb": true,
-			},
+			indexImage:           reg.ReferenceAddonSQLiteIndex,
+			pkgName:              "",
+			expectedBundleImages: reg.ReferenceAddonBundles[:6],
 		},
 		{
 			// file-based catalog image
-			indexImage: "quay.io/osd-addons/reference-addon-index:file-based-poc",
-			pkgName:    "reference-addon",
-			expectedBundleImages: map[string]bool{
-				"quay.io/osd-addons/reference-addon-bundle:0.1.6-single": true,
-			},
+			indexImage:           reg.ReferenceAddonFBCIndex,
+			pkgName:              "reference-addon",
+			expectedBundleImages: reg.ReferenceAddonBundles[6:],
 		},
 	}
-	extractor := New()
+	extractor := New(
+		WithIndexExtractor(NewIndexExtractor(WithIndexRegistryOptions(reg.RegistryOptions()...))),
+		WithBundleExtractor(NewBundleExtractor(WithBundleRegistryOptions(reg.RegistryOptions()...))),
+	)
 	for _, tc := range cases {
 		tc := tc // pin
 		t.Run(tc.indexImage, func(t *testing.T) {
@@ -58,11 +66,176 @@ func TestMainExtractorWithDefaultValues(t *testing.T) {
 			}
 			require.NoError(t, err)
 
-			require.Equal(t, len(bundles), len(tc.expectedBundleImages))
+			bundleImages := make([]string, 0, len(bundles))
 			for _, bundle := range bundles {
-				_, ok := tc.expectedBundleImages[bundle.BundleImage]
-				require.True(t, ok)
+				bundleImages = append(bundleImages, bundle.BundleImage)
 			}
+			require.ElementsMatch(t, tc.expectedBundleImages, bundleImages)
 		})
 	}
 }
+
+func TestMainExtractorWithPolicy(t *testing.T) {
+	t.Parallel()
+
+	reg := newTestRegistry(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	require.NoError(t, reg.Sign("osd-addons/reference-addon-index:file-based", key))
+	require.NoError(t, reg.Sign("osd-addons/reference-addon-bundle:0.1.6", key))
+	require.NoError(t, reg.Sign("osd-addons/reference-addon-index:sqlite", key))
+
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+
+	verifier, err := imagepolicy.NewVerifier(imagepolicy.Policy{
+		Default: imagepolicy.Requirements{{Type: imagepolicy.TypeReject}},
+		Transports: map[string]map[string]imagepolicy.Requirements{
+			imagepolicy.DockerTransport: {
+				reg.Host() + "/osd-addons": {{
+					Type:    imagepolicy.TypeSigstoreSigned,
+					KeyData: base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+				}},
+			},
+		},
+	}, imagepolicy.WithHTTPClient{Client: reg.Client()})
+	require.NoError(t, err)
+
+	extractor := New(
+		WithIndexExtractor(NewIndexExtractor(
+			WithIndexRegistryOptions(reg.RegistryOptions()...),
+			WithIndexPolicy(verifier),
+		)),
+		WithBundleExtractor(NewBundleExtractor(
+			WithBundleRegistryOptions(reg.RegistryOptions()...),
+			WithBundlePolicy(verifier),
+		)),
+	)
+
+	ctx := context.Background()
+
+	bundles, err := extractor.ExtractBundles(ctx, reg.ReferenceAddonFBCIndex, "reference-addon")
+	require.NoError(t, err)
+	require.Len(t, bundles, 1)
+
+	// the index is signed while its bundles are not
+	_, err = extractor.ExtractAllBundles(ctx, reg.ReferenceAddonSQLiteIndex)
+	require.ErrorIs(t, err, ErrExtractionFailed)
+	require.ErrorIs(t, err, imagepolicy.ErrUnsigned)
+
+	_, err = extractor.ExtractAllBundles(ctx, reg.GPUOperatorIndex)
+	require.ErrorIs(t, err, imagepolicy.ErrUnsigned)
+}
+
+func TestMainExtractorWithRetry(t *testing.T) {
+	t.Parallel()
+
+	reg := newTestRegistry(t)
+
+	newExtractor := func(retry Retry) *MainExtractor {
+		return New(
+			WithIndexExtractor(NewIndexExtractor(
+				WithIndexRegistryOptions(reg.RegistryOptions()...),
+				WithIndexRetry(&retry),
+			)),
+			WithBundleExtractor(NewBundleExtractor(
+				WithBundleRegistryOptions(reg.RegistryOptions()...),
+				WithBundleRetry(&retry),
+			)),
+		)
+	}
+
+	ctx := context.Background()
+
+	reg.FailRequests(1, http.StatusBadGateway)
+
+	_, err := newExtractor(Retry{}).ExtractBundles(ctx, reg.ReferenceAddonFBCIndex, "reference-addon")
+	require.ErrorIs(t, err, ErrExtractionFailed)
+
+	reg.FailRequests(2, http.StatusBadGateway)
+
+	bundles, err := newExtractor(Retry{Retries: 3, Backoff: time.Millisecond}).
+		ExtractBundles(ctx, reg.ReferenceAddonFBCIndex, "reference-addon")
+	require.NoError(t, err)
+	require.Len(t, bundles, 1)
+}
+
+// testRegistry serves the index and bundle images used by the
+// extractor tests so that they do not depend on quay.io.
+type testRegistry struct {
+	*registrytest.Server
+	// ReferenceAddonBundles lists the synthetic bundles 0.1.0 to 0.1.5
+	// followed by bundle 0.1.6 loaded from the testdata directory.
+	ReferenceAddonBundles     []string
+	ReferenceAddonSQLiteIndex string
+	ReferenceAddonFBCIndex    string
+	GPUOperatorBundles        map[string][]string
+	GPUOperatorIndex          string
+}
+
+func newTestRegistry(t *testing.T) testRegistry {
+	t.Helper()
+
+	srv := registrytest.NewServer()
+	t.Cleanup(srv.Close)
+
+	res := testRegistry{
+		Server:             srv,
+		GPUOperatorBundles: make(map[string][]string),
+	}
+
+	var replaces string
+
+	for i := 0; i <= 5; i++ {
+		bundle := registrytest.Bundle{
+			PackageName: "reference-addon",
+			Version:     fmt.Sprintf("0.1.%d", i),
+			Replaces:    replaces,
+		}
+
+		ref, err := srv.AddBundle(fmt.Sprintf("osd-addons/reference-addon-bundle:0.1.%d", i), bundle)
+		require.NoError(t, err)
+
+		res.ReferenceAddonBundles = append(res.ReferenceAddonBundles, ref)
+		replaces = bundle.CSVName()
+	}
+
+	ref, err := srv.AddBundleDir(
+		"osd-addons/reference-addon-bundle:0.1.6",
+		filepath.Join("..", "..", "internal", "testdata", "bundles", "reference-addon", "main", "0.1.6"),
+	)
+	require.NoError(t, err)
+
+	res.ReferenceAddonBundles = append(res.ReferenceAddonBundles, ref)
+
+	res.ReferenceAddonSQLiteIndex, err = srv.AddIndex(
+		"osd-addons/reference-addon-index:sqlite", registrytest.IndexFormatSQLite, res.ReferenceAddonBundles[:6]...,
+	)
+	require.NoError(t, err)
+
+	res.ReferenceAddonFBCIndex, err = srv.AddIndex(
+		"osd-addons/reference-addon-index:file-based", registrytest.IndexFormatFBC, res.ReferenceAddonBundles[6:]...,
+	)
+	require.NoError(t, err)
+
+	var gpuBundles []string
+
+	for _, b := range []registrytest.Bundle{
+		{PackageName: "gpu-operator-certified-addon", Version: "1.7.1"},
+		{PackageName: "gpu-operator-certified-addon", Version: "1.8.0", Replaces: "gpu-operator-certified-addon.v1.7.1"},
+		{PackageName: "node-feature-discovery-operator", Version: "4.8.0"},
+	} {
+		ref, err := srv.AddBundle(fmt.Sprintf("osd-addons/%s-bundle:%s", b.PackageName, b.Version), b)
+		require.NoError(t, err)
+
+		res.GPUOperatorBundles[b.PackageName] = append(res.GPUOperatorBundles[b.PackageName], ref)
+		gpuBundles = append(gpuBundles, ref)
+	}
+
+	res.GPUOperatorIndex, err = srv.AddIndex("osd-addons/gpu-operator-index:file-based", registrytest.IndexFormatFBC, gpuBundles...)
+	require.NoError(t, err)
+
+	return res
+}
@@ -3,11 +3,15 @@ package extractor
 import (
 	"context"
 	"fmt"
+	"os"
 	"sort"
 
+	"github.com/go-logr/logr"
+	"github.com/mt-sre/addon-metadata-operator/internal/logging"
+	"github.com/mt-sre/addon-metadata-operator/pkg/imagepolicy"
 	"github.com/operator-framework/operator-registry/alpha/action"
 	"github.com/operator-framework/operator-registry/alpha/model"
-	"github.com/sirupsen/logrus"
+	"github.com/operator-framework/operator-registry/pkg/image/containerdregistry"
 )
 
 // IndexCache provides a cache of index images which store related bundles
@@ -26,8 +30,19 @@ type IndexCache interface {
 const allBundlesKey = "__ALL__"
 
 type DefaultIndexExtractor struct {
-	Log   logrus.FieldLogger
+	Log   logr.Logger
 	Cache IndexCache
+	// RegistryOptions are applied to the registry used to pull index
+	// images. The default registry of opm is used if unset.
+	RegistryOptions []containerdregistry.RegistryOption
+	// Policy is verified for every index image before it is pulled
+	// and disables TLS verification for insecure registries. If unset
+	// the image policy carried by the context of each call is enforced.
+	Policy *imagepolicy.Verifier
+	// Retry configures retries of pulls failing with transient
+	// registry errors. If unset the Retry carried by the context
+	// of each call is applied.
+	Retry *Retry
 }
 
 // NewIndexExtractor - takes a variadic slice of options to configure an
@@ -39,16 +54,10 @@ func NewIndexExtractor(opts ...IndexExtractorOpt) *DefaultIndexExtractor {
 		opt(&extractor)
 	}
 
-	if extractor.Log == nil {
-		extractor.Log = logrus.New()
-	}
-
 	if extractor.Cache == nil {
 		extractor.Cache = NewIndexCacheImpl()
 	}
 
-	extractor.Log = extractor.Log.WithField("source", "indexExtractor")
-
 	return &extractor
 }
 
@@ -60,53 +69,146 @@ func WithIndexCache(cache IndexCache) IndexExtractorOpt {
 	}
 }
 
-func WithIndexLog(log logrus.FieldLogger) IndexExtractorOpt {
+func WithIndexLog(log logr.Logger) IndexExtractorOpt {
 	return func(e *DefaultIndexExtractor) {
 		e.Log = log
 	}
 }
 
+// WithIndexRegistryOptions applies options to the registry used
+// to pull index images, e.g. to trust a test registry.
+func WithIndexRegistryOptions(opts ...containerdregistry.RegistryOption) IndexExtractorOpt {
+	return func(e *DefaultIndexExtractor) {
+		e.RegistryOptions = append(e.RegistryOptions, opts...)
+	}
+}
+
+// WithIndexPolicy enforces the given image policy for all index images.
+func WithIndexPolicy(policy *imagepolicy.Verifier) IndexExtractorOpt {
+	return func(e *DefaultIndexExtractor) {
+		e.Policy = policy
+	}
+}
+
+// WithIndexRetry retries pulls of index images failing
+// with transient registry errors as configured by retry.
+func WithIndexRetry(retry *Retry) IndexExtractorOpt {
+	return func(e *DefaultIndexExtractor) {
+		e.Retry = retry
+	}
+}
+
 // ExtractBundleImages - returns a sorted list of bundles for a given pkg
 func (e *DefaultIndexExtractor) ExtractBundleImages(ctx context.Context, indexImage string, pkgName string) ([]string, error) {
-	e.Log.Debugf("extracting bundles for '%s', matching pkgName '%s'", indexImage, pkgName)
+	e.logger(ctx).V(1).Info("extracting bundles", "indexImage", indexImage, "pkgName", pkgName)
 	return e.extractBundleImages(ctx, indexImage, pkgName)
 }
 
 // ExtractAllBundleImages - returns a sorted list of all bundles for all pkgs
 func (e *DefaultIndexExtractor) ExtractAllBundleImages(ctx context.Context, indexImage string) ([]string, error) {
-	e.Log.Debugf("extracting all bundles for '%s'", indexImage)
+	e.logger(ctx).V(1).Info("extracting all bundles", "indexImage", indexImage)
 	return e.extractBundleImages(ctx, indexImage, allBundlesKey)
 }
 
 // listBundles - return a list of all bundleImages. Need to sort bundleImages
 // everytime as order might not be preserved in the cache.
 func (e *DefaultIndexExtractor) extractBundleImages(ctx context.Context, indexImage string, cacheKey string) ([]string, error) {
+	log := e.logger(ctx)
+
+	policy := policyFor(ctx, e.Policy)
+
+	if policy != nil {
+		if err := policy.Verify(ctx, indexImage); err != nil {
+			return nil, extractionError(fmt.Errorf("verifying image policy: %w", err))
+		}
+	}
+
 	bundleImages, err := e.Cache.GetBundleImages(indexImage, cacheKey)
 	if err != nil {
-		e.Log.Warnf("getting bundle images from cache: %w", err)
+		log.Error(err, "getting bundle images from cache")
 	}
 
 	if bundleImages != nil {
-		e.Log.Debugf("cache hit for '%s'", indexImage)
+		log.V(1).Info("cache hit", "indexImage", indexImage)
 		return sortedBundleImages(bundleImages), nil
 	}
 
-	e.Log.Debugf("cache miss for '%s'", indexImage)
+	log.V(1).Info("cache miss", "indexImage", indexImage)
 	lb := action.ListBundles{IndexReference: indexImage, PackageName: pkgNameFromCacheKey(cacheKey)}
-	data, err := lb.Run(ctx)
+
+	insecure := policy != nil && policy.Insecure(indexImage)
+
+	if len(e.RegistryOptions) > 0 || insecure {
+		registry, cleanup, err := e.newRegistry(ctx, insecure)
+		if err != nil {
+			return nil, extractionError(fmt.Errorf("creating registry: %w", err))
+		}
+		defer cleanup()
+
+		lb.Registry = registry
+	}
+
+	var data *action.ListBundlesResult
+
+	err = retryFor(ctx, e.Retry).do(ctx, log, indexImage, func() error {
+		var err error
+
+		data, err = lb.Run(ctx)
+
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list bundles with opm: %w", err)
+		return nil, extractionError(fmt.Errorf("failed to list bundles with opm: %w", err))
 	}
 
 	bundleImages, bundleImagesMap := parseBundles(cacheKey, data.Bundles)
 
 	if err := e.Cache.SetBundleImages(indexImage, bundleImagesMap); err != nil {
-		e.Log.Warnf("caching bundle images: %w", err)
+		log.Error(err, "caching bundle images")
 	}
 
 	return sortedBundleImages(bundleImages), nil
 }
 
+// newRegistry returns a registry with a private cache directory
+// configured with RegistryOptions and a function releasing it. TLS
+// verification is skipped if insecure is set.
+func (e *DefaultIndexExtractor) newRegistry(ctx context.Context, insecure bool) (*containerdregistry.Registry, func(), error) {
+	log := e.logger(ctx)
+
+	cacheDir, err := os.MkdirTemp("", "containerd-")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	registry, err := containerdregistry.NewRegistry(append([]containerdregistry.RegistryOption{
+		containerdregistry.SkipTLSVerify(insecure),
+		containerdregistry.WithLog(logging.Logrus(log)),
+		containerdregistry.WithCacheDir(cacheDir),
+	}, e.RegistryOptions...)...)
+	if err != nil {
+		_ = os.RemoveAll(cacheDir)
+
+		return nil, nil, err
+	}
+
+	cleanup := func() {
+		if err := registry.Destroy(); err != nil {
+			log.Error(err, "failed to destroy registry")
+		}
+
+		if err := os.RemoveAll(cacheDir); err != nil {
+			log.Error(err, "removing registry cache", "dir", cacheDir)
+		}
+	}
+
+	return registry, cleanup, nil
+}
+
+func (e *DefaultIndexExtractor) logger(ctx context.Context) logr.Logger {
+	return loggerFor(ctx, e.Log).WithValues("source", "indexExtractor")
+}
+
 func pkgNameFromCacheKey(cacheKey string) string {
 	if cacheKey == allBundlesKey {
 		// a pkg name of "" will list all bundles in an indexImage
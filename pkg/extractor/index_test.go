@@ -17,8 +17,13 @@ func TestDefaultIndexExtractorImplements(t *testing.T) {
 func TestExtractorFileBasedAndSQLCatalogs(t *testing.T) {
 	t.Parallel()
 
+	reg := newTestRegistry(t)
+
 	cache := NewIndexCacheImpl()
-	extractor := NewIndexExtractor(WithIndexCache(cache))
+	extractor := NewIndexExtractor(
+		WithIndexCache(cache),
+		WithIndexRegistryOptions(reg.RegistryOptions()...),
+	)
 
 	for name, tc := range map[string]struct {
 		IndexImage           string
@@ -26,23 +31,14 @@ func TestExtractorFileBasedAndSQLCatalogs(t *testing.T) {
 		ExpectedBundleImages []string
 	}{
 		"sql-based catalog image": {
-			IndexImage: "quay.io/osd-addons/reference-addon-index@sha256:b9e87a598e7fd6afb4bfedb31e4098435c2105cc8ebe33231c341e515ba9054d",
-			PkgName:    "reference-addon",
-			ExpectedBundleImages: []string{
-				"quay.io/osd-addons/reference-addon-bundle:0.1.0-c15cedb",
-				"quay.io/osd-addons/reference-addon-bundle:0.1.1-c15cedb",
-				"quay.io/osd-addons/reference-addon-bundle:0.1.2-c15cedb",
-				"quay.io/osd-addons/reference-addon-bundle:0.1.3-c15cedb",
-				"quay.io/osd-addons/reference-addon-bundle:0.1.4-c15cedb",
-				"quay.io/osd-addons/reference-addon-bundle:0.1.5-c15cedb",
-			},
+			IndexImage:           reg.ReferenceAddonSQLiteIndex,
+			PkgName:              "reference-addon",
+			ExpectedBundleImages: reg.ReferenceAddonBundles[:6],
 		},
 		"file-based catalog image": {
-			IndexImage: "quay.io/osd-addons/reference-addon-index:file-based-poc",
-			PkgName:    "reference-addon",
-			ExpectedBundleImages: []string{
-				"quay.io/osd-addons/reference-addon-bundle:0.1.6-single",
-			},
+			IndexImage:           reg.ReferenceAddonFBCIndex,
+			PkgName:              "reference-addon",
+			ExpectedBundleImages: reg.ReferenceAddonBundles[6:],
 		},
 	} {
 		tc := tc // pin
@@ -66,8 +62,18 @@ func TestExtractorFileBasedAndSQLCatalogs(t *testing.T) {
 func TestIndexExtractorListAllBundles(t *testing.T) {
 	t.Parallel()
 
+	reg := newTestRegistry(t)
+
 	cache := NewIndexCacheImpl()
-	extractor := NewIndexExtractor(WithIndexCache(cache))
+	extractor := NewIndexExtractor(
+		WithIndexCache(cache),
+		WithIndexRegistryOptions(reg.RegistryOptions()...),
+	)
+
+	var allBundles []string
+	for _, bundles := range reg.GPUOperatorBundles {
+		allBundles = append(allBundles, bundles...)
+	}
 
 	for name, tc := range map[string]struct {
 		IndexImage           string
@@ -75,27 +81,9 @@ func TestIndexExtractorListAllBundles(t *testing.T) {
 		ExpectedBundleImages []string
 	}{
 		"gpu-operator": {
-			IndexImage: "quay.io/osd-addons/gpu-operator-index@sha256:62e0f330276375758f875c62c90e6c3e4e217247f221c96ce5e4ab64f6617e38",
-			PackageToBundle: map[string][]string{
-				"gpu-operator-certified-addon": {
-					"quay.io/osd-addons/gpu-operator-bundle:1.7.1-0ddc381",
-					"quay.io/osd-addons/gpu-operator-bundle:1.8.0-0ddc381",
-					"quay.io/osd-addons/gpu-operator-bundle:1.8.2-0ddc381",
-					"quay.io/osd-addons/gpu-operator-bundle:1.8.3-0ddc381",
-					"quay.io/osd-addons/gpu-operator-bundle:1.9.0-beta-0ddc381",
-				},
-				"node-feature-discovery-operator": {
-					"quay.io/osd-addons/gpu-operator-nfd-operator-bundle:4.8.0-0ddc381",
-				},
-			},
-			ExpectedBundleImages: []string{
-				"quay.io/osd-addons/gpu-operator-bundle:1.7.1-0ddc381",
-				"quay.io/osd-addons/gpu-operator-bundle:1.8.0-0ddc381",
-				"quay.io/osd-addons/gpu-operator-bundle:1.8.2-0ddc381",
-				"quay.io/osd-addons/gpu-operator-bundle:1.8.3-0ddc381",
-				"quay.io/osd-addons/gpu-operator-bundle:1.9.0-beta-0ddc381",
-				"quay.io/osd-addons/gpu-operator-nfd-operator-bundle:4.8.0-0ddc381",
-			},
+			IndexImage:           reg.GPUOperatorIndex,
+			PackageToBundle:      reg.GPUOperatorBundles,
+			ExpectedBundleImages: allBundles,
 		},
 	} {
 		tc := tc
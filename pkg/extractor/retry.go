@@ -0,0 +1,73 @@
+package extractor
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// Retry configures how pulls of index and bundle images failing with
+// transient registry errors, e.g. a 502 returned by quay.io, are retried.
+// The zero value disables retries.
+type Retry struct {
+	// Retries is the number of times a failed pull is retried.
+	Retries int
+	// Backoff is the delay before the first retry which
+	// doubles for every subsequent retry.
+	Backoff time.Duration
+}
+
+type retryContextKey struct{}
+
+// NewRetryContext returns a copy of ctx carrying retry.
+func NewRetryContext(ctx context.Context, retry Retry) context.Context {
+	return context.WithValue(ctx, retryContextKey{}, retry)
+}
+
+// RetryFromContext returns the Retry carried by ctx or
+// the zero Retry if pulls are not retried.
+func RetryFromContext(ctx context.Context) Retry {
+	retry, _ := ctx.Value(retryContextKey{}).(Retry)
+
+	return retry
+}
+
+// retryFor returns retry if it was configured and the
+// Retry carried by ctx otherwise.
+func retryFor(ctx context.Context, retry *Retry) Retry {
+	if retry != nil {
+		return *retry
+	}
+
+	return RetryFromContext(ctx)
+}
+
+// do calls pull until it succeeds, fails with an error which is not
+// transient or the retries are exhausted. The last error is returned.
+func (r Retry) do(ctx context.Context, log logr.Logger, image string, pull func() error) error {
+	delay := r.Backoff
+
+	for attempt := 1; ; attempt++ {
+		err := pull()
+		if err == nil || attempt > r.Retries || !isTransientError(err) {
+			return err
+		}
+
+		log.V(1).Info("retrying pull after transient error",
+			"image", image,
+			"attempt", attempt,
+			"retries", r.Retries,
+			"backoff", delay.String(),
+			"error", err.Error(),
+		)
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+	}
+}
@@ -0,0 +1,66 @@
+package extractor
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-logr/logr"
+	"github.com/mt-sre/addon-metadata-operator/internal/logging"
+	"github.com/operator-framework/operator-registry/pkg/image"
+	"github.com/operator-framework/operator-registry/pkg/image/containerdregistry"
+)
+
+// UnpackImage pulls img and unpacks its file system to dir, e.g. to
+// obtain validators distributed as images. The image policy and the
+// retries carried by ctx are applied. The given options are applied
+// to the registry in addition to the default options.
+func UnpackImage(ctx context.Context, img, dir string, opts ...containerdregistry.RegistryOption) error {
+	log := loggerFor(ctx, logr.Logger{}).WithValues("source", "imageUnpacker")
+
+	policy := policyFor(ctx, nil)
+	if policy != nil {
+		if err := policy.Verify(ctx, img); err != nil {
+			return extractionError(fmt.Errorf("verifying image policy: %w", err))
+		}
+	}
+
+	cacheDir, err := os.MkdirTemp("", "containerd-")
+	if err != nil {
+		return fmt.Errorf("creating cache dir: %w", err)
+	}
+
+	defer func() {
+		if err := os.RemoveAll(cacheDir); err != nil {
+			log.Error(err, "removing cache dir", "dir", cacheDir)
+		}
+	}()
+
+	registry, err := containerdregistry.NewRegistry(append([]containerdregistry.RegistryOption{
+		containerdregistry.SkipTLSVerify(policy != nil && policy.Insecure(img)),
+		containerdregistry.WithLog(logging.Logrus(log)),
+		containerdregistry.WithCacheDir(cacheDir),
+	}, opts...)...)
+	if err != nil {
+		return fmt.Errorf("initializing registry: %w", err)
+	}
+
+	defer func() {
+		if err := registry.Destroy(); err != nil {
+			log.Error(err, "failed to destroy registry")
+		}
+	}()
+
+	ref := image.SimpleReference(img)
+	pull := func() error { return registry.Pull(ctx, ref) }
+
+	if err := retryFor(ctx, nil).do(ctx, log, img, pull); err != nil {
+		return extractionError(fmt.Errorf("pulling image: %w", err))
+	}
+
+	if err := registry.Unpack(ctx, ref, dir); err != nil {
+		return extractionError(fmt.Errorf("unpacking image: %w", err))
+	}
+
+	return nil
+}
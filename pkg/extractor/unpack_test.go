@@ -0,0 +1,42 @@
+package extractor
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/registrytest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnpackImage(t *testing.T) {
+	t.Parallel()
+
+	srv := registrytest.NewServer()
+	t.Cleanup(srv.Close)
+
+	ref, err := srv.AddImage("osd-addons/validators:v1", registrytest.Image{
+		Files: map[string][]byte{
+			"owner-org.wasm": []byte("\x00asm"),
+		},
+	})
+	require.NoError(t, err)
+
+	ctx := NewRetryContext(context.Background(), Retry{Retries: 1, Backoff: time.Millisecond})
+
+	// the transient failure is retried
+	srv.FailRequests(1, http.StatusBadGateway)
+
+	dir := t.TempDir()
+	require.NoError(t, UnpackImage(ctx, ref, dir, srv.RegistryOptions()...))
+
+	data, err := os.ReadFile(filepath.Join(dir, "owner-org.wasm"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("\x00asm"), data)
+
+	err = UnpackImage(ctx, srv.Reference("osd-addons/validators:missing"), t.TempDir(), srv.RegistryOptions()...)
+	require.ErrorIs(t, err, ErrExtractionFailed)
+}
@@ -0,0 +1,102 @@
+// Package httputil provides the HTTP client shared by all components
+// which talk to remote services. Clients retry transient failures,
+// rate limit requests per host, report every attempt to hooks and
+// record per-host metrics. Defaults such as timeouts and proxies can
+// be configured once for the whole process using SetDefaults.
+package httputil
+
+import (
+	"net/http"
+	"sync"
+)
+
+var (
+	defaultsMu sync.RWMutex
+	defaults   []ClientOption
+)
+
+// SetDefaults replaces the options applied to every client and transport
+// created afterwards before any options given to NewClient or NewTransport.
+func SetDefaults(opts ...ClientOption) {
+	defaultsMu.Lock()
+	defer defaultsMu.Unlock()
+
+	defaults = append([]ClientOption(nil), opts...)
+}
+
+func newConfig(opts ...ClientOption) ClientConfig {
+	defaultsMu.RLock()
+	all := append(append([]ClientOption(nil), defaults...), opts...)
+	defaultsMu.RUnlock()
+
+	var cfg ClientConfig
+
+	cfg.Option(all...)
+	cfg.Default()
+
+	return cfg
+}
+
+// NewClient returns an *http.Client using a transport created
+// by NewTransport and the configured timeout.
+func NewClient(opts ...ClientOption) *http.Client {
+	cfg := newConfig(opts...)
+
+	return &http.Client{
+		Transport: cfg.transport(),
+		Timeout:   cfg.Timeout,
+	}
+}
+
+// NewTransport returns a http.RoundTripper applying rate limits, retries,
+// hooks and metrics to requests sent through the configured base transport.
+// It can be used to instrument clients constructed by other libraries.
+func NewTransport(opts ...ClientOption) http.RoundTripper {
+	cfg := newConfig(opts...)
+
+	return cfg.transport()
+}
+
+// Wrap returns a function wrapping a base http.RoundTripper
+// as done by NewTransport, e.g. for SDK transport wrappers.
+func Wrap(opts ...ClientOption) func(http.RoundTripper) http.RoundTripper {
+	return func(base http.RoundTripper) http.RoundTripper {
+		return NewTransport(append(opts, WithTransport{RoundTripper: base})...)
+	}
+}
+
+func (c ClientConfig) transport() http.RoundTripper {
+	base := c.Transport
+	if base == nil {
+		base = defaultTransport(c.Proxy)
+	}
+
+	var rt http.RoundTripper = &instrumentedTransport{
+		next:    base,
+		hooks:   c.Hooks,
+		metrics: c.Metrics,
+	}
+
+	rt = &retryTransport{
+		next:       rt,
+		maxRetries: c.MaxRetries,
+		delay:      c.RetryDelay,
+		metrics:    c.Metrics,
+	}
+
+	if c.RateLimit > 0 {
+		rt = newRateLimitTransport(rt, c.RateLimit, c.Burst)
+	}
+
+	return rt
+}
+
+func defaultTransport(proxy ProxyFunc) http.RoundTripper {
+	tp := http.DefaultTransport.(*http.Transport).Clone()
+
+	if proxy != nil {
+		tp.Proxy = proxy
+	}
+
+	return tp
+}
@@ -0,0 +1,197 @@
+package httputil_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/httputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestClientRetries(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Method           string
+		Body             string
+		Statuses         []int
+		ExpectedStatus   int
+		ExpectedRequests int64
+	}{
+		"retries until success": {
+			Method:           http.MethodGet,
+			Statuses:         []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK},
+			ExpectedStatus:   http.StatusOK,
+			ExpectedRequests: 3,
+		},
+		"gives up after max retries": {
+			Method:           http.MethodHead,
+			Statuses:         []int{http.StatusTooManyRequests},
+			ExpectedStatus:   http.StatusTooManyRequests,
+			ExpectedRequests: 3,
+		},
+		"client errors are not retried": {
+			Method:           http.MethodGet,
+			Statuses:         []int{http.StatusNotFound},
+			ExpectedStatus:   http.StatusNotFound,
+			ExpectedRequests: 1,
+		},
+		"non-idempotent requests are not retried": {
+			Method:           http.MethodPost,
+			Body:             "data",
+			Statuses:         []int{http.StatusServiceUnavailable},
+			ExpectedStatus:   http.StatusServiceUnavailable,
+			ExpectedRequests: 1,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests int64
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := atomic.AddInt64(&requests, 1)
+
+				w.WriteHeader(tc.Statuses[min(int(n), len(tc.Statuses))-1])
+			}))
+			defer srv.Close()
+
+			metrics := httputil.NewMetrics()
+
+			c := httputil.NewClient(
+				httputil.WithMaxRetries(2),
+				httputil.WithRetryDelay(time.Millisecond),
+				httputil.WithMetrics{Metrics: metrics},
+			)
+
+			req, err := http.NewRequestWithContext(context.Background(), tc.Method, srv.URL, strings.NewReader(tc.Body))
+			require.NoError(t, err)
+
+			res, err := c.Do(req)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+
+			assert.Equal(t, tc.ExpectedStatus, res.StatusCode)
+			assert.Equal(t, tc.ExpectedRequests, atomic.LoadInt64(&requests))
+
+			stats := metrics.Snapshot()[hostOf(t, srv.URL)]
+			assert.Equal(t, tc.ExpectedRequests, stats.Requests)
+			assert.Equal(t, tc.ExpectedRequests-1, stats.Retries)
+		})
+	}
+}
+
+func TestClientHooks(t *testing.T) {
+	t.Parallel()
+
+	var calls int64
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if atomic.AddInt64(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	var (
+		mu    sync.Mutex
+		infos []httputil.RequestInfo
+	)
+
+	c := httputil.NewClient(
+		httputil.WithRetryDelay(time.Millisecond),
+		httputil.WithMetrics{Metrics: httputil.NewMetrics()},
+		httputil.WithHooks{func(_ context.Context, info httputil.RequestInfo) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			infos = append(infos, info)
+		}},
+	)
+
+	res, err := c.Get(srv.URL)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+
+	require.Len(t, infos, 2)
+
+	for i, info := range infos {
+		assert.Equal(t, i, info.Attempt)
+		assert.Equal(t, http.MethodGet, info.Method)
+		assert.Equal(t, hostOf(t, srv.URL), info.Host)
+	}
+
+	assert.Equal(t, http.StatusServiceUnavailable, infos[0].StatusCode)
+	assert.Equal(t, http.StatusOK, infos[1].StatusCode)
+}
+
+func TestClientRateLimit(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := httputil.NewClient(
+		httputil.WithRateLimit{Limit: rate.Every(50 * time.Millisecond), Burst: 1},
+		httputil.WithMetrics{Metrics: httputil.NewMetrics()},
+	)
+
+	start := time.Now()
+
+	for i := 0; i < 3; i++ {
+		res, err := c.Get(srv.URL)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+	}
+
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestClientCancelledContextIsNotRetried(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := httputil.NewClient(
+		httputil.WithRetryDelay(time.Hour),
+		httputil.WithMetrics{Metrics: httputil.NewMetrics()},
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+
+	_, err = c.Do(req)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func hostOf(t *testing.T, rawURL string) string {
+	t.Helper()
+
+	u, err := url.Parse(rawURL)
+	require.NoError(t, err)
+
+	return u.Host
+}
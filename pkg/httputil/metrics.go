@@ -0,0 +1,70 @@
+package httputil
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultMetrics records the metrics of all clients
+// which are not configured using WithMetrics.
+var DefaultMetrics = NewMetrics()
+
+// NewMetrics returns an empty Metrics instance.
+func NewMetrics() *Metrics {
+	return &Metrics{hosts: make(map[string]HostStats)}
+}
+
+// Metrics accumulates request statistics per host.
+type Metrics struct {
+	mu    sync.Mutex
+	hosts map[string]HostStats
+}
+
+// HostStats are the statistics of all requests sent to a single host.
+type HostStats struct {
+	// Requests counts every attempt including retries.
+	Requests int64
+	// Errors counts attempts failing without a response
+	// or with a response status of 500 and higher.
+	Errors  int64
+	Retries int64
+	// Duration is the total time spent waiting for responses.
+	Duration time.Duration
+}
+
+// Snapshot returns a copy of the statistics of every host.
+func (m *Metrics) Snapshot() map[string]HostStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	res := make(map[string]HostStats, len(m.hosts))
+	for host, stats := range m.hosts {
+		res[host] = stats
+	}
+
+	return res
+}
+
+func (m *Metrics) record(info RequestInfo) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := m.hosts[info.Host]
+	stats.Requests++
+	stats.Duration += info.Duration
+
+	if info.Err != nil || info.StatusCode >= 500 {
+		stats.Errors++
+	}
+
+	m.hosts[info.Host] = stats
+}
+
+func (m *Metrics) recordRetry(host string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := m.hosts[host]
+	stats.Retries++
+	m.hosts[host] = stats
+}
@@ -0,0 +1,139 @@
+package httputil
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+type ClientConfig struct {
+	// Transport is the base transport requests are sent through.
+	// A clone of http.DefaultTransport is used if unset.
+	Transport http.RoundTripper
+	// Timeout limits the duration of a request including retries.
+	Timeout time.Duration
+	// MaxRetries is the number of times a failed request is retried.
+	MaxRetries int
+	// RetryDelay is the delay before the first retry which
+	// doubles for every subsequent retry.
+	RetryDelay time.Duration
+	// RateLimit is the number of requests per second allowed
+	// for each host. Zero disables rate limiting.
+	RateLimit rate.Limit
+	// Burst is the number of requests which may exceed the RateLimit.
+	Burst int
+	// Proxy selects the proxy for requests sent through the default
+	// transport. Proxies are read from the environment if unset.
+	Proxy   ProxyFunc
+	Hooks   []Hook
+	Metrics *Metrics
+}
+
+// ProxyFunc returns the proxy URL to use for a request.
+type ProxyFunc func(*http.Request) (*url.URL, error)
+
+func (c *ClientConfig) Option(opts ...ClientOption) {
+	for _, opt := range opts {
+		opt.ConfigureClient(c)
+	}
+}
+
+const (
+	defaultMaxRetries = 3
+	defaultRetryDelay = 500 * time.Millisecond
+)
+
+func (c *ClientConfig) Default() {
+	if c.MaxRetries < 0 {
+		c.MaxRetries = 0
+	} else if c.MaxRetries == 0 {
+		c.MaxRetries = defaultMaxRetries
+	}
+
+	if c.RetryDelay == 0 {
+		c.RetryDelay = defaultRetryDelay
+	}
+
+	if c.RateLimit > 0 && c.Burst < 1 {
+		c.Burst = 1
+	}
+
+	if c.Metrics == nil {
+		c.Metrics = DefaultMetrics
+	}
+}
+
+type ClientOption interface {
+	ConfigureClient(*ClientConfig)
+}
+
+// WithTransport applies the given base transport.
+type WithTransport struct{ http.RoundTripper }
+
+func (w WithTransport) ConfigureClient(c *ClientConfig) {
+	c.Transport = w.RoundTripper
+}
+
+// WithTimeout limits the duration of each request including retries.
+type WithTimeout time.Duration
+
+func (w WithTimeout) ConfigureClient(c *ClientConfig) {
+	c.Timeout = time.Duration(w)
+}
+
+// WithMaxRetries applies the number of retries for failed requests.
+// Negative values disable retries.
+type WithMaxRetries int
+
+func (w WithMaxRetries) ConfigureClient(c *ClientConfig) {
+	c.MaxRetries = int(w)
+}
+
+// WithRetryDelay applies the delay before the first retry.
+type WithRetryDelay time.Duration
+
+func (w WithRetryDelay) ConfigureClient(c *ClientConfig) {
+	c.RetryDelay = time.Duration(w)
+}
+
+// WithRateLimit limits the number of requests per second to each host.
+type WithRateLimit struct {
+	Limit rate.Limit
+	Burst int
+}
+
+func (w WithRateLimit) ConfigureClient(c *ClientConfig) {
+	c.RateLimit = w.Limit
+	c.Burst = w.Burst
+}
+
+// WithProxy sends all requests through the proxy at the given URL.
+type WithProxy string
+
+func (w WithProxy) ConfigureClient(c *ClientConfig) {
+	c.Proxy = func(*http.Request) (*url.URL, error) {
+		u, err := url.Parse(string(w))
+		if err != nil {
+			return nil, fmt.Errorf("parsing proxy URL: %w", err)
+		}
+
+		return u, nil
+	}
+}
+
+// WithHooks appends hooks which are called after every request attempt.
+type WithHooks []Hook
+
+func (w WithHooks) ConfigureClient(c *ClientConfig) {
+	c.Hooks = append(c.Hooks, w...)
+}
+
+// WithMetrics records metrics in the given Metrics instead of DefaultMetrics.
+type WithMetrics struct{ *Metrics }
+
+func (w WithMetrics) ConfigureClient(c *ClientConfig) {
+	c.Metrics = w.Metrics
+}
@@ -0,0 +1,197 @@
+package httputil
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// RequestInfo describes a single attempt of a request.
+type RequestInfo struct {
+	Method string
+	Host   string
+	URL    string
+	// Attempt is zero for the initial request and counts retries.
+	Attempt    int
+	StatusCode int
+	Err        error
+	Duration   time.Duration
+}
+
+// Hook is called after every request attempt, e.g. to trace requests.
+type Hook func(context.Context, RequestInfo)
+
+type attemptKey struct{}
+
+type instrumentedTransport struct {
+	next    http.RoundTripper
+	hooks   []Hook
+	metrics *Metrics
+}
+
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+
+	res, err := t.next.RoundTrip(req)
+
+	attempt, _ := req.Context().Value(attemptKey{}).(int)
+
+	info := RequestInfo{
+		Method:   req.Method,
+		Host:     req.URL.Host,
+		URL:      req.URL.Redacted(),
+		Attempt:  attempt,
+		Err:      err,
+		Duration: time.Since(start),
+	}
+
+	if res != nil {
+		info.StatusCode = res.StatusCode
+	}
+
+	t.metrics.record(info)
+
+	for _, hook := range t.hooks {
+		hook(req.Context(), info)
+	}
+
+	return res, err
+}
+
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	delay      time.Duration
+	metrics    *Metrics
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	delay := t.delay
+
+	for attempt := 0; ; attempt++ {
+		attemptReq, err := requestForAttempt(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := t.next.RoundTrip(attemptReq)
+		if attempt >= t.maxRetries || !isRetryable(req, res, err) {
+			return res, err
+		}
+
+		if res != nil {
+			drainBody(res)
+		}
+
+		t.metrics.recordRetry(req.URL.Host)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+	}
+}
+
+// requestForAttempt returns a copy of req with a fresh body and
+// the attempt number stored in its context for instrumentation.
+func requestForAttempt(req *http.Request, attempt int) (*http.Request, error) {
+	res := req.Clone(context.WithValue(req.Context(), attemptKey{}, attempt))
+
+	if attempt > 0 && req.Body != nil && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+
+		res.Body = body
+	}
+
+	return res, nil
+}
+
+// isRetryable reports whether a request failed transiently and is safe
+// to send again. Requests with bodies which can't be replayed and
+// cancelled requests are never retried.
+func isRetryable(req *http.Request, res *http.Response, err error) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(req.Method)
+	default:
+		return false
+	}
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func drainBody(res *http.Response) {
+	const maxDrain = 4096
+
+	_, _ = io.CopyN(io.Discard, res.Body, maxDrain)
+	_ = res.Body.Close()
+}
+
+func newRateLimitTransport(next http.RoundTripper, limit rate.Limit, burst int) *rateLimitTransport {
+	return &rateLimitTransport{
+		next:     next,
+		limit:    limit,
+		burst:    burst,
+		limiters: make(map[string]*rate.Limiter),
+	}
+}
+
+type rateLimitTransport struct {
+	next     http.RoundTripper
+	limit    rate.Limit
+	burst    int
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter(req.URL.Host).Wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	return t.next.RoundTrip(req)
+}
+
+func (t *rateLimitTransport) limiter(host string) *rate.Limiter {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	l, ok := t.limiters[host]
+	if !ok {
+		l = rate.NewLimiter(t.limit, t.burst)
+		t.limiters[host] = l
+	}
+
+	return l
+}
@@ -0,0 +1,18 @@
+package imagepolicy
+
+import "context"
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying verifier.
+func NewContext(ctx context.Context, verifier *Verifier) context.Context {
+	return context.WithValue(ctx, contextKey{}, verifier)
+}
+
+// FromContext returns the Verifier carried by ctx or
+// nil if no policy is enforced.
+func FromContext(ctx context.Context) *Verifier {
+	verifier, _ := ctx.Value(contextKey{}).(*Verifier)
+
+	return verifier
+}
@@ -0,0 +1,270 @@
+// Package imagepolicy enforces a signature policy on the images pulled by
+// mtcli. Policies use a subset of the format of containers-policy.json(5):
+//
+//	{
+//	  "default": [{"type": "insecureAcceptAnything"}],
+//	  "transports": {
+//	    "docker": {
+//	      "quay.io/osd-addons": [
+//	        {"type": "sigstoreSigned", "keyPath": "/etc/mtcli/cosign.pub"}
+//	      ],
+//	      "registry.example.com": [{"type": "reject"}]
+//	    }
+//	  },
+//	  "insecureRegistries": ["registry.local:5000"]
+//	}
+//
+// Only the 'docker' transport is considered. Scopes are matched from the
+// most to the least specific, i.e. repository, namespaces, host and
+// '*.domain' wildcards, before falling back to the default requirements.
+// Registries listed in 'insecureRegistries' are accessed without
+// verifying their TLS certificates.
+package imagepolicy
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	imageparser "github.com/novln/docker-parser"
+)
+
+// DockerTransport is the only transport considered by policies.
+const DockerTransport = "docker"
+
+type RequirementType string
+
+const (
+	// TypeInsecureAcceptAnything accepts any image.
+	TypeInsecureAcceptAnything RequirementType = "insecureAcceptAnything"
+	// TypeReject rejects every image.
+	TypeReject RequirementType = "reject"
+	// TypeSigstoreSigned requires images to carry a cosign
+	// signature created by one of the configured keys.
+	TypeSigstoreSigned RequirementType = "sigstoreSigned"
+)
+
+// Policy defines the requirements images must satisfy to be pulled.
+type Policy struct {
+	// Default applies to images not matched by any scope.
+	Default Requirements `json:"default"`
+	// Transports maps transport names to scopes and
+	// the requirements of images within those scopes.
+	Transports map[string]map[string]Requirements `json:"transports,omitempty"`
+	// InsecureRegistries are hosts ('host[:port]') accessed
+	// without verifying TLS certificates.
+	InsecureRegistries []string `json:"insecureRegistries,omitempty"`
+}
+
+// Requirements must all be satisfied by an image.
+type Requirements []Requirement
+
+type Requirement struct {
+	Type RequirementType `json:"type"`
+	// KeyPath is the path of a PEM encoded public key.
+	KeyPath string `json:"keyPath,omitempty"`
+	// KeyPaths are paths of PEM encoded public keys
+	// any of which may have signed the image.
+	KeyPaths []string `json:"keyPaths,omitempty"`
+	// KeyData is a base64 encoded PEM public key.
+	KeyData string `json:"keyData,omitempty"`
+}
+
+// Load reads and parses the policy at path.
+func Load(path string) (Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Policy{}, fmt.Errorf("reading policy: %w", err)
+	}
+
+	policy, err := Parse(data)
+	if err != nil {
+		return Policy{}, fmt.Errorf("parsing policy %q: %w", path, err)
+	}
+
+	return policy, nil
+}
+
+// Parse decodes and validates a JSON encoded policy.
+func Parse(data []byte) (Policy, error) {
+	var policy Policy
+
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return Policy{}, err
+	}
+
+	if err := policy.Validate(); err != nil {
+		return Policy{}, err
+	}
+
+	return policy, nil
+}
+
+// Validate returns an error if the policy does not define default
+// requirements or contains an invalid requirement.
+func (p Policy) Validate() error {
+	if len(p.Default) == 0 {
+		return errors.New("default requirements must not be empty")
+	}
+
+	if err := p.Default.validate(); err != nil {
+		return fmt.Errorf("default: %w", err)
+	}
+
+	for scope, reqs := range p.Transports[DockerTransport] {
+		if scope == "" {
+			return errors.New("scopes must not be empty")
+		}
+
+		if len(reqs) == 0 {
+			return fmt.Errorf("scope %q: requirements must not be empty", scope)
+		}
+
+		if err := reqs.validate(); err != nil {
+			return fmt.Errorf("scope %q: %w", scope, err)
+		}
+	}
+
+	return nil
+}
+
+func (rs Requirements) validate() error {
+	for _, r := range rs {
+		if err := r.validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r Requirement) validate() error {
+	switch r.Type {
+	case TypeInsecureAcceptAnything, TypeReject:
+		return nil
+	case TypeSigstoreSigned:
+		var sources int
+
+		for _, set := range []bool{r.KeyPath != "", len(r.KeyPaths) > 0, r.KeyData != ""} {
+			if set {
+				sources++
+			}
+		}
+
+		if sources != 1 {
+			return fmt.Errorf("%q requires exactly one of 'keyPath', 'keyPaths' or 'keyData'", r.Type)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("unsupported requirement type %q", r.Type)
+	}
+}
+
+// keys returns the PEM encoded public keys of the requirement.
+func (r Requirement) keys() ([][]byte, error) {
+	if r.KeyData != "" {
+		data, err := base64.StdEncoding.DecodeString(r.KeyData)
+		if err != nil {
+			return nil, fmt.Errorf("decoding key data: %w", err)
+		}
+
+		return [][]byte{data}, nil
+	}
+
+	paths := r.KeyPaths
+	if r.KeyPath != "" {
+		paths = []string{r.KeyPath}
+	}
+
+	res := make([][]byte, 0, len(paths))
+
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return nil, fmt.Errorf("reading key: %w", err)
+		}
+
+		res = append(res, data)
+	}
+
+	return res, nil
+}
+
+// RequirementsFor returns the requirements of the most specific scope
+// matching image together with that scope. The scope is empty if the
+// default requirements apply.
+func (p Policy) RequirementsFor(image string) (Requirements, string, error) {
+	ref, err := imageparser.Parse(image)
+	if err != nil {
+		return nil, "", fmt.Errorf("parsing image %q: %w", image, err)
+	}
+
+	scopes := p.Transports[DockerTransport]
+
+	for _, scope := range candidateScopes(ref.Repository()) {
+		if reqs, ok := scopes[scope]; ok {
+			return reqs, scope, nil
+		}
+	}
+
+	return p.Default, "", nil
+}
+
+// candidateScopes returns the scopes matching repo ordered from the most
+// to the least specific: the repository, its parent namespaces, the host
+// and wildcards of the host's parent domains.
+func candidateScopes(repo string) []string {
+	var res []string
+
+	for s := repo; ; {
+		res = append(res, s)
+
+		i := strings.LastIndex(s, "/")
+		if i < 0 {
+			break
+		}
+
+		s = s[:i]
+	}
+
+	host := res[len(res)-1]
+	host, _, _ = strings.Cut(host, ":")
+
+	for d := host; ; {
+		i := strings.Index(d, ".")
+		if i < 0 {
+			break
+		}
+
+		d = d[i+1:]
+		res = append(res, "*."+d)
+	}
+
+	return res
+}
+
+// Insecure returns true if the registry hosting image
+// is listed as an insecure registry.
+func (p Policy) Insecure(image string) bool {
+	ref, err := imageparser.Parse(image)
+	if err != nil {
+		return false
+	}
+
+	return p.InsecureRegistry(ref.Registry())
+}
+
+// InsecureRegistry returns true if host is listed as an insecure registry.
+func (p Policy) InsecureRegistry(host string) bool {
+	for _, r := range p.InsecureRegistries {
+		if r == host {
+			return true
+		}
+	}
+
+	return false
+}
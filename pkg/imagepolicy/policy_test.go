@@ -0,0 +1,167 @@
+package imagepolicy_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/imagepolicy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Data        string
+		ExpectError bool
+	}{
+		"accept anything": {
+			Data: `{"default":[{"type":"insecureAcceptAnything"}]}`,
+		},
+		"scoped requirements": {
+			Data: `{
+				"default": [{"type": "reject"}],
+				"transports": {
+					"docker": {"quay.io/osd-addons": [{"type": "sigstoreSigned", "keyPath": "/key.pub"}]},
+					"docker-daemon": {"": [{"type": "signedBy"}]}
+				},
+				"insecureRegistries": ["registry.local:5000"]
+			}`,
+		},
+		"missing default": {
+			Data:        `{"transports":{"docker":{"quay.io":[{"type":"reject"}]}}}`,
+			ExpectError: true,
+		},
+		"unsupported type": {
+			Data:        `{"default":[{"type":"signedBy"}]}`,
+			ExpectError: true,
+		},
+		"empty scope requirements": {
+			Data:        `{"default":[{"type":"reject"}],"transports":{"docker":{"quay.io":[]}}}`,
+			ExpectError: true,
+		},
+		"sigstoreSigned without key": {
+			Data:        `{"default":[{"type":"sigstoreSigned"}]}`,
+			ExpectError: true,
+		},
+		"sigstoreSigned with multiple key sources": {
+			Data:        `{"default":[{"type":"sigstoreSigned","keyPath":"/a.pub","keyData":"YQ=="}]}`,
+			ExpectError: true,
+		},
+		"invalid json": {
+			Data:        `{`,
+			ExpectError: true,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := imagepolicy.Parse([]byte(tc.Data))
+			if tc.ExpectError {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "policy.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"default":[{"type":"reject"}]}`), 0o600))
+
+	policy, err := imagepolicy.Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, imagepolicy.TypeReject, policy.Default[0].Type)
+
+	_, err = imagepolicy.Load(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestPolicyRequirementsFor(t *testing.T) {
+	t.Parallel()
+
+	policy := imagepolicy.Policy{
+		Default: imagepolicy.Requirements{{Type: imagepolicy.TypeInsecureAcceptAnything}},
+		Transports: map[string]map[string]imagepolicy.Requirements{
+			imagepolicy.DockerTransport: {
+				"quay.io":                       {{Type: imagepolicy.TypeReject}},
+				"quay.io/osd-addons":            {{Type: imagepolicy.TypeSigstoreSigned, KeyPath: "/addons.pub"}},
+				"quay.io/osd-addons/reference":  {{Type: imagepolicy.TypeSigstoreSigned, KeyPath: "/reference.pub"}},
+				"*.example.com":                 {{Type: imagepolicy.TypeReject}},
+				"registry.local:5000/namespace": {{Type: imagepolicy.TypeInsecureAcceptAnything}},
+			},
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		Image         string
+		ExpectedScope string
+	}{
+		"repository": {
+			Image:         "quay.io/osd-addons/reference:v1.0.0",
+			ExpectedScope: "quay.io/osd-addons/reference",
+		},
+		"repository by digest": {
+			Image:         "quay.io/osd-addons/reference@sha256:d6c39b2f3e39e3ba2ab3a3c3b0a2e32b4bde7b2d1b4c6a33e86e4ffe2fd8d9b0",
+			ExpectedScope: "quay.io/osd-addons/reference",
+		},
+		"namespace": {
+			Image:         "quay.io/osd-addons/other:v1.0.0",
+			ExpectedScope: "quay.io/osd-addons",
+		},
+		"host": {
+			Image:         "quay.io/other/image:latest",
+			ExpectedScope: "quay.io",
+		},
+		"wildcard": {
+			Image:         "registry.example.com/image:latest",
+			ExpectedScope: "*.example.com",
+		},
+		"host with port": {
+			Image:         "registry.local:5000/namespace/image:latest",
+			ExpectedScope: "registry.local:5000/namespace",
+		},
+		"default": {
+			Image:         "registry.redhat.io/image:latest",
+			ExpectedScope: "",
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			reqs, scope, err := policy.RequirementsFor(tc.Image)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.ExpectedScope, scope)
+
+			if tc.ExpectedScope == "" {
+				assert.Equal(t, policy.Default, reqs)
+			} else {
+				assert.Equal(t, policy.Transports[imagepolicy.DockerTransport][tc.ExpectedScope], reqs)
+			}
+		})
+	}
+}
+
+func TestPolicyInsecure(t *testing.T) {
+	t.Parallel()
+
+	policy := imagepolicy.Policy{
+		InsecureRegistries: []string{"registry.local:5000"},
+	}
+
+	assert.True(t, policy.Insecure("registry.local:5000/image:v1"))
+	assert.False(t, policy.Insecure("registry.local/image:v1"))
+	assert.False(t, policy.Insecure("quay.io/image:v1"))
+}
@@ -0,0 +1,65 @@
+package imagepolicy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/registry"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// SignatureAnnotation holds the base64 encoded signature
+	// of a layer in a cosign signature manifest.
+	SignatureAnnotation = "dev.cosignproject.cosign/signature"
+	// SimpleSigningMediaType is the media type of cosign signature payloads.
+	SimpleSigningMediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+)
+
+// SignatureTag returns the tag under which cosign stores the
+// signatures of the manifest with the given digest.
+func SignatureTag(dgst digest.Digest) string {
+	return fmt.Sprintf("%s-%s.sig", dgst.Algorithm(), dgst.Encoded())
+}
+
+// signatures returns the cosign signatures stored for dgst. No
+// signatures and no error are returned if the image is unsigned.
+func signatures(ctx context.Context, repo *registry.Repository, dgst digest.Digest) ([]signature, error) {
+	data, err := repo.Manifest(ctx, SignatureTag(dgst))
+	if errors.Is(err, registry.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("decoding signature manifest: %w", err)
+	}
+
+	var sigs []signature
+
+	for _, layer := range manifest.Layers {
+		encoded, ok := layer.Annotations[SignatureAnnotation]
+		if !ok {
+			continue
+		}
+
+		sig, err := decodeSignature(encoded)
+		if err != nil || layer.Digest.Validate() != nil {
+			continue
+		}
+
+		payload, err := repo.Blob(ctx, layer.Digest)
+		if err != nil {
+			return nil, fmt.Errorf("retrieving signature payload: %w", err)
+		}
+
+		sigs = append(sigs, signature{payload: payload, sig: sig})
+	}
+
+	return sigs, nil
+}
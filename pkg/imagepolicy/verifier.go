@@ -0,0 +1,296 @@
+package imagepolicy
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/httputil"
+	"github.com/mt-sre/addon-metadata-operator/pkg/registry"
+	imageparser "github.com/novln/docker-parser"
+	"github.com/opencontainers/go-digest"
+)
+
+var (
+	ErrRejected = errors.New("image rejected by policy")
+	ErrUnsigned = errors.New("image has no signature trusted by policy")
+)
+
+// IsViolation returns true if err was caused by
+// an image not satisfying the policy.
+func IsViolation(err error) bool {
+	return errors.Is(err, ErrRejected) || errors.Is(err, ErrUnsigned)
+}
+
+// NewVerifier returns a Verifier enforcing policy configured with a
+// variadic slice of options. An error is returned if the policy is
+// invalid or any of its keys cannot be loaded.
+func NewVerifier(policy Policy, opts ...VerifierOption) (*Verifier, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("validating policy: %w", err)
+	}
+
+	var cfg VerifierConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	v := &Verifier{
+		cfg:    cfg,
+		policy: policy,
+		keys:   make(map[string][]crypto.PublicKey),
+	}
+
+	add := func(scope string, reqs Requirements) error {
+		for _, r := range reqs {
+			if r.Type != TypeSigstoreSigned {
+				continue
+			}
+
+			keys, err := parseKeys(r)
+			if err != nil {
+				return fmt.Errorf("loading keys of scope %q: %w", scope, err)
+			}
+
+			v.keys[scope] = append(v.keys[scope], keys...)
+		}
+
+		return nil
+	}
+
+	if err := add("", policy.Default); err != nil {
+		return nil, err
+	}
+
+	for scope, reqs := range policy.Transports[DockerTransport] {
+		if err := add(scope, reqs); err != nil {
+			return nil, err
+		}
+	}
+
+	return v, nil
+}
+
+// Verifier checks images against a Policy.
+type Verifier struct {
+	cfg    VerifierConfig
+	policy Policy
+	// keys maps scopes to the trusted keys of their
+	// 'sigstoreSigned' requirements.
+	keys map[string][]crypto.PublicKey
+}
+
+// Policy returns the policy enforced by the verifier.
+func (v *Verifier) Policy() Policy {
+	return v.policy
+}
+
+// Insecure returns true if the registry hosting
+// image must be accessed without verifying TLS.
+func (v *Verifier) Insecure(image string) bool {
+	return v.policy.Insecure(image)
+}
+
+// Verify returns nil if image satisfies the policy. ErrRejected or
+// ErrUnsigned are wrapped by the returned error if the image violates
+// the policy. Any other error means the image could not be checked.
+func (v *Verifier) Verify(ctx context.Context, image string) error {
+	reqs, scope, err := v.policy.RequirementsFor(image)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range reqs {
+		switch r.Type {
+		case TypeInsecureAcceptAnything:
+			continue
+		case TypeReject:
+			return fmt.Errorf("%w: %s", ErrRejected, image)
+		case TypeSigstoreSigned:
+			if err := v.verifySignature(ctx, image, v.keys[scope]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (v *Verifier) verifySignature(ctx context.Context, image string, keys []crypto.PublicKey) error {
+	ref, err := imageparser.Parse(image)
+	if err != nil {
+		return fmt.Errorf("parsing image %q: %w", image, err)
+	}
+
+	repo, err := registry.NewRepository(v.clientFor(ref.Registry()), image)
+	if err != nil {
+		return err
+	}
+
+	dgst, err := repo.Resolve(ctx, repo.Reference())
+	if err != nil {
+		return fmt.Errorf("resolving digest of %q: %w", image, err)
+	}
+
+	sigs, err := signatures(ctx, repo, dgst)
+	if err != nil {
+		return fmt.Errorf("retrieving signatures of %q: %w", image, err)
+	}
+
+	for _, sig := range sigs {
+		if sig.verify(keys, dgst) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s@%s", ErrUnsigned, repo.Name(), dgst)
+}
+
+func (v *Verifier) clientFor(host string) *http.Client {
+	if v.policy.InsecureRegistry(host) {
+		return v.cfg.InsecureClient
+	}
+
+	return v.cfg.Client
+}
+
+// signature is a cosign 'simple signing' signature.
+type signature struct {
+	payload []byte
+	sig     []byte
+}
+
+func (s signature) verify(keys []crypto.PublicKey, dgst digest.Digest) bool {
+	var payload struct {
+		Critical struct {
+			Image struct {
+				DockerManifestDigest string `json:"docker-manifest-digest"`
+			} `json:"image"`
+		} `json:"critical"`
+	}
+
+	if err := json.Unmarshal(s.payload, &payload); err != nil {
+		return false
+	}
+
+	// the payload must be bound to the verified image, otherwise
+	// the signature of another image could be replayed
+	if payload.Critical.Image.DockerManifestDigest != dgst.String() {
+		return false
+	}
+
+	for _, key := range keys {
+		if verifySignature(key, s.payload, s.sig) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func verifySignature(key crypto.PublicKey, payload, sig []byte) bool {
+	sum := sha256.Sum256(payload)
+
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(k, sum[:], sig)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, sum[:], sig) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(k, payload, sig)
+	default:
+		return false
+	}
+}
+
+func parseKeys(r Requirement) ([]crypto.PublicKey, error) {
+	data, err := r.keys()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]crypto.PublicKey, 0, len(data))
+
+	for _, d := range data {
+		key, err := ParsePublicKey(d)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, key)
+	}
+
+	return res, nil
+}
+
+// ParsePublicKey parses a PEM encoded PKIX public key
+// as written by 'cosign generate-key-pair'.
+func ParsePublicKey(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing public key: %w", err)
+	}
+
+	return key, nil
+}
+
+func decodeSignature(s string) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(s)
+}
+
+type VerifierConfig struct {
+	// Client is used to access registries.
+	Client *http.Client
+	// InsecureClient is used to access insecure registries.
+	InsecureClient *http.Client
+}
+
+func (c *VerifierConfig) Option(opts ...VerifierOption) {
+	for _, opt := range opts {
+		opt.ConfigureVerifier(c)
+	}
+}
+
+func (c *VerifierConfig) Default() {
+	if c.Client == nil {
+		c.Client = httputil.NewClient()
+	}
+
+	if c.InsecureClient == nil {
+		tp := http.DefaultTransport.(*http.Transport).Clone()
+		tp.TLSClientConfig = &tls.Config{
+			InsecureSkipVerify: true, //nolint:gosec // registry is configured as insecure
+		}
+
+		c.InsecureClient = httputil.NewClient(httputil.WithTransport{RoundTripper: tp})
+	}
+}
+
+type VerifierOption interface {
+	ConfigureVerifier(*VerifierConfig)
+}
+
+// WithHTTPClient applies the client used to access all registries
+// including insecure ones.
+type WithHTTPClient struct{ *http.Client }
+
+func (w WithHTTPClient) ConfigureVerifier(c *VerifierConfig) {
+	c.Client = w.Client
+	c.InsecureClient = w.Client
+}
@@ -0,0 +1,181 @@
+package imagepolicy_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/imagepolicy"
+	"github.com/mt-sre/addon-metadata-operator/pkg/registrytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifierVerify(t *testing.T) {
+	t.Parallel()
+
+	srv := registrytest.NewServer()
+	t.Cleanup(srv.Close)
+
+	trusted := newKey(t)
+	untrusted := newKey(t)
+
+	signed, err := srv.AddImage("addons/signed:v1", registrytest.Image{})
+	require.NoError(t, err)
+	require.NoError(t, srv.Sign("addons/signed:v1", trusted))
+
+	unsigned, err := srv.AddImage("addons/unsigned:v1", registrytest.Image{
+		Labels: map[string]string{"unsigned": "true"},
+	})
+	require.NoError(t, err)
+
+	foreign, err := srv.AddImage("addons/foreign:v1", registrytest.Image{
+		Labels: map[string]string{"foreign": "true"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, srv.Sign("addons/foreign:v1", untrusted))
+
+	multi, err := srv.AddImage("addons/multi:v1", registrytest.Image{
+		Labels: map[string]string{"multi": "true"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, srv.Sign("addons/multi:v1", untrusted))
+	require.NoError(t, srv.Sign("addons/multi:v1", trusted))
+
+	rejected, err := srv.AddImage("rejected/image:v1", registrytest.Image{})
+	require.NoError(t, err)
+
+	accepted, err := srv.AddImage("other/image:v1", registrytest.Image{})
+	require.NoError(t, err)
+
+	policy := imagepolicy.Policy{
+		Default: imagepolicy.Requirements{{Type: imagepolicy.TypeInsecureAcceptAnything}},
+		Transports: map[string]map[string]imagepolicy.Requirements{
+			imagepolicy.DockerTransport: {
+				srv.Host() + "/addons": {{
+					Type:    imagepolicy.TypeSigstoreSigned,
+					KeyData: base64.StdEncoding.EncodeToString(publicKeyPEM(t, trusted)),
+				}},
+				srv.Host() + "/rejected": {{Type: imagepolicy.TypeReject}},
+			},
+		},
+	}
+
+	verifier, err := imagepolicy.NewVerifier(policy, imagepolicy.WithHTTPClient{Client: srv.Client()})
+	require.NoError(t, err)
+
+	for name, tc := range map[string]struct {
+		Image       string
+		ExpectedErr error
+	}{
+		"signed": {
+			Image: signed,
+		},
+		"signed by digest": {
+			Image: digestRef(t, srv, "addons/signed:v1"),
+		},
+		"unsigned": {
+			Image:       unsigned,
+			ExpectedErr: imagepolicy.ErrUnsigned,
+		},
+		"signed by untrusted key": {
+			Image:       foreign,
+			ExpectedErr: imagepolicy.ErrUnsigned,
+		},
+		"signed by multiple keys": {
+			Image: multi,
+		},
+		"rejected": {
+			Image:       rejected,
+			ExpectedErr: imagepolicy.ErrRejected,
+		},
+		"accepted by default": {
+			Image: accepted,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := verifier.Verify(context.Background(), tc.Image)
+			if tc.ExpectedErr == nil {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			require.ErrorIs(t, err, tc.ExpectedErr)
+			assert.True(t, imagepolicy.IsViolation(err))
+		})
+	}
+}
+
+func TestVerifierVerifyMissingImage(t *testing.T) {
+	t.Parallel()
+
+	srv := registrytest.NewServer()
+	t.Cleanup(srv.Close)
+
+	key := newKey(t)
+
+	verifier, err := imagepolicy.NewVerifier(imagepolicy.Policy{
+		Default: imagepolicy.Requirements{{
+			Type:    imagepolicy.TypeSigstoreSigned,
+			KeyData: base64.StdEncoding.EncodeToString(publicKeyPEM(t, key)),
+		}},
+	}, imagepolicy.WithHTTPClient{Client: srv.Client()})
+	require.NoError(t, err)
+
+	err = verifier.Verify(context.Background(), srv.Reference("missing/image:v1"))
+	require.Error(t, err)
+	assert.False(t, imagepolicy.IsViolation(err))
+}
+
+func TestNewVerifierInvalidKey(t *testing.T) {
+	t.Parallel()
+
+	_, err := imagepolicy.NewVerifier(imagepolicy.Policy{
+		Default: imagepolicy.Requirements{{
+			Type:    imagepolicy.TypeSigstoreSigned,
+			KeyData: base64.StdEncoding.EncodeToString([]byte("not a key")),
+		}},
+	})
+	assert.Error(t, err)
+}
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	return key
+}
+
+func publicKeyPEM(t *testing.T, key *ecdsa.PrivateKey) []byte {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func digestRef(t *testing.T, srv *registrytest.Server, name string) string {
+	t.Helper()
+
+	repo, tag, _ := strings.Cut(name, ":")
+
+	res, err := srv.Client().Head("https://" + srv.Host() + "/v2/" + repo + "/manifests/" + tag)
+	require.NoError(t, err)
+	res.Body.Close()
+
+	return srv.Reference(repo + "@" + res.Header.Get("Docker-Content-Digest"))
+}
@@ -0,0 +1,207 @@
+package metadata
+
+import (
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrAliasCycle     = errors.New("alias refers to an enclosing anchor")
+	ErrAliasExpansion = errors.New("alias expansion exceeds limit")
+	ErrInvalidMerge   = errors.New("merge key value must be a mapping or a sequence of mappings")
+)
+
+// maxExpandedNodes bounds the number of nodes created while expanding
+// aliases which protects against exponential 'billion laughs' documents.
+const maxExpandedNodes = 100000
+
+// expandDocuments replaces every document containing aliases or merge
+// keys with an equivalent document without them. It returns 'true' if
+// any document was changed.
+func expandDocuments(docs []metaDocument) (bool, error) {
+	var changed bool
+
+	for i, doc := range docs {
+		if !hasAliases(doc.Node) {
+			continue
+		}
+
+		e := aliasExpander{
+			file:      doc.File,
+			expanding: make(map[*yaml.Node]bool),
+		}
+
+		expanded, err := e.expand(doc.Node)
+		if err != nil {
+			return false, err
+		}
+
+		docs[i].Node = expanded
+		changed = true
+	}
+
+	return changed, nil
+}
+
+// expandImageSet returns data unchanged unless it contains aliases
+// in which case the expanded document is re-encoded.
+func expandImageSet(file string, data []byte) ([]byte, error) {
+	docs, err := splitDocuments(file, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// only the first document of an imageset is decoded
+	if len(docs) == 0 {
+		return data, nil
+	}
+
+	docs = docs[:1]
+
+	if expanded, err := expandDocuments(docs); err != nil || !expanded {
+		return data, err
+	}
+
+	return yaml.Marshal(docs[0].Node)
+}
+
+// hasAliases reports whether node contains any alias or anchor.
+// Anchors without aliases are reported as well so that they are
+// dropped when a document is re-encoded.
+func hasAliases(node *yaml.Node) bool {
+	if node.Kind == yaml.AliasNode || node.Anchor != "" {
+		return true
+	}
+
+	for _, child := range node.Content {
+		if hasAliases(child) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type aliasExpander struct {
+	file      string
+	expanding map[*yaml.Node]bool
+	created   int
+}
+
+func (e *aliasExpander) expand(node *yaml.Node) (*yaml.Node, error) {
+	if node.Kind == yaml.AliasNode {
+		if node.Alias == nil {
+			return nil, fmt.Errorf("%s:%d:%d: unknown anchor %q", e.file, node.Line, node.Column, node.Value)
+		}
+
+		if e.expanding[node.Alias] {
+			return nil, fmt.Errorf("%s:%d:%d: %w %q", e.file, node.Line, node.Column, ErrAliasCycle, node.Value)
+		}
+
+		return e.expand(node.Alias)
+	}
+
+	if e.created++; e.created > maxExpandedNodes {
+		return nil, fmt.Errorf("%s:%d:%d: %w of %d nodes", e.file, node.Line, node.Column, ErrAliasExpansion, maxExpandedNodes)
+	}
+
+	e.expanding[node] = true
+	defer delete(e.expanding, node)
+
+	res := *node
+	res.Anchor = ""
+	res.Content = nil
+
+	if node.Kind == yaml.MappingNode {
+		return e.expandMapping(node, &res)
+	}
+
+	for _, child := range node.Content {
+		expanded, err := e.expand(child)
+		if err != nil {
+			return nil, err
+		}
+
+		res.Content = append(res.Content, expanded)
+	}
+
+	return &res, nil
+}
+
+// expandMapping copies the entries of a mapping resolving merge keys
+// ('<<') such that explicitly defined keys take precedence over merged
+// ones and earlier merged mappings take precedence over later ones.
+func (e *aliasExpander) expandMapping(node, res *yaml.Node) (*yaml.Node, error) {
+	var merged []*yaml.Node
+
+	defined := make(map[string]bool)
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, val := node.Content[i], node.Content[i+1]
+
+		if isMergeKey(key) {
+			sources, err := e.mergeSources(val)
+			if err != nil {
+				return nil, err
+			}
+
+			merged = append(merged, sources...)
+
+			continue
+		}
+
+		expandedKey, err := e.expand(key)
+		if err != nil {
+			return nil, err
+		}
+
+		expandedVal, err := e.expand(val)
+		if err != nil {
+			return nil, err
+		}
+
+		defined[key.Value] = true
+		res.Content = append(res.Content, expandedKey, expandedVal)
+	}
+
+	for _, src := range merged {
+		for i := 0; i+1 < len(src.Content); i += 2 {
+			if defined[src.Content[i].Value] {
+				continue
+			}
+
+			defined[src.Content[i].Value] = true
+			res.Content = append(res.Content, src.Content[i], src.Content[i+1])
+		}
+	}
+
+	return res, nil
+}
+
+func (e *aliasExpander) mergeSources(val *yaml.Node) ([]*yaml.Node, error) {
+	expanded, err := e.expand(val)
+	if err != nil {
+		return nil, err
+	}
+
+	switch expanded.Kind {
+	case yaml.MappingNode:
+		return []*yaml.Node{expanded}, nil
+	case yaml.SequenceNode:
+		for _, item := range expanded.Content {
+			if item.Kind != yaml.MappingNode {
+				return nil, fmt.Errorf("%s:%d:%d: %w", e.file, item.Line, item.Column, ErrInvalidMerge)
+			}
+		}
+
+		return expanded.Content, nil
+	default:
+		return nil, fmt.Errorf("%s:%d:%d: %w", e.file, val.Line, val.Column, ErrInvalidMerge)
+	}
+}
+
+func isMergeKey(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.Value == "<<" && (node.Tag == "!!merge" || node.Tag == "")
+}
@@ -0,0 +1,139 @@
+package metadata
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/blang/semver/v4"
+)
+
+var (
+	ErrImageSetExists   = errors.New("imageset already exists")
+	ErrUnknownIncrement = errors.New("unknown version increment")
+)
+
+// Increment is the part of a semantic version which is bumped.
+type Increment string
+
+const (
+	IncrementMajor Increment = "major"
+	IncrementMinor Increment = "minor"
+	IncrementPatch Increment = "patch"
+)
+
+// Next returns the version following v for the increment.
+func (i Increment) Next(v semver.Version) (semver.Version, error) {
+	next := semver.Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+
+	switch i {
+	case IncrementMajor:
+		next.Major++
+		next.Minor, next.Patch = 0, 0
+	case IncrementMinor:
+		next.Minor++
+		next.Patch = 0
+	case IncrementPatch:
+		next.Patch++
+	default:
+		return semver.Version{}, fmt.Errorf("%w %q: must be one of %s, %s or %s",
+			ErrUnknownIncrement, i, IncrementMajor, IncrementMinor, IncrementPatch,
+		)
+	}
+
+	return next, nil
+}
+
+// BumpResult describes the changes made by BumpImageSet.
+type BumpResult struct {
+	// Version is the version of the created imageset.
+	Version string
+	// ImageSetPath is the path of the created imageset file.
+	ImageSetPath string
+	// MetadataPath is the path of the updated metadata file and is
+	// empty if 'addonImageSetVersion' did not need to be updated.
+	MetadataPath string
+}
+
+// BumpImageSet creates a copy of the imageset with the highest version of
+// a local addon directory using the next version and the configured
+// index image. If 'addonImageSetVersion' is pinned in 'addon.yaml' it is
+// updated to the new version. Both files keep their formatting and comments.
+func BumpImageSet(addonDir string, opts ...BumpOption) (BumpResult, error) {
+	var cfg BumpConfig
+
+	cfg.Option(opts...)
+	cfg.Default(addonDir)
+
+	imageSetDir := filepath.Join(addonDir, "addonimagesets", cfg.Env)
+
+	latest, err := SemverVersionStrategy{}.LatestImageSet(os.DirFS(imageSetDir), ".", nil)
+	if err != nil {
+		return BumpResult{}, fmt.Errorf("resolving latest imageset: %w", err)
+	}
+
+	current, _ := parseNameVersion(latest[:len(latest)-len(filepath.Ext(latest))])
+
+	next, err := cfg.Increment.Next(current)
+	if err != nil {
+		return BumpResult{}, err
+	}
+
+	res := BumpResult{
+		Version:      next.String(),
+		ImageSetPath: filepath.Join(imageSetDir, fmt.Sprintf("%s.v%s.yaml", cfg.AddonName, next)),
+	}
+
+	if _, err := os.Stat(res.ImageSetPath); err == nil {
+		return BumpResult{}, fmt.Errorf("%w: %s", ErrImageSetExists, res.ImageSetPath)
+	}
+
+	imageSet, err := ReadDocument(filepath.Join(imageSetDir, latest))
+	if err != nil {
+		return BumpResult{}, fmt.Errorf("reading imageset %q: %w", latest, err)
+	}
+
+	if err := imageSet.Set(fmt.Sprintf("%s.v%s", cfg.AddonName, next), "name"); err != nil {
+		return BumpResult{}, err
+	}
+
+	if cfg.IndexImage != "" {
+		if err := imageSet.Set(cfg.IndexImage, "indexImage"); err != nil {
+			return BumpResult{}, err
+		}
+	}
+
+	if cfg.RelatedImages != nil {
+		if err := imageSet.Set(cfg.RelatedImages, "relatedImages"); err != nil {
+			return BumpResult{}, err
+		}
+	}
+
+	if err := imageSet.WriteFile(res.ImageSetPath); err != nil {
+		return BumpResult{}, fmt.Errorf("writing imageset: %w", err)
+	}
+
+	metaPath := filepath.Join(addonDir, "metadata", cfg.Env, addonMetadataFile)
+
+	meta, err := ReadDocument(metaPath)
+	if err != nil {
+		return BumpResult{}, fmt.Errorf("reading metadata: %w", err)
+	}
+
+	if node, ok := meta.Lookup("addonImageSetVersion"); !ok || node.Value == "latest" {
+		return res, nil
+	}
+
+	if err := meta.Set(res.Version, "addonImageSetVersion"); err != nil {
+		return BumpResult{}, err
+	}
+
+	if err := meta.WriteFile(metaPath); err != nil {
+		return BumpResult{}, fmt.Errorf("writing metadata: %w", err)
+	}
+
+	res.MetadataPath = metaPath
+
+	return res, nil
+}
@@ -0,0 +1,109 @@
+package metadata_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/mt-sre/addon-metadata-operator/pkg/metadata"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBumpImageSet(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		ImageSetVersion  string
+		Options          []metadata.BumpOption
+		ExpectedVersion  string
+		ExpectedImageSet string
+		ExpectedMetadata string
+	}{
+		"pinned version with new related images": {
+			ImageSetVersion: "0.10.0",
+			Options: []metadata.BumpOption{
+				metadata.WithIndexImage("quay.io/osd-addons/bump-addon-index:v0.10.1"),
+				metadata.WithRelatedImages{"quay.io/osd-addons/bump-addon:v0.10.1"},
+			},
+			ExpectedVersion: "0.10.1",
+			ExpectedImageSet: `# managed by the release pipeline
+name: bump-addon.v0.10.1
+indexImage: quay.io/osd-addons/bump-addon-index:v0.10.1 # pinned by digest upstream
+relatedImages:
+  - quay.io/osd-addons/bump-addon:v0.10.1
+`,
+			ExpectedMetadata: `id: bump-addon
+# keep in sync with imagesets
+addonImageSetVersion: 0.10.1
+`,
+		},
+		"latest version with minor increment": {
+			ImageSetVersion: "latest",
+			Options: []metadata.BumpOption{
+				metadata.WithIndexImage("quay.io/osd-addons/bump-addon-index:v0.11.0"),
+				metadata.WithIncrement(metadata.IncrementMinor),
+			},
+			ExpectedVersion: "0.11.0",
+			ExpectedImageSet: `# managed by the release pipeline
+name: bump-addon.v0.11.0
+indexImage: quay.io/osd-addons/bump-addon-index:v0.11.0 # pinned by digest upstream
+relatedImages:
+  - quay.io/osd-addons/bump-addon:v0.10.0
+`,
+			ExpectedMetadata: `id: bump-addon
+# keep in sync with imagesets
+addonImageSetVersion: latest
+`,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			addonDir := filepath.Join(t.TempDir(), "bump-addon")
+			metaPath := filepath.Join(addonDir, "metadata", "stage", "addon.yaml")
+			imageSetDir := filepath.Join(addonDir, "addonimagesets", "stage")
+
+			writeFile(t, metaPath, "id: bump-addon\n# keep in sync with imagesets\naddonImageSetVersion: "+tc.ImageSetVersion+"\n")
+			writeFile(t, filepath.Join(imageSetDir, "bump-addon.v0.9.0.yaml"), "name: bump-addon.v0.9.0\n")
+			writeFile(t, filepath.Join(imageSetDir, "bump-addon.v0.10.0.yaml"), `# managed by the release pipeline
+name: bump-addon.v0.10.0
+indexImage: quay.io/osd-addons/bump-addon-index:v0.10.0 # pinned by digest upstream
+relatedImages:
+  - quay.io/osd-addons/bump-addon:v0.10.0
+`)
+
+			res, err := metadata.BumpImageSet(addonDir, tc.Options...)
+			require.NoError(t, err)
+			require.Equal(t, tc.ExpectedVersion, res.Version)
+
+			imageSet, err := os.ReadFile(res.ImageSetPath)
+			require.NoError(t, err)
+			require.Equal(t, tc.ExpectedImageSet, string(imageSet))
+
+			meta, err := os.ReadFile(metaPath)
+			require.NoError(t, err)
+			require.Equal(t, tc.ExpectedMetadata, string(meta))
+		})
+	}
+}
+
+func TestIncrementNext(t *testing.T) {
+	t.Parallel()
+
+	_, err := metadata.Increment("micro").Next(semver.MustParse("1.0.0"))
+	require.ErrorIs(t, err, metadata.ErrUnknownIncrement)
+
+	next, err := metadata.IncrementMajor.Next(semver.MustParse("1.2.3-rc.1"))
+	require.NoError(t, err)
+	require.Equal(t, "2.0.0", next.String())
+}
+
+func writeFile(t *testing.T, name, content string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(name), 0o755))
+	require.NoError(t, os.WriteFile(name, []byte(content), 0o644))
+}
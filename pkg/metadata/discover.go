@@ -0,0 +1,95 @@
+package metadata
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// promotionOrder lists the known environments in
+// the order addons are promoted through them.
+var promotionOrder = []string{"integration", "stage", "production"}
+
+// DiscoveredAddon is an addon found by DiscoverAddons.
+type DiscoveredAddon struct {
+	// Dir is the addon directory containing the 'metadata' directory.
+	Dir string
+	// Envs are the environments the addon has metadata for ordered
+	// as addons are promoted: integration, stage and production.
+	Envs []string
+}
+
+// DiscoverAddons walks the managed-tenants checkout at root and returns
+// every addon directory with metadata for at least one known environment
+// sorted by directory. Hidden directories are skipped.
+func DiscoverAddons(root string) ([]DiscoveredAddon, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, fmt.Errorf("reading repository: %w", err)
+	}
+
+	if !info.IsDir() {
+		return nil, fmt.Errorf("repository %q is not a directory", root)
+	}
+
+	addons := make(map[string]map[string]bool)
+
+	walk := func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if path != root && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if d.Name() != "addon.yaml" {
+			return nil
+		}
+
+		envDir := filepath.Dir(path)
+		metaDir := filepath.Dir(envDir)
+
+		if filepath.Base(metaDir) != "metadata" || !knownEnvs[filepath.Base(envDir)] {
+			return nil
+		}
+
+		dir := filepath.Dir(metaDir)
+		if addons[dir] == nil {
+			addons[dir] = make(map[string]bool)
+		}
+
+		addons[dir][filepath.Base(envDir)] = true
+
+		return nil
+	}
+
+	if err := filepath.WalkDir(root, walk); err != nil {
+		return nil, fmt.Errorf("discovering addons: %w", err)
+	}
+
+	res := make([]DiscoveredAddon, 0, len(addons))
+
+	for dir, envs := range addons {
+		addon := DiscoveredAddon{Dir: dir}
+
+		for _, env := range promotionOrder {
+			if envs[env] {
+				addon.Envs = append(addon.Envs, env)
+			}
+		}
+
+		res = append(res, addon)
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].Dir < res[j].Dir })
+
+	return res, nil
+}
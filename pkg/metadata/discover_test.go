@@ -0,0 +1,42 @@
+package metadata_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscoverAddons(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+
+	for _, file := range []string{
+		"addons/b-addon/metadata/production/addon.yaml",
+		"addons/b-addon/metadata/integration/addon.yaml",
+		"addons/a-addon/metadata/stage/addon.yaml",
+		"addons/a-addon/metadata/staging/addon.yaml",
+		"addons/c-addon/metadata/stage/README.md",
+		".git/metadata/stage/addon.yaml",
+	} {
+		path := filepath.Join(root, file)
+
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte("id: addon\n"), 0o600))
+	}
+
+	addons, err := metadata.DiscoverAddons(root)
+	require.NoError(t, err)
+
+	assert.Equal(t, []metadata.DiscoveredAddon{
+		{Dir: filepath.Join(root, "addons", "a-addon"), Envs: []string{"stage"}},
+		{Dir: filepath.Join(root, "addons", "b-addon"), Envs: []string{"integration", "production"}},
+	}, addons)
+
+	_, err = metadata.DiscoverAddons(filepath.Join(root, "missing"))
+	assert.Error(t, err)
+}
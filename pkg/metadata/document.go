@@ -0,0 +1,346 @@
+package metadata
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrAliasedPath = errors.New("path traverses an alias")
+	ErrEmptyPath   = errors.New("empty path")
+	ErrNotAMapping = errors.New("not a mapping")
+	ErrNoSuchKey   = errors.New("no such key")
+)
+
+// Document is a YAML metadata file which can be modified while retaining
+// the comments, key ordering and formatting of everything which is not
+// explicitly changed. All top-level keys of a multi-document file are
+// addressed as if the documents were merged.
+//
+// Plain scalars spanning multiple lines are written on a single line and
+// block sequences are always indented relative to their parent key.
+type Document struct {
+	docs          []*yaml.Node
+	explicitStart bool
+	indent        int
+}
+
+// ReadDocument reads and parses the YAML file at the given path.
+func ReadDocument(name string) (*Document, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseDocument(data)
+}
+
+const defaultIndent = 2
+
+// ParseDocument parses every YAML document contained in data.
+func ParseDocument(data []byte) (*Document, error) {
+	d := &Document{
+		explicitStart: bytes.HasPrefix(bytes.TrimLeft(data, " \t\r\n"), []byte("---")),
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+
+	for {
+		var node yaml.Node
+
+		err := dec.Decode(&node)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		d.docs = append(d.docs, &node)
+	}
+
+	d.indent = detectIndent(d.docs)
+
+	return d, nil
+}
+
+// detectIndent returns the indentation of the first nested block
+// collection so that re-encoding does not re-indent the whole file.
+func detectIndent(nodes []*yaml.Node) int {
+	for _, n := range nodes {
+		if n.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				key, val := n.Content[i], n.Content[i+1]
+
+				if (val.Kind == yaml.MappingNode || val.Kind == yaml.SequenceNode) &&
+					val.Style&yaml.FlowStyle == 0 && val.Line > key.Line && val.Column > key.Column {
+					return val.Column - key.Column
+				}
+			}
+		}
+
+		if indent := detectIndent(n.Content); indent > 0 {
+			return indent
+		}
+	}
+
+	return 0
+}
+
+// Lookup returns the node found by following the given sequence
+// of mapping keys and 'false' if no such node exists.
+func (d *Document) Lookup(keys ...string) (*yaml.Node, bool) {
+	if len(keys) == 0 {
+		return nil, false
+	}
+
+	for _, doc := range d.docs {
+		root := documentRoot(doc)
+		if root == nil {
+			continue
+		}
+
+		if node, ok := lookup(root, keys); ok {
+			return node, true
+		}
+	}
+
+	return nil, false
+}
+
+func lookup(node *yaml.Node, keys []string) (*yaml.Node, bool) {
+	for _, key := range keys {
+		if node.Kind == yaml.AliasNode {
+			node = node.Alias
+		}
+
+		if node.Kind != yaml.MappingNode {
+			return nil, false
+		}
+
+		idx := keyIndex(node, key)
+		if idx < 0 {
+			return nil, false
+		}
+
+		node = node.Content[idx+1]
+	}
+
+	return node, true
+}
+
+// Set encodes value and stores it at the given sequence of mapping keys.
+// Missing mappings along the path are created and new top-level keys are
+// appended to the last document. When replacing an existing value its
+// comments and, for strings, its quoting style are kept.
+func (d *Document) Set(value interface{}, keys ...string) error {
+	if len(keys) == 0 {
+		return ErrEmptyPath
+	}
+
+	var node yaml.Node
+	if err := node.Encode(value); err != nil {
+		return fmt.Errorf("encoding value for %q: %w", keys, err)
+	}
+
+	parent, err := d.parentFor(keys)
+	if err != nil {
+		return err
+	}
+
+	key := keys[len(keys)-1]
+
+	if idx := keyIndex(parent, key); idx >= 0 {
+		replaceNode(parent.Content[idx+1], &node)
+
+		return nil
+	}
+
+	parent.Content = append(parent.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		&node,
+	)
+
+	return nil
+}
+
+// parentFor returns the mapping which holds or should hold the
+// final key of the given path, creating it if necessary.
+func (d *Document) parentFor(keys []string) (*yaml.Node, error) {
+	var parent *yaml.Node
+
+	for _, doc := range d.docs {
+		root := documentRoot(doc)
+		if root == nil || root.Kind != yaml.MappingNode {
+			continue
+		}
+
+		parent = root
+
+		if keyIndex(root, keys[0]) >= 0 {
+			break
+		}
+	}
+
+	if parent == nil {
+		parent = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		d.docs = append(d.docs, &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{parent}})
+	}
+
+	for i, key := range keys[:len(keys)-1] {
+		idx := keyIndex(parent, key)
+		if idx < 0 {
+			child := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			parent.Content = append(parent.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+				child,
+			)
+			parent = child
+
+			continue
+		}
+
+		child := parent.Content[idx+1]
+
+		switch {
+		case child.Kind == yaml.AliasNode || child.Anchor != "":
+			return nil, fmt.Errorf("setting %q: %w at %q", keys, ErrAliasedPath, keys[:i+1])
+		case isNullNode(child):
+			replaceNode(child, &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"})
+		case child.Kind != yaml.MappingNode:
+			return nil, fmt.Errorf("setting %q: %w at %q", keys, ErrNotAMapping, keys[:i+1])
+		}
+
+		parent = child
+	}
+
+	return parent, nil
+}
+
+// Delete removes the value at the given sequence of mapping keys
+// together with its key. Deleting a missing key returns ErrNoSuchKey.
+func (d *Document) Delete(keys ...string) error {
+	if len(keys) == 0 {
+		return ErrEmptyPath
+	}
+
+	parentKeys, key := keys[:len(keys)-1], keys[len(keys)-1]
+
+	for _, doc := range d.docs {
+		root := documentRoot(doc)
+		if root == nil {
+			continue
+		}
+
+		parent := root
+		if len(parentKeys) > 0 {
+			var ok bool
+			if parent, ok = lookup(root, parentKeys); !ok {
+				continue
+			}
+		}
+
+		if parent.Kind != yaml.MappingNode {
+			continue
+		}
+
+		if idx := keyIndex(parent, key); idx >= 0 {
+			parent.Content = append(parent.Content[:idx], parent.Content[idx+2:]...)
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("deleting %q: %w", keys, ErrNoSuchKey)
+}
+
+// Bytes encodes the document using the indentation detected while parsing.
+func (d *Document) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := d.Encode(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Encode writes the encoded document to w.
+func (d *Document) Encode(w io.Writer) error {
+	if d.explicitStart {
+		if _, err := io.WriteString(w, "---\n"); err != nil {
+			return err
+		}
+	}
+
+	enc := yaml.NewEncoder(w)
+
+	indent := d.indent
+	if indent == 0 {
+		indent = defaultIndent
+	}
+
+	enc.SetIndent(indent)
+
+	for _, doc := range d.docs {
+		if err := enc.Encode(doc); err != nil {
+			return err
+		}
+	}
+
+	return enc.Close()
+}
+
+// WriteFile encodes the document and writes it to the named file
+// keeping the permissions of the file if it already exists.
+func (d *Document) WriteFile(name string) error {
+	data, err := d.Bytes()
+	if err != nil {
+		return err
+	}
+
+	perm := os.FileMode(0o644)
+	if info, err := os.Stat(name); err == nil {
+		perm = info.Mode().Perm()
+	}
+
+	return os.WriteFile(name, data, perm)
+}
+
+func documentRoot(doc *yaml.Node) *yaml.Node {
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil
+	}
+
+	return doc.Content[0]
+}
+
+func keyIndex(mapping *yaml.Node, key string) int {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// replaceNode overwrites dst with src in place so that references held
+// by the parent remain valid while keeping the comments attached to dst.
+func replaceNode(dst, src *yaml.Node) {
+	if dst.Tag == "!!str" && src.Tag == "!!str" && src.Kind == yaml.ScalarNode {
+		src.Style = dst.Style
+	}
+
+	src.HeadComment = dst.HeadComment
+	src.LineComment = dst.LineComment
+	src.FootComment = dst.FootComment
+
+	*dst = *src
+}
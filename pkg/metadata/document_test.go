@@ -0,0 +1,132 @@
+package metadata_test
+
+import (
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/metadata"
+	"github.com/stretchr/testify/require"
+)
+
+const commentedMetadata = `---
+# Reference addon used for testing
+id: reference-addon
+name: "Reference Addon" # display name
+namespaces:
+  - redhat-reference-addon
+# pinned imageset
+addonImageSetVersion: 0.0.5
+`
+
+func TestDocumentRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	doc, err := metadata.ParseDocument([]byte(commentedMetadata))
+	require.NoError(t, err)
+
+	data, err := doc.Bytes()
+	require.NoError(t, err)
+	require.Equal(t, commentedMetadata, string(data))
+}
+
+func TestDocumentSet(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Keys     []string
+		Value    interface{}
+		Expected string
+	}{
+		"replace scalar keeps comments": {
+			Keys:  []string{"addonImageSetVersion"},
+			Value: "0.0.6",
+			Expected: `---
+# Reference addon used for testing
+id: reference-addon
+name: "Reference Addon" # display name
+namespaces:
+  - redhat-reference-addon
+# pinned imageset
+addonImageSetVersion: 0.0.6
+`,
+		},
+		"replace string keeps quoting": {
+			Keys:  []string{"name"},
+			Value: "Reference",
+			Expected: `---
+# Reference addon used for testing
+id: reference-addon
+name: "Reference" # display name
+namespaces:
+  - redhat-reference-addon
+# pinned imageset
+addonImageSetVersion: 0.0.5
+`,
+		},
+		"add nested key": {
+			Keys:  []string{"namespaceLabels", "monitoring"},
+			Value: "true",
+			Expected: `---
+# Reference addon used for testing
+id: reference-addon
+name: "Reference Addon" # display name
+namespaces:
+  - redhat-reference-addon
+# pinned imageset
+addonImageSetVersion: 0.0.5
+namespaceLabels:
+  monitoring: "true"
+`,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			doc, err := metadata.ParseDocument([]byte(commentedMetadata))
+			require.NoError(t, err)
+
+			require.NoError(t, doc.Set(tc.Value, tc.Keys...))
+
+			data, err := doc.Bytes()
+			require.NoError(t, err)
+			require.Equal(t, tc.Expected, string(data))
+		})
+	}
+}
+
+func TestDocumentMultipleDocuments(t *testing.T) {
+	t.Parallel()
+
+	doc, err := metadata.ParseDocument([]byte(`id: reference-addon
+---
+ocmQuotaCost: 1
+`))
+	require.NoError(t, err)
+
+	require.NoError(t, doc.Set(2, "ocmQuotaCost"))
+	require.NoError(t, doc.Delete("id"))
+	require.ErrorIs(t, doc.Delete("id"), metadata.ErrNoSuchKey)
+
+	node, ok := doc.Lookup("ocmQuotaCost")
+	require.True(t, ok)
+	require.Equal(t, "2", node.Value)
+
+	data, err := doc.Bytes()
+	require.NoError(t, err)
+	require.Equal(t, "{}\n---\nocmQuotaCost: 2\n", string(data))
+}
+
+func TestDocumentSetThroughAlias(t *testing.T) {
+	t.Parallel()
+
+	doc, err := metadata.ParseDocument([]byte(`labels: &labels
+  team: mt-sre
+namespaceLabels: *labels
+`))
+	require.NoError(t, err)
+
+	require.ErrorIs(t, doc.Set("sre", "namespaceLabels", "team"), metadata.ErrAliasedPath)
+	require.ErrorIs(t, doc.Set("sre", "labels", "team"), metadata.ErrAliasedPath)
+	require.NoError(t, doc.Set("other", "namespaceLabels"))
+}
@@ -0,0 +1,60 @@
+package metadata
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+)
+
+// FixFile returns the file fix has to be written to relative to the addon
+// directory: the file which defines the fixed field or its closest parent
+// according to prov and defaultFile if neither is defined.
+func FixFile(prov types.Provenance, defaultFile string, fix types.Fix) string {
+	if src, ok := prov.Lookup(fix.Field()); ok {
+		return src.File
+	}
+
+	return defaultFile
+}
+
+// ApplyFixes writes fixes to the files of the addon at addonDir selected
+// by FixFile. Each file keeps its formatting and comments. The paths of
+// all written files relative to addonDir are returned sorted.
+func ApplyFixes(addonDir string, prov types.Provenance, defaultFile string, fixes ...types.Fix) ([]string, error) {
+	byFile := make(map[string][]types.Fix)
+
+	for _, fix := range fixes {
+		file := FixFile(prov, defaultFile, fix)
+		byFile[file] = append(byFile[file], fix)
+	}
+
+	files := make([]string, 0, len(byFile))
+	for file := range byFile {
+		files = append(files, file)
+	}
+
+	sort.Strings(files)
+
+	for _, file := range files {
+		name := filepath.Join(addonDir, filepath.FromSlash(file))
+
+		doc, err := ReadDocument(name)
+		if err != nil {
+			return nil, fmt.Errorf("reading %q: %w", file, err)
+		}
+
+		for _, fix := range byFile[file] {
+			if err := doc.Set(fix.Value, fix.Path...); err != nil {
+				return nil, fmt.Errorf("fixing %q in %q: %w", fix.Field(), file, err)
+			}
+		}
+
+		if err := doc.WriteFile(name); err != nil {
+			return nil, fmt.Errorf("writing %q: %w", file, err)
+		}
+	}
+
+	return files, nil
+}
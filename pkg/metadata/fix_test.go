@@ -0,0 +1,54 @@
+package metadata_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/metadata"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyFixes(t *testing.T) {
+	t.Parallel()
+
+	addonDir := t.TempDir()
+
+	write := func(name, content string) {
+		path := filepath.Join(addonDir, filepath.FromSlash(name))
+
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	write("metadata/stage/addon.yaml", "id: fix-addon\n# managed by the addon team\nlabel: api.openshift.com/addon-Fix-Addon\n")
+	write("metadata/stage/dms.yaml", "deadmanssnitch:\n  tags: [a]\n")
+
+	prov := types.Provenance{
+		"id":             {File: "metadata/stage/addon.yaml", Line: 1, Column: 1},
+		"label":          {File: "metadata/stage/addon.yaml", Line: 3, Column: 1},
+		"deadmanssnitch": {File: "metadata/stage/dms.yaml", Line: 1, Column: 1},
+	}
+
+	fixes := []types.Fix{
+		{Path: []string{"label"}, Value: "api.openshift.com/addon-fix-addon"},
+		{Path: []string{"deadmanssnitch", "snitchNamePostFix"}, Value: "fix-addon"},
+		{Path: []string{"icon"}, Value: "aWNvbg=="},
+	}
+
+	assert.Equal(t, "metadata/stage/dms.yaml", metadata.FixFile(prov, "metadata/stage/addon.yaml", fixes[1]))
+
+	files, err := metadata.ApplyFixes(addonDir, prov, "metadata/stage/addon.yaml", fixes...)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"metadata/stage/addon.yaml", "metadata/stage/dms.yaml"}, files)
+
+	meta, err := os.ReadFile(filepath.Join(addonDir, "metadata", "stage", "addon.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "id: fix-addon\n# managed by the addon team\nlabel: api.openshift.com/addon-fix-addon\nicon: aWNvbg==\n", string(meta))
+
+	dms, err := os.ReadFile(filepath.Join(addonDir, "metadata", "stage", "dms.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "deadmanssnitch:\n  tags: [a]\n  snitchNamePostFix: fix-addon\n", string(dms))
+}
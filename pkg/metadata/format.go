@@ -0,0 +1,224 @@
+package metadata
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"gopkg.in/yaml.v3"
+)
+
+// sortedSequences lists the top-level fields whose scalar
+// items are sorted since their order carries no meaning.
+var sortedSequences = map[string]bool{
+	"relatedImages": true,
+}
+
+// base64Fields lists the top-level fields holding base64 encoded
+// data which is written on a single line regardless of its length.
+var base64Fields = map[string]bool{
+	"icon": true,
+}
+
+// Format canonicalizes a metadata or imageset file. Mapping keys are
+// ordered as declared by the type of target (e.g. AddonMetadataSpec)
+// with unknown keys sorted after known ones, map keys are sorted,
+// 'relatedImages' are sorted and base64 data is unwrapped. Comments
+// are kept and the output is indented by two spaces.
+func Format(data []byte, target interface{}) ([]byte, error) {
+	doc, err := ParseDocument(data)
+	if err != nil {
+		return nil, err
+	}
+
+	t := reflect.TypeOf(target)
+
+	for _, d := range doc.docs {
+		root := documentRoot(d)
+		if root == nil || root.Kind != yaml.MappingNode {
+			continue
+		}
+
+		formatTopLevel(root)
+		sortKeys(root, t)
+	}
+
+	doc.indent = defaultIndent
+
+	return doc.Bytes()
+}
+
+// FormatDir formats the metadata and imageset files of every environment
+// within a local addon directory and returns the paths of all files which
+// were not formatted. The files are only rewritten if write is 'true'.
+func FormatDir(addonDir string, write bool) ([]string, error) {
+	var unformatted []string
+
+	for _, group := range []struct {
+		Pattern string
+		Target  interface{}
+	}{
+		{Pattern: filepath.Join(addonDir, "metadata", "*", "*"), Target: &addonsv1alpha1.AddonMetadataSpec{}},
+		{Pattern: filepath.Join(addonDir, "addonimagesets", "*", "*"), Target: &addonsv1alpha1.AddonImageSetSpec{}},
+	} {
+		matches, err := filepath.Glob(group.Pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range matches {
+			if ext := filepath.Ext(name); !strings.EqualFold(ext, ".yaml") && !strings.EqualFold(ext, ".yml") {
+				continue
+			}
+
+			changed, err := formatFile(name, group.Target, write)
+			if err != nil {
+				return nil, fmt.Errorf("formatting %q: %w", name, err)
+			}
+
+			if changed {
+				unformatted = append(unformatted, name)
+			}
+		}
+	}
+
+	return unformatted, nil
+}
+
+func formatFile(name string, target interface{}, write bool) (bool, error) {
+	info, err := os.Stat(name)
+	if err != nil {
+		return false, err
+	}
+
+	if info.IsDir() {
+		return false, nil
+	}
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return false, err
+	}
+
+	formatted, err := Format(data, target)
+	if err != nil {
+		return false, err
+	}
+
+	if bytes.Equal(data, formatted) {
+		return false, nil
+	}
+
+	if write {
+		if err := os.WriteFile(name, formatted, info.Mode().Perm()); err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+func formatTopLevel(root *yaml.Node) {
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, val := root.Content[i].Value, root.Content[i+1]
+
+		switch {
+		case sortedSequences[key] && val.Kind == yaml.SequenceNode:
+			sort.SliceStable(val.Content, func(i, j int) bool {
+				return val.Content[i].Kind == yaml.ScalarNode && val.Content[j].Kind == yaml.ScalarNode &&
+					val.Content[i].Value < val.Content[j].Value
+			})
+		case base64Fields[key] && val.Kind == yaml.ScalarNode && val.Tag == "!!str":
+			val.Value = strings.Join(strings.Fields(val.Value), "")
+			val.Style = 0
+		}
+	}
+}
+
+// sortKeys orders the keys of all mappings below node according to t.
+func sortKeys(node *yaml.Node, t reflect.Type) {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	// types with custom decoding (e.g. apiextensionsv1.JSON) are kept as is
+	if t == nil || node.Kind == yaml.AliasNode || reflect.PtrTo(t).Implements(jsonUnmarshalerType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+
+		fields := orderedJSONFields(t)
+		rank := make(map[string]int, len(fields))
+		types := make(map[string]reflect.Type, len(fields))
+
+		for i, f := range fields {
+			rank[f.Name] = i
+			types[f.Name] = f.Type
+		}
+
+		sortMapping(node, func(a, b string) bool {
+			ra, okA := rank[a]
+			rb, okB := rank[b]
+
+			switch {
+			case okA && okB:
+				return ra < rb
+			case okA != okB:
+				return okA
+			default:
+				return a < b
+			}
+		})
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			sortKeys(node.Content[i+1], types[node.Content[i].Value])
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+
+		sortMapping(node, func(a, b string) bool { return a < b })
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			sortKeys(node.Content[i+1], t.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		if node.Kind != yaml.SequenceNode {
+			return
+		}
+
+		for _, item := range node.Content {
+			sortKeys(item, t.Elem())
+		}
+	}
+}
+
+// sortMapping stably reorders the key/value pairs of a mapping node.
+func sortMapping(node *yaml.Node, less func(a, b string) bool) {
+	pairs := make([][2]*yaml.Node, 0, len(node.Content)/2)
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		pairs = append(pairs, [2]*yaml.Node{node.Content[i], node.Content[i+1]})
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return less(pairs[i][0].Value, pairs[j][0].Value)
+	})
+
+	node.Content = node.Content[:0]
+
+	for _, p := range pairs {
+		node.Content = append(node.Content, p[0], p[1])
+	}
+}
@@ -0,0 +1,113 @@
+package metadata_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/metadata"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Input    string
+		Target   interface{}
+		Expected string
+	}{
+		"metadata keys are ordered": {
+			Input: `# maintained by MT-SRE
+
+# set by the release pipeline
+operatorName: my-addon
+zzCustom: true
+name: My Addon
+id: my-addon # must match the directory
+namespaceLabels:
+    b: "2"
+    a: "1"
+channels:
+- name: alpha
+  currentCSV: my-addon.v1.0.0
+icon: >-
+    aGVsbG8g
+    d29ybGQ=
+`,
+			Target: &addonsv1alpha1.AddonMetadataSpec{},
+			Expected: `# maintained by MT-SRE
+
+id: my-addon # must match the directory
+name: My Addon
+icon: aGVsbG8gd29ybGQ=
+# set by the release pipeline
+operatorName: my-addon
+channels:
+  - name: alpha
+    currentCSV: my-addon.v1.0.0
+namespaceLabels:
+  a: "1"
+  b: "2"
+zzCustom: true
+`,
+		},
+		"related images are sorted": {
+			Input: `relatedImages:
+  - quay.io/b
+  - quay.io/a
+indexImage: quay.io/index
+name: my-addon.v1.0.0
+`,
+			Target: &addonsv1alpha1.AddonImageSetSpec{},
+			Expected: `name: my-addon.v1.0.0
+indexImage: quay.io/index
+relatedImages:
+  - quay.io/a
+  - quay.io/b
+`,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			out, err := metadata.Format([]byte(tc.Input), tc.Target)
+			require.NoError(t, err)
+			require.Equal(t, tc.Expected, string(out))
+
+			again, err := metadata.Format(out, tc.Target)
+			require.NoError(t, err)
+			require.Equal(t, string(out), string(again))
+		})
+	}
+}
+
+func TestFormatDir(t *testing.T) {
+	t.Parallel()
+
+	addonDir := t.TempDir()
+	metaPath := filepath.Join(addonDir, "metadata", "stage", "addon.yaml")
+	imageSetPath := filepath.Join(addonDir, "addonimagesets", "stage", "my-addon.v1.0.0.yaml")
+
+	writeFile(t, metaPath, "name: My Addon\nid: my-addon\n")
+	writeFile(t, imageSetPath, "name: my-addon.v1.0.0\n")
+
+	unformatted, err := metadata.FormatDir(addonDir, false)
+	require.NoError(t, err)
+	require.Equal(t, []string{metaPath}, unformatted)
+
+	unformatted, err = metadata.FormatDir(addonDir, true)
+	require.NoError(t, err)
+	require.Equal(t, []string{metaPath}, unformatted)
+
+	data, err := os.ReadFile(metaPath)
+	require.NoError(t, err)
+	require.Equal(t, "id: my-addon\nname: My Addon\n", string(data))
+
+	unformatted, err = metadata.FormatDir(addonDir, false)
+	require.NoError(t, err)
+	require.Empty(t, unformatted)
+}
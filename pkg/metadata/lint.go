@@ -0,0 +1,253 @@
+package metadata
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"go.uber.org/multierr"
+	"gopkg.in/yaml.v3"
+)
+
+// LintRule names the class of check which reported a LintIssue.
+type LintRule string
+
+const (
+	// LintRuleSchema reports content which does not match the API types.
+	LintRuleSchema LintRule = "schema"
+	// LintRuleFormat reports files which are not formatted as by Format.
+	LintRuleFormat LintRule = "format"
+	// LintRuleNaming reports files and names not following conventions.
+	LintRuleNaming LintRule = "naming"
+)
+
+// LintIssue is a single problem found by Lint. Line and Column
+// are zero when the issue concerns the file as a whole.
+type LintIssue struct {
+	File    string
+	Line    int
+	Column  int
+	Rule    LintRule
+	Message string
+}
+
+func (i LintIssue) String() string {
+	if i.Line == 0 {
+		return fmt.Sprintf("%s: %s (%s)", i.File, i.Message, i.Rule)
+	}
+
+	return fmt.Sprintf("%s:%d:%d: %s (%s)", i.File, i.Line, i.Column, i.Message, i.Rule)
+}
+
+// knownEnvs lists the environments an addon may be deployed to.
+var knownEnvs = map[string]bool{
+	"stage":       true,
+	"integration": true,
+	"production":  true,
+}
+
+// Lint runs fast file-level checks against a local addon directory without
+// resolving imagesets or accessing the network. It reports schema violations,
+// unformatted files and files or names which do not follow the naming
+// conventions. Issues are ordered by file. An error is only returned if the
+// directory could not be read.
+func Lint(addonDir string) ([]LintIssue, error) {
+	abs, err := filepath.Abs(addonDir)
+	if err != nil {
+		return nil, err
+	}
+
+	l := linter{addonName: filepath.Base(abs)}
+
+	for _, group := range []struct {
+		Dir      string
+		Target   func() interface{}
+		Check    func(name string, data []byte)
+		Required string
+	}{
+		{
+			Dir:      filepath.Join(addonDir, "metadata"),
+			Target:   func() interface{} { return &addonsv1alpha1.AddonMetadataSpec{} },
+			Check:    l.checkMetadataNaming,
+			Required: addonMetadataFile,
+		},
+		{
+			Dir:    filepath.Join(addonDir, "addonimagesets"),
+			Target: func() interface{} { return &addonsv1alpha1.AddonImageSetSpec{} },
+			Check:  l.checkImageSetNaming,
+		},
+	} {
+		envs, err := os.ReadDir(group.Dir)
+		if errors.Is(err, os.ErrNotExist) {
+			if group.Required != "" {
+				l.report(group.Dir, LintRuleNaming, "missing directory")
+			}
+
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		for _, env := range envs {
+			if !env.IsDir() {
+				continue
+			}
+
+			envDir := filepath.Join(group.Dir, env.Name())
+
+			if !knownEnvs[env.Name()] {
+				l.report(envDir, LintRuleNaming, "unknown environment %q", env.Name())
+			}
+
+			if group.Required != "" {
+				if _, err := os.Stat(filepath.Join(envDir, group.Required)); errors.Is(err, os.ErrNotExist) {
+					l.report(envDir, LintRuleNaming, "missing %q", group.Required)
+				}
+			}
+
+			if err := l.lintEnv(envDir, group.Target, group.Check); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	sort.SliceStable(l.issues, func(i, j int) bool {
+		return l.issues[i].File < l.issues[j].File
+	})
+
+	return l.issues, nil
+}
+
+type linter struct {
+	addonName string
+	issues    []LintIssue
+}
+
+func (l *linter) report(file string, rule LintRule, format string, args ...interface{}) {
+	l.issues = append(l.issues, LintIssue{
+		File:    file,
+		Rule:    rule,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (l *linter) lintEnv(envDir string, target func() interface{}, check func(name string, data []byte)) error {
+	entries, err := os.ReadDir(envDir)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		name := filepath.Join(envDir, e.Name())
+
+		switch ext := filepath.Ext(name); {
+		case ext == ".yaml":
+		case strings.EqualFold(ext, ".yaml"), strings.EqualFold(ext, ".yml"):
+			l.report(name, LintRuleNaming, "file extension must be '.yaml'")
+		default:
+			continue
+		}
+
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return err
+		}
+
+		if !l.checkSchema(name, data, target()) {
+			continue
+		}
+
+		if formatted, err := Format(data, target()); err == nil && !bytes.Equal(data, formatted) {
+			l.report(name, LintRuleFormat, "file is not formatted, run 'mtcli fmt'")
+		}
+
+		check(name, data)
+	}
+
+	return nil
+}
+
+// checkSchema reports unknown fields and values which can't be decoded
+// into target. It returns 'false' if the file could not be parsed.
+func (l *linter) checkSchema(name string, data []byte, target interface{}) bool {
+	var doc yaml.Node
+
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		l.report(name, LintRuleSchema, "%v", err)
+
+		return false
+	}
+
+	if len(doc.Content) > 0 {
+		for _, err := range multierr.Errors(checkKnownFieldsNode(name, doc.Content[0], target)) {
+			var unknown UnknownFieldError
+			if !errors.As(err, &unknown) {
+				continue
+			}
+
+			l.issues = append(l.issues, LintIssue{
+				File:    name,
+				Line:    unknown.Line,
+				Column:  unknown.Column,
+				Rule:    LintRuleSchema,
+				Message: fmt.Sprintf("%s %q", ErrUnknownField, unknown.Path),
+			})
+		}
+	}
+
+	var err error
+
+	switch t := target.(type) {
+	case *addonsv1alpha1.AddonMetadataSpec:
+		err = t.FromYAML(data)
+	case *addonsv1alpha1.AddonImageSetSpec:
+		err = t.FromYAML(data)
+	}
+
+	if err != nil {
+		l.report(name, LintRuleSchema, "%v", err)
+	}
+
+	return true
+}
+
+func (l *linter) checkMetadataNaming(name string, data []byte) {
+	if filepath.Base(name) != addonMetadataFile {
+		return
+	}
+
+	var meta addonsv1alpha1.AddonMetadataSpec
+	if err := meta.FromYAML(data); err != nil {
+		return
+	}
+
+	if meta.ID != l.addonName {
+		l.report(name, LintRuleNaming, "id %q must match the addon directory %q", meta.ID, l.addonName)
+	}
+}
+
+func (l *linter) checkImageSetNaming(name string, data []byte) {
+	stem := strings.TrimSuffix(filepath.Base(name), filepath.Ext(name))
+
+	if _, ok := parseNameVersion(stem); !ok || !strings.HasPrefix(stem, l.addonName+".v") {
+		l.report(name, LintRuleNaming, "file name must be '%s.v<semver>.yaml'", l.addonName)
+	}
+
+	var imageSet addonsv1alpha1.AddonImageSetSpec
+	if err := imageSet.FromYAML(data); err != nil {
+		return
+	}
+
+	if imageSet.Name != stem {
+		l.report(name, LintRuleNaming, "name %q must match the file name %q", imageSet.Name, stem)
+	}
+}
@@ -0,0 +1,104 @@
+package metadata_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/metadata"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLint(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Files    map[string]string
+		Expected []metadata.LintIssue
+	}{
+		"valid addon": {
+			Files: map[string]string{
+				"metadata/stage/addon.yaml":                    "id: lint-addon\naddonImageSetVersion: latest\n",
+				"addonimagesets/stage/lint-addon.v1.0.0.yaml":  "name: lint-addon.v1.0.0\nindexImage: quay.io/lint-addon-index\n",
+				"addonimagesets/stage/lint-addon.v1.10.0.yaml": "name: lint-addon.v1.10.0\nindexImage: quay.io/lint-addon-index\n",
+			},
+		},
+		"schema, format and naming issues": {
+			Files: map[string]string{
+				"metadata/staging/addon.yaml":            "name: Lint Addon\nid: other-addon\nunknownField: true\n",
+				"addonimagesets/staging/lint-addon.yml":  "name: lint-addon.v1.0.0\n",
+				"addonimagesets/staging/README.md":       "ignored\n",
+				"addonimagesets/staging/invalid.v1.yaml": "name: [\n",
+			},
+			Expected: []metadata.LintIssue{
+				{
+					File: "addonimagesets/staging", Rule: metadata.LintRuleNaming,
+					Message: `unknown environment "staging"`,
+				},
+				{
+					File: "addonimagesets/staging/invalid.v1.yaml", Rule: metadata.LintRuleSchema,
+					Message: `yaml: line 1: did not find expected node content`,
+				},
+				{
+					File: "addonimagesets/staging/lint-addon.yml", Rule: metadata.LintRuleNaming,
+					Message: "file extension must be '.yaml'",
+				},
+				{
+					File: "addonimagesets/staging/lint-addon.yml", Rule: metadata.LintRuleNaming,
+					Message: "file name must be 'lint-addon.v<semver>.yaml'",
+				},
+				{
+					File: "addonimagesets/staging/lint-addon.yml", Rule: metadata.LintRuleNaming,
+					Message: `name "lint-addon.v1.0.0" must match the file name "lint-addon"`,
+				},
+				{
+					File: "metadata/staging", Rule: metadata.LintRuleNaming,
+					Message: `unknown environment "staging"`,
+				},
+				{
+					File: "metadata/staging/addon.yaml", Line: 3, Column: 1, Rule: metadata.LintRuleSchema,
+					Message: `unknown field "unknownField"`,
+				},
+				{
+					File: "metadata/staging/addon.yaml", Rule: metadata.LintRuleFormat,
+					Message: "file is not formatted, run 'mtcli fmt'",
+				},
+				{
+					File: "metadata/staging/addon.yaml", Rule: metadata.LintRuleNaming,
+					Message: `id "other-addon" must match the addon directory "lint-addon"`,
+				},
+			},
+		},
+		"missing metadata": {
+			Files: map[string]string{
+				"addonimagesets/stage/lint-addon.v1.0.0.yaml": "name: lint-addon.v1.0.0\n",
+			},
+			Expected: []metadata.LintIssue{
+				{File: "metadata", Rule: metadata.LintRuleNaming, Message: "missing directory"},
+			},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			addonDir := filepath.Join(t.TempDir(), "lint-addon")
+
+			for name, content := range tc.Files {
+				writeFile(t, filepath.Join(addonDir, name), content)
+			}
+
+			issues, err := metadata.Lint(addonDir)
+			require.NoError(t, err)
+
+			for i := range issues {
+				rel, err := filepath.Rel(addonDir, issues[i].File)
+				require.NoError(t, err)
+
+				issues[i].File = filepath.ToSlash(rel)
+			}
+
+			require.Equal(t, tc.Expected, issues)
+		})
+	}
+}
@@ -0,0 +1,384 @@
+// Package metadata loads addon metadata and imagesets from an addon
+// directory and resolves them into a single AddonMetadataSpec.
+//
+// The metadata of an environment is read from 'metadata/<env>/addon.yaml'
+// which may contain multiple YAML documents. Fragments named
+// 'addon.<name>.yaml' (e.g. 'addon.parameters.yaml') in the same directory
+// are merged into it in lexical order; a top-level key may only be defined
+// once. Other files in the directory are ignored.
+package metadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+
+	"github.com/blang/semver/v4"
+	"github.com/go-logr/logr"
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"go.uber.org/multierr"
+	"gopkg.in/yaml.v3"
+)
+
+// NewLoader returns a Loader for the given addon directory which may
+// either be a local path or an 'https://' URL pointing to the root of
+// an addon directory (e.g. a raw GitHub/GitLab file URL). A variadic
+// slice of options may be given to alter the behavior of the Loader.
+func NewLoader(addonDir string, opts ...LoaderOption) *Loader {
+	var cfg LoaderConfig
+
+	cfg.Option(opts...)
+	cfg.Default(addonDir)
+
+	return &Loader{
+		addonDir: addonDir,
+		cfg:      cfg,
+	}
+}
+
+// Loader reads addon metadata for a single environment and combines it
+// with the selected imageset if the addon uses imagesets.
+type Loader struct {
+	addonDir string
+	cfg      LoaderConfig
+}
+
+// AddonName returns the name of the addon being loaded.
+func (l *Loader) AddonName() string { return l.cfg.AddonName }
+
+var (
+	ErrLegacyAddon           = errors.New("No validation support for legacy addon. Please use the imageSet feature.")
+	ErrIndexImageAndImageSet = errors.New("Can't set both the 'indexImage' and the 'imageSetVersion' field.")
+	ErrNoImageSets           = errors.New("No imageset present in the directory.")
+	ErrMetadataNotFound      = errors.New("addon metadata not found")
+	ErrImageSetWithoutDir    = errors.New("addon metadata referring to an imageset requires an addon directory")
+)
+
+// Load - loads the addon metadata and imageSet
+func (l *Loader) Load(ctx context.Context) (*addonsv1alpha1.AddonMetadataSpec, error) {
+	meta, _, err := l.LoadWithProvenance(ctx)
+
+	return meta, err
+}
+
+// LoadWithProvenance loads the addon metadata and imageSet and
+// additionally returns the file location of every resolved field.
+func (l *Loader) LoadWithProvenance(ctx context.Context) (*addonsv1alpha1.AddonMetadataSpec, types.Provenance, error) {
+	mb, err := l.LoadMetaBundle(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return mb.AddonMeta, mb.Provenance, nil
+}
+
+// LoadMetaBundle loads the addon metadata and imageSet into a MetaBundle
+// carrying the provenance of every field and the resolved imageset
+// version and default channel. Bundles are left for the caller to add.
+func (l *Loader) LoadMetaBundle(ctx context.Context) (*types.MetaBundle, error) {
+	fsys := l.fs(ctx)
+	prov := make(types.Provenance)
+
+	meta, err := l.readMeta(ctx, fsys, prov)
+	if err != nil {
+		return nil, err
+	}
+	// invalid - legacy addon
+	if meta.IndexImage == nil && meta.ImageSetVersion == nil {
+		return nil, ErrLegacyAddon
+	}
+	// invalid - misconfiguration
+	if meta.IndexImage != nil && meta.ImageSetVersion != nil {
+		return nil, ErrIndexImageAndImageSet
+	}
+
+	mb := &types.MetaBundle{
+		AddonMeta:  meta,
+		Provenance: prov,
+	}
+	// imageSet
+	if meta.ImageSetVersion != nil {
+		if l.cfg.Metadata != nil {
+			return nil, ErrImageSetWithoutDir
+		}
+
+		imageSet, err := l.readImageSet(ctx, fsys, meta, prov, &mb.Resolution)
+		if err != nil {
+			return nil, fmt.Errorf("Could not read imageSet, got %v.\n", err)
+		}
+		combinedMeta, err := meta.CombineWithImageSet(imageSet)
+		if err != nil {
+			return nil, fmt.Errorf("Could not combine metadata and imageset, got %v.", err)
+		}
+		mb.AddonMeta = combinedMeta
+		mb.ImageSet = imageSet
+	}
+
+	mb.Resolution = mb.Resolved()
+
+	return mb, nil
+}
+
+// LoadMetadata loads the addon metadata without combining
+// it with the imageset it refers to.
+func (l *Loader) LoadMetadata(ctx context.Context) (*addonsv1alpha1.AddonMetadataSpec, error) {
+	return l.readMeta(ctx, l.fs(ctx), make(types.Provenance))
+}
+
+// LoadImageSet loads the imageset selected by the addon metadata
+// or by the WithVersion option.
+func (l *Loader) LoadImageSet(ctx context.Context) (*addonsv1alpha1.AddonImageSetSpec, error) {
+	fsys := l.fs(ctx)
+
+	meta, err := l.readMeta(ctx, fsys, make(types.Provenance))
+	if err != nil {
+		return nil, err
+	}
+
+	if meta.ImageSetVersion == nil {
+		return nil, ErrLegacyAddon
+	}
+
+	if l.cfg.Metadata != nil {
+		return nil, ErrImageSetWithoutDir
+	}
+
+	return l.readImageSet(ctx, fsys, meta, make(types.Provenance), &types.Resolution{})
+}
+
+// logger returns the configured logger or the one carried by ctx.
+func (l *Loader) logger(ctx context.Context) logr.Logger {
+	if l.cfg.Log.GetSink() != nil {
+		return l.cfg.Log
+	}
+
+	return logr.FromContextOrDiscard(ctx)
+}
+
+func (l *Loader) fs(ctx context.Context) fs.FS {
+	fsys := l.cfg.FS
+
+	switch {
+	case fsys != nil:
+	case l.cfg.Metadata != nil:
+		fsys = newFileFS(l.MetadataPath(), l.cfg.Metadata)
+	case IsRemote(l.addonDir):
+		fsys = NewRemoteFS(ctx, l.addonDir, l.cfg.Transport)
+	default:
+		fsys = os.DirFS(l.addonDir)
+	}
+
+	if len(l.cfg.Checksums) > 0 {
+		fsys = checksumFS{fsys: fsys, checksums: l.cfg.Checksums}
+	}
+
+	return fsys
+}
+
+func (l *Loader) readMeta(ctx context.Context, fsys fs.FS, prov types.Provenance) (*addonsv1alpha1.AddonMetadataSpec, error) {
+	data, err := fs.ReadFile(fsys, l.MetadataPath())
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %w", ErrMetadataNotFound, err)
+	} else if err != nil {
+		return nil, err
+	}
+	l.logger(ctx).V(1).Info("raw metadata read from addon", "addon", l.cfg.AddonName, "data", string(data))
+
+	docs, err := l.readMetaDocuments(ctx, fsys, data)
+	if err != nil {
+		return nil, err
+	}
+
+	expanded, err := expandDocuments(docs)
+	if err != nil {
+		return nil, fmt.Errorf("expanding aliases: %w", err)
+	}
+
+	recordDocuments(prov, docs)
+
+	meta := &addonsv1alpha1.AddonMetadataSpec{}
+	for _, doc := range docs {
+		if err := l.handleUnknownFields(ctx, checkKnownFieldsNode(doc.File, doc.Node, meta)); err != nil {
+			return nil, err
+		}
+	}
+	// a single document without aliases is decoded as is to avoid re-encoding its content
+	if len(docs) > 1 || expanded {
+		merged, err := mergeDocuments(docs)
+		if err != nil {
+			return nil, fmt.Errorf("merging metadata documents: %w", err)
+		}
+		if data, err = yaml.Marshal(merged); err != nil {
+			return nil, fmt.Errorf("encoding merged metadata: %w", err)
+		}
+	}
+	err = meta.FromYAML(data)
+	return meta, err
+}
+
+// readMetaDocuments returns all documents found in 'addon.yaml' followed by
+// the documents of the metadata fragments within the same directory named
+// 'addon.<name>.yaml' (e.g. 'addon.parameters.yaml') in lexical order.
+func (l *Loader) readMetaDocuments(ctx context.Context, fsys fs.FS, data []byte) ([]metaDocument, error) {
+	docs, err := splitDocuments(l.MetadataPath(), data)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := path.Dir(l.MetadataPath())
+
+	fragments, err := metadataFragments(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("listing metadata files: %w", err)
+	}
+
+	for _, name := range fragments {
+		fragmentPath := path.Join(dir, name)
+
+		fragment, err := fs.ReadFile(fsys, fragmentPath)
+		if err != nil {
+			return nil, err
+		}
+		l.logger(ctx).V(1).Info("raw metadata fragment read from addon", "addon", l.cfg.AddonName, "file", name, "data", string(fragment))
+
+		fragmentDocs, err := splitDocuments(fragmentPath, fragment)
+		if err != nil {
+			return nil, err
+		}
+
+		docs = append(docs, fragmentDocs...)
+	}
+
+	return docs, nil
+}
+
+// handleUnknownFields returns err when strict decoding is enabled.
+// Otherwise every unknown field reported by err is logged as a warning
+// so that typos in metadata files do not go unnoticed.
+func (l *Loader) handleUnknownFields(ctx context.Context, err error) error {
+	if err == nil || l.cfg.Strict {
+		return err
+	}
+
+	log := l.logger(ctx)
+
+	for _, e := range multierr.Errors(err) {
+		// other errors (e.g. invalid YAML) are reported when decoding
+		var unknown UnknownFieldError
+		if !errors.As(e, &unknown) {
+			continue
+		}
+
+		log.Info("ignoring unknown field; enable strict decoding to reject it",
+			"file", unknown.File, "line", unknown.Line, "field", unknown.Path,
+		)
+	}
+
+	return nil
+}
+
+const addonMetadataFile = "addon.yaml"
+
+// MetadataPath returns the path of the addon metadata file
+// of the configured environment relative to the addon dir.
+func (l *Loader) MetadataPath() string {
+	return path.Join("metadata", l.cfg.Env, addonMetadataFile)
+}
+
+// readImageSet reads the imageset selected by meta and records the
+// requested version, the file it resolved to and all available versions.
+func (l *Loader) readImageSet(ctx context.Context, fsys fs.FS, meta *addonsv1alpha1.AddonMetadataSpec, prov types.Provenance, res *types.Resolution) (*addonsv1alpha1.AddonImageSetSpec, error) {
+	version := l.imageSetVersion(*meta.ImageSetVersion)
+	imageSetPath, err := l.imageSetPath(fsys, version, meta)
+	if err != nil {
+		return nil, err
+	}
+	res.RequestedImageSetVersion = version
+	res.ImageSetFile = imageSetPath
+	res.ImageSetVersions = l.availableImageSetVersions(fsys)
+	data, err := fs.ReadFile(fsys, imageSetPath)
+	if err != nil {
+		return nil, err
+	}
+	l.logger(ctx).V(1).Info("raw imageSet read from addon", "addon", l.cfg.AddonName, "data", string(data))
+	if data, err = expandImageSet(imageSetPath, data); err != nil {
+		return nil, fmt.Errorf("expanding aliases: %w", err)
+	}
+	imageSet := &addonsv1alpha1.AddonImageSetSpec{}
+	if err := l.handleUnknownFields(ctx, CheckKnownFields(imageSetPath, data, imageSet)); err != nil {
+		return nil, err
+	}
+	if err := recordImageSet(prov, imageSetPath, data); err != nil {
+		return nil, err
+	}
+	err = imageSet.FromYAML(data)
+	return imageSet, err
+}
+
+// availableImageSetVersions returns the sorted versions of all imagesets
+// of the environment or nil if they can't be listed (e.g. remote addons).
+func (l *Loader) availableImageSetVersions(fsys fs.FS) []string {
+	versions, err := imageSetVersions(fsys, path.Join("addonimagesets", l.cfg.Env))
+	if err != nil {
+		return nil
+	}
+
+	sorted := make([]semver.Version, 0, len(versions))
+	for _, v := range versions {
+		sorted = append(sorted, v)
+	}
+
+	semver.Sort(sorted)
+
+	res := make([]string, 0, len(sorted))
+	for _, v := range sorted {
+		res = append(res, v.String())
+	}
+
+	return res
+}
+
+// defaultVersion == meta.ImageSetVersion
+// Can be overriden by providing the WithVersion option
+func (l *Loader) imageSetVersion(defaultVersion string) string {
+	if l.cfg.Version != "" {
+		return l.cfg.Version
+	}
+	return defaultVersion
+}
+
+func (l *Loader) imageSetPath(fsys fs.FS, version string, meta *addonsv1alpha1.AddonMetadataSpec) (string, error) {
+	baseDir := path.Join("addonimagesets", l.cfg.Env)
+	target := fmt.Sprintf("%s.v%s.yaml", l.cfg.AddonName, version)
+	if version == "latest" {
+		latest, err := l.cfg.VersionStrategy.LatestImageSet(fsys, baseDir, meta)
+		if err != nil {
+			return "", fmt.Errorf("resolving latest imageset: %w", err)
+		}
+		target = latest
+	}
+	return path.Join(baseDir, target), nil
+}
+
+// LatestImageSet returns the name of the latest imageset file
+// within the given directory of fsys.
+func LatestImageSet(fsys fs.FS, dir string) (string, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return "", err
+	}
+	if len(entries) == 0 {
+		return "", ErrNoImageSets
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(names)))
+	return names[0], nil
+}
@@ -0,0 +1,527 @@
+package metadata_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/go-logr/logr/funcr"
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	"github.com/mt-sre/addon-metadata-operator/pkg/metadata"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoaderWithFS(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"metadata/production/addon.yaml": {Data: []byte(`
+id: fs-addon
+addonImageSetVersion: latest
+`)},
+		"addonimagesets/production/fs-addon.v1.0.0.yaml": {Data: []byte(`
+name: fs-addon.v1.0.0
+indexImage: quay.io/osd-addons/fs-addon-index:v1.0.0
+relatedImages: []
+`)},
+		"addonimagesets/production/fs-addon.v1.1.0.yaml": {Data: []byte(`
+name: fs-addon.v1.1.0
+indexImage: quay.io/osd-addons/fs-addon-index:v1.1.0
+relatedImages: []
+`)},
+	}
+
+	for name, tc := range map[string]struct {
+		Version         string
+		ExpectedVersion string
+	}{
+		"latest": {
+			ExpectedVersion: "1.1.0",
+		},
+		"pinned": {
+			Version:         "1.0.0",
+			ExpectedVersion: "1.0.0",
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			loader := metadata.NewLoader("fs-addon",
+				metadata.WithFS{FS: fsys},
+				metadata.WithEnv("production"),
+				metadata.WithVersion(tc.Version),
+				metadata.WithStrict(true),
+			)
+
+			meta, err := loader.Load(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, tc.ExpectedVersion, *meta.ImageSetVersion)
+			require.Equal(t, "quay.io/osd-addons/fs-addon-index:v"+tc.ExpectedVersion, *meta.IndexImage)
+		})
+	}
+}
+
+func TestLoaderMetadataNotFound(t *testing.T) {
+	t.Parallel()
+
+	_, err := metadata.NewLoader("missing-addon", metadata.WithFS{FS: fstest.MapFS{}}).Load(context.Background())
+	require.ErrorIs(t, err, metadata.ErrMetadataNotFound)
+	require.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestLoaderUnknownFields(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"metadata/stage/addon.yaml": {Data: []byte(`
+id: typo-addon
+indexImage: quay.io/osd-addons/typo-addon-index:v1.0.0
+defaultChanel: alpha
+`)},
+	}
+
+	var logs []string
+
+	logger := funcr.New(func(_, args string) {
+		logs = append(logs, args)
+	}, funcr.Options{})
+
+	_, err := metadata.NewLoader("typo-addon", metadata.WithFS{FS: fsys}, metadata.WithLog{Logger: logger}).
+		Load(context.Background())
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	require.Contains(t, logs[0], `"field"="defaultChanel"`)
+
+	_, err = metadata.NewLoader("typo-addon", metadata.WithFS{FS: fsys}, metadata.WithStrict(true)).
+		Load(context.Background())
+	require.ErrorIs(t, err, metadata.ErrUnknownField)
+}
+
+func TestLoaderRemote(t *testing.T) {
+	t.Parallel()
+
+	env := "stage"
+	refAddonStage, err := testutils.GetReferenceAddonStage()
+	require.NoError(t, err)
+
+	srv := httptest.NewTLSServer(http.FileServer(http.Dir(filepath.Dir(refAddonStage.ImageSetDir()))))
+	t.Cleanup(srv.Close)
+
+	addonURL := srv.URL + "/reference-addon"
+	metaData, err := os.ReadFile(filepath.Join(refAddonStage.ImageSetDir(), "metadata", env, "addon.yaml"))
+	require.NoError(t, err)
+
+	metaSum := sha256.Sum256(metaData)
+
+	for name, tc := range map[string]struct {
+		AddonURL        string
+		Version         string
+		Options         []metadata.LoaderOption
+		ExpectedVersion string
+		ExpectError     bool
+	}{
+		"explicit version": {
+			AddonURL:        addonURL,
+			Version:         "0.0.1",
+			ExpectedVersion: "0.0.1",
+		},
+		"metadata version": {
+			AddonURL:        addonURL,
+			ExpectedVersion: "0.0.5",
+		},
+		"path placeholder": {
+			AddonURL:        srv.URL + "/reference-addon/{path}",
+			Options:         []metadata.LoaderOption{metadata.WithAddonName("reference-addon")},
+			ExpectedVersion: "0.0.5",
+		},
+		"latest unsupported": {
+			AddonURL:    addonURL,
+			Version:     "latest",
+			ExpectError: true,
+		},
+		"valid checksum": {
+			AddonURL: addonURL,
+			Options: []metadata.LoaderOption{
+				metadata.WithChecksums{"metadata/stage/addon.yaml": hex.EncodeToString(metaSum[:])},
+			},
+			ExpectedVersion: "0.0.5",
+		},
+		"invalid checksum": {
+			AddonURL: addonURL,
+			Options: []metadata.LoaderOption{
+				metadata.WithChecksums{"metadata/stage/addon.yaml": "deadbeef"},
+			},
+			ExpectError: true,
+		},
+		"missing file": {
+			AddonURL:    srv.URL + "/does-not-exist",
+			ExpectError: true,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := append([]metadata.LoaderOption{
+				metadata.WithTransport{RoundTripper: srv.Client().Transport},
+			}, tc.Options...)
+
+			opts = append(opts, metadata.WithEnv(env), metadata.WithVersion(tc.Version))
+
+			meta, err := metadata.NewLoader(tc.AddonURL, opts...).Load(context.Background())
+			if tc.ExpectError {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.ExpectedVersion, *meta.ImageSetVersion)
+		})
+	}
+}
+
+func TestLoaderWithMetadata(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Data               string
+		Options            []metadata.LoaderOption
+		ExpectedIndexImage string
+		ExpectedErr        error
+	}{
+		"index image": {
+			Data: `
+id: stdin-addon
+indexImage: quay.io/osd-addons/stdin-addon-index:v1.0.0
+`,
+			Options:            []metadata.LoaderOption{metadata.WithStrict(true)},
+			ExpectedIndexImage: "quay.io/osd-addons/stdin-addon-index:v1.0.0",
+		},
+		"valid checksum": {
+			Data: "id: stdin-addon\nindexImage: quay.io/osd-addons/stdin-addon-index:v1.0.0\n",
+			Options: []metadata.LoaderOption{
+				metadata.WithEnv("production"),
+				metadata.WithChecksums{"metadata/production/addon.yaml": sha256Hex("id: stdin-addon\nindexImage: quay.io/osd-addons/stdin-addon-index:v1.0.0\n")},
+			},
+			ExpectedIndexImage: "quay.io/osd-addons/stdin-addon-index:v1.0.0",
+		},
+		"imageset": {
+			Data: `
+id: stdin-addon
+addonImageSetVersion: latest
+`,
+			ExpectedErr: metadata.ErrImageSetWithoutDir,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := append([]metadata.LoaderOption{metadata.WithMetadata(tc.Data)}, tc.Options...)
+
+			mb, err := metadata.NewLoader("-", opts...).LoadMetaBundle(context.Background())
+			if tc.ExpectedErr != nil {
+				require.ErrorIs(t, err, tc.ExpectedErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.ExpectedIndexImage, *mb.AddonMeta.IndexImage)
+		})
+	}
+}
+
+func TestFetchFile(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/addon.yaml":
+			_, _ = w.Write([]byte("id: remote-addon\n"))
+		case "/forbidden.yaml":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	data, err := metadata.FetchFile(context.Background(), srv.URL+"/addon.yaml", srv.Client().Transport)
+	require.NoError(t, err)
+	require.Equal(t, "id: remote-addon\n", string(data))
+
+	_, err = metadata.FetchFile(context.Background(), srv.URL+"/missing.yaml", srv.Client().Transport)
+	require.ErrorIs(t, err, fs.ErrNotExist)
+
+	_, err = metadata.FetchFile(context.Background(), srv.URL+"/forbidden.yaml", srv.Client().Transport)
+	require.ErrorIs(t, err, metadata.ErrUnexpectedResponseCode)
+
+	_, err = metadata.FetchFile(context.Background(), "http://example.com/addon.yaml", nil)
+	require.Error(t, err)
+}
+
+func sha256Hex(data string) string {
+	sum := sha256.Sum256([]byte(data))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func TestLoaderSplitFiles(t *testing.T) {
+	t.Parallel()
+
+	const addonYAML = `
+id: split-addon
+operatorName: split-addon
+indexImage: quay.io/osd-addons/split-addon-index:v1
+---
+name: Split Addon
+`
+
+	for name, tc := range map[string]struct {
+		Files       map[string]string
+		ExpectError bool
+	}{
+		"multi document addon.yaml": {
+			Files: map[string]string{
+				"addon.yaml": addonYAML,
+			},
+		},
+		"additional files": {
+			Files: map[string]string{
+				"addon.yaml": addonYAML,
+				"addon.parameters.yaml": `
+addOnParameters:
+  - id: size
+    name: Size
+`,
+				"addon.monitoring.yml": `
+monitoring:
+  namespace: split-addon
+`,
+				"README.md": "ignored",
+			},
+		},
+		"unrelated yaml files": {
+			Files: map[string]string{
+				"addon.yaml": addonYAML,
+				"notes.yaml": "name: Other Name\n",
+			},
+		},
+		"duplicate key": {
+			Files: map[string]string{
+				"addon.yaml":            addonYAML,
+				"addon.extra-name.yaml": "name: Other Name\n",
+			},
+			ExpectError: true,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			addonDir := filepath.Join(t.TempDir(), "split-addon")
+			metaDir := filepath.Join(addonDir, "metadata", "stage")
+			require.NoError(t, os.MkdirAll(metaDir, 0o755))
+
+			for name, content := range tc.Files {
+				require.NoError(t, os.WriteFile(filepath.Join(metaDir, name), []byte(content), 0o644))
+			}
+
+			meta, err := metadata.NewLoader(addonDir, metadata.WithStrict(true)).Load(context.Background())
+			if tc.ExpectError {
+				require.ErrorIs(t, err, metadata.ErrDuplicateKey)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "split-addon", meta.ID)
+			require.Equal(t, "Split Addon", meta.Name)
+
+			if _, ok := tc.Files["addon.parameters.yaml"]; ok {
+				require.NotNil(t, meta.AddOnParameters)
+				require.Len(t, *meta.AddOnParameters, 1)
+				require.NotNil(t, meta.Monitoring)
+			}
+		})
+	}
+}
+
+func TestLoaderProvenance(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"metadata/stage/addon.yaml": {Data: []byte(`id: prov-addon
+addonImageSetVersion: 1.0.0
+channels:
+  - name: alpha
+    currentCSV: prov-addon.v1.0.0
+`)},
+		"metadata/stage/addon.parameters.yaml": {Data: []byte(`addOnParameters:
+  - id: size
+    name: Size
+`)},
+		"addonimagesets/stage/prov-addon.v1.0.0.yaml": {Data: []byte(`name: prov-addon.v1.0.0
+indexImage: quay.io/osd-addons/prov-addon-index:v1.0.0
+relatedImages: []
+`)},
+	}
+
+	_, prov, err := metadata.NewLoader("prov-addon", metadata.WithFS{FS: fsys}).LoadWithProvenance(context.Background())
+	require.NoError(t, err)
+
+	for path, expected := range map[string]types.Source{
+		"id": {
+			File: "metadata/stage/addon.yaml", Line: 1, Column: 1, Kind: types.SourceKindBase,
+		},
+		"channels[0].currentCSV": {
+			File: "metadata/stage/addon.yaml", Line: 5, Column: 5, Kind: types.SourceKindBase,
+		},
+		"channels[0].name.unknown": {
+			File: "metadata/stage/addon.yaml", Line: 4, Column: 5, Kind: types.SourceKindBase,
+		},
+		"addOnParameters[0].id": {
+			File: "metadata/stage/addon.parameters.yaml", Line: 2, Column: 5, Kind: types.SourceKindOverlay,
+		},
+		"indexImage": {
+			File: "addonimagesets/stage/prov-addon.v1.0.0.yaml", Line: 2, Column: 1, Kind: types.SourceKindImageSet,
+		},
+	} {
+		src, ok := prov.Lookup(path)
+		require.True(t, ok, path)
+		require.Equal(t, expected, src, path)
+	}
+}
+
+func TestLoaderResolution(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"metadata/stage/addon.yaml": {Data: []byte(`id: res-addon
+addonImageSetVersion: latest
+defaultChannel: beta
+channels:
+  - name: alpha
+    currentCSV: res-addon.v0.9.0
+  - name: beta
+    currentCSV: res-addon.v1.10.0
+`)},
+		"addonimagesets/stage/res-addon.v1.9.0.yaml": {Data: []byte(`name: res-addon.v1.9.0
+indexImage: quay.io/osd-addons/res-addon-index:v1.9.0
+relatedImages: []
+`)},
+		"addonimagesets/stage/res-addon.v1.10.0.yaml": {Data: []byte(`name: res-addon.v1.10.0
+indexImage: quay.io/osd-addons/res-addon-index:v1.10.0
+relatedImages: []
+`)},
+	}
+
+	mb, err := metadata.NewLoader("res-addon",
+		metadata.WithFS{FS: fsys},
+		metadata.WithVersionStrategy{VersionStrategy: metadata.SemverVersionStrategy{}},
+	).LoadMetaBundle(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, types.Resolution{
+		RequestedImageSetVersion: "latest",
+		ImageSetVersion:          "1.10.0",
+		ImageSetFile:             "addonimagesets/stage/res-addon.v1.10.0.yaml",
+		ImageSetVersions:         []string{"1.9.0", "1.10.0"},
+		DefaultChannel:           "beta",
+		ChannelHead:              "res-addon.v1.10.0",
+	}, mb.Resolution)
+	require.True(t, mb.Resolution.IsLatest())
+	require.Nil(t, mb.Resolved().HeadBundle)
+	require.NotNil(t, mb.ImageSet)
+	require.Equal(t, "res-addon.v1.10.0", mb.ImageSet.Name)
+}
+
+func TestLoaderAliases(t *testing.T) {
+	t.Parallel()
+
+	const imageSet = `
+name: alias-addon.v1.0.0
+indexImage: &index quay.io/osd-addons/alias-addon-index:v1.0.0
+relatedImages:
+  - *index
+`
+
+	for name, tc := range map[string]struct {
+		Metadata      string
+		ExpectedError error
+	}{
+		"anchors and merge keys": {
+			Metadata: `
+id: alias-addon
+name: &name Alias Addon
+description: *name
+addonImageSetVersion: 1.0.0
+commonLabels: &labels
+  team: mt-sre
+namespaceLabels:
+  <<: *labels
+  monitoring: "true"
+`,
+		},
+		"cycle": {
+			Metadata: `
+id: alias-addon
+addonImageSetVersion: 1.0.0
+namespaces: &ns
+  - *ns
+`,
+			ExpectedError: metadata.ErrAliasCycle,
+		},
+		"expansion bomb": {
+			Metadata: `
+id: alias-addon
+addonImageSetVersion: 1.0.0
+a: &a ["x", "x", "x", "x", "x", "x", "x", "x", "x", "x"]
+b: &b [*a, *a, *a, *a, *a, *a, *a, *a, *a, *a]
+c: &c [*b, *b, *b, *b, *b, *b, *b, *b, *b, *b]
+d: &d [*c, *c, *c, *c, *c, *c, *c, *c, *c, *c]
+e: &e [*d, *d, *d, *d, *d, *d, *d, *d, *d, *d]
+f: &f [*e, *e, *e, *e, *e, *e, *e, *e, *e, *e]
+`,
+			ExpectedError: metadata.ErrAliasExpansion,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			fsys := fstest.MapFS{
+				"metadata/stage/addon.yaml":                    {Data: []byte(tc.Metadata)},
+				"addonimagesets/stage/alias-addon.v1.0.0.yaml": {Data: []byte(imageSet)},
+			}
+
+			meta, err := metadata.NewLoader("alias-addon", metadata.WithFS{FS: fsys}).Load(context.Background())
+			if tc.ExpectedError != nil {
+				require.ErrorIs(t, err, tc.ExpectedError)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "Alias Addon", meta.Description)
+			require.Equal(t, map[string]string{"team": "mt-sre", "monitoring": "true"}, meta.NamespaceLabels)
+			require.Equal(t, "quay.io/osd-addons/alias-addon-index:v1.0.0", *meta.IndexImage)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package metadata
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+var ErrDuplicateKey = errors.New("duplicate key")
+
+// metaDocument is a single YAML document and the file it was read from.
+type metaDocument struct {
+	File string
+	Node *yaml.Node
+}
+
+// splitDocuments decodes every YAML document contained in data.
+// Empty documents are skipped.
+func splitDocuments(file string, data []byte) ([]metaDocument, error) {
+	var docs []metaDocument
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+
+	for {
+		var node yaml.Node
+
+		err := dec.Decode(&node)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("parsing %q: %w", file, err)
+		}
+
+		if len(node.Content) == 0 || isNullNode(node.Content[0]) {
+			continue
+		}
+
+		if node.Content[0].Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("parsing %q: document at line %d is not a mapping", file, node.Content[0].Line)
+		}
+
+		docs = append(docs, metaDocument{File: file, Node: node.Content[0]})
+	}
+
+	return docs, nil
+}
+
+func isNullNode(n *yaml.Node) bool {
+	return n.Kind == yaml.ScalarNode && n.Tag == "!!null"
+}
+
+// mergeDocuments combines the top-level keys of all documents into a
+// single mapping in the order given. A key may only be defined once
+// across all documents so that the result never depends on ordering.
+func mergeDocuments(docs []metaDocument) (*yaml.Node, error) {
+	type origin struct {
+		File string
+		Line int
+	}
+
+	merged := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	seen := make(map[string]origin)
+
+	for _, doc := range docs {
+		for i := 0; i+1 < len(doc.Node.Content); i += 2 {
+			key := doc.Node.Content[i]
+
+			if prev, ok := seen[key.Value]; ok {
+				return nil, fmt.Errorf("%w %q: defined in %s:%d and %s:%d",
+					ErrDuplicateKey, key.Value, prev.File, prev.Line, doc.File, key.Line,
+				)
+			}
+
+			seen[key.Value] = origin{File: doc.File, Line: key.Line}
+			merged.Content = append(merged.Content, key, doc.Node.Content[i+1])
+		}
+	}
+
+	return merged, nil
+}
+
+// fragmentPatterns match the names of metadata fragments which are
+// merged into 'addon.yaml' (e.g. 'addon.parameters.yaml'). Other files
+// next to 'addon.yaml' are never read so that unrelated YAML files in
+// the metadata directory do not change the metadata of an addon.
+var fragmentPatterns = []string{"addon.*.yaml", "addon.*.yml"}
+
+// metadataFragments returns the names of the metadata fragments found
+// next to 'addon.yaml' sorted lexically so that merging is deterministic.
+func metadataFragments(fsys fs.FS, dir string) ([]string, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if errors.Is(err, ErrRemoteListUnsupported) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var res []string
+
+	for _, e := range entries {
+		if e.IsDir() || !isMetadataFragment(e.Name()) {
+			continue
+		}
+
+		res = append(res, e.Name())
+	}
+
+	sort.Strings(res)
+
+	return res, nil
+}
+
+func isMetadataFragment(name string) bool {
+	for _, pattern := range fragmentPatterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+
+	return false
+}
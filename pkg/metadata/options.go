@@ -0,0 +1,208 @@
+package metadata
+
+import (
+	"io/fs"
+	"net/http"
+	"path"
+	"path/filepath"
+
+	"github.com/go-logr/logr"
+)
+
+type LoaderConfig struct {
+	AddonName       string
+	Checksums       map[string]string
+	Env             string
+	FS              fs.FS
+	Log             logr.Logger
+	Metadata        []byte
+	Strict          bool
+	Transport       http.RoundTripper
+	Version         string
+	VersionStrategy VersionStrategy
+}
+
+func (c *LoaderConfig) Option(opts ...LoaderOption) {
+	for _, opt := range opts {
+		opt.ConfigureLoader(c)
+	}
+}
+
+const defaultEnv = "stage"
+
+func (c *LoaderConfig) Default(addonDir string) {
+	if c.AddonName == "" {
+		if IsRemote(addonDir) {
+			c.AddonName = remoteAddonName(addonDir)
+		} else {
+			c.AddonName = path.Base(addonDir)
+		}
+	}
+
+	if c.Env == "" {
+		c.Env = defaultEnv
+	}
+
+	if c.VersionStrategy == nil {
+		c.VersionStrategy = LexicalVersionStrategy{}
+	}
+}
+
+type LoaderOption interface {
+	ConfigureLoader(*LoaderConfig)
+}
+
+// WithAddonName overrides the addon name which is otherwise
+// derived from the last element of the addon directory.
+type WithAddonName string
+
+func (w WithAddonName) ConfigureLoader(c *LoaderConfig) {
+	c.AddonName = string(w)
+}
+
+func (w WithAddonName) ConfigureBump(c *BumpConfig) {
+	c.AddonName = string(w)
+}
+
+// WithChecksums maps slash separated paths relative to the addon
+// directory (e.g. 'metadata/stage/addon.yaml') to their expected
+// hex encoded sha256 sums. Files with a listed checksum are verified
+// after being read.
+type WithChecksums map[string]string
+
+func (w WithChecksums) ConfigureLoader(c *LoaderConfig) {
+	if c.Checksums == nil {
+		c.Checksums = make(map[string]string, len(w))
+	}
+
+	for p, sum := range w {
+		c.Checksums[p] = sum
+	}
+}
+
+// WithEnv selects the environment (integration, stage or production)
+// to load metadata for. Defaults to 'stage'.
+type WithEnv string
+
+func (w WithEnv) ConfigureLoader(c *LoaderConfig) {
+	c.Env = string(w)
+}
+
+func (w WithEnv) ConfigureBump(c *BumpConfig) {
+	c.Env = string(w)
+}
+
+// WithFS reads addon files from the given fs.FS which must be rooted
+// at the addon directory. The addon directory passed to NewLoader is
+// then only used to derive the addon name.
+type WithFS struct{ fs.FS }
+
+func (w WithFS) ConfigureLoader(c *LoaderConfig) {
+	c.FS = w.FS
+}
+
+// WithLog applies the given logger. Defaults to the
+// logger carried by the context passed when loading.
+type WithLog struct{ logr.Logger }
+
+func (w WithLog) ConfigureLoader(c *LoaderConfig) {
+	c.Log = w.Logger
+}
+
+// WithMetadata reads the addon metadata from the given content rather
+// than the addon directory, e.g. an 'addon.yaml' read from stdin or
+// fetched from a URL. No other files of the addon directory are read
+// so addons referring to imagesets can't be loaded.
+type WithMetadata []byte
+
+func (w WithMetadata) ConfigureLoader(c *LoaderConfig) {
+	c.Metadata = []byte(w)
+}
+
+// WithStrict enables strict decoding which rejects metadata
+// files containing keys unknown to the metadata types. Unknown
+// keys are otherwise logged as warnings and ignored.
+type WithStrict bool
+
+func (w WithStrict) ConfigureLoader(c *LoaderConfig) {
+	c.Strict = bool(w)
+}
+
+// WithTransport applies the given http.RoundTripper to requests
+// made when loading metadata from a remote addon directory.
+type WithTransport struct{ http.RoundTripper }
+
+func (w WithTransport) ConfigureLoader(c *LoaderConfig) {
+	c.Transport = w.RoundTripper
+}
+
+// WithVersion overrides the 'addonImageSetVersion' of the addon
+// metadata. Accepts either 'latest' or a 'MAJOR.MINOR.PATCH' version.
+type WithVersion string
+
+func (w WithVersion) ConfigureLoader(c *LoaderConfig) {
+	c.Version = string(w)
+}
+
+// WithVersionStrategy selects how the 'latest' imageset version is
+// resolved. Defaults to LexicalVersionStrategy.
+type WithVersionStrategy struct{ VersionStrategy }
+
+func (w WithVersionStrategy) ConfigureLoader(c *LoaderConfig) {
+	c.VersionStrategy = w.VersionStrategy
+}
+
+type BumpConfig struct {
+	AddonName     string
+	Env           string
+	IndexImage    string
+	Increment     Increment
+	RelatedImages []string
+}
+
+func (c *BumpConfig) Option(opts ...BumpOption) {
+	for _, opt := range opts {
+		opt.ConfigureBump(c)
+	}
+}
+
+func (c *BumpConfig) Default(addonDir string) {
+	if c.AddonName == "" {
+		c.AddonName = filepath.Base(addonDir)
+	}
+
+	if c.Env == "" {
+		c.Env = defaultEnv
+	}
+
+	if c.Increment == "" {
+		c.Increment = IncrementPatch
+	}
+}
+
+type BumpOption interface {
+	ConfigureBump(*BumpConfig)
+}
+
+// WithIncrement selects the part of the version which is
+// bumped. Defaults to IncrementPatch.
+type WithIncrement Increment
+
+func (w WithIncrement) ConfigureBump(c *BumpConfig) {
+	c.Increment = Increment(w)
+}
+
+// WithIndexImage sets the 'indexImage' of the new imageset.
+type WithIndexImage string
+
+func (w WithIndexImage) ConfigureBump(c *BumpConfig) {
+	c.IndexImage = string(w)
+}
+
+// WithRelatedImages replaces the 'relatedImages' of the new imageset.
+// The related images of the previous imageset are kept otherwise.
+type WithRelatedImages []string
+
+func (w WithRelatedImages) ConfigureBump(c *BumpConfig) {
+	c.RelatedImages = []string(w)
+}
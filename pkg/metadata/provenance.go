@@ -0,0 +1,126 @@
+package metadata
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// imageSetFields are the top-level fields of the addon metadata
+// which are replaced by the selected imageset.
+var imageSetFields = []string{
+	"indexImage",
+	"addOnParameters",
+	"addOnRequirements",
+	"subOperators",
+}
+
+// recordDocuments records the location of every field defined in docs.
+// Fields of the first file are attributed to the base metadata and all
+// others to overlays.
+func recordDocuments(prov types.Provenance, docs []metaDocument) {
+	for _, doc := range docs {
+		kind := types.SourceKindOverlay
+		if doc.File == docs[0].File {
+			kind = types.SourceKindBase
+		}
+
+		recorder{prov: prov, file: doc.File, kind: kind}.record(doc.Node, "")
+	}
+}
+
+// recordImageSet replaces the locations of all fields which are
+// defined by the imageset and therefore override the metadata.
+func recordImageSet(prov types.Provenance, file string, data []byte) error {
+	var doc yaml.Node
+
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("parsing %q: %w", file, err)
+	}
+
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil
+	}
+
+	root := doc.Content[0]
+	rec := recorder{prov: prov, file: file, kind: types.SourceKindImageSet}
+
+	for _, field := range imageSetFields {
+		idx := keyIndex(root, field)
+		if idx < 0 || isNullNode(root.Content[idx+1]) {
+			continue
+		}
+
+		for path := range prov {
+			if path == field || strings.HasPrefix(path, field+".") || strings.HasPrefix(path, field+"[") {
+				delete(prov, path)
+			}
+		}
+
+		rec.recordField(root.Content[idx], root.Content[idx+1], field)
+	}
+
+	return nil
+}
+
+type recorder struct {
+	prov types.Provenance
+	file string
+	kind types.SourceKind
+}
+
+func (r recorder) record(node *yaml.Node, prefix string) {
+	r.walk(node, prefix, make(map[*yaml.Node]bool))
+}
+
+func (r recorder) recordField(key, val *yaml.Node, path string) {
+	r.set(path, key)
+	r.walk(val, path, make(map[*yaml.Node]bool))
+}
+
+func (r recorder) walk(node *yaml.Node, prefix string, visiting map[*yaml.Node]bool) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+
+	// guard against recursive aliases
+	if visiting[node] {
+		return
+	}
+
+	visiting[node] = true
+	defer delete(visiting, node)
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+
+			path := key.Value
+			if prefix != "" {
+				path = prefix + "." + key.Value
+			}
+
+			r.set(path, key)
+			r.walk(node.Content[i+1], path, visiting)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			path := fmt.Sprintf("%s[%d]", prefix, i)
+
+			r.set(path, item)
+			r.walk(item, path, visiting)
+		}
+	}
+}
+
+func (r recorder) set(path string, node *yaml.Node) {
+	r.prov[path] = types.Source{
+		File:   r.file,
+		Line:   node.Line,
+		Column: node.Column,
+		Kind:   r.kind,
+	}
+}
@@ -0,0 +1,148 @@
+package metadata
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+)
+
+const schemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// MetadataSchema returns a JSON Schema of the addon metadata format
+// ('addon.yaml') derived from the JSON field tags of AddonMetadataSpec
+// and the types it refers to. Fields tagged 'validate:"required"' are
+// required and unknown fields are rejected like in strict mode.
+func MetadataSchema() ([]byte, error) {
+	return generateSchema(
+		reflect.TypeOf(addonsv1alpha1.AddonMetadataSpec{}),
+		"https://github.com/mt-sre/addon-metadata-operator/schemas/addon-metadata.json",
+		"Addon metadata",
+	)
+}
+
+// ImageSetSchema returns a JSON Schema of the addon imageset format
+// derived from AddonImageSetSpec in the same way as MetadataSchema.
+func ImageSetSchema() ([]byte, error) {
+	return generateSchema(
+		reflect.TypeOf(addonsv1alpha1.AddonImageSetSpec{}),
+		"https://github.com/mt-sre/addon-metadata-operator/schemas/addon-imageset.json",
+		"Addon imageset",
+	)
+}
+
+func generateSchema(root reflect.Type, id, title string) ([]byte, error) {
+	g := schemaGenerator{
+		defs:  make(map[string]interface{}),
+		names: make(map[reflect.Type]string),
+	}
+
+	schema := g.structSchema(root)
+	schema["$schema"] = schemaDialect
+	schema["$id"] = id
+	schema["title"] = title
+
+	if len(g.defs) > 0 {
+		schema["$defs"] = g.defs
+	}
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encoding schema: %w", err)
+	}
+
+	return append(data, '\n'), nil
+}
+
+type schemaGenerator struct {
+	defs  map[string]interface{}
+	names map[reflect.Type]string
+}
+
+func (g *schemaGenerator) schema(t reflect.Type) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	// types with custom decoding (e.g. apiextensionsv1.JSON) accept arbitrary content
+	if reflect.PtrTo(t).Implements(jsonUnmarshalerType) {
+		return map[string]interface{}{}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return map[string]interface{}{"$ref": "#/$defs/" + g.define(t)}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "contentEncoding": "base64"}
+		}
+
+		return map[string]interface{}{"type": "array", "items": g.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": g.schema(t.Elem())}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// define adds the schema of the named struct t to the definitions
+// unless it is already defined and returns its definition name.
+func (g *schemaGenerator) define(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+
+	name := t.Name()
+	if _, taken := g.defs[name]; taken || name == "" {
+		name = path.Base(t.PkgPath()) + "." + t.Name()
+	}
+
+	g.names[t] = name
+	// reserve the name before recursing as types may refer to themselves
+	g.defs[name] = nil
+	g.defs[name] = g.structSchema(t)
+
+	return name
+}
+
+func (g *schemaGenerator) structSchema(t reflect.Type) map[string]interface{} {
+	var (
+		props    = make(map[string]interface{})
+		required []string
+	)
+
+	for _, f := range orderedJSONFields(t) {
+		props[f.Name] = g.schema(f.Type)
+
+		for _, rule := range strings.Split(f.Tag.Get("validate"), ",") {
+			if rule == "required" {
+				required = append(required, f.Name)
+			}
+		}
+	}
+
+	res := map[string]interface{}{
+		"type":                 "object",
+		"properties":           props,
+		"additionalProperties": false,
+	}
+
+	if len(required) > 0 {
+		res["required"] = required
+	}
+
+	return res
+}
@@ -0,0 +1,84 @@
+package metadata_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	"github.com/mt-sre/addon-metadata-operator/pkg/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+type testSchema struct {
+	ID         string                     `json:"$id"`
+	Properties map[string]json.RawMessage `json:"properties"`
+	Required   []string                   `json:"required"`
+	Defs       map[string]testSchema      `json:"$defs"`
+}
+
+func TestMetadataSchema(t *testing.T) {
+	t.Parallel()
+
+	data, err := metadata.MetadataSchema()
+	require.NoError(t, err)
+
+	var schema testSchema
+	require.NoError(t, json.Unmarshal(data, &schema))
+
+	assert.NotEmpty(t, schema.ID)
+	assert.Contains(t, schema.Required, "id")
+	assert.NotContains(t, schema.Required, "addOnParameters")
+	assert.Contains(t, schema.Properties, "addOnParameters")
+
+	require.Contains(t, schema.Defs, "AddOnParameter")
+	assert.Contains(t, schema.Defs["AddOnParameter"].Properties, "value_type")
+	assert.Contains(t, schema.Defs, "AddOnRequirement")
+
+	assertRefsDefined(t, string(data), schema.Defs)
+
+	path := filepath.Join(testutils.RootDir().TestData().MetadataV1().Legacy(),
+		"reference-addon", "metadata", "stage", "addon.yaml")
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var meta map[string]interface{}
+	require.NoError(t, yaml.Unmarshal(raw, &meta))
+
+	for field := range meta {
+		assert.Contains(t, schema.Properties, field)
+	}
+}
+
+func TestImageSetSchema(t *testing.T) {
+	t.Parallel()
+
+	data, err := metadata.ImageSetSchema()
+	require.NoError(t, err)
+
+	var schema testSchema
+	require.NoError(t, json.Unmarshal(data, &schema))
+
+	assert.NotEmpty(t, schema.ID)
+	assert.Contains(t, schema.Properties, "indexImage")
+	assert.Contains(t, schema.Properties, "relatedImages")
+
+	assertRefsDefined(t, string(data), schema.Defs)
+}
+
+func assertRefsDefined(t *testing.T, data string, defs map[string]testSchema) {
+	t.Helper()
+
+	const prefix = `"$ref": "#/$defs/`
+
+	for _, part := range strings.Split(data, prefix)[1:] {
+		name := part[:strings.Index(part, `"`)]
+
+		assert.Contains(t, defs, name)
+	}
+}
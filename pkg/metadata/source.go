@@ -0,0 +1,254 @@
+package metadata
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/httputil"
+)
+
+var (
+	ErrChecksumMismatch       = errors.New("checksum mismatch")
+	ErrRemoteListUnsupported  = errors.New("listing directories is not supported for remote addon sources")
+	ErrUnexpectedResponseCode = errors.New("unexpected response code")
+)
+
+// IsRemote returns 'true' if the given addon directory
+// refers to a remote location rather than a local path.
+func IsRemote(addonDir string) bool {
+	return strings.HasPrefix(addonDir, "https://")
+}
+
+// pathPlaceholder can be used within a remote addon URL to control where
+// the relative file path is inserted. This is required for API endpoints
+// such as GitLab's 'repository/files/<path>/raw' which expect the path to
+// be URL encoded and followed by additional path segments or queries.
+const pathPlaceholder = "{path}"
+
+// NewRemoteFS returns an fs.FS which retrieves files relative to the given
+// 'https://' base URL. Directory listings are not supported.
+func NewRemoteFS(ctx context.Context, baseURL string, transport http.RoundTripper) *RemoteFS {
+	var opts []httputil.ClientOption
+
+	if transport != nil {
+		opts = append(opts, httputil.WithTransport{RoundTripper: transport})
+	}
+
+	return &RemoteFS{
+		ctx:     ctx,
+		baseURL: baseURL,
+		client:  httputil.NewClient(opts...),
+	}
+}
+
+type RemoteFS struct {
+	ctx     context.Context
+	baseURL string
+	client  *http.Client
+}
+
+// URLFor returns the URL from which the file at 'name' is retrieved.
+func (s *RemoteFS) URLFor(name string) string {
+	if strings.Contains(s.baseURL, pathPlaceholder) {
+		return strings.ReplaceAll(s.baseURL, pathPlaceholder, url.PathEscape(name))
+	}
+
+	return strings.TrimSuffix(s.baseURL, "/") + "/" + name
+}
+
+func (s *RemoteFS) Open(name string) (fs.File, error) {
+	data, err := s.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return newMemFile(name, data), nil
+}
+
+func (s *RemoteFS) ReadFile(name string) ([]byte, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrInvalid}
+	}
+
+	data, err := get(s.ctx, s.client, s.URLFor(name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrNotExist}
+	}
+
+	return data, err
+}
+
+func (s *RemoteFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	return nil, &fs.PathError{Op: "readdir", Path: name, Err: ErrRemoteListUnsupported}
+}
+
+// FetchFile retrieves the single file at the given 'https://' URL, e.g.
+// the raw file URL of an 'addon.yaml'.
+func FetchFile(ctx context.Context, fileURL string, transport http.RoundTripper) ([]byte, error) {
+	if !IsRemote(fileURL) {
+		return nil, fmt.Errorf("%q is not an 'https://' URL", fileURL)
+	}
+
+	var opts []httputil.ClientOption
+
+	if transport != nil {
+		opts = append(opts, httputil.WithTransport{RoundTripper: transport})
+	}
+
+	return get(ctx, httputil.NewClient(opts...), fileURL)
+}
+
+// get returns the body of a successful GET request to target. An error
+// wrapping fs.ErrNotExist is returned if target is not found.
+func get(ctx context.Context, client *http.Client, target string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request for %q: %w", target, err)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("requesting %q: %w", target, err)
+	}
+
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return io.ReadAll(res.Body)
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("requesting %q: %w", target, fs.ErrNotExist)
+	default:
+		return nil, fmt.Errorf("requesting %q: %w: %d", target, ErrUnexpectedResponseCode, res.StatusCode)
+	}
+}
+
+// remoteAddonName derives the addon name from the final path segment
+// of a remote addon URL which does not contain a path placeholder.
+func remoteAddonName(baseURL string) string {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return ""
+	}
+
+	return path.Base(strings.TrimSuffix(u.Path, "/"))
+}
+
+// checksumFS verifies the sha256 sum of any file with a
+// known checksum before returning its contents.
+type checksumFS struct {
+	fsys      fs.FS
+	checksums map[string]string
+}
+
+func (s checksumFS) Open(name string) (fs.File, error) {
+	if _, ok := s.checksums[name]; !ok {
+		return s.fsys.Open(name)
+	}
+
+	data, err := s.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return newMemFile(name, data), nil
+}
+
+func (s checksumFS) ReadFile(name string) ([]byte, error) {
+	data, err := fs.ReadFile(s.fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	expected, ok := s.checksums[name]
+	if !ok {
+		return data, nil
+	}
+
+	sum := sha256.Sum256(data)
+	if actual := hex.EncodeToString(sum[:]); !strings.EqualFold(actual, expected) {
+		return nil, fmt.Errorf("verifying %q: %w: expected %s, got %s", name, ErrChecksumMismatch, expected, actual)
+	}
+
+	return data, nil
+}
+
+func (s checksumFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	return fs.ReadDir(s.fsys, name)
+}
+
+// fileFS is an fs.FS containing a single file.
+type fileFS struct {
+	name string
+	data []byte
+}
+
+func newFileFS(name string, data []byte) fileFS {
+	return fileFS{name: name, data: data}
+}
+
+func (s fileFS) Open(name string) (fs.File, error) {
+	data, err := s.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return newMemFile(name, data), nil
+}
+
+func (s fileFS) ReadFile(name string) ([]byte, error) {
+	if name != s.name {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrNotExist}
+	}
+
+	return s.data, nil
+}
+
+func (s fileFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if name != path.Dir(s.name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+
+	info := memFileInfo{name: path.Base(s.name), size: int64(len(s.data))}
+
+	return []fs.DirEntry{fs.FileInfoToDirEntry(info)}, nil
+}
+
+func newMemFile(name string, data []byte) *memFile {
+	return &memFile{
+		Reader: bytes.NewReader(data),
+		info:   memFileInfo{name: path.Base(name), size: int64(len(data))},
+	}
+}
+
+// memFile is a read-only fs.File backed by an in-memory buffer.
+type memFile struct {
+	*bytes.Reader
+	info memFileInfo
+}
+
+func (f *memFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *memFile) Close() error               { return nil }
+
+type memFileInfo struct {
+	name string
+	size int64
+}
+
+func (i memFileInfo) Name() string       { return i.name }
+func (i memFileInfo) Size() int64        { return i.size }
+func (i memFileInfo) Mode() fs.FileMode  { return 0o444 }
+func (i memFileInfo) ModTime() time.Time { return time.Time{} }
+func (i memFileInfo) IsDir() bool        { return false }
+func (i memFileInfo) Sys() interface{}   { return nil }
@@ -0,0 +1,177 @@
+package metadata
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"go.uber.org/multierr"
+	"gopkg.in/yaml.v3"
+)
+
+var ErrUnknownField = errors.New("unknown field")
+
+// UnknownFieldError reports a key present in a metadata file which
+// does not map to any field of the type it is decoded into.
+type UnknownFieldError struct {
+	File   string
+	Line   int
+	Column int
+	Path   string
+}
+
+func (e UnknownFieldError) Error() string {
+	return fmt.Sprintf("%s:%d:%d: %s %q", e.File, e.Line, e.Column, ErrUnknownField, e.Path)
+}
+
+func (e UnknownFieldError) Unwrap() error { return ErrUnknownField }
+
+// CheckKnownFields parses the given YAML document and returns a combined
+// error listing every mapping key which is not recognized by the JSON field
+// tags of 'target'. The 'file' argument is only used for reporting.
+func CheckKnownFields(file string, data []byte, target interface{}) error {
+	var doc yaml.Node
+
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("parsing %q: %w", file, err)
+	}
+
+	if len(doc.Content) == 0 {
+		return nil
+	}
+
+	return checkKnownFieldsNode(file, doc.Content[0], target)
+}
+
+func checkKnownFieldsNode(file string, node *yaml.Node, target interface{}) error {
+	c := knownFieldsChecker{file: file}
+	c.check(node, reflect.TypeOf(target), "")
+
+	return c.err
+}
+
+type knownFieldsChecker struct {
+	file string
+	err  error
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+func (c *knownFieldsChecker) check(node *yaml.Node, t reflect.Type, path string) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+
+	// types with custom decoding (e.g. apiextensionsv1.JSON) accept arbitrary content
+	if reflect.PtrTo(t).Implements(jsonUnmarshalerType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+
+		fields := jsonFields(t)
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, val := node.Content[i], node.Content[i+1]
+			fieldPath := path + "." + key.Value
+
+			ft, ok := fields[key.Value]
+			if !ok {
+				c.err = multierr.Append(c.err, UnknownFieldError{
+					File:   c.file,
+					Line:   key.Line,
+					Column: key.Column,
+					Path:   strings.TrimPrefix(fieldPath, "."),
+				})
+
+				continue
+			}
+
+			c.check(val, ft, fieldPath)
+		}
+	case reflect.Slice, reflect.Array:
+		if node.Kind != yaml.SequenceNode {
+			return
+		}
+
+		for i, item := range node.Content {
+			c.check(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i))
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			c.check(node.Content[i+1], t.Elem(), path+"."+node.Content[i].Value)
+		}
+	}
+}
+
+// jsonFields maps the JSON names of a struct's fields to their types
+// following the conventions of 'encoding/json' for embedded structs.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	res := make(map[string]reflect.Type)
+
+	for _, f := range orderedJSONFields(t) {
+		res[f.Name] = f.Type
+	}
+
+	return res
+}
+
+type jsonField struct {
+	Name string
+	Type reflect.Type
+	Tag  reflect.StructTag
+}
+
+// orderedJSONFields returns the JSON fields of a struct in declaration
+// order with the fields of embedded structs in place of the embedding.
+func orderedJSONFields(t reflect.Type) []jsonField {
+	var res []jsonField
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if f.Anonymous && (name == "" || strings.Contains(opts, "inline")) {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+
+			if ft.Kind() == reflect.Struct {
+				res = append(res, orderedJSONFields(ft)...)
+
+				continue
+			}
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		res = append(res, jsonField{Name: name, Type: f.Type, Tag: f.Tag})
+	}
+
+	return res
+}
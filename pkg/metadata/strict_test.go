@@ -0,0 +1,101 @@
+package metadata_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	"github.com/mt-sre/addon-metadata-operator/pkg/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
+)
+
+func TestCheckKnownFields(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Data          string
+		ExpectedPaths []string
+		ExpectedLines []int
+	}{
+		"known fields": {
+			Data: `
+id: reference-addon
+namespaceLabels:
+  any-label: "value"
+addOnParameters:
+  - id: size
+    value_type: string
+addOnRequirements:
+  - id: req
+    data:
+      anything:
+        goes: here
+`,
+		},
+		"unknown top-level field": {
+			Data: `
+id: reference-addon
+defaultChanel: alpha
+`,
+			ExpectedPaths: []string{"defaultChanel"},
+			ExpectedLines: []int{3},
+		},
+		"unknown nested fields": {
+			Data: `
+id: reference-addon
+addOnParameters:
+  - id: size
+    valueType: string
+config:
+  env:
+    - name: FOO
+      vale: bar
+`,
+			ExpectedPaths: []string{"addOnParameters[0].valueType", "config.env[0].vale"},
+			ExpectedLines: []int{5, 9},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := metadata.CheckKnownFields("addon.yaml", []byte(tc.Data), &addonsv1alpha1.AddonMetadataSpec{})
+			if len(tc.ExpectedPaths) == 0 {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.ErrorIs(t, err, metadata.ErrUnknownField)
+
+			errs := multierr.Errors(err)
+			require.Len(t, errs, len(tc.ExpectedPaths))
+
+			for i, err := range errs {
+				var fieldErr metadata.UnknownFieldError
+
+				require.True(t, errors.As(err, &fieldErr))
+				assert.Equal(t, "addon.yaml", fieldErr.File)
+				assert.Equal(t, tc.ExpectedPaths[i], fieldErr.Path)
+				assert.Equal(t, tc.ExpectedLines[i], fieldErr.Line)
+			}
+		})
+	}
+}
+
+func TestLoaderStrict(t *testing.T) {
+	t.Parallel()
+
+	refAddonStage, err := testutils.GetReferenceAddonStage()
+	require.NoError(t, err)
+
+	loader := metadata.NewLoader(refAddonStage.ImageSetDir(), metadata.WithStrict(true))
+
+	_, err = loader.Load(context.Background())
+	require.NoError(t, err)
+}
@@ -0,0 +1,216 @@
+package metadata
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+)
+
+var (
+	ErrNoChannelHead          = errors.New("no head found for the default channel")
+	ErrUnknownVersionStrategy = errors.New("unknown version strategy")
+)
+
+// VersionStrategy resolves the 'latest' imageset version of an addon.
+type VersionStrategy interface {
+	// LatestImageSet returns the name of the latest imageset file
+	// within dir of fsys for the addon described by meta.
+	LatestImageSet(fsys fs.FS, dir string, meta *addonsv1alpha1.AddonMetadataSpec) (string, error)
+}
+
+var versionStrategies = map[string]VersionStrategy{
+	"lexical":      LexicalVersionStrategy{},
+	"semver":       SemverVersionStrategy{},
+	"modified":     ModifiedVersionStrategy{},
+	"channel-head": ChannelHeadVersionStrategy{},
+}
+
+// VersionStrategyNames returns the sorted names accepted by ParseVersionStrategy.
+func VersionStrategyNames() []string {
+	names := make([]string, 0, len(versionStrategies))
+
+	for name := range versionStrategies {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// ParseVersionStrategy returns the VersionStrategy registered under name.
+func ParseVersionStrategy(name string) (VersionStrategy, error) {
+	strategy, ok := versionStrategies[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %q: must be one of %s",
+			ErrUnknownVersionStrategy, name, strings.Join(VersionStrategyNames(), ", "),
+		)
+	}
+
+	return strategy, nil
+}
+
+// LexicalVersionStrategy selects the imageset whose file name sorts last.
+// Note that 'v0.10.0' sorts before 'v0.9.0' with this strategy.
+type LexicalVersionStrategy struct{}
+
+func (LexicalVersionStrategy) LatestImageSet(fsys fs.FS, dir string, _ *addonsv1alpha1.AddonMetadataSpec) (string, error) {
+	return LatestImageSet(fsys, dir)
+}
+
+// SemverVersionStrategy selects the imageset with the highest
+// semantic version. Files without a parsable version are ignored.
+type SemverVersionStrategy struct{}
+
+func (SemverVersionStrategy) LatestImageSet(fsys fs.FS, dir string, _ *addonsv1alpha1.AddonMetadataSpec) (string, error) {
+	versions, err := imageSetVersions(fsys, dir)
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		latest  string
+		highest semver.Version
+	)
+
+	for name, version := range versions {
+		if latest == "" || version.GT(highest) || (version.EQ(highest) && name > latest) {
+			latest, highest = name, version
+		}
+	}
+
+	return latest, nil
+}
+
+// ModifiedVersionStrategy selects the most recently modified imageset.
+type ModifiedVersionStrategy struct{}
+
+func (ModifiedVersionStrategy) LatestImageSet(fsys fs.FS, dir string, _ *addonsv1alpha1.AddonMetadataSpec) (string, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return "", err
+	}
+
+	var latest fs.FileInfo
+
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		info, err := e.Info()
+		if err != nil {
+			return "", err
+		}
+
+		if latest == nil || info.ModTime().After(latest.ModTime()) ||
+			(info.ModTime().Equal(latest.ModTime()) && info.Name() > latest.Name()) {
+			latest = info
+		}
+	}
+
+	if latest == nil {
+		return "", ErrNoImageSets
+	}
+
+	return latest.Name(), nil
+}
+
+// ChannelHeadVersionStrategy selects the imageset matching the version
+// of the 'currentCSV' of the addon's default channel.
+type ChannelHeadVersionStrategy struct{}
+
+func (ChannelHeadVersionStrategy) LatestImageSet(fsys fs.FS, dir string, meta *addonsv1alpha1.AddonMetadataSpec) (string, error) {
+	head, err := channelHeadVersion(meta)
+	if err != nil {
+		return "", err
+	}
+
+	versions, err := imageSetVersions(fsys, dir)
+	if err != nil {
+		return "", err
+	}
+
+	for name, version := range versions {
+		if version.EQ(head) {
+			return name, nil
+		}
+	}
+
+	return "", fmt.Errorf("no imageset found for channel head version %s: %w", head, fs.ErrNotExist)
+}
+
+func channelHeadVersion(meta *addonsv1alpha1.AddonMetadataSpec) (semver.Version, error) {
+	if meta == nil || meta.Channels == nil {
+		return semver.Version{}, ErrNoChannelHead
+	}
+
+	for _, ch := range *meta.Channels {
+		if ch.Name != meta.DefaultChannel {
+			continue
+		}
+
+		version, ok := parseNameVersion(ch.CurrentCSV)
+		if !ok {
+			return semver.Version{}, fmt.Errorf("parsing version of %q: %w", ch.CurrentCSV, ErrNoChannelHead)
+		}
+
+		return version, nil
+	}
+
+	return semver.Version{}, fmt.Errorf("channel %q: %w", meta.DefaultChannel, ErrNoChannelHead)
+}
+
+// imageSetVersions maps the names of all imageset files within
+// dir to their versions skipping files without a version.
+func imageSetVersions(fsys fs.FS, dir string) (map[string]semver.Version, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make(map[string]semver.Version)
+
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		name := e.Name()
+
+		if version, ok := parseNameVersion(strings.TrimSuffix(name, path.Ext(name))); ok {
+			versions[name] = version
+		}
+	}
+
+	if len(versions) == 0 {
+		return nil, ErrNoImageSets
+	}
+
+	return versions, nil
+}
+
+// parseNameVersion extracts the version from names such as
+// 'reference-addon.v0.0.5' where the addon name itself may contain dots.
+func parseNameVersion(name string) (semver.Version, bool) {
+	for i := strings.Index(name, ".v"); i >= 0; {
+		if version, err := semver.Parse(name[i+2:]); err == nil {
+			return version, true
+		}
+
+		next := strings.Index(name[i+2:], ".v")
+		if next < 0 {
+			break
+		}
+
+		i += next + 2
+	}
+
+	return semver.Version{}, false
+}
@@ -0,0 +1,83 @@
+package metadata_test
+
+import (
+	"testing"
+	"testing/fstest"
+	"time"
+
+	addonsv1alpha1 "github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/metadata"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionStrategies(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	fsys := fstest.MapFS{
+		"imagesets/my.addon.v0.9.0.yaml":  {ModTime: now.Add(-2 * time.Hour)},
+		"imagesets/my.addon.v0.10.0.yaml": {ModTime: now.Add(-1 * time.Hour)},
+		"imagesets/my.addon.v0.2.0.yaml":  {ModTime: now},
+	}
+
+	meta := &addonsv1alpha1.AddonMetadataSpec{
+		DefaultChannel: "stable",
+		Channels: &[]addonsv1alpha1.Channel{
+			{Name: "alpha", CurrentCSV: "my.addon.v0.10.0"},
+			{Name: "stable", CurrentCSV: "my.addon.v0.9.0"},
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		Strategy string
+		Expected string
+	}{
+		"lexical": {
+			Strategy: "lexical",
+			Expected: "my.addon.v0.9.0.yaml",
+		},
+		"semver": {
+			Strategy: "semver",
+			Expected: "my.addon.v0.10.0.yaml",
+		},
+		"modified": {
+			Strategy: "modified",
+			Expected: "my.addon.v0.2.0.yaml",
+		},
+		"channel-head": {
+			Strategy: "channel-head",
+			Expected: "my.addon.v0.9.0.yaml",
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			strategy, err := metadata.ParseVersionStrategy(tc.Strategy)
+			require.NoError(t, err)
+
+			latest, err := strategy.LatestImageSet(fsys, "imagesets", meta)
+			require.NoError(t, err)
+			require.Equal(t, tc.Expected, latest)
+		})
+	}
+}
+
+func TestVersionStrategyErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := metadata.ParseVersionStrategy("newest")
+	require.ErrorIs(t, err, metadata.ErrUnknownVersionStrategy)
+
+	fsys := fstest.MapFS{
+		"imagesets/README.md": {},
+	}
+
+	_, err = metadata.SemverVersionStrategy{}.LatestImageSet(fsys, "imagesets", nil)
+	require.ErrorIs(t, err, metadata.ErrNoImageSets)
+
+	_, err = metadata.ChannelHeadVersionStrategy{}.LatestImageSet(fsys, "imagesets", &addonsv1alpha1.AddonMetadataSpec{})
+	require.ErrorIs(t, err, metadata.ErrNoChannelHead)
+}
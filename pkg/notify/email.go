@@ -0,0 +1,249 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// NewEmail returns a Notifier sending summaries by e-mail through the
+// SMTP server described by rawURL which has the following format:
+//
+//	smtp[s]://[<user>:<password>@]<host>[:<port>]?from=<address>&to=<address>[,<address>...]
+//
+// The 'smtps' scheme uses implicit TLS and defaults to port 465 while
+// 'smtp' defaults to port 587 and upgrades the connection using
+// STARTTLS if the server supports it.
+func NewEmail(rawURL string) (*Email, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing smtp url: %w", err)
+	}
+
+	e := Email{host: u.Hostname()}
+
+	switch u.Scheme {
+	case "smtp":
+		e.port = "587"
+	case "smtps":
+		e.port = "465"
+		e.implicitTLS = true
+	default:
+		return nil, fmt.Errorf("smtp url must use the 'smtp' or 'smtps' scheme")
+	}
+
+	if e.host == "" {
+		return nil, errors.New("smtp url has no host")
+	}
+
+	if port := u.Port(); port != "" {
+		e.port = port
+	}
+
+	if u.User != nil {
+		e.user = u.User.Username()
+		e.password, _ = u.User.Password()
+	}
+
+	q := u.Query()
+
+	if e.from = q.Get("from"); e.from == "" {
+		return nil, errors.New("smtp url requires a 'from' address")
+	}
+
+	for _, to := range strings.Split(q.Get("to"), ",") {
+		if to = strings.TrimSpace(to); to != "" {
+			e.to = append(e.to, to)
+		}
+	}
+
+	if len(e.to) == 0 {
+		return nil, errors.New("smtp url requires at least one 'to' address")
+	}
+
+	return &e, nil
+}
+
+// Email sends summaries by e-mail attaching the JSON report.
+type Email struct {
+	host        string
+	port        string
+	implicitTLS bool
+	user        string
+	password    string
+	from        string
+	to          []string
+}
+
+func (e *Email) Notify(ctx context.Context, summary Summary) error {
+	msg, err := e.message(summary)
+	if err != nil {
+		return fmt.Errorf("composing e-mail: %w", err)
+	}
+
+	if err := e.send(ctx, msg); err != nil {
+		return fmt.Errorf("sending e-mail: %w", err)
+	}
+
+	return nil
+}
+
+func (e *Email) send(ctx context.Context, msg []byte) error {
+	addr := net.JoinHostPort(e.host, e.port)
+
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	// net/smtp does not accept a context so the deadline is
+	// applied to the connection instead
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	} else {
+		_ = conn.SetDeadline(time.Now().Add(time.Minute))
+	}
+
+	tlsCfg := &tls.Config{ServerName: e.host, MinVersion: tls.VersionTLS12}
+
+	if e.implicitTLS {
+		conn = tls.Client(conn, tlsCfg)
+	}
+
+	client, err := smtp.NewClient(conn, e.host)
+	if err != nil {
+		return err
+	}
+
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok && !e.implicitTLS {
+		if err := client.StartTLS(tlsCfg); err != nil {
+			return fmt.Errorf("starting tls: %w", err)
+		}
+	}
+
+	if e.user != "" {
+		if err := client.Auth(smtp.PlainAuth("", e.user, e.password, e.host)); err != nil {
+			return fmt.Errorf("authenticating: %w", err)
+		}
+	}
+
+	if err := client.Mail(e.from); err != nil {
+		return err
+	}
+
+	for _, to := range e.to {
+		if err := client.Rcpt(to); err != nil {
+			return err
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// message returns a multipart message holding the
+// text summary and the JSON report as attachment.
+func (e *Email) message(summary Summary) ([]byte, error) {
+	report, err := json.MarshalIndent(summary.Report, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encoding report: %w", err)
+	}
+
+	var body bytes.Buffer
+
+	mw := multipart.NewWriter(&body)
+
+	text, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {"text/plain; charset=utf-8"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := text.Write([]byte(strings.ReplaceAll(summary.Text(), "\n", "\r\n"))); err != nil {
+		return nil, err
+	}
+
+	attachment, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"application/json"},
+		"Content-Disposition":       {`attachment; filename="report.json"`},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := attachment.Write([]byte(wrapBase64(report))); err != nil {
+		return nil, err
+	}
+
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+
+	for _, h := range [][2]string{
+		{"From", e.from},
+		{"To", strings.Join(e.to, ", ")},
+		{"Subject", mime.QEncoding.Encode("utf-8", summary.Title())},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", fmt.Sprintf("multipart/mixed; boundary=%q", mw.Boundary())},
+	} {
+		fmt.Fprintf(&msg, "%s: %s\r\n", h[0], h[1])
+	}
+
+	msg.WriteString("\r\n")
+	msg.Write(body.Bytes())
+
+	return msg.Bytes(), nil
+}
+
+// wrapBase64 encodes data wrapping lines at 76 characters as required by MIME.
+func wrapBase64(data []byte) string {
+	const lineLen = 76
+
+	encoded := base64.StdEncoding.EncodeToString(data)
+
+	var sb strings.Builder
+
+	for len(encoded) > lineLen {
+		sb.WriteString(encoded[:lineLen] + "\r\n")
+		encoded = encoded[lineLen:]
+	}
+
+	sb.WriteString(encoded)
+
+	return sb.String()
+}
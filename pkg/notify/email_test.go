@@ -0,0 +1,149 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailNotify(t *testing.T) {
+	t.Parallel()
+
+	srv := newSMTPServer(t)
+
+	email, err := NewEmail(fmt.Sprintf("smtp://%s?from=mtcli@example.com&to=a@example.com,b@example.com", srv.Addr()))
+	require.NoError(t, err)
+
+	require.NoError(t, email.Notify(context.Background(), newSummary(t)))
+
+	received := <-srv.Messages
+
+	assert.Equal(t, "mtcli@example.com", received.From)
+	assert.Equal(t, []string{"a@example.com", "b@example.com"}, received.To)
+
+	msg, err := mail.ReadMessage(strings.NewReader(received.Data))
+	require.NoError(t, err)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "Validation of addon 'reference-addon' (stage) failed", subject)
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/mixed", mediaType)
+
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+
+	text, err := mr.NextPart()
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(text)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "- AM0001 name: failure")
+
+	attachment, err := mr.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, "report.json", attachment.FileName())
+}
+
+type receivedMail struct {
+	From string
+	To   []string
+	Data string
+}
+
+// smtpServer accepts a single unauthenticated SMTP session
+// and passes the received message on to Messages.
+type smtpServer struct {
+	l        net.Listener
+	Messages chan receivedMail
+}
+
+func newSMTPServer(t *testing.T) *smtpServer {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = l.Close() })
+
+	s := &smtpServer{l: l, Messages: make(chan receivedMail, 1)}
+
+	go s.serve()
+
+	return s
+}
+
+func (s *smtpServer) Addr() string { return s.l.Addr().String() }
+
+func (s *smtpServer) serve() {
+	conn, err := s.l.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	reply := func(line string) { fmt.Fprintf(conn, "%s\r\n", line) }
+
+	var msg receivedMail
+
+	reply("220 localhost ESMTP")
+
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		cmd := strings.TrimSpace(line)
+
+		switch upper := strings.ToUpper(cmd); {
+		case strings.HasPrefix(upper, "EHLO"), strings.HasPrefix(upper, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(upper, "MAIL FROM:"):
+			msg.From = strings.Trim(cmd[len("MAIL FROM:"):], "<>")
+			reply("250 OK")
+		case strings.HasPrefix(upper, "RCPT TO:"):
+			msg.To = append(msg.To, strings.Trim(cmd[len("RCPT TO:"):], "<>"))
+			reply("250 OK")
+		case upper == "DATA":
+			reply("354 end data with <CR><LF>.<CR><LF>")
+
+			var data strings.Builder
+
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+
+				if l == ".\r\n" {
+					break
+				}
+
+				data.WriteString(strings.TrimPrefix(l, "."))
+			}
+
+			msg.Data = data.String()
+			reply("250 OK")
+		case upper == "QUIT":
+			reply("221 bye")
+			s.Messages <- msg
+
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/httputil"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validate"
+)
+
+// NewSlack returns a Notifier posting summaries to
+// the given Slack incoming webhook URL.
+func NewSlack(webhookURL string, opts ...NotifierOption) (*Slack, error) {
+	if err := validateURL(webhookURL); err != nil {
+		return nil, fmt.Errorf("invalid slack webhook: %w", err)
+	}
+
+	var cfg NotifierConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	return &Slack{cfg: cfg, url: webhookURL}, nil
+}
+
+// Slack posts summaries to a Slack incoming webhook. Incoming webhooks
+// cannot carry files so the report is only linked if its URL is known.
+type Slack struct {
+	cfg NotifierConfig
+	url string
+}
+
+func (s *Slack) Notify(ctx context.Context, summary Summary) error {
+	icon := ":white_check_mark:"
+	if summary.Outcome() != OutcomePassed {
+		icon = ":x:"
+	}
+
+	payload := map[string]string{
+		"text": icon + " " + summary.Text(),
+	}
+
+	if err := post(ctx, s.cfg.Client, s.url, payload); err != nil {
+		return fmt.Errorf("notifying slack: %w", err)
+	}
+
+	return nil
+}
+
+// NewWebhook returns a Notifier posting summaries
+// as JSON documents to the given URL.
+func NewWebhook(webhookURL string, opts ...NotifierOption) (*Webhook, error) {
+	if err := validateURL(webhookURL); err != nil {
+		return nil, fmt.Errorf("invalid webhook: %w", err)
+	}
+
+	var cfg NotifierConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	return &Webhook{cfg: cfg, url: webhookURL}, nil
+}
+
+// Webhook posts summaries including the complete
+// JSON report to a generic HTTP endpoint.
+type Webhook struct {
+	cfg NotifierConfig
+	url string
+}
+
+// WebhookPayload is the JSON document posted by Webhook.
+type WebhookPayload struct {
+	Addon     string          `json:"addon"`
+	Env       string          `json:"env,omitempty"`
+	Version   string          `json:"version,omitempty"`
+	Outcome   Outcome         `json:"outcome"`
+	Summary   string          `json:"summary"`
+	ReportURL string          `json:"reportURL,omitempty"`
+	Report    validate.Report `json:"report"`
+}
+
+func (w *Webhook) Notify(ctx context.Context, summary Summary) error {
+	payload := WebhookPayload{
+		Addon:     summary.Addon,
+		Env:       summary.Env,
+		Version:   summary.Version,
+		Outcome:   summary.Outcome(),
+		Summary:   summary.Text(),
+		ReportURL: summary.ReportURL,
+		Report:    summary.Report,
+	}
+
+	if err := post(ctx, w.cfg.Client, w.url, payload); err != nil {
+		return fmt.Errorf("notifying webhook: %w", err)
+	}
+
+	return nil
+}
+
+func post(ctx context.Context, client *http.Client, url string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encoding payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("unexpected status '%s'", res.Status)
+	}
+
+	return nil
+}
+
+func validateURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%q is not an http(s) URL", raw)
+	}
+
+	if u.Host == "" {
+		return fmt.Errorf("%q has no host", raw)
+	}
+
+	return nil
+}
+
+type NotifierConfig struct {
+	// Client sends requests of HTTP based notifiers.
+	Client *http.Client
+}
+
+func (c *NotifierConfig) Option(opts ...NotifierOption) {
+	for _, opt := range opts {
+		opt.ConfigureNotifier(c)
+	}
+}
+
+func (c *NotifierConfig) Default() {
+	if c.Client == nil {
+		c.Client = httputil.NewClient()
+	}
+}
+
+type NotifierOption interface {
+	ConfigureNotifier(*NotifierConfig)
+}
+
+// WithHTTPClient applies the client used by HTTP based notifiers.
+type WithHTTPClient struct{ *http.Client }
+
+func (w WithHTTPClient) ConfigureNotifier(c *NotifierConfig) {
+	c.Client = w.Client
+}
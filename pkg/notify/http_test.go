@@ -0,0 +1,98 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validate"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlackNotify(t *testing.T) {
+	t.Parallel()
+
+	var payload map[string]string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+	}))
+	t.Cleanup(srv.Close)
+
+	slack, err := NewSlack(srv.URL, WithHTTPClient{Client: srv.Client()})
+	require.NoError(t, err)
+
+	require.NoError(t, slack.Notify(context.Background(), newSummary(t)))
+	assert.Contains(t, payload["text"], ":x: Validation of addon 'reference-addon' (stage) failed")
+}
+
+func TestWebhookNotify(t *testing.T) {
+	t.Parallel()
+
+	var body []byte
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+
+		body, err = io.ReadAll(r.Body)
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(srv.Close)
+
+	webhook, err := NewWebhook(srv.URL, WithHTTPClient{Client: srv.Client()})
+	require.NoError(t, err)
+
+	require.NoError(t, webhook.Notify(context.Background(), newSummary(t)))
+
+	var payload struct {
+		Addon   string  `json:"addon"`
+		Env     string  `json:"env"`
+		Outcome Outcome `json:"outcome"`
+		Report  struct {
+			Version string `json:"version"`
+			Passed  bool   `json:"passed"`
+		} `json:"report"`
+	}
+
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, "reference-addon", payload.Addon)
+	assert.Equal(t, "stage", payload.Env)
+	assert.Equal(t, OutcomeFailed, payload.Outcome)
+	assert.Equal(t, validate.ReportVersion, payload.Report.Version)
+	assert.False(t, payload.Report.Passed)
+}
+
+func TestWebhookNotifyUnexpectedStatus(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	t.Cleanup(srv.Close)
+
+	webhook, err := NewWebhook(srv.URL, WithHTTPClient{Client: srv.Client()})
+	require.NoError(t, err)
+
+	assert.Error(t, webhook.Notify(context.Background(), newSummary(t)))
+}
+
+func newSummary(t *testing.T) Summary {
+	t.Helper()
+
+	base, err := validator.NewBase(1, validator.BaseName("name"), validator.BaseDesc("desc"))
+	require.NoError(t, err)
+
+	return Summary{
+		Addon: "reference-addon",
+		Env:   "stage",
+		Report: validate.Report{
+			Results: validator.ResultList{base.Fail("failure")},
+		},
+	}
+}
@@ -0,0 +1,165 @@
+// Package notify sends a summary of the outcome of a validation run to
+// external services such as Slack, generic HTTP webhooks or e-mail.
+//
+// Notifiers are usually created from specs of the form '<kind>=<target>':
+//
+//	slack=https://hooks.slack.com/services/<id>
+//	webhook=https://ci.example.com/hooks/addons
+//	email=smtp://<user>:<password>@smtp.example.com:587?from=mtcli@example.com&to=team@example.com
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validate"
+)
+
+// Outcome is the overall result of a validation run.
+type Outcome string
+
+const (
+	OutcomePassed  Outcome = "passed"
+	OutcomeFailed  Outcome = "failed"
+	OutcomeErrored Outcome = "errored"
+)
+
+// Summary describes a completed validation run.
+type Summary struct {
+	Addon   string
+	Env     string
+	Version string
+	Report  validate.Report
+	// ReportURL optionally links to the published report.
+	ReportURL string
+}
+
+// Outcome returns OutcomeErrored if any validator encountered an
+// error, OutcomeFailed if any validator failed and OutcomePassed
+// otherwise.
+func (s Summary) Outcome() Outcome {
+	switch {
+	case len(s.Report.Errors()) > 0:
+		return OutcomeErrored
+	case !s.Report.Passed():
+		return OutcomeFailed
+	default:
+		return OutcomePassed
+	}
+}
+
+// Counts returns the number of succeeded, failed and errored
+// validators; skipped validators are not counted.
+func (s Summary) Counts() (passed, failed, errored int) {
+	for _, res := range s.Report.Results {
+		switch {
+		case res.IsSkipped():
+			continue
+		case res.IsSuccess():
+			passed++
+		case res.IsError():
+			errored++
+		default:
+			failed++
+		}
+	}
+
+	return passed, failed, errored
+}
+
+// Title returns a single line describing the run and its outcome.
+func (s Summary) Title() string {
+	var qualifiers []string
+
+	for _, q := range []string{s.Env, s.Version} {
+		if q != "" {
+			qualifiers = append(qualifiers, q)
+		}
+	}
+
+	addon := fmt.Sprintf("addon '%s'", s.Addon)
+	if len(qualifiers) > 0 {
+		addon += fmt.Sprintf(" (%s)", strings.Join(qualifiers, ", "))
+	}
+
+	return fmt.Sprintf("Validation of %s %s", addon, s.Outcome())
+}
+
+// Text returns a plain text summary listing all validators
+// which did not succeed and the link to the report if any.
+func (s Summary) Text() string {
+	passed, failed, errored := s.Counts()
+
+	lines := []string{
+		s.Title(),
+		fmt.Sprintf("%d passed, %d failed, %d errored", passed, failed, errored),
+	}
+
+	for _, res := range s.Report.Results {
+		switch {
+		case res.IsSuccess():
+			continue
+		case res.IsSkipped():
+			lines = append(lines, fmt.Sprintf("- %s %s: skipped: %s", res.Code, res.Name, res.SkipReason))
+		case res.IsError():
+			lines = append(lines, fmt.Sprintf("- %s %s: error: %v", res.Code, res.Name, res.Error))
+		default:
+			for _, msg := range res.Messages() {
+				lines = append(lines, fmt.Sprintf("- %s %s: %s", res.Code, res.Name, msg))
+			}
+		}
+	}
+
+	if s.ReportURL != "" {
+		lines = append(lines, "Report: "+s.ReportURL)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Notifier sends a Summary to an external service.
+type Notifier interface {
+	Notify(ctx context.Context, summary Summary) error
+}
+
+// NotifyAll sends summary using every notifier. All notifiers are
+// attempted and an error joining all encountered errors is returned.
+func NotifyAll(ctx context.Context, summary Summary, notifiers ...Notifier) error {
+	var errs []error
+
+	for _, n := range notifiers {
+		if err := n.Notify(ctx, summary); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Kinds lists the kinds of notifiers accepted by Parse.
+var Kinds = []string{"email", "slack", "webhook"}
+
+// ErrUnknownKind is returned by Parse for unsupported notifier kinds.
+var ErrUnknownKind = errors.New("unknown notifier kind")
+
+// Parse returns the Notifier described by a '<kind>=<target>' spec.
+// The options are applied to notifiers sending HTTP requests.
+func Parse(spec string, opts ...NotifierOption) (Notifier, error) {
+	kind, target, ok := strings.Cut(spec, "=")
+	if !ok || target == "" {
+		return nil, fmt.Errorf("notifier %q must have the format '<kind>=<target>'", spec)
+	}
+
+	switch kind {
+	case "slack":
+		return NewSlack(target, opts...)
+	case "webhook":
+		return NewWebhook(target, opts...)
+	case "email":
+		return NewEmail(target)
+	default:
+		return nil, fmt.Errorf("%w %q: expected one of %s", ErrUnknownKind, kind, strings.Join(Kinds, ", "))
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"gopkg.in/yaml.v2"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	sigsyaml "sigs.k8s.io/yaml"
 )
 
 func NewBundleFromDirectory(path string) (Bundle, error) {
@@ -37,6 +38,102 @@ func NewBundleFromDirectory(path string) (Bundle, error) {
 	return bundle, nil
 }
 
+// NewBundlesFromDirectory reads every subdirectory of path as a bundle
+// using NewBundleFromDirectory, e.g. the bundles of a single package
+// written by Bundle.WriteToDirectory.
+func NewBundlesFromDirectory(path string) ([]Bundle, error) {
+	items, err := os.ReadDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading bundles dir: %w", err)
+	}
+
+	var bundles []Bundle
+
+	for _, item := range items {
+		if !item.IsDir() {
+			continue
+		}
+
+		bundle, err := NewBundleFromDirectory(filepath.Join(path, item.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("reading bundle %q: %w", item.Name(), err)
+		}
+
+		bundles = append(bundles, bundle)
+	}
+
+	return bundles, nil
+}
+
+// WriteToDirectory writes the manifests and annotations of the bundle
+// to path using the on-disk bundle format read by NewBundleFromDirectory.
+func (b *Bundle) WriteToDirectory(path string) error {
+	manifestsDir := filepath.Join(path, opmbundle.ManifestsDir)
+	if err := os.MkdirAll(manifestsDir, 0o755); err != nil {
+		return fmt.Errorf("creating manifests dir: %w", err)
+	}
+
+	for _, obj := range b.Manifests {
+		if err := writeManifest(manifestsDir, obj); err != nil {
+			return fmt.Errorf("writing manifest %s '%s': %w", obj.GetKind(), obj.GetName(), err)
+		}
+	}
+
+	metadataDir := filepath.Join(path, opmbundle.MetadataDir)
+	if err := os.MkdirAll(metadataDir, 0o755); err != nil {
+		return fmt.Errorf("creating metadata dir: %w", err)
+	}
+
+	if err := b.writeAnnotations(metadataDir); err != nil {
+		return fmt.Errorf("writing annotations: %w", err)
+	}
+
+	return nil
+}
+
+func writeManifest(manifestsDir string, obj *unstructured.Unstructured) error {
+	data, err := sigsyaml.Marshal(obj.Object)
+	if err != nil {
+		return fmt.Errorf("marshalling manifest: %w", err)
+	}
+
+	name := fmt.Sprintf("%s.%s.yaml", obj.GetName(), strings.ToLower(obj.GetKind()))
+
+	return os.WriteFile(filepath.Join(manifestsDir, name), data, 0o644)
+}
+
+func (b *Bundle) writeAnnotations(metadataDir string) error {
+	pkg, channels := b.Annotations.PackageName, b.Annotations.Channels
+	if pkg == "" {
+		pkg = b.Package
+	}
+
+	if len(channels) == 0 {
+		channels = b.Channels
+	}
+
+	annotations := yaml.MapSlice{
+		{Key: opmbundle.MediatypeLabel, Value: opmbundle.RegistryV1Type},
+		{Key: opmbundle.ManifestsLabel, Value: opmbundle.ManifestsDir},
+		{Key: opmbundle.MetadataLabel, Value: opmbundle.MetadataDir},
+		{Key: opmbundle.PackageLabel, Value: pkg},
+		{Key: opmbundle.ChannelsLabel, Value: strings.Join(channels, ",")},
+	}
+
+	if b.Annotations.DefaultChannelName != "" {
+		annotations = append(annotations, yaml.MapItem{
+			Key: opmbundle.ChannelDefaultLabel, Value: b.Annotations.DefaultChannelName,
+		})
+	}
+
+	data, err := yaml.Marshal(yaml.MapSlice{{Key: "annotations", Value: annotations}})
+	if err != nil {
+		return fmt.Errorf("marshalling annotations: %w", err)
+	}
+
+	return os.WriteFile(filepath.Join(metadataDir, opmbundle.AnnotationsFile), data, 0o644)
+}
+
 func readAllManifests(manifestsDir string) ([]*unstructured.Unstructured, error) {
 	var objs []*unstructured.Unstructured
 
@@ -116,8 +213,18 @@ func NewBundleFromRegistryBundle(b registry.Bundle) (Bundle, error) {
 		}
 	}
 
+	manifests := make([]*unstructured.Unstructured, 0, len(b.Objects))
+	for _, obj := range b.Objects {
+		if obj == nil {
+			continue
+		}
+
+		manifests = append(manifests, obj.DeepCopy())
+	}
+
 	return Bundle{
 		Annotations:           annotations,
+		Manifests:             manifests,
 		Name:                  b.Name,
 		Package:               b.Package,
 		Channels:              b.Channels,
@@ -135,6 +242,41 @@ type Bundle struct {
 	Name                  string
 	Package               string
 	Version               string
+	// Manifests holds every object of the bundle's manifests
+	// directory including the ClusterServiceVersion.
+	Manifests []*unstructured.Unstructured
+}
+
+// RelatedImages returns the sorted and deduplicated
+// related images of the bundle's ClusterServiceVersion.
+func (b *Bundle) RelatedImages() []string {
+	images := make([]string, 0, len(b.ClusterServiceVersion.Spec.RelatedImages))
+	seen := make(map[string]struct{})
+
+	for _, img := range b.ClusterServiceVersion.Spec.RelatedImages {
+		if _, ok := seen[img.Image]; ok {
+			continue
+		}
+
+		seen[img.Image] = struct{}{}
+		images = append(images, img.Image)
+	}
+
+	sort.Strings(images)
+
+	return images
+}
+
+// CSVAnnotations returns the annotations of the ClusterServiceVersion
+// among the manifests of the bundle or nil if there is none.
+func (b *Bundle) CSVAnnotations() map[string]string {
+	for _, obj := range b.Manifests {
+		if obj.GetKind() == "ClusterServiceVersion" {
+			return obj.GetAnnotations()
+		}
+	}
+
+	return nil
 }
 
 func (b *Bundle) GetNameVersion() string {
@@ -222,7 +364,9 @@ func HeadBundle(bundles ...Bundle) (Bundle, bool) {
 		return bundles[0], true
 	}
 
-	ordered := OrderedBundles(bundles)
+	// sort a copy as bundles may be shared between concurrent callers
+	ordered := make(OrderedBundles, len(bundles))
+	copy(ordered, bundles)
 
 	sort.Sort(ordered)
 
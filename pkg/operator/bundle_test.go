@@ -0,0 +1,51 @@
+package operator_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundleWriteToDirectory(t *testing.T) {
+	t.Parallel()
+
+	bundle, err := operator.NewBundleFromDirectory(
+		filepath.Join(testutils.RootDir().TestData().Bundles(), "reference-addon", "main", "0.1.6"),
+	)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, bundle.WriteToDirectory(dir))
+
+	assert.FileExists(t, filepath.Join(dir, "manifests", "reference-addon.v0.1.6.clusterserviceversion.yaml"))
+	assert.FileExists(t, filepath.Join(dir, "metadata", "annotations.yaml"))
+
+	written, err := operator.NewBundleFromDirectory(dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, bundle.Name, written.Name)
+	assert.Equal(t, bundle.Version, written.Version)
+	assert.Equal(t, bundle.Annotations, written.Annotations)
+	assert.Equal(t, bundle.ClusterServiceVersion, written.ClusterServiceVersion)
+}
+
+func TestNewBundlesFromDirectory(t *testing.T) {
+	t.Parallel()
+
+	bundles, err := operator.NewBundlesFromDirectory(
+		filepath.Join(testutils.RootDir().TestData().Bundles(), "reference-addon", "main"),
+	)
+	require.NoError(t, err)
+	require.NotEmpty(t, bundles)
+
+	for _, b := range bundles {
+		assert.Equal(t, "reference-addon", b.Annotations.PackageName)
+	}
+
+	_, err = operator.NewBundlesFromDirectory(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,125 @@
+package operator
+
+import (
+	"slices"
+	"sort"
+)
+
+// BundleDiff lists the differences between two sets of bundles
+// matched by bundle name, e.g. the bundles of two index images.
+type BundleDiff struct {
+	// Added are bundles only present in the new set.
+	Added []Bundle
+	// Removed are bundles only present in the old set.
+	Removed []Bundle
+	// ChannelChanges are bundles whose channels changed.
+	ChannelChanges []ChannelChange
+	// ImageChanges are bundles whose bundle image changed.
+	ImageChanges []ImageChange
+}
+
+// ChannelChange describes the changed channels of a single bundle.
+type ChannelChange struct {
+	Bundle            string
+	OldChannels       []string
+	NewChannels       []string
+	OldDefaultChannel string
+	NewDefaultChannel string
+}
+
+// ImageChange describes the changed bundle image of a single bundle.
+// Images are compared as referenced by the index image; a changed
+// digest shows as a changed reference.
+type ImageChange struct {
+	Bundle   string
+	OldImage string
+	NewImage string
+}
+
+// Empty returns true if no differences were found.
+func (d BundleDiff) Empty() bool {
+	return len(d.Added) == 0 &&
+		len(d.Removed) == 0 &&
+		len(d.ChannelChanges) == 0 &&
+		len(d.ImageChanges) == 0
+}
+
+// DiffBundles compares the bundles in olds to those in news.
+// All lists of the result are sorted by bundle name.
+func DiffBundles(olds, news []Bundle) BundleDiff {
+	var (
+		diff   BundleDiff
+		oldMap = bundlesByName(olds)
+		newMap = bundlesByName(news)
+	)
+
+	for _, name := range sortedNames(newMap) {
+		nb := newMap[name]
+
+		ob, ok := oldMap[name]
+		if !ok {
+			diff.Added = append(diff.Added, nb)
+
+			continue
+		}
+
+		oldChannels, newChannels := sortedChannels(ob), sortedChannels(nb)
+
+		if !slices.Equal(oldChannels, newChannels) ||
+			ob.Annotations.DefaultChannelName != nb.Annotations.DefaultChannelName {
+			diff.ChannelChanges = append(diff.ChannelChanges, ChannelChange{
+				Bundle:            name,
+				OldChannels:       oldChannels,
+				NewChannels:       newChannels,
+				OldDefaultChannel: ob.Annotations.DefaultChannelName,
+				NewDefaultChannel: nb.Annotations.DefaultChannelName,
+			})
+		}
+
+		if ob.BundleImage != nb.BundleImage {
+			diff.ImageChanges = append(diff.ImageChanges, ImageChange{
+				Bundle:   name,
+				OldImage: ob.BundleImage,
+				NewImage: nb.BundleImage,
+			})
+		}
+	}
+
+	for _, name := range sortedNames(oldMap) {
+		if _, ok := newMap[name]; !ok {
+			diff.Removed = append(diff.Removed, oldMap[name])
+		}
+	}
+
+	return diff
+}
+
+func bundlesByName(bundles []Bundle) map[string]Bundle {
+	res := make(map[string]Bundle, len(bundles))
+
+	for _, b := range bundles {
+		res[b.Name] = b
+	}
+
+	return res
+}
+
+func sortedNames(bundles map[string]Bundle) []string {
+	res := make([]string, 0, len(bundles))
+
+	for name := range bundles {
+		res = append(res, name)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+func sortedChannels(b Bundle) []string {
+	res := slices.Clone(b.Channels)
+
+	sort.Strings(res)
+
+	return res
+}
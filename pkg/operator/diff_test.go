@@ -0,0 +1,74 @@
+package operator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffBundles(t *testing.T) {
+	t.Parallel()
+
+	bundle := func(name, image, defaultChannel string, channels ...string) Bundle {
+		return Bundle{
+			Name:        name,
+			BundleImage: image,
+			Channels:    channels,
+			Annotations: Annotations{DefaultChannelName: defaultChannel},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		Old      []Bundle
+		New      []Bundle
+		Expected BundleDiff
+	}{
+		"no differences": {
+			Old: []Bundle{bundle("a.v0.1.0", "quay.io/a@sha256:1", "alpha", "beta", "alpha")},
+			New: []Bundle{bundle("a.v0.1.0", "quay.io/a@sha256:1", "alpha", "alpha", "beta")},
+		},
+		"added and removed": {
+			Old: []Bundle{bundle("a.v0.1.0", "quay.io/a@sha256:1", "alpha", "alpha")},
+			New: []Bundle{
+				bundle("a.v0.1.2", "quay.io/a@sha256:3", "alpha", "alpha"),
+				bundle("a.v0.1.1", "quay.io/a@sha256:2", "alpha", "alpha"),
+			},
+			Expected: BundleDiff{
+				Added: []Bundle{
+					bundle("a.v0.1.1", "quay.io/a@sha256:2", "alpha", "alpha"),
+					bundle("a.v0.1.2", "quay.io/a@sha256:3", "alpha", "alpha"),
+				},
+				Removed: []Bundle{bundle("a.v0.1.0", "quay.io/a@sha256:1", "alpha", "alpha")},
+			},
+		},
+		"changed channels and image": {
+			Old: []Bundle{bundle("a.v0.1.0", "quay.io/a@sha256:1", "alpha", "alpha")},
+			New: []Bundle{bundle("a.v0.1.0", "quay.io/a@sha256:2", "stable", "stable", "alpha")},
+			Expected: BundleDiff{
+				ChannelChanges: []ChannelChange{{
+					Bundle:            "a.v0.1.0",
+					OldChannels:       []string{"alpha"},
+					NewChannels:       []string{"alpha", "stable"},
+					OldDefaultChannel: "alpha",
+					NewDefaultChannel: "stable",
+				}},
+				ImageChanges: []ImageChange{{
+					Bundle:   "a.v0.1.0",
+					OldImage: "quay.io/a@sha256:1",
+					NewImage: "quay.io/a@sha256:2",
+				}},
+			},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			diff := DiffBundles(tc.Old, tc.New)
+
+			assert.Equal(t, tc.Expected, diff)
+			assert.Equal(t, tc.Expected.Empty(), diff.Empty())
+		})
+	}
+}
@@ -0,0 +1,270 @@
+// Package registry provides read-only access to repositories of
+// container registries through the registry v2 API, requesting
+// anonymous bearer tokens where required.
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	imageparser "github.com/novln/docker-parser"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// ErrNotFound is returned if a manifest or blob does not exist.
+var ErrNotFound = errors.New("not found")
+
+const (
+	dockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	dockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+
+	// maxSize limits the size of manifests and blobs read.
+	maxSize = 4 << 20
+)
+
+// NewRepository returns a Repository for the repository of image
+// which is accessed using client. A nil client is not permitted.
+func NewRepository(client *http.Client, image string) (*Repository, error) {
+	ref, err := imageparser.Parse(image)
+	if err != nil {
+		return nil, fmt.Errorf("parsing image %q: %w", image, err)
+	}
+
+	host := ref.Registry()
+	if host == "docker.io" {
+		host = "registry-1.docker.io"
+	}
+
+	return &Repository{
+		client: client,
+		base:   "https://" + host + "/v2/",
+		name:   ref.ShortName(),
+		ref:    ref,
+	}, nil
+}
+
+// Repository reads manifests and blobs of a single repository.
+type Repository struct {
+	client *http.Client
+	base   string
+	name   string
+	ref    *imageparser.Reference
+
+	mu    sync.Mutex
+	token string
+}
+
+// Name returns the full name of the repository ('<host>/<path>').
+func (r *Repository) Name() string { return r.ref.Repository() }
+
+// Reference returns the tag or digest the repository was created with.
+func (r *Repository) Reference() string { return r.ref.Tag() }
+
+// Resolve returns the manifest digest of the given tag or digest.
+func (r *Repository) Resolve(ctx context.Context, tagOrDigest string) (digest.Digest, error) {
+	if dgst, err := digest.Parse(tagOrDigest); err == nil {
+		return dgst, nil
+	}
+
+	res, err := r.do(ctx, http.MethodHead, "manifests/"+tagOrDigest)
+	if err != nil {
+		return "", err
+	}
+	res.Body.Close()
+
+	if res.StatusCode == http.StatusOK {
+		if dgst, err := digest.Parse(res.Header.Get("Docker-Content-Digest")); err == nil {
+			return dgst, nil
+		}
+	}
+
+	data, err := r.Manifest(ctx, tagOrDigest)
+	if err != nil {
+		return "", err
+	}
+
+	return digest.FromBytes(data), nil
+}
+
+// Manifest returns the manifest with the given tag or digest.
+func (r *Repository) Manifest(ctx context.Context, tagOrDigest string) ([]byte, error) {
+	return r.get(ctx, "manifests/"+tagOrDigest)
+}
+
+// Blob returns the blob with the given digest after
+// verifying that its content matches the digest.
+func (r *Repository) Blob(ctx context.Context, dgst digest.Digest) ([]byte, error) {
+	if err := dgst.Validate(); err != nil {
+		return nil, err
+	}
+
+	data, err := r.get(ctx, "blobs/"+dgst.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if dgst.Algorithm().FromBytes(data) != dgst {
+		return nil, fmt.Errorf("content of blob %s does not match its digest", dgst)
+	}
+
+	return data, nil
+}
+
+func (r *Repository) get(ctx context.Context, path string) ([]byte, error) {
+	res, err := r.do(ctx, http.MethodGet, path)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	return readBody(res)
+}
+
+func readBody(res *http.Response) ([]byte, error) {
+	switch res.StatusCode {
+	case http.StatusOK:
+		return io.ReadAll(io.LimitReader(res.Body, maxSize))
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, fmt.Errorf("unexpected status '%s'", res.Status)
+	}
+}
+
+// do sends a request for path relative to the repository requesting
+// an anonymous pull token if the registry requires authentication.
+func (r *Repository) do(ctx context.Context, method, path string) (*http.Response, error) {
+	res, err := r.send(ctx, method, path)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusUnauthorized {
+		return res, nil
+	}
+
+	challenge := res.Header.Get("WWW-Authenticate")
+	res.Body.Close()
+
+	if err := r.authenticate(ctx, challenge); err != nil {
+		return nil, fmt.Errorf("authenticating: %w", err)
+	}
+
+	return r.send(ctx, method, path)
+}
+
+func (r *Repository) send(ctx context.Context, method, path string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, r.base+r.name+"/"+path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Accept", strings.Join([]string{
+		ocispec.MediaTypeImageManifest,
+		ocispec.MediaTypeImageIndex,
+		dockerManifest,
+		dockerManifestList,
+	}, ", "))
+
+	r.mu.Lock()
+	if r.token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.token)
+	}
+	r.mu.Unlock()
+
+	return r.client.Do(req)
+}
+
+func (r *Repository) authenticate(ctx context.Context, challenge string) error {
+	scheme, params, ok := strings.Cut(challenge, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return fmt.Errorf("unsupported challenge %q", challenge)
+	}
+
+	attrs := parseChallengeParams(params)
+
+	realm, err := url.Parse(attrs["realm"])
+	if err != nil || realm.Scheme == "" {
+		return fmt.Errorf("invalid realm %q", attrs["realm"])
+	}
+
+	q := realm.Query()
+
+	if service := attrs["service"]; service != "" {
+		q.Set("service", service)
+	}
+
+	scope := attrs["scope"]
+	if scope == "" {
+		scope = "repository:" + r.name + ":pull"
+	}
+
+	q.Set("scope", scope)
+	realm.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	res, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	data, err := readBody(res)
+	if err != nil {
+		return err
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+
+	if err := json.Unmarshal(data, &token); err != nil {
+		return fmt.Errorf("decoding token: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.token = token.Token
+	if r.token == "" {
+		r.token = token.AccessToken
+	}
+
+	return nil
+}
+
+// parseChallengeParams parses comma separated 'key="value"' pairs.
+func parseChallengeParams(s string) map[string]string {
+	res := make(map[string]string)
+
+	for s != "" {
+		var key, val string
+
+		key, s, _ = strings.Cut(strings.TrimLeft(s, " ,"), "=")
+
+		if strings.HasPrefix(s, `"`) {
+			val, s, _ = strings.Cut(s[1:], `"`)
+		} else {
+			val, s, _ = strings.Cut(s, ",")
+		}
+
+		if key != "" {
+			res[strings.ToLower(strings.TrimSpace(key))] = val
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,96 @@
+package registry_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/registry"
+	"github.com/mt-sre/addon-metadata-operator/pkg/registrytest"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository(t *testing.T) {
+	t.Parallel()
+
+	srv := registrytest.NewServer()
+	defer srv.Close()
+
+	ref, err := srv.AddImage("test/image:v1", registrytest.Image{
+		Labels: map[string]string{"key": "value"},
+	})
+	require.NoError(t, err)
+
+	repo, err := registry.NewRepository(srv.Client(), ref)
+	require.NoError(t, err)
+
+	assert.Equal(t, srv.Host()+"/test/image", repo.Name())
+	assert.Equal(t, "v1", repo.Reference())
+
+	ctx := context.Background()
+
+	dgst, err := repo.Resolve(ctx, repo.Reference())
+	require.NoError(t, err)
+
+	data, err := repo.Manifest(ctx, dgst.String())
+	require.NoError(t, err)
+	assert.Equal(t, dgst, digest.FromBytes(data))
+
+	resolved, err := repo.Resolve(ctx, dgst.String())
+	require.NoError(t, err)
+	assert.Equal(t, dgst, resolved)
+
+	var manifest ocispec.Manifest
+	require.NoError(t, json.Unmarshal(data, &manifest))
+
+	_, err = repo.Blob(ctx, manifest.Config.Digest)
+	require.NoError(t, err)
+
+	_, err = repo.Resolve(ctx, "v2")
+	assert.ErrorIs(t, err, registry.ErrNotFound)
+
+	_, err = repo.Blob(ctx, digest.FromString("missing"))
+	assert.ErrorIs(t, err, registry.ErrNotFound)
+}
+
+func TestRepositoryRequestsAnonymousToken(t *testing.T) {
+	t.Parallel()
+
+	const token = "anonymous"
+
+	manifest := []byte(`{"schemaVersion":2}`)
+
+	var srv *httptest.Server
+
+	srv = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			assert.Equal(t, "repository:test/image:pull", r.URL.Query().Get("scope"))
+			assert.Equal(t, "test", r.URL.Query().Get("service"))
+
+			fmt.Fprintf(w, `{"token":%q}`, token)
+		case r.Header.Get("Authorization") != "Bearer "+token:
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test"`, srv.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+		case strings.HasPrefix(r.URL.Path, "/v2/test/image/manifests/"):
+			_, _ = w.Write(manifest)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	repo, err := registry.NewRepository(srv.Client(), strings.TrimPrefix(srv.URL, "https://")+"/test/image:v1")
+	require.NoError(t, err)
+
+	dgst, err := repo.Resolve(context.Background(), "v1")
+	require.NoError(t, err)
+	assert.Equal(t, digest.FromBytes(manifest), dgst)
+}
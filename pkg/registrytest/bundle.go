@@ -0,0 +1,252 @@
+package registrytest
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+)
+
+// Bundle describes a synthetic registry+v1 operator bundle. A minimal
+// ClusterServiceVersion and the bundle annotations are generated.
+type Bundle struct {
+	PackageName string
+	// Version is the semantic version of the ClusterServiceVersion.
+	Version string
+	// Channels defaults to 'alpha'.
+	Channels []string
+	// DefaultChannel defaults to the first channel.
+	DefaultChannel string
+	// Replaces is the name of the ClusterServiceVersion being replaced.
+	Replaces string
+	// Files are added to the generated ones (e.g. CRDs below 'manifests/')
+	// and replace generated files with the same path.
+	Files map[string][]byte
+}
+
+// CSVName returns the name of the generated ClusterServiceVersion.
+func (b Bundle) CSVName() string {
+	return fmt.Sprintf("%s.v%s", b.PackageName, b.Version)
+}
+
+func (b Bundle) channels() []string {
+	if len(b.Channels) == 0 {
+		return []string{"alpha"}
+	}
+
+	return b.Channels
+}
+
+func (b Bundle) defaultChannel() string {
+	if b.DefaultChannel == "" {
+		return b.channels()[0]
+	}
+
+	return b.DefaultChannel
+}
+
+func (b Bundle) files() (map[string][]byte, error) {
+	var csv, annotations bytes.Buffer
+
+	if err := csvTemplate.Execute(&csv, b); err != nil {
+		return nil, fmt.Errorf("generating ClusterServiceVersion: %w", err)
+	}
+
+	if err := annotationsTemplate.Execute(&annotations, map[string]string{
+		"PackageName":    b.PackageName,
+		"Channels":       strings.Join(b.channels(), ","),
+		"DefaultChannel": b.defaultChannel(),
+	}); err != nil {
+		return nil, fmt.Errorf("generating annotations: %w", err)
+	}
+
+	res := map[string][]byte{
+		"manifests/" + b.PackageName + ".clusterserviceversion.yaml": csv.Bytes(),
+		"metadata/annotations.yaml":                                  annotations.Bytes(),
+	}
+
+	for name, data := range b.Files {
+		res[name] = data
+	}
+
+	return res, nil
+}
+
+// bundleInfo holds the content of a bundle image and
+// the fields required to add the bundle to an index.
+type bundleInfo struct {
+	Files          map[string][]byte
+	PackageName    string
+	CSVName        string
+	Version        string
+	Replaces       string
+	Channels       []string
+	DefaultChannel string
+}
+
+// AddBundle serves a bundle image generated from b under
+// name ('<repository>:<tag>') and returns its full reference.
+func (s *Server) AddBundle(name string, b Bundle) (string, error) {
+	if b.PackageName == "" || b.Version == "" {
+		return "", fmt.Errorf("bundle %q requires a package name and version", name)
+	}
+
+	files, err := b.files()
+	if err != nil {
+		return "", err
+	}
+
+	return s.addBundle(name, bundleInfo{
+		Files:          files,
+		PackageName:    b.PackageName,
+		CSVName:        b.CSVName(),
+		Version:        b.Version,
+		Replaces:       b.Replaces,
+		Channels:       b.channels(),
+		DefaultChannel: b.defaultChannel(),
+	})
+}
+
+// AddBundleDir serves a bundle image containing the 'manifests' and
+// 'metadata' directories of the bundle at dir under name
+// ('<repository>:<tag>') and returns its full reference.
+func (s *Server) AddBundleDir(name, dir string) (string, error) {
+	bundle, err := operator.NewBundleFromDirectory(dir)
+	if err != nil {
+		return "", fmt.Errorf("loading bundle from %q: %w", dir, err)
+	}
+
+	files, err := readFiles(dir)
+	if err != nil {
+		return "", err
+	}
+
+	return s.addBundle(name, bundleInfo{
+		Files:          files,
+		PackageName:    bundle.Annotations.PackageName,
+		CSVName:        bundle.ClusterServiceVersion.Name,
+		Version:        bundle.Version,
+		Replaces:       bundle.ClusterServiceVersion.Spec.Replaces,
+		Channels:       bundle.Annotations.Channels,
+		DefaultChannel: bundle.Annotations.DefaultChannelName,
+	})
+}
+
+func (s *Server) addBundle(name string, info bundleInfo) (string, error) {
+	ref, err := s.AddImage(name, Image{
+		Labels: bundleLabels(info),
+		Files:  info.Files,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.bundles[ref] = info
+
+	return ref, nil
+}
+
+func bundleLabels(info bundleInfo) map[string]string {
+	return map[string]string{
+		"operators.operatorframework.io.bundle.mediatype.v1":       "registry+v1",
+		"operators.operatorframework.io.bundle.manifests.v1":       "manifests/",
+		"operators.operatorframework.io.bundle.metadata.v1":        "metadata/",
+		"operators.operatorframework.io.bundle.package.v1":         info.PackageName,
+		"operators.operatorframework.io.bundle.channels.v1":        strings.Join(info.Channels, ","),
+		"operators.operatorframework.io.bundle.channel.default.v1": info.DefaultChannel,
+	}
+}
+
+func readFiles(dir string) (map[string][]byte, error) {
+	res := make(map[string][]byte)
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		res[filepath.ToSlash(rel)] = data
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading %q: %w", dir, err)
+	}
+
+	return res, nil
+}
+
+var annotationsTemplate = template.Must(template.New("annotations").Parse(`annotations:
+  operators.operatorframework.io.bundle.mediatype.v1: registry+v1
+  operators.operatorframework.io.bundle.manifests.v1: manifests/
+  operators.operatorframework.io.bundle.metadata.v1: metadata/
+  operators.operatorframework.io.bundle.package.v1: {{ .PackageName }}
+  operators.operatorframework.io.bundle.channels.v1: {{ .Channels }}
+  operators.operatorframework.io.bundle.channel.default.v1: {{ .DefaultChannel }}
+`))
+
+var csvTemplate = template.Must(template.New("csv").Parse(`apiVersion: operators.coreos.com/v1alpha1
+kind: ClusterServiceVersion
+metadata:
+  name: {{ .CSVName }}
+  annotations:
+    capabilities: Basic Install
+spec:
+  displayName: {{ .PackageName }}
+  description: Synthetic bundle served by registrytest.
+  version: {{ .Version }}
+{{- if .Replaces }}
+  replaces: {{ .Replaces }}
+{{- end }}
+  maturity: alpha
+  provider:
+    name: registrytest
+  maintainers:
+    - name: registrytest
+      email: registrytest@example.com
+  installModes:
+    - supported: true
+      type: OwnNamespace
+    - supported: false
+      type: SingleNamespace
+    - supported: false
+      type: MultiNamespace
+    - supported: true
+      type: AllNamespaces
+  install:
+    strategy: deployment
+    spec:
+      deployments:
+        - name: {{ .PackageName }}
+          spec:
+            replicas: 1
+            selector:
+              matchLabels:
+                app.kubernetes.io/name: {{ .PackageName }}
+            template:
+              metadata:
+                labels:
+                  app.kubernetes.io/name: {{ .PackageName }}
+              spec:
+                containers:
+                  - name: manager
+                    image: registrytest.example.com/{{ .PackageName }}:v{{ .Version }}
+`))
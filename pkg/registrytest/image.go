@@ -0,0 +1,162 @@
+package registrytest
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// Image is an image consisting of a single layer holding Files.
+type Image struct {
+	// Labels are added to the image configuration.
+	Labels map[string]string
+	// Files maps slash separated paths relative to the
+	// image root to the content of the file at that path.
+	Files map[string][]byte
+}
+
+type builtImage struct {
+	Manifest []byte
+	Digest   digest.Digest
+	Blobs    map[digest.Digest][]byte
+}
+
+func (i Image) build() (builtImage, error) {
+	layer, diffID, err := i.layer()
+	if err != nil {
+		return builtImage{}, err
+	}
+
+	config, err := json.Marshal(ocispec.Image{
+		Platform: ocispec.Platform{
+			Architecture: "amd64",
+			OS:           "linux",
+		},
+		Config: ocispec.ImageConfig{Labels: i.Labels},
+		RootFS: ocispec.RootFS{
+			Type:    "layers",
+			DiffIDs: []digest.Digest{diffID},
+		},
+	})
+	if err != nil {
+		return builtImage{}, err
+	}
+
+	manifest, err := json.Marshal(ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    descriptorFor(ocispec.MediaTypeImageConfig, config),
+		Layers: []ocispec.Descriptor{
+			descriptorFor(ocispec.MediaTypeImageLayerGzip, layer),
+		},
+	})
+	if err != nil {
+		return builtImage{}, err
+	}
+
+	return builtImage{
+		Manifest: manifest,
+		Digest:   digest.FromBytes(manifest),
+		Blobs: map[digest.Digest][]byte{
+			digest.FromBytes(config): config,
+			digest.FromBytes(layer):  layer,
+		},
+	}, nil
+}
+
+// layer returns the gzip compressed tar archive of all files
+// and the digest of the uncompressed archive.
+func (i Image) layer() ([]byte, digest.Digest, error) {
+	var archive bytes.Buffer
+
+	tw := tar.NewWriter(&archive)
+
+	for _, e := range i.entries() {
+		if err := tw.WriteHeader(e.Header); err != nil {
+			return nil, "", err
+		}
+
+		if _, err := tw.Write(e.Data); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, "", err
+	}
+
+	var compressed bytes.Buffer
+
+	gw := gzip.NewWriter(&compressed)
+
+	if _, err := gw.Write(archive.Bytes()); err != nil {
+		return nil, "", err
+	}
+
+	if err := gw.Close(); err != nil {
+		return nil, "", err
+	}
+
+	return compressed.Bytes(), digest.FromBytes(archive.Bytes()), nil
+}
+
+type tarEntry struct {
+	Header *tar.Header
+	Data   []byte
+}
+
+// entries returns the tar entries of all files and their parent
+// directories in lexical order so that layers are reproducible.
+func (i Image) entries() []tarEntry {
+	dirs := make(map[string]bool)
+
+	var res []tarEntry
+
+	for name, data := range i.Files {
+		name = strings.TrimPrefix(path.Clean(name), "/")
+
+		for dir := path.Dir(name); dir != "."; dir = path.Dir(dir) {
+			dirs[dir] = true
+		}
+
+		res = append(res, tarEntry{
+			Header: &tar.Header{
+				Typeflag: tar.TypeReg,
+				Name:     name,
+				Mode:     0o644,
+				Size:     int64(len(data)),
+			},
+			Data: data,
+		})
+	}
+
+	for dir := range dirs {
+		res = append(res, tarEntry{
+			Header: &tar.Header{
+				Typeflag: tar.TypeDir,
+				Name:     dir + "/",
+				Mode:     0o755,
+			},
+		})
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].Header.Name < res[j].Header.Name })
+
+	return res
+}
+
+func descriptorFor(mediaType string, data []byte) ocispec.Descriptor {
+	return ocispec.Descriptor{
+		MediaType: mediaType,
+		Digest:    digest.FromBytes(data),
+		Size:      int64(len(data)),
+	}
+}
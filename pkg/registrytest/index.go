@@ -0,0 +1,246 @@
+package registrytest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/alpha/property"
+	"github.com/operator-framework/operator-registry/pkg/containertools"
+	"github.com/operator-framework/operator-registry/pkg/image"
+	"github.com/operator-framework/operator-registry/pkg/registry"
+	"github.com/operator-framework/operator-registry/pkg/sqlite"
+)
+
+// IndexFormat is the format in which an index image stores its catalog.
+type IndexFormat string
+
+const (
+	// IndexFormatFBC stores the catalog as file-based catalog
+	// beneath '/configs'.
+	IndexFormatFBC IndexFormat = "fbc"
+	// IndexFormatSQLite stores the catalog in the deprecated
+	// SQLite database format at '/database/index.db'.
+	IndexFormatSQLite IndexFormat = "sqlite"
+)
+
+const (
+	configsDir   = "/configs"
+	databaseFile = "/database/index.db"
+)
+
+// AddIndex serves an index image in the given format under name
+// ('<repository>:<tag>') which lists the bundle images with the given
+// references and returns the full reference of the index. The bundles
+// must have been added using AddBundle or AddBundleDir beforehand.
+func (s *Server) AddIndex(name string, format IndexFormat, bundleRefs ...string) (string, error) {
+	bundles := make(map[string]bundleInfo, len(bundleRefs))
+
+	s.mu.RLock()
+	for _, ref := range bundleRefs {
+		info, ok := s.bundles[ref]
+		if !ok {
+			s.mu.RUnlock()
+
+			return "", fmt.Errorf("unknown bundle %q", ref)
+		}
+
+		bundles[ref] = info
+	}
+	s.mu.RUnlock()
+
+	var (
+		img Image
+		err error
+	)
+
+	switch format {
+	case IndexFormatFBC:
+		img, err = fbcIndex(bundles)
+	case IndexFormatSQLite:
+		img, err = sqliteIndex(bundles)
+	default:
+		return "", fmt.Errorf("unknown index format %q", format)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("building %s index %q: %w", format, name, err)
+	}
+
+	return s.AddImage(name, img)
+}
+
+func fbcIndex(bundles map[string]bundleInfo) (Image, error) {
+	var cfg declcfg.DeclarativeConfig
+
+	packages := make(map[string]string)
+	channels := make(map[[2]string][]declcfg.ChannelEntry)
+
+	for _, ref := range sortedKeys(bundles) {
+		info := bundles[ref]
+
+		packages[info.PackageName] = info.DefaultChannel
+
+		for _, ch := range info.Channels {
+			key := [2]string{info.PackageName, ch}
+			channels[key] = append(channels[key], declcfg.ChannelEntry{
+				Name:     info.CSVName,
+				Replaces: info.Replaces,
+			})
+		}
+
+		pkgProp, err := json.Marshal(property.Package{
+			PackageName: info.PackageName,
+			Version:     info.Version,
+		})
+		if err != nil {
+			return Image{}, err
+		}
+
+		cfg.Bundles = append(cfg.Bundles, declcfg.Bundle{
+			Schema:  declcfg.SchemaBundle,
+			Name:    info.CSVName,
+			Package: info.PackageName,
+			Image:   ref,
+			Properties: []property.Property{
+				{Type: property.TypePackage, Value: pkgProp},
+			},
+		})
+	}
+
+	for _, pkg := range sortedKeys(packages) {
+		cfg.Packages = append(cfg.Packages, declcfg.Package{
+			Schema:         declcfg.SchemaPackage,
+			Name:           pkg,
+			DefaultChannel: packages[pkg],
+		})
+	}
+
+	for key, entries := range channels {
+		cfg.Channels = append(cfg.Channels, declcfg.Channel{
+			Schema:  declcfg.SchemaChannel,
+			Package: key[0],
+			Name:    key[1],
+			Entries: entries,
+		})
+	}
+
+	sort.Slice(cfg.Channels, func(i, j int) bool {
+		if cfg.Channels[i].Package != cfg.Channels[j].Package {
+			return cfg.Channels[i].Package < cfg.Channels[j].Package
+		}
+
+		return cfg.Channels[i].Name < cfg.Channels[j].Name
+	})
+
+	var buf bytes.Buffer
+
+	if err := declcfg.WriteJSON(cfg, &buf); err != nil {
+		return Image{}, err
+	}
+
+	return Image{
+		Labels: map[string]string{containertools.ConfigsLocationLabel: configsDir},
+		Files:  map[string][]byte{configsDir + "/index.json": buf.Bytes()},
+	}, nil
+}
+
+// sqliteIndex populates a database from the unpacked bundles
+// the same way 'opm index add' does.
+func sqliteIndex(bundles map[string]bundleInfo) (Image, error) {
+	tmpDir, err := os.MkdirTemp("", "registrytest-")
+	if err != nil {
+		return Image{}, err
+	}
+
+	defer os.RemoveAll(tmpDir)
+
+	dirs := make(map[image.Reference]string, len(bundles))
+
+	for i, ref := range sortedKeys(bundles) {
+		dir := filepath.Join(tmpDir, fmt.Sprintf("bundle-%d", i))
+
+		if err := writeFiles(dir, bundles[ref].Files); err != nil {
+			return Image{}, err
+		}
+
+		dirs[image.SimpleReference(ref)] = dir
+	}
+
+	dbPath := filepath.Join(tmpDir, "index.db")
+
+	if err := populateDatabase(dbPath, dirs); err != nil {
+		return Image{}, err
+	}
+
+	data, err := os.ReadFile(dbPath)
+	if err != nil {
+		return Image{}, err
+	}
+
+	return Image{
+		Labels: map[string]string{containertools.DbLocationLabel: databaseFile},
+		Files:  map[string][]byte{databaseFile: data},
+	}, nil
+}
+
+func populateDatabase(dbPath string, dirs map[image.Reference]string) error {
+	db, err := sqlite.Open(dbPath)
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	loader, err := sqlite.NewSQLLiteLoader(db)
+	if err != nil {
+		return err
+	}
+
+	if err := loader.Migrate(context.Background()); err != nil {
+		return err
+	}
+
+	graphLoader, err := sqlite.NewSQLGraphLoaderFromDB(db)
+	if err != nil {
+		return err
+	}
+
+	querier := sqlite.NewSQLLiteQuerierFromDb(db)
+
+	return registry.NewDirectoryPopulator(loader, graphLoader, querier, dirs, nil).
+		Populate(registry.ReplacesMode)
+}
+
+func writeFiles(dir string, files map[string][]byte) error {
+	for name, data := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,241 @@
+// Package registrytest provides an in-process container registry serving
+// synthetic bundle and index images so that tests which extract bundles
+// do not depend on images hosted by remote registries such as quay.io.
+//
+// Typical usage:
+//
+//	srv := registrytest.NewServer()
+//	defer srv.Close()
+//
+//	bundle, _ := srv.AddBundle("my-addon-bundle:v1.0.0", registrytest.Bundle{
+//		PackageName: "my-addon",
+//		Version:     "1.0.0",
+//	})
+//	index, _ := srv.AddIndex("my-addon-index:v1.0.0", registrytest.IndexFormatFBC, bundle)
+//
+// The registry is served over TLS with a self-signed certificate. Clients
+// must trust it using the options returned by RegistryOptions.
+package registrytest
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/operator-framework/operator-registry/pkg/image/containerdregistry"
+)
+
+// NewServer starts a registry which serves no images
+// until they are added. It must be closed after use.
+func NewServer() *Server {
+	s := &Server{
+		tags:      make(map[string]digest.Digest),
+		manifests: make(map[digest.Digest][]byte),
+		blobs:     make(map[digest.Digest][]byte),
+		bundles:   make(map[string]bundleInfo),
+	}
+
+	s.srv = httptest.NewTLSServer(http.HandlerFunc(s.serve))
+
+	return s
+}
+
+type Server struct {
+	srv *httptest.Server
+
+	mu sync.RWMutex
+	// tags maps '<repository>:<tag>' to manifest digests.
+	tags      map[string]digest.Digest
+	manifests map[digest.Digest][]byte
+	blobs     map[digest.Digest][]byte
+	// bundles maps the references of bundle images to
+	// the information required to build indexes.
+	bundles map[string]bundleInfo
+	// failures is the number of upcoming requests
+	// answered with failureStatus.
+	failures      int
+	failureStatus int
+}
+
+// Close shuts down the registry.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Host returns the 'host:port' address of the registry.
+func (s *Server) Host() string {
+	return strings.TrimPrefix(s.srv.URL, "https://")
+}
+
+// Reference returns the full reference of an image named
+// '<repository>:<tag>' or '<repository>@<digest>'.
+func (s *Server) Reference(name string) string {
+	return s.Host() + "/" + name
+}
+
+// Client returns an *http.Client which trusts the registry.
+func (s *Server) Client() *http.Client {
+	return s.srv.Client()
+}
+
+// RegistryOptions returns options which configure
+// a containerd registry to trust this registry.
+func (s *Server) RegistryOptions() []containerdregistry.RegistryOption {
+	pool := x509.NewCertPool()
+	pool.AddCert(s.srv.Certificate())
+
+	return []containerdregistry.RegistryOption{
+		containerdregistry.WithRootCAs(pool),
+	}
+}
+
+// AddImage serves img under name ('<repository>:<tag>')
+// and returns the full reference of the image.
+func (s *Server) AddImage(name string, img Image) (string, error) {
+	repo, tag, ok := strings.Cut(name, ":")
+	if !ok || repo == "" || tag == "" {
+		return "", fmt.Errorf("image name %q must have the format '<repository>:<tag>'", name)
+	}
+
+	built, err := img.build()
+	if err != nil {
+		return "", fmt.Errorf("building image %q: %w", name, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tags[name] = built.Digest
+	s.manifests[built.Digest] = built.Manifest
+
+	for dgst, data := range built.Blobs {
+		s.blobs[dgst] = data
+	}
+
+	return s.Reference(name), nil
+}
+
+// FailRequests answers the next n requests with the given status
+// code, e.g. to simulate transient failures of a registry.
+func (s *Server) FailRequests(n, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = n
+	s.failureStatus = status
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	if status, ok := s.takeFailure(); ok {
+		w.WriteHeader(status)
+
+		return
+	}
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	p := strings.TrimPrefix(r.URL.Path, "/v2/")
+
+	switch {
+	case r.URL.Path == "/v2/" || r.URL.Path == "/v2":
+		s.write(w, r, "application/json", []byte("{}"))
+	case strings.Contains(p, "/manifests/"):
+		repo, ref := cutLast(p, "/manifests/")
+
+		data, ok := s.manifest(repo, ref)
+		if !ok {
+			writeError(w, "MANIFEST_UNKNOWN")
+
+			return
+		}
+
+		s.write(w, r, ocispec.MediaTypeImageManifest, data)
+	case strings.Contains(p, "/blobs/"):
+		_, ref := cutLast(p, "/blobs/")
+
+		s.mu.RLock()
+		data, ok := s.blobs[digest.Digest(ref)]
+		s.mu.RUnlock()
+
+		if !ok {
+			writeError(w, "BLOB_UNKNOWN")
+
+			return
+		}
+
+		s.write(w, r, "application/octet-stream", data)
+	default:
+		writeError(w, "NAME_UNKNOWN")
+	}
+}
+
+func (s *Server) takeFailure() (int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failures < 1 {
+		return 0, false
+	}
+
+	s.failures--
+
+	return s.failureStatus, true
+}
+
+func (s *Server) manifest(repo, ref string) ([]byte, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	dgst := digest.Digest(ref)
+
+	if dgst.Validate() != nil {
+		var ok bool
+
+		if dgst, ok = s.tags[repo+":"+ref]; !ok {
+			return nil, false
+		}
+	}
+
+	data, ok := s.manifests[dgst]
+
+	return data, ok
+}
+
+func (s *Server) write(w http.ResponseWriter, r *http.Request, contentType string, data []byte) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.Header().Set("Docker-Content-Digest", digest.FromBytes(data).String())
+	w.WriteHeader(http.StatusOK)
+
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	_, _ = w.Write(data)
+}
+
+func writeError(w http.ResponseWriter, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNotFound)
+
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"errors": []map[string]string{{"code": code}},
+	})
+}
+
+func cutLast(s, sep string) (string, string) {
+	i := strings.LastIndex(s, sep)
+
+	return s[:i], s[i+len(sep):]
+}
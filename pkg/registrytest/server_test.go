@@ -0,0 +1,79 @@
+package registrytest_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/registrytest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerServesImages(t *testing.T) {
+	t.Parallel()
+
+	srv := registrytest.NewServer()
+	defer srv.Close()
+
+	ref, err := srv.AddImage("test/image:v1", registrytest.Image{
+		Labels: map[string]string{"key": "value"},
+		Files:  map[string][]byte{"/dir/file": []byte("data")},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, srv.Host()+"/test/image:v1", ref)
+
+	client := srv.Client()
+
+	res, body := get(t, client, srv, "/v2/test/image/manifests/v1")
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, ocispec.MediaTypeImageManifest, res.Header.Get("Content-Type"))
+
+	var manifest ocispec.Manifest
+	require.NoError(t, json.Unmarshal(body, &manifest))
+	require.Len(t, manifest.Layers, 1)
+
+	res, _ = get(t, client, srv, "/v2/test/image/manifests/"+res.Header.Get("Docker-Content-Digest"))
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	res, body = get(t, client, srv, "/v2/test/image/blobs/"+manifest.Config.Digest.String())
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var config ocispec.Image
+	require.NoError(t, json.Unmarshal(body, &config))
+	assert.Equal(t, map[string]string{"key": "value"}, config.Config.Labels)
+
+	res, _ = get(t, client, srv, "/v2/test/image/manifests/v2")
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+}
+
+func TestServerRejectsInvalidNames(t *testing.T) {
+	t.Parallel()
+
+	srv := registrytest.NewServer()
+	defer srv.Close()
+
+	for _, name := range []string{"image", "image:", ":tag"} {
+		_, err := srv.AddImage(name, registrytest.Image{})
+		assert.Error(t, err, name)
+	}
+
+	_, err := srv.AddIndex("index:v1", registrytest.IndexFormatFBC, "unknown/bundle:v1")
+	assert.Error(t, err)
+}
+
+func get(t *testing.T, c *http.Client, srv *registrytest.Server, path string) (*http.Response, []byte) {
+	t.Helper()
+
+	res, err := c.Get("https://" + srv.Host() + path)
+	require.NoError(t, err)
+
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	return res, body
+}
@@ -0,0 +1,95 @@
+package registrytest
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/imagepolicy"
+	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// Sign adds a cosign signature created with key for the image served
+// under name ('<repository>:<tag>'). Signatures are stored alongside
+// existing signatures of the image.
+func (s *Server) Sign(name string, key crypto.Signer) error {
+	repo, _, ok := strings.Cut(name, ":")
+	if !ok {
+		return fmt.Errorf("image name %q must have the format '<repository>:<tag>'", name)
+	}
+
+	s.mu.RLock()
+	dgst, ok := s.tags[name]
+	s.mu.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("image %q is not served", name)
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"critical": map[string]interface{}{
+			"identity": map[string]string{"docker-reference": s.Reference(repo)},
+			"image":    map[string]string{"docker-manifest-digest": dgst.String()},
+			"type":     "cosign container image signature",
+		},
+		"optional": nil,
+	})
+	if err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256(payload)
+
+	sig, err := key.Sign(rand.Reader, sum[:], crypto.SHA256)
+	if err != nil {
+		return fmt.Errorf("signing payload: %w", err)
+	}
+
+	layer := descriptorFor(imagepolicy.SimpleSigningMediaType, payload)
+	layer.Annotations = map[string]string{
+		imagepolicy.SignatureAnnotation: base64.StdEncoding.EncodeToString(sig),
+	}
+
+	sigTag := repo + ":" + imagepolicy.SignatureTag(dgst)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var layers []ocispec.Descriptor
+
+	if existing, ok := s.tags[sigTag]; ok {
+		var m ocispec.Manifest
+		if err := json.Unmarshal(s.manifests[existing], &m); err != nil {
+			return err
+		}
+
+		layers = m.Layers
+	}
+
+	config := []byte("{}")
+
+	manifest, err := json.Marshal(ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    descriptorFor(ocispec.MediaTypeImageConfig, config),
+		Layers:    append(layers, layer),
+	})
+	if err != nil {
+		return err
+	}
+
+	manifestDigest := digest.FromBytes(manifest)
+
+	s.tags[sigTag] = manifestDigest
+	s.manifests[manifestDigest] = manifest
+	s.blobs[digest.FromBytes(config)] = config
+	s.blobs[layer.Digest] = payload
+
+	return nil
+}
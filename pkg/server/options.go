@@ -0,0 +1,127 @@
+package server
+
+import (
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/extractor"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validate"
+)
+
+type Config struct {
+	// Env is the environment whose metadata is validated.
+	Env string
+	// Concurrency limits the number of validations running at
+	// the same time. Further jobs wait until a slot is free.
+	Concurrency int
+	// Timeout limits the duration of each validation including
+	// bundle extraction. Values less than one mean no limit.
+	Timeout time.Duration
+	// JobTTL is the duration finished jobs are kept for.
+	JobTTL time.Duration
+	// MaxPendingJobs limits the number of jobs which did not finish
+	// yet. Further submissions are rejected until jobs finish.
+	MaxPendingJobs int
+	// Token is required as bearer token of all validation requests
+	// unless it is empty.
+	Token string
+	// Extractor extracts the bundles of jobs giving an index image.
+	Extractor extractor.Extractor
+	// ValidateOptions are applied to every validation.
+	ValidateOptions []validate.Option
+}
+
+func (c *Config) Option(opts ...Option) {
+	for _, opt := range opts {
+		opt.ConfigureServer(c)
+	}
+}
+
+const (
+	defaultEnv         = "stage"
+	defaultConcurrency = 2
+	defaultJobTTL      = time.Hour
+	defaultMaxPending  = 100
+)
+
+func (c *Config) Default() {
+	if c.Env == "" {
+		c.Env = defaultEnv
+	}
+
+	if c.Concurrency < 1 {
+		c.Concurrency = defaultConcurrency
+	}
+
+	if c.JobTTL <= 0 {
+		c.JobTTL = defaultJobTTL
+	}
+
+	if c.MaxPendingJobs < 1 {
+		c.MaxPendingJobs = defaultMaxPending
+	}
+
+	if c.Extractor == nil {
+		c.Extractor = extractor.New()
+	}
+}
+
+type Option interface {
+	ConfigureServer(*Config)
+}
+
+// WithEnv applies the environment whose metadata is validated.
+type WithEnv string
+
+func (w WithEnv) ConfigureServer(c *Config) {
+	c.Env = string(w)
+}
+
+// WithConcurrency limits the number of validations running at the same time.
+type WithConcurrency int
+
+func (w WithConcurrency) ConfigureServer(c *Config) {
+	c.Concurrency = int(w)
+}
+
+// WithTimeout limits the duration of each validation.
+type WithTimeout time.Duration
+
+func (w WithTimeout) ConfigureServer(c *Config) {
+	c.Timeout = time.Duration(w)
+}
+
+// WithJobTTL applies the duration finished jobs are kept for.
+type WithJobTTL time.Duration
+
+func (w WithJobTTL) ConfigureServer(c *Config) {
+	c.JobTTL = time.Duration(w)
+}
+
+// WithMaxPendingJobs limits the number of jobs which did not finish yet.
+type WithMaxPendingJobs int
+
+func (w WithMaxPendingJobs) ConfigureServer(c *Config) {
+	c.MaxPendingJobs = int(w)
+}
+
+// WithToken requires the given bearer token on all validation requests.
+type WithToken string
+
+func (w WithToken) ConfigureServer(c *Config) {
+	c.Token = string(w)
+}
+
+// WithExtractor extracts bundles using the given Extractor.
+type WithExtractor struct{ extractor.Extractor }
+
+func (w WithExtractor) ConfigureServer(c *Config) {
+	c.Extractor = w.Extractor
+}
+
+// WithValidateOptions applies options to every validation,
+// e.g. filters or runner options providing clients.
+type WithValidateOptions []validate.Option
+
+func (w WithValidateOptions) ConfigureServer(c *Config) {
+	c.ValidateOptions = append(c.ValidateOptions, w...)
+}
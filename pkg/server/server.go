@@ -0,0 +1,365 @@
+// Package server exposes the registered addon validators as an HTTP
+// service so that bots can validate addon metadata without shelling out
+// to mtcli. Validations are submitted as jobs which run asynchronously:
+//
+//	POST /v1/validations       submits a Request and returns the Job
+//	GET  /v1/validations/{id}  returns the Job including its report once done
+//	GET  /healthz              reports that the server is up
+//
+// Submissions are rejected with '503 Service Unavailable' while the
+// configured number of jobs is pending. If a token is configured the
+// validation endpoints require it as bearer token. Jobs are kept in
+// memory for about the configured TTL after they finished.
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/mt-sre/addon-metadata-operator/pkg/metadata"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validate"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+// maxRequestBytes limits the size of submitted requests.
+const maxRequestBytes = 1 << 20
+
+// Request submits addon metadata for validation.
+type Request struct {
+	// Metadata is the content of an 'addon.yaml' file. Addons
+	// using imagesets can not be validated as only a single
+	// file is submitted.
+	Metadata string `json:"metadata"`
+	// IndexImage optionally overrides the index image of the metadata.
+	// Bundles are only extracted and validated if it is set.
+	IndexImage string `json:"indexImage,omitempty"`
+}
+
+// JobStatus is the state of a submitted validation.
+type JobStatus string
+
+const (
+	JobStatusPending JobStatus = "pending"
+	JobStatusRunning JobStatus = "running"
+	// JobStatusDone is reported once all validators ran; the
+	// report tells whether the addon passed validation.
+	JobStatusDone JobStatus = "done"
+	// JobStatusError is reported if the addon could not be
+	// validated, e.g. because its bundles could not be extracted.
+	JobStatusError JobStatus = "error"
+)
+
+// Job is a submitted validation.
+type Job struct {
+	ID     string           `json:"id"`
+	Status JobStatus        `json:"status"`
+	Error  string           `json:"error,omitempty"`
+	Report *validate.Report `json:"report,omitempty"`
+
+	finished time.Time
+}
+
+// New returns a Server validating submitted addon metadata. Jobs run
+// with a context derived from ctx, which carries the logger, image
+// policy, OCM client and registry retries, and are cancelled once
+// ctx is done. Expired jobs are removed until ctx is done.
+func New(ctx context.Context, opts ...Option) *Server {
+	var cfg Config
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	s := &Server{
+		cfg:  cfg,
+		ctx:  ctx,
+		jobs: make(map[string]*Job),
+		sem:  make(chan struct{}, cfg.Concurrency),
+		mux:  http.NewServeMux(),
+	}
+
+	s.mux.HandleFunc("POST /v1/validations", s.authorized(s.submit))
+	s.mux.HandleFunc("GET /v1/validations/{id}", s.authorized(s.get))
+	s.mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+
+	go s.expireJobs()
+
+	return s
+}
+
+// Server is a http.Handler serving validation jobs.
+type Server struct {
+	cfg Config
+	ctx context.Context
+
+	mu   sync.Mutex
+	jobs map[string]*Job
+	// pending counts the jobs which did not finish yet.
+	pending int
+	// sem limits the number of running validations.
+	sem chan struct{}
+	mux *http.ServeMux
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// authorized rejects requests without the configured token.
+func (s *Server) authorized(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.cfg.Token == "" {
+			next(w, r)
+
+			return
+		}
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.cfg.Token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, errors.New("missing or invalid bearer token"))
+
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+func (s *Server) submit(w http.ResponseWriter, r *http.Request) {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+	dec.DisallowUnknownFields()
+
+	var req Request
+	if err := dec.Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("decoding request: %w", err))
+
+		return
+	}
+
+	if req.Metadata == "" {
+		writeError(w, http.StatusBadRequest, errors.New("'metadata' must not be empty"))
+
+		return
+	}
+
+	mb, err := metadata.NewLoader(".",
+		metadata.WithEnv(s.cfg.Env),
+		metadata.WithMetadata([]byte(req.Metadata)),
+	).LoadMetaBundle(r.Context())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("loading addon metadata: %w", err))
+
+		return
+	}
+
+	if req.IndexImage != "" {
+		mb.AddonMeta.IndexImage = &req.IndexImage
+	}
+
+	id, err := newJobID()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("generating job id: %w", err))
+
+		return
+	}
+
+	job, ok := s.addJob(id)
+	if !ok {
+		writeError(w, http.StatusServiceUnavailable, fmt.Errorf("%d validations are pending; retry later", s.cfg.MaxPendingJobs))
+
+		return
+	}
+
+	go s.run(id, *mb, req.IndexImage != "")
+
+	w.Header().Set("Location", "/v1/validations/"+id)
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+func (s *Server) get(w http.ResponseWriter, r *http.Request) {
+	job, ok := s.job(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("job %q not found", r.PathValue("id")))
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, job)
+}
+
+// addJob registers a pending job with the given id unless
+// the maximum number of pending jobs is reached.
+func (s *Server) addJob(id string) (Job, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pending >= s.cfg.MaxPendingJobs {
+		return Job{}, false
+	}
+
+	job := &Job{ID: id, Status: JobStatusPending}
+
+	s.jobs[id] = job
+	s.pending++
+
+	return *job, true
+}
+
+// maxExpiryInterval limits the interval expired jobs are removed in.
+const maxExpiryInterval = time.Minute
+
+// expireJobs periodically removes jobs which finished
+// longer than the configured TTL ago until s.ctx is done.
+func (s *Server) expireJobs() {
+	ticker := time.NewTicker(min(s.cfg.JobTTL, maxExpiryInterval))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.removeExpiredJobs(now)
+		}
+	}
+}
+
+func (s *Server) removeExpiredJobs(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, job := range s.jobs {
+		if !job.finished.IsZero() && now.Sub(job.finished) > s.cfg.JobTTL {
+			delete(s.jobs, key)
+		}
+	}
+}
+
+// job returns a copy of the job with the given id.
+func (s *Server) job(id string) (Job, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+
+	return *job, true
+}
+
+func (s *Server) update(id string, fn func(*Job)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if job, ok := s.jobs[id]; ok {
+		fn(job)
+	}
+}
+
+func (s *Server) run(id string, mb types.MetaBundle, withBundles bool) {
+	log := logr.FromContextOrDiscard(s.ctx).WithValues("job", id, "addon", mb.AddonMeta.ID)
+
+	select {
+	case s.sem <- struct{}{}:
+		defer func() { <-s.sem }()
+	case <-s.ctx.Done():
+		s.finish(id, validate.Report{}, s.ctx.Err())
+
+		return
+	}
+
+	s.update(id, func(job *Job) { job.Status = JobStatusRunning })
+
+	log.Info("validating addon")
+
+	ctx, cancel := withTimeout(s.ctx, s.cfg.Timeout)
+	defer cancel()
+
+	report, err := s.validate(ctx, mb, withBundles)
+	if err != nil {
+		log.Error(err, "validating addon")
+	} else {
+		log.Info("validated addon", "passed", report.Passed())
+	}
+
+	s.finish(id, report, err)
+}
+
+func (s *Server) validate(ctx context.Context, mb types.MetaBundle, withBundles bool) (validate.Report, error) {
+	filters := validate.WithFilters{validator.EnabledForEnv(s.cfg.Env)}
+
+	if withBundles {
+		bundles, err := s.cfg.Extractor.ExtractBundles(ctx, *mb.AddonMeta.IndexImage, mb.AddonMeta.OperatorName)
+		if err != nil {
+			return validate.Report{}, fmt.Errorf("extracting and parsing addon bundles: %w", err)
+		}
+
+		mb.Bundles = bundles
+	} else {
+		filters = append(filters, validator.Not(validator.MatchesTags(validator.TagBundle)))
+	}
+
+	return validate.Run(ctx, mb, append([]validate.Option{filters}, s.cfg.ValidateOptions...)...)
+}
+
+func (s *Server) finish(id string, report validate.Report, err error) {
+	s.update(id, func(job *Job) {
+		job.finished = time.Now()
+		s.pending--
+
+		if err != nil {
+			job.Status = JobStatusError
+			job.Error = err.Error()
+
+			return
+		}
+
+		job.Status = JobStatusDone
+		job.Report = &report
+	})
+}
+
+// withTimeout returns a context which is cancelled after
+// timeout unless timeout is less than one.
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+func newJobID() (string, error) {
+	buf := make([]byte, 16)
+
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buf), nil
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(v)
+}
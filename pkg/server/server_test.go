@@ -0,0 +1,339 @@
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/server"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validate"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMetadata = `
+id: test-addon
+label: api.openshift.com/addon-test-addon
+indexImage: quay.io/osd-addons/test-addon-index:v0.1.0
+operatorName: test-addon
+`
+
+type testJob struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Report *struct {
+		Passed  bool `json:"passed"`
+		Results []struct {
+			Code string `json:"code"`
+		} `json:"results"`
+	} `json:"report"`
+}
+
+func TestServer(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Request         server.Request
+		Extractor       *testExtractor
+		ExpectedStatus  string
+		ExpectedPassed  bool
+		ExpectedError   string
+		ExpectedBundles bool
+	}{
+		"passing metadata": {
+			Request:        server.Request{Metadata: testMetadata},
+			ExpectedStatus: "done",
+			ExpectedPassed: true,
+		},
+		"failing metadata": {
+			Request: server.Request{
+				Metadata: testMetadata + "\nlabel: api.openshift.com/addon-Test-Addon\n",
+			},
+			ExpectedStatus: "done",
+		},
+		"with index image": {
+			Request: server.Request{
+				Metadata:   testMetadata,
+				IndexImage: "quay.io/osd-addons/test-addon-index@sha256:0c8b02008f2c2faeb681ae8cd454821266a794435aea4b3f7ae28c74bc2e280d",
+			},
+			Extractor:       &testExtractor{},
+			ExpectedStatus:  "done",
+			ExpectedPassed:  true,
+			ExpectedBundles: true,
+		},
+		"extraction failure": {
+			Request: server.Request{
+				Metadata:   testMetadata,
+				IndexImage: "quay.io/osd-addons/test-addon-index:v0.1.0",
+			},
+			Extractor:       &testExtractor{Err: errors.New("502 Bad Gateway")},
+			ExpectedStatus:  "error",
+			ExpectedError:   "extracting and parsing addon bundles: 502 Bad Gateway",
+			ExpectedBundles: true,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ext := tc.Extractor
+			if ext == nil {
+				ext = &testExtractor{}
+			}
+
+			srv := httptest.NewServer(server.New(context.Background(),
+				server.WithExtractor{Extractor: ext},
+				server.WithValidateOptions{
+					validate.WithFilters{validator.MatchesCodes(2)},
+				},
+			))
+			t.Cleanup(srv.Close)
+
+			job := submit(t, srv.URL, tc.Request, http.StatusAccepted)
+			require.NotEmpty(t, job.ID)
+
+			job = await(t, srv.URL, job.ID)
+
+			assert.Equal(t, tc.ExpectedStatus, job.Status)
+			assert.Equal(t, tc.ExpectedError, job.Error)
+			assert.Equal(t, tc.ExpectedBundles, ext.Called)
+
+			if tc.ExpectedStatus != "done" {
+				assert.Nil(t, job.Report)
+
+				return
+			}
+
+			require.NotNil(t, job.Report)
+			assert.Equal(t, tc.ExpectedPassed, job.Report.Passed)
+			require.Len(t, job.Report.Results, 1)
+			assert.Equal(t, "AM0002", job.Report.Results[0].Code)
+		})
+	}
+}
+
+func TestServerInvalidRequests(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(server.New(context.Background()))
+	t.Cleanup(srv.Close)
+
+	for name, body := range map[string]string{
+		"malformed JSON":    `{"metadata":`,
+		"unknown field":     `{"metadata":"id: test","index":"quay.io/test"}`,
+		"empty metadata":    `{}`,
+		"invalid metadata":  `{"metadata":"id: [test"}`,
+		"without any image": `{"metadata":"id: test-addon"}`,
+	} {
+		body := body
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := http.Post(srv.URL+"/v1/validations", "application/json", bytes.NewBufferString(body))
+			require.NoError(t, err)
+
+			defer res.Body.Close()
+
+			assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		})
+	}
+
+	res, err := http.Get(srv.URL + "/v1/validations/unknown")
+	require.NoError(t, err)
+
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+}
+
+func TestServerMaxPendingJobs(t *testing.T) {
+	t.Parallel()
+
+	ext := &testExtractor{Release: make(chan struct{})}
+
+	srv := httptest.NewServer(server.New(context.Background(),
+		server.WithExtractor{Extractor: ext},
+		server.WithMaxPendingJobs(1),
+		server.WithValidateOptions{
+			validate.WithFilters{validator.MatchesCodes(2)},
+		},
+	))
+	t.Cleanup(srv.Close)
+
+	// extraction blocks until released
+	req := server.Request{Metadata: testMetadata, IndexImage: "quay.io/osd-addons/test-addon-index:v0.1.0"}
+
+	job := submit(t, srv.URL, req, http.StatusAccepted)
+
+	res := post(t, srv.URL, "", req)
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+
+	close(ext.Release)
+
+	assert.Equal(t, "done", await(t, srv.URL, job.ID).Status)
+
+	submit(t, srv.URL, req, http.StatusAccepted)
+}
+
+func TestServerToken(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(server.New(context.Background(),
+		server.WithExtractor{Extractor: &testExtractor{}},
+		server.WithToken("secret"),
+	))
+	t.Cleanup(srv.Close)
+
+	req := server.Request{Metadata: testMetadata}
+
+	for name, tc := range map[string]struct {
+		Token        string
+		ExpectedCode int
+	}{
+		"without token": {
+			ExpectedCode: http.StatusUnauthorized,
+		},
+		"invalid token": {
+			Token:        "guess",
+			ExpectedCode: http.StatusUnauthorized,
+		},
+		"valid token": {
+			Token:        "secret",
+			ExpectedCode: http.StatusAccepted,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			res := post(t, srv.URL, tc.Token, req)
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.ExpectedCode, res.StatusCode)
+		})
+	}
+
+	res, err := http.Get(srv.URL + "/healthz")
+	require.NoError(t, err)
+
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+}
+
+func TestServerExpiresJobs(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	srv := httptest.NewServer(server.New(ctx,
+		server.WithExtractor{Extractor: &testExtractor{}},
+		server.WithJobTTL(10*time.Millisecond),
+		server.WithValidateOptions{
+			validate.WithFilters{validator.MatchesCodes(2)},
+		},
+	))
+	t.Cleanup(srv.Close)
+
+	job := submit(t, srv.URL, server.Request{Metadata: testMetadata}, http.StatusAccepted)
+
+	require.Eventually(t, func() bool {
+		res, err := http.Get(srv.URL + "/v1/validations/" + job.ID)
+		require.NoError(t, err)
+
+		defer res.Body.Close()
+
+		return res.StatusCode == http.StatusNotFound
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+// post submits req with the given bearer token unless it is empty.
+func post(t *testing.T, url, token string, req server.Request) *http.Response {
+	t.Helper()
+
+	data, err := json.Marshal(req)
+	require.NoError(t, err)
+
+	httpReq, err := http.NewRequest(http.MethodPost, url+"/v1/validations", bytes.NewReader(data))
+	require.NoError(t, err)
+
+	if token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	res, err := http.DefaultClient.Do(httpReq)
+	require.NoError(t, err)
+
+	return res
+}
+
+func submit(t *testing.T, url string, req server.Request, expectedCode int) testJob {
+	t.Helper()
+
+	res := post(t, url, "", req)
+	defer res.Body.Close()
+
+	require.Equal(t, expectedCode, res.StatusCode)
+
+	var job testJob
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&job))
+
+	assert.Equal(t, "/v1/validations/"+job.ID, res.Header.Get("Location"))
+
+	return job
+}
+
+func await(t *testing.T, url, id string) testJob {
+	t.Helper()
+
+	var job testJob
+
+	require.Eventually(t, func() bool {
+		res, err := http.Get(url + "/v1/validations/" + id)
+		require.NoError(t, err)
+
+		defer res.Body.Close()
+
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&job))
+
+		return job.Status != "pending" && job.Status != "running"
+	}, 10*time.Second, 10*time.Millisecond)
+
+	return job
+}
+
+type testExtractor struct {
+	Err    error
+	Called bool
+	// Release blocks extraction until it is closed unless it is nil.
+	Release chan struct{}
+}
+
+func (e *testExtractor) ExtractBundles(context.Context, string, string) ([]operator.Bundle, error) {
+	e.Called = true
+
+	if e.Release != nil {
+		<-e.Release
+	}
+
+	return nil, e.Err
+}
+
+func (e *testExtractor) ExtractAllBundles(context.Context, string) ([]operator.Bundle, error) {
+	return nil, e.Err
+}
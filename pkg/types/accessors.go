@@ -0,0 +1,71 @@
+package types
+
+import (
+	"sort"
+
+	"github.com/blang/semver/v4"
+	op "github.com/mt-sre/addon-metadata-operator/pkg/operator"
+)
+
+// HeadBundle returns the bundle with the highest version as
+// resolved by Resolved. It returns 'false' if there are no bundles.
+func (mb MetaBundle) HeadBundle() (op.Bundle, bool) {
+	head := mb.Resolved().HeadBundle
+	if head == nil {
+		return op.Bundle{}, false
+	}
+
+	return *head, true
+}
+
+// BundlesByChannel groups the bundles by the channels they are
+// published to. The bundles of each channel are ordered from the
+// highest to the lowest version. Bundles without channels fall back
+// to the channels listed in their annotations.
+func (mb MetaBundle) BundlesByChannel() map[string][]op.Bundle {
+	res := make(map[string][]op.Bundle)
+
+	for _, bundle := range mb.Bundles {
+		channels := bundle.Channels
+		if len(channels) == 0 {
+			channels = bundle.Annotations.Channels
+		}
+
+		for _, ch := range channels {
+			if ch == "" {
+				continue
+			}
+
+			res[ch] = append(res[ch], bundle)
+		}
+	}
+
+	for _, bundles := range res {
+		sort.Stable(op.OrderedBundles(bundles))
+	}
+
+	return res
+}
+
+// CSVFor returns the ClusterServiceVersion of the bundle with the given
+// version. Versions are compared semantically so that a leading 'v' is
+// ignored. It returns 'false' if no bundle has the given version.
+func (mb MetaBundle) CSVFor(version string) (op.ClusterServiceVersion, bool) {
+	want, err := semver.ParseTolerant(version)
+
+	for _, bundle := range mb.Bundles {
+		if bundle.Version == version {
+			return bundle.ClusterServiceVersion, true
+		}
+
+		if err != nil {
+			continue
+		}
+
+		if ver, err := semver.ParseTolerant(bundle.Version); err == nil && ver.Equals(want) {
+			return bundle.ClusterServiceVersion, true
+		}
+	}
+
+	return op.ClusterServiceVersion{}, false
+}
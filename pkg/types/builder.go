@@ -0,0 +1,76 @@
+package types
+
+import (
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	op "github.com/mt-sre/addon-metadata-operator/pkg/operator"
+)
+
+// NewMetaBundleBuilder returns an empty MetaBundleBuilder.
+func NewMetaBundleBuilder() *MetaBundleBuilder {
+	return &MetaBundleBuilder{}
+}
+
+// MetaBundleBuilder assembles a MetaBundle, e.g.
+//
+//	mb := types.NewMetaBundleBuilder().
+//		WithMeta(meta).
+//		WithBundles(bundles...).
+//		Build()
+type MetaBundleBuilder struct {
+	mb MetaBundle
+}
+
+// WithMeta sets the addon metadata.
+func (b *MetaBundleBuilder) WithMeta(meta *v1alpha1.AddonMetadataSpec) *MetaBundleBuilder {
+	b.mb.AddonMeta = meta
+
+	return b
+}
+
+// WithBundles appends the given bundles.
+func (b *MetaBundleBuilder) WithBundles(bundles ...op.Bundle) *MetaBundleBuilder {
+	b.mb.Bundles = append(b.mb.Bundles, bundles...)
+
+	return b
+}
+
+// WithImageSet sets the imageset the metadata was combined with.
+func (b *MetaBundleBuilder) WithImageSet(imageSet *v1alpha1.AddonImageSetSpec) *MetaBundleBuilder {
+	b.mb.ImageSet = imageSet
+
+	return b
+}
+
+// WithProvenance sets the locations the metadata fields were defined at.
+func (b *MetaBundleBuilder) WithProvenance(p Provenance) *MetaBundleBuilder {
+	b.mb.Provenance = p
+
+	return b
+}
+
+// WithResolution sets the resolution performed while loading.
+func (b *MetaBundleBuilder) WithResolution(r Resolution) *MetaBundleBuilder {
+	b.mb.Resolution = r
+
+	return b
+}
+
+// Build returns the assembled MetaBundle. The builder may be
+// reused afterwards without affecting the returned MetaBundle.
+func (b *MetaBundleBuilder) Build() *MetaBundle {
+	mb := b.mb
+
+	if b.mb.Bundles != nil {
+		mb.Bundles = make([]op.Bundle, len(b.mb.Bundles))
+		copy(mb.Bundles, b.mb.Bundles)
+	}
+
+	if b.mb.Provenance != nil {
+		mb.Provenance = make(Provenance, len(b.mb.Provenance))
+		for k, v := range b.mb.Provenance {
+			mb.Provenance[k] = v
+		}
+	}
+
+	return &mb
+}
@@ -0,0 +1,125 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	op "github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetaBundleBuilder(t *testing.T) {
+	t.Parallel()
+
+	meta := &v1alpha1.AddonMetadataSpec{ID: "test-addon"}
+	v1 := testBundle("1.0.0", "alpha")
+
+	builder := NewMetaBundleBuilder().
+		WithMeta(meta).
+		WithBundles(v1).
+		WithProvenance(Provenance{"id": {File: "addon.yaml"}})
+
+	mb := builder.Build()
+
+	// further use of the builder must not affect built MetaBundles
+	builder.WithBundles(testBundle("2.0.0", "alpha"))
+
+	assert.Same(t, meta, mb.AddonMeta)
+	assert.Equal(t, []op.Bundle{v1}, mb.Bundles)
+	assert.Equal(t, "addon.yaml", mb.Provenance["id"].File)
+	assert.Len(t, builder.Build().Bundles, 2)
+}
+
+func TestMetaBundleHeadBundle(t *testing.T) {
+	t.Parallel()
+
+	_, ok := NewMetaBundleBuilder().Build().HeadBundle()
+	assert.False(t, ok)
+
+	head, ok := NewMetaBundleBuilder().
+		WithBundles(
+			testBundle("1.0.0", "alpha"),
+			testBundle("1.2.0", "alpha"),
+			testBundle("1.1.0", "alpha"),
+		).
+		Build().
+		HeadBundle()
+	require.True(t, ok)
+	assert.Equal(t, "1.2.0", head.Version)
+}
+
+func TestMetaBundleBundlesByChannel(t *testing.T) {
+	t.Parallel()
+
+	annotated := testBundle("0.9.0")
+	annotated.Annotations.Channels = []string{"beta"}
+
+	mb := NewMetaBundleBuilder().
+		WithBundles(
+			testBundle("1.0.0", "alpha"),
+			testBundle("1.1.0", "alpha", "stable"),
+			annotated,
+		).
+		Build()
+
+	byChannel := mb.BundlesByChannel()
+	require.Len(t, byChannel, 3)
+
+	assert.Equal(t, []string{"1.1.0", "1.0.0"}, versions(byChannel["alpha"]))
+	assert.Equal(t, []string{"1.1.0"}, versions(byChannel["stable"]))
+	assert.Equal(t, []string{"0.9.0"}, versions(byChannel["beta"]))
+}
+
+func TestMetaBundleCSVFor(t *testing.T) {
+	t.Parallel()
+
+	mb := NewMetaBundleBuilder().
+		WithBundles(
+			testBundle("1.0.0", "alpha"),
+			testBundle("1.1.0", "alpha"),
+		).
+		Build()
+
+	for name, tc := range map[string]struct {
+		Version  string
+		Found    bool
+		Expected string
+	}{
+		"exact":    {Version: "1.1.0", Found: true, Expected: "test.v1.1.0"},
+		"v prefix": {Version: "v1.0.0", Found: true, Expected: "test.v1.0.0"},
+		"missing":  {Version: "2.0.0"},
+		"invalid":  {Version: "latest"},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			csv, ok := mb.CSVFor(tc.Version)
+			require.Equal(t, tc.Found, ok)
+			assert.Equal(t, tc.Expected, csv.Name)
+		})
+	}
+}
+
+func testBundle(version string, channels ...string) op.Bundle {
+	return op.Bundle{
+		Name:     "test.v" + version,
+		Version:  version,
+		Channels: channels,
+		ClusterServiceVersion: op.ClusterServiceVersion{
+			Name: "test.v" + version,
+		},
+	}
+}
+
+func versions(bundles []op.Bundle) []string {
+	res := make([]string, 0, len(bundles))
+
+	for _, b := range bundles {
+		res = append(res, b.Version)
+	}
+
+	return res
+}
@@ -0,0 +1,18 @@
+package types
+
+import "strings"
+
+// Fix is a change of a single addon metadata field
+// which resolves a validation failure.
+type Fix struct {
+	// Path is the sequence of mapping keys leading to the field, e.g.
+	// ['deadmanssnitch', 'snitchNamePostFix'].
+	Path []string
+	// Value is the corrected value of the field.
+	Value interface{}
+	// Description explains the change.
+	Description string
+}
+
+// Field returns the path of the fixed field as recorded by Provenance.
+func (f Fix) Field() string { return strings.Join(f.Path, ".") }
@@ -0,0 +1,54 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SourceKind classifies the file a metadata field was read from.
+type SourceKind string
+
+const (
+	// SourceKindBase is the 'addon.yaml' file of an environment.
+	SourceKindBase SourceKind = "base"
+	// SourceKindOverlay is a metadata fragment ('addon.<name>.yaml') next to 'addon.yaml'.
+	SourceKindOverlay SourceKind = "overlay"
+	// SourceKindImageSet is the imageset combined with the metadata.
+	SourceKindImageSet SourceKind = "imageset"
+)
+
+// Source is the location of a single metadata field.
+// File is relative to the addon directory.
+type Source struct {
+	File   string
+	Line   int
+	Column int
+	Kind   SourceKind
+}
+
+func (s Source) String() string {
+	return fmt.Sprintf("%s:%d:%d", s.File, s.Line, s.Column)
+}
+
+// Provenance maps the JSON path of resolved metadata fields
+// (e.g. 'channels[0].currentCSV') to the location they were defined at.
+type Provenance map[string]Source
+
+// Lookup returns the Source of the field at the given path. If the field
+// itself was not recorded the Source of its closest parent is returned.
+func (p Provenance) Lookup(path string) (Source, bool) {
+	for path != "" {
+		if src, ok := p[path]; ok {
+			return src, true
+		}
+
+		idx := strings.LastIndexAny(path, ".[")
+		if idx < 0 {
+			break
+		}
+
+		path = path[:idx]
+	}
+
+	return Source{}, false
+}
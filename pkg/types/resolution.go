@@ -0,0 +1,89 @@
+package types
+
+import (
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	op "github.com/mt-sre/addon-metadata-operator/pkg/operator"
+)
+
+// Resolution is the single resolved view of the imageset version,
+// default channel and head bundle of an addon so that validators do
+// not need to re-implement how these relate to each other.
+type Resolution struct {
+	// RequestedImageSetVersion is the imageset version as requested by
+	// the metadata or the caller (e.g. 'latest').
+	RequestedImageSetVersion string
+	// ImageSetVersion is the concrete version of the imageset in use.
+	// It is empty if the addon references an 'indexImage' directly.
+	ImageSetVersion string
+	// ImageSetFile is the imageset file relative to the addon directory.
+	ImageSetFile string
+	// ImageSetVersions lists the versions of all imagesets available
+	// for the environment in ascending order.
+	ImageSetVersions []string
+	// DefaultChannel is the default channel of the addon.
+	DefaultChannel string
+	// ChannelHead is the 'currentCSV' of the default channel as listed
+	// in the deprecated 'channels' field. It is empty if not listed.
+	ChannelHead string
+	// HeadBundle is the bundle with the highest version. It is nil if
+	// no bundles were extracted.
+	HeadBundle *op.Bundle
+}
+
+// IsLatest reports whether the imageset in use is the one with the
+// highest version available. It is 'true' if the available versions
+// are unknown.
+func (r Resolution) IsLatest() bool {
+	if len(r.ImageSetVersions) == 0 {
+		return true
+	}
+
+	return r.ImageSetVersion == r.ImageSetVersions[len(r.ImageSetVersions)-1]
+}
+
+// Resolved returns the Resolution of the MetaBundle. Fields which were
+// not resolved while loading (e.g. for MetaBundles constructed directly)
+// are derived from AddonMeta and Bundles.
+func (mb MetaBundle) Resolved() Resolution {
+	res := mb.Resolution
+
+	if meta := mb.AddonMeta; meta != nil {
+		if res.ImageSetVersion == "" && meta.ImageSetVersion != nil {
+			res.ImageSetVersion = *meta.ImageSetVersion
+		}
+
+		if res.RequestedImageSetVersion == "" {
+			res.RequestedImageSetVersion = res.ImageSetVersion
+		}
+
+		if res.DefaultChannel == "" {
+			res.DefaultChannel = meta.DefaultChannel
+		}
+
+		if res.ChannelHead == "" {
+			res.ChannelHead = channelHead(meta)
+		}
+	}
+
+	if res.HeadBundle == nil {
+		if head, ok := op.HeadBundle(mb.Bundles...); ok {
+			res.HeadBundle = &head
+		}
+	}
+
+	return res
+}
+
+func channelHead(meta *v1alpha1.AddonMetadataSpec) string {
+	if meta.Channels == nil {
+		return ""
+	}
+
+	for _, ch := range *meta.Channels {
+		if ch.Name == meta.DefaultChannel {
+			return ch.CurrentCSV
+		}
+	}
+
+	return ""
+}
@@ -8,6 +8,15 @@ import (
 type MetaBundle struct {
 	AddonMeta *v1alpha1.AddonMetadataSpec
 	Bundles   []op.Bundle
+	// ImageSet is the imageset AddonMeta was combined with. It is nil
+	// for addons referencing an 'indexImage' directly.
+	ImageSet *v1alpha1.AddonImageSetSpec
+	// Provenance records where the fields of AddonMeta were defined.
+	// It is empty when the metadata was not loaded from files.
+	Provenance Provenance
+	// Resolution holds the imageset and channel resolution performed
+	// while loading. Use Resolved to also obtain derived fields.
+	Resolution Resolution
 }
 
 func NewMetaBundle(addonMeta *v1alpha1.AddonMetadataSpec, bundles []op.Bundle) *MetaBundle {
@@ -0,0 +1,175 @@
+package validate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+var ErrInvalidIterations = errors.New("iterations must be greater than zero")
+
+// Benchmark runs every selected validator against mb the given number of
+// times and measures the duration and heap allocations of each run. In
+// contrast to Run validators are run one after another, ignoring the
+// configured concurrency, so that allocations can be attributed to a
+// single validator. Runner middleware (e.g. retries) is not applied and
+// reporters are not used.
+func Benchmark(ctx context.Context, mb types.MetaBundle, iterations int, opts ...Option) (BenchmarkReport, error) {
+	if iterations < 1 {
+		return BenchmarkReport{}, ErrInvalidIterations
+	}
+
+	var cfg Config
+
+	cfg.Option(opts...)
+
+	runner, err := cfg.newRunner(ctx)
+	if err != nil {
+		return BenchmarkReport{}, err
+	}
+
+	report := BenchmarkReport{Iterations: iterations}
+
+	for _, val := range runner.GetValidators(cfg.Filters...) {
+		res, err := benchmarkValidator(ctx, val, mb, iterations)
+		if err != nil {
+			return BenchmarkReport{}, err
+		}
+
+		report.Validators = append(report.Validators, res)
+	}
+
+	return report, nil
+}
+
+func benchmarkValidator(ctx context.Context, val validator.Validator, mb types.MetaBundle, iterations int) (ValidatorBenchmark, error) {
+	res := ValidatorBenchmark{
+		Code: val.Code(),
+		Name: val.Name(),
+	}
+
+	durations := make([]time.Duration, 0, iterations)
+
+	var (
+		before, after runtime.MemStats
+		allocs, bytes uint64
+	)
+
+	for i := 0; i < iterations; i++ {
+		if err := ctx.Err(); err != nil {
+			return ValidatorBenchmark{}, err
+		}
+
+		runtime.ReadMemStats(&before)
+		start := time.Now()
+
+		result := val.Run(ctx, mb)
+
+		elapsed := time.Since(start)
+		runtime.ReadMemStats(&after)
+
+		durations = append(durations, elapsed)
+		allocs += after.Mallocs - before.Mallocs
+		bytes += after.TotalAlloc - before.TotalAlloc
+
+		if result.IsError() {
+			res.Errors++
+		}
+	}
+
+	res.Mean, res.P95, res.Max = durationStats(durations)
+	res.AllocsPerRun = allocs / uint64(iterations)
+	res.BytesPerRun = bytes / uint64(iterations)
+
+	return res, nil
+}
+
+// durationStats returns the mean, 95th percentile and maximum of durations
+// using the nearest-rank method for the percentile. durations is sorted.
+func durationStats(durations []time.Duration) (mean, p95, max time.Duration) {
+	if len(durations) == 0 {
+		return 0, 0, 0
+	}
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+	var total time.Duration
+
+	for _, d := range durations {
+		total += d
+	}
+
+	rank := int(math.Ceil(0.95 * float64(len(durations))))
+
+	return total / time.Duration(len(durations)), durations[rank-1], durations[len(durations)-1]
+}
+
+// BenchmarkReport holds the measurements of all benchmarked validators.
+type BenchmarkReport struct {
+	Iterations int
+	// Validators are ordered by validator code.
+	Validators []ValidatorBenchmark
+}
+
+// ValidatorBenchmark holds the measurements of a single validator.
+type ValidatorBenchmark struct {
+	Code validator.Code
+	Name string
+	Mean time.Duration
+	P95  time.Duration
+	Max  time.Duration
+	// AllocsPerRun is the mean number of heap allocations per run.
+	AllocsPerRun uint64
+	// BytesPerRun is the mean number of bytes allocated per run.
+	BytesPerRun uint64
+	// Errors counts the runs in which the validator encountered an
+	// error. Measurements of such runs are likely not representative.
+	Errors int
+}
+
+type jsonBenchmarkReport struct {
+	Iterations int                      `json:"iterations"`
+	Validators []jsonValidatorBenchmark `json:"validators"`
+}
+
+type jsonValidatorBenchmark struct {
+	Code         string `json:"code"`
+	Name         string `json:"name"`
+	MeanNanos    int64  `json:"meanNanoseconds"`
+	P95Nanos     int64  `json:"p95Nanoseconds"`
+	MaxNanos     int64  `json:"maxNanoseconds"`
+	AllocsPerRun uint64 `json:"allocsPerRun"`
+	BytesPerRun  uint64 `json:"bytesPerRun"`
+	Errors       int    `json:"errors"`
+}
+
+// MarshalJSON encodes durations as integer nanoseconds
+// and codes in their string form (e.g. 'AM0001').
+func (r BenchmarkReport) MarshalJSON() ([]byte, error) {
+	report := jsonBenchmarkReport{
+		Iterations: r.Iterations,
+		Validators: make([]jsonValidatorBenchmark, 0, len(r.Validators)),
+	}
+
+	for _, v := range r.Validators {
+		report.Validators = append(report.Validators, jsonValidatorBenchmark{
+			Code:         v.Code.String(),
+			Name:         v.Name,
+			MeanNanos:    v.Mean.Nanoseconds(),
+			P95Nanos:     v.P95.Nanoseconds(),
+			MaxNanos:     v.Max.Nanoseconds(),
+			AllocsPerRun: v.AllocsPerRun,
+			BytesPerRun:  v.BytesPerRun,
+			Errors:       v.Errors,
+		})
+	}
+
+	return json.Marshal(report)
+}
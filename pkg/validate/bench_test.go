@@ -0,0 +1,66 @@
+package validate_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validate"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBenchmark(t *testing.T) {
+	t.Parallel()
+
+	report, err := validate.Benchmark(context.Background(), types.MetaBundle{}, 5,
+		validate.WithFilters{validator.Not(validator.MatchesCodes(3))},
+		validate.WithRunnerOptions{
+			validator.WithInitializers{
+				newValidator(2, true),
+				newValidator(1, false),
+				newValidator(3, true),
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, report.Iterations)
+	require.Len(t, report.Validators, 2)
+
+	for i, code := range []validator.Code{1, 2} {
+		v := report.Validators[i]
+
+		assert.Equal(t, code, v.Code)
+		assert.Zero(t, v.Errors)
+		assert.LessOrEqual(t, v.Mean, v.Max)
+		assert.LessOrEqual(t, v.P95, v.Max)
+	}
+
+	data, err := json.Marshal(report)
+	require.NoError(t, err)
+
+	var decoded struct {
+		Iterations int `json:"iterations"`
+		Validators []struct {
+			Code     string `json:"code"`
+			MaxNanos int64  `json:"maxNanoseconds"`
+		} `json:"validators"`
+	}
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	assert.Equal(t, 5, decoded.Iterations)
+	require.Len(t, decoded.Validators, 2)
+	assert.Equal(t, "AM0001", decoded.Validators[0].Code)
+	assert.Equal(t, report.Validators[0].Max, time.Duration(decoded.Validators[0].MaxNanos))
+}
+
+func TestBenchmarkInvalidIterations(t *testing.T) {
+	t.Parallel()
+
+	_, err := validate.Benchmark(context.Background(), types.MetaBundle{}, 0)
+	require.ErrorIs(t, err, validate.ErrInvalidIterations)
+}
@@ -0,0 +1,173 @@
+package validate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	bolt "go.etcd.io/bbolt"
+)
+
+// CacheKey identifies the result of a single validator for
+// an addon. Results are only reused if all fields match.
+type CacheKey struct {
+	Code validator.Code
+	// Version identifies the implementation of the validator,
+	// e.g. the version of the program it is built into.
+	Version string
+	// IndexDigest is the digest of the index image
+	// the bundles of the addon were extracted from.
+	IndexDigest string
+	// MetadataHash identifies the addon metadata (see MetadataHash).
+	MetadataHash string
+}
+
+func (k CacheKey) String() string {
+	return fmt.Sprintf("%s@%s/%s/%s", k.Code, k.Version, k.IndexDigest, k.MetadataHash)
+}
+
+// CacheEntry is the recorded result of a validator.
+type CacheEntry struct {
+	Success    bool                `json:"success"`
+	Findings   []validator.Finding `json:"findings,omitempty"`
+	RecordedAt time.Time           `json:"recordedAt"`
+}
+
+// ResultCache records the results of validators so
+// that unchanged addons are not validated again.
+type ResultCache interface {
+	// Get returns the entry recorded for key and 'true'
+	// or 'false' if no entry was recorded.
+	Get(CacheKey) (CacheEntry, bool, error)
+	// Put records entry for key replacing earlier entries.
+	Put(CacheKey, CacheEntry) error
+}
+
+// MetadataHash returns the hex encoded SHA-256 hash of the addon
+// metadata and imageset of mb as well as the names and images of
+// its bundles.
+func MetadataHash(mb types.MetaBundle) (string, error) {
+	type bundle struct {
+		Name  string `json:"name"`
+		Image string `json:"image"`
+	}
+
+	in := struct {
+		Metadata interface{} `json:"metadata"`
+		ImageSet interface{} `json:"imageSet"`
+		Bundles  []bundle    `json:"bundles"`
+	}{
+		Metadata: mb.AddonMeta,
+		ImageSet: mb.ImageSet,
+	}
+
+	for _, b := range mb.Bundles {
+		in.Bundles = append(in.Bundles, bundle{Name: b.Name, Image: b.BundleImage})
+	}
+
+	data, err := json.Marshal(in)
+	if err != nil {
+		return "", fmt.Errorf("encoding metadata: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+var resultsBucket = []byte("results")
+
+// ErrCacheLocked is returned by OpenBoltCache if the
+// cache file is held open by another process.
+var ErrCacheLocked = errors.New("result cache is in use by another process")
+
+// boltLockTimeout limits the time waiting for
+// other processes to release the cache file.
+const boltLockTimeout = 5 * time.Second
+
+// OpenBoltCache opens the bolt database at path as ResultCache creating
+// it and its parent directories if necessary. The returned cache must be
+// closed to release the file for other processes.
+func OpenBoltCache(path string) (*BoltCache, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("creating result cache directory: %w", err)
+	}
+
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: boltLockTimeout})
+	if errors.Is(err, bolt.ErrTimeout) {
+		return nil, fmt.Errorf("opening '%s': %w", path, ErrCacheLocked)
+	} else if err != nil {
+		return nil, fmt.Errorf("opening result cache: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(resultsBucket)
+
+		return err
+	})
+	if err != nil {
+		_ = db.Close()
+
+		return nil, fmt.Errorf("initializing result cache: %w", err)
+	}
+
+	return &BoltCache{db: db}, nil
+}
+
+// BoltCache is a ResultCache stored in a local bolt database.
+type BoltCache struct {
+	db *bolt.DB
+}
+
+func (c *BoltCache) Get(key CacheKey) (CacheEntry, bool, error) {
+	var data []byte
+
+	err := c.db.View(func(tx *bolt.Tx) error {
+		// the returned value is only valid within the transaction
+		if v := tx.Bucket(resultsBucket).Get([]byte(key.String())); v != nil {
+			data = append([]byte(nil), v...)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return CacheEntry{}, false, fmt.Errorf("reading result cache: %w", err)
+	}
+
+	if data == nil {
+		return CacheEntry{}, false, nil
+	}
+
+	var entry CacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return CacheEntry{}, false, fmt.Errorf("decoding cached result of %s: %w", key.Code, err)
+	}
+
+	return entry, true, nil
+}
+
+func (c *BoltCache) Put(key CacheKey, entry CacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encoding result of %s: %w", key.Code, err)
+	}
+
+	err = c.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(resultsBucket).Put([]byte(key.String()), data)
+	})
+	if err != nil {
+		return fmt.Errorf("writing result cache: %w", err)
+	}
+
+	return nil
+}
+
+// Close releases the cache file.
+func (c *BoltCache) Close() error { return c.db.Close() }
@@ -0,0 +1,230 @@
+package validate_test
+
+import (
+	"context"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validate"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoltCache(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "mtcli", "results.db")
+
+	cache, err := validate.OpenBoltCache(path)
+	require.NoError(t, err)
+
+	key := validate.CacheKey{
+		Code:         1,
+		Version:      "1.0.0",
+		IndexDigest:  "sha256:abc",
+		MetadataHash: "def",
+	}
+
+	_, ok, err := cache.Get(key)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	entry := validate.CacheEntry{
+		Findings:   []validator.Finding{{Message: "failed", Field: "id"}},
+		RecordedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	require.NoError(t, cache.Put(key, entry))
+	require.NoError(t, cache.Close())
+
+	// entries outlive the process which recorded them
+	cache, err = validate.OpenBoltCache(path)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = cache.Close() })
+
+	got, ok, err := cache.Get(key)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, entry, got)
+
+	key.Version = "1.1.0"
+
+	_, ok, err = cache.Get(key)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestRunWithCache(t *testing.T) {
+	t.Parallel()
+
+	cache, err := validate.OpenBoltCache(filepath.Join(t.TempDir(), "results.db"))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = cache.Close() })
+
+	var runs [4]atomic.Int32
+
+	initializers := validator.WithInitializers{
+		newCountingValidator(1, true, &runs[1]),
+		newCountingValidator(2, false, &runs[2]),
+		newCountingValidator(3, true, &runs[3], validator.TagService),
+	}
+
+	run := func(mb types.MetaBundle) validate.Report {
+		t.Helper()
+
+		report, err := validate.Run(context.Background(), mb,
+			validate.WithRunnerOptions{initializers},
+			validate.WithCache{Cache: cache, Version: "1.0.0", IndexDigest: "sha256:abc"},
+		)
+		require.NoError(t, err)
+		require.Len(t, report.Results, 3)
+
+		return report
+	}
+
+	mb := types.MetaBundle{
+		AddonMeta: &v1alpha1.AddonMetadataSpec{ID: "reference-addon"},
+	}
+
+	report := run(mb)
+	for _, res := range report.Results {
+		assert.False(t, res.Cached, res.Code)
+	}
+
+	report = run(mb)
+
+	assert.True(t, report.Results[0].Cached)
+	assert.True(t, report.Results[0].IsSuccess())
+	assert.True(t, report.Results[1].Cached)
+	assert.False(t, report.Results[1].IsSuccess())
+	assert.Equal(t, []string{"failed"}, report.Results[1].Messages())
+	// validators depending on remote services are always run
+	assert.False(t, report.Results[2].Cached)
+
+	assert.Equal(t, int32(1), runs[1].Load())
+	assert.Equal(t, int32(1), runs[2].Load())
+	assert.Equal(t, int32(2), runs[3].Load())
+
+	// changed metadata is validated again
+	mb.AddonMeta.Name = "Reference Addon"
+
+	report = run(mb)
+	assert.False(t, report.Results[0].Cached)
+	assert.Equal(t, int32(2), runs[1].Load())
+}
+
+func TestRunWithCachedPrerequisite(t *testing.T) {
+	t.Parallel()
+
+	cache, err := validate.OpenBoltCache(filepath.Join(t.TempDir(), "results.db"))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = cache.Close() })
+
+	var runs atomic.Int32
+
+	dependent := func(deps validator.Dependencies) (validator.Validator, error) {
+		base, err := validator.NewBase(
+			2,
+			validator.BaseName("dependent_validator"),
+			validator.BaseDocs(validator.Docs{DependsOn: []validator.Code{1}}),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return &countingValidator{
+			testValidator: &testValidator{Base: base, success: true},
+			runs:          &runs,
+		}, nil
+	}
+
+	run := func() validate.Report {
+		t.Helper()
+
+		report, err := validate.Run(context.Background(), types.MetaBundle{},
+			validate.WithRunnerOptions{validator.WithInitializers{
+				newValidator(1, false),
+				dependent,
+			}},
+			validate.WithCache{Cache: cache, Version: "1.0.0"},
+		)
+		require.NoError(t, err)
+		require.Len(t, report.Results, 2)
+
+		return report
+	}
+
+	uncached := run()
+	cached := run()
+
+	assert.True(t, cached.Results[0].Cached)
+	assert.False(t, cached.Results[0].IsSuccess())
+
+	// dependents of failed cached prerequisites are skipped as well
+	assert.True(t, uncached.Results[1].IsSkipped())
+	assert.True(t, cached.Results[1].IsSkipped())
+	assert.Equal(t, uncached.Results[1].SkipReason, cached.Results[1].SkipReason)
+	assert.Zero(t, runs.Load())
+}
+
+func TestMetadataHash(t *testing.T) {
+	t.Parallel()
+
+	mb := types.MetaBundle{
+		AddonMeta: &v1alpha1.AddonMetadataSpec{ID: "reference-addon"},
+	}
+
+	hash, err := validate.MetadataHash(mb)
+	require.NoError(t, err)
+
+	same, err := validate.MetadataHash(types.MetaBundle{
+		AddonMeta: &v1alpha1.AddonMetadataSpec{ID: "reference-addon"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, hash, same)
+
+	mb.ImageSet = &v1alpha1.AddonImageSetSpec{Name: "reference-addon.v1.0.0"}
+
+	changed, err := validate.MetadataHash(mb)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, changed)
+}
+
+// newCountingValidator returns a validator like newValidator
+// which counts its runs in runs.
+func newCountingValidator(code validator.Code, success bool, runs *atomic.Int32, tags ...string) validator.Initializer {
+	return func(deps validator.Dependencies) (validator.Validator, error) {
+		base, err := validator.NewBase(
+			code,
+			validator.BaseName("counting_validator"),
+			validator.BaseDocs(validator.Docs{Tags: tags}),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return &countingValidator{
+			testValidator: &testValidator{Base: base, success: success},
+			runs:          runs,
+		}, nil
+	}
+}
+
+type countingValidator struct {
+	*testValidator
+	runs *atomic.Int32
+}
+
+func (v *countingValidator) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
+	v.runs.Add(1)
+
+	return v.testValidator.Run(ctx, mb)
+}
@@ -0,0 +1,69 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+// ValidatorFixes are the fixes proposed by a single validator.
+type ValidatorFixes struct {
+	Code  validator.Code
+	Name  string
+	Fixes []types.Fix
+}
+
+// Fixes returns the fixes for mb proposed by all registered validators
+// which implement validator.Fixer and are not excluded by the configured
+// filters or the addon metadata. Validators without fixes are omitted and the remaining ones
+// are ordered by code. Reporters and concurrency options are not used.
+func Fixes(ctx context.Context, mb types.MetaBundle, opts ...Option) ([]ValidatorFixes, error) {
+	var cfg Config
+
+	cfg.Option(opts...)
+
+	runner, err := cfg.newRunner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []ValidatorFixes
+
+	for _, v := range runner.GetValidators(filtersFor(mb, cfg)...) {
+		fixer, ok := v.(validator.Fixer)
+		if !ok {
+			continue
+		}
+
+		fixes, err := fix(ctx, fixer, mb)
+		if err != nil {
+			return nil, fmt.Errorf("fixing %s: %w", v.Code(), err)
+		}
+
+		if len(fixes) == 0 {
+			continue
+		}
+
+		res = append(res, ValidatorFixes{
+			Code:  v.Code(),
+			Name:  v.Name(),
+			Fixes: fixes,
+		})
+	}
+
+	return res, nil
+}
+
+// fix returns the fixes of fixer for mb. Panics
+// are returned as errors wrapping validator.ErrPanic.
+func fix(ctx context.Context, fixer validator.Fixer, mb types.MetaBundle) (fixes []types.Fix, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("%w: %v", validator.ErrPanic, p)
+		}
+	}()
+
+	return fixer.Fix(ctx, mb)
+}
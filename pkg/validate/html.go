@@ -0,0 +1,155 @@
+package validate
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+//go:embed report.html.tmpl
+var htmlTemplate string
+
+var htmlReportTemplate = template.Must(template.New("report").Parse(htmlTemplate))
+
+type htmlReport struct {
+	Title      string
+	Addon      string
+	IndexImage string
+	Passed     bool
+	Counts     htmlCounts
+	Bundles    []htmlBundle
+	Results    []htmlResult
+}
+
+type htmlCounts struct {
+	Passed, Failed, Errored int
+}
+
+type htmlBundle struct {
+	Name     string
+	Version  string
+	Image    string
+	Findings int
+}
+
+type htmlResult struct {
+	Code        string
+	Name        string
+	Description string
+	Status      ResultStatus
+	Duration    string
+	DocsURL     string
+	Messages    []htmlMessage
+}
+
+type htmlMessage struct {
+	Text string
+	// Bundle is the name of the bundle the message
+	// refers to or empty if it refers to the addon.
+	Bundle string
+}
+
+// WriteHTML renders the Report of validating mb as a self-contained HTML
+// page with the given title. Besides the results of all validators the
+// page lists the bundles of mb and attributes failure messages to the
+// bundle whose name or image they mention. Results can be filtered by
+// text, status and bundle in the browser.
+func (r Report) WriteHTML(w io.Writer, title string, mb types.MetaBundle) error {
+	data := htmlReport{
+		Title:  title,
+		Passed: r.Passed(),
+	}
+
+	if mb.AddonMeta != nil {
+		data.Addon = mb.AddonMeta.ID
+
+		if mb.AddonMeta.IndexImage != nil {
+			data.IndexImage = *mb.AddonMeta.IndexImage
+		}
+	}
+
+	for _, b := range mb.Bundles {
+		data.Bundles = append(data.Bundles, htmlBundle{
+			Name:    b.Name,
+			Version: b.Version,
+			Image:   b.BundleImage,
+		})
+	}
+
+	for _, res := range r.Results {
+		result := newHTMLResult(res, data.Bundles)
+
+		switch result.Status {
+		case ResultStatusSuccess:
+			data.Counts.Passed++
+		case ResultStatusFailure:
+			data.Counts.Failed++
+		case ResultStatusError, ResultStatusTimeout:
+			data.Counts.Errored++
+		}
+
+		data.Results = append(data.Results, result)
+	}
+
+	if err := htmlReportTemplate.Execute(w, data); err != nil {
+		return fmt.Errorf("rendering html report: %w", err)
+	}
+
+	return nil
+}
+
+// newHTMLResult converts res counting the findings of bundles.
+func newHTMLResult(res validator.Result, bundles []htmlBundle) htmlResult {
+	out := htmlResult{
+		Code:        res.Code.String(),
+		Name:        res.Name,
+		Description: res.Description,
+		Status:      newJSONResult(res).Status,
+		Duration:    res.Duration.Round(time.Millisecond).String(),
+		DocsURL:     validator.Docs{DocsURL: res.DocsURL}.EffectiveDocsURL(res.Code),
+	}
+
+	msgs := res.Messages()
+
+	switch {
+	case res.IsError():
+		msgs = []string{res.Error.Error()}
+	case res.IsSkipped():
+		msgs = []string{res.SkipReason}
+	}
+
+	for _, msg := range msgs {
+		m := htmlMessage{Text: msg}
+
+		if idx := matchBundle(msg, bundles); idx >= 0 {
+			m.Bundle = bundles[idx].Name
+			bundles[idx].Findings++
+		}
+
+		out.Messages = append(out.Messages, m)
+	}
+
+	return out
+}
+
+// matchBundle returns the index of the bundle whose name or image is the
+// longest one mentioned in msg or -1 if no bundle is mentioned.
+func matchBundle(msg string, bundles []htmlBundle) int {
+	res, longest := -1, 0
+
+	for i, b := range bundles {
+		for _, s := range []string{b.Name, b.Image} {
+			if s != "" && len(s) > longest && strings.Contains(msg, s) {
+				res, longest = i, len(s)
+			}
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,62 @@
+package validate_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validate"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportWriteHTML(t *testing.T) {
+	t.Parallel()
+
+	base, err := validator.NewBase(1, validator.BaseName("name"), validator.BaseDesc("desc"))
+	require.NoError(t, err)
+
+	indexImage := "quay.io/osd-addons/reference-addon-index:v1"
+
+	mb := types.MetaBundle{
+		AddonMeta: &v1alpha1.AddonMetadataSpec{
+			ID:         "reference-addon",
+			IndexImage: &indexImage,
+		},
+		Bundles: []operator.Bundle{
+			{Name: "reference-addon.v0.1.0", Version: "0.1.0", BundleImage: "quay.io/osd-addons/reference-addon-bundle:v0.1.0"},
+			{Name: "reference-addon.v0.1.1", Version: "0.1.1", BundleImage: "quay.io/osd-addons/reference-addon-bundle:v0.1.1"},
+		},
+	}
+
+	report := validate.Report{
+		Results: validator.ResultList{
+			base.Success(),
+			base.Fail(
+				"Bundle reference-addon.v0.1.1 failed CSV validation: <script>.",
+				"addon label is invalid",
+			),
+			base.Error(errors.New("boom")),
+		},
+	}
+
+	var buf bytes.Buffer
+
+	require.NoError(t, report.WriteHTML(&buf, "Validation of reference-addon", mb))
+
+	html := buf.String()
+
+	assert.Contains(t, html, "<title>Validation of reference-addon</title>")
+	assert.Contains(t, html, "1 passed, 1 failed, 1 errored")
+	assert.Contains(t, html, indexImage)
+	assert.Contains(t, html, `<td class="success">0</td>`, "first bundle has no findings")
+	assert.Contains(t, html, `<td class="failure">1</td>`, "second bundle has one finding")
+	assert.Contains(t, html, `<li class="message" data-bundle="reference-addon.v0.1.1"><strong>reference-addon.v0.1.1</strong>: Bundle reference-addon.v0.1.1 failed CSV validation: &lt;script&gt;.</li>`)
+	assert.Contains(t, html, `<li class="message" data-bundle="">addon label is invalid</li>`)
+	assert.Contains(t, html, `<li class="message" data-bundle="">validator internal error: boom</li>`)
+	assert.NotContains(t, html, "<script>.")
+}
@@ -0,0 +1,144 @@
+package validate
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr,omitempty"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// WriteJUnit encodes the Report as JUnit XML to w. Every validator is a
+// test case of a single test suite with the given name, e.g. the addon
+// ID. Failure messages and errors are reported as failures and errors
+// of the corresponding test case; skipped validators are skipped test cases.
+func (r Report) WriteJUnit(w io.Writer, name string) error {
+	suite := junitTestSuite{
+		Name:      name,
+		Tests:     len(r.Results),
+		TestCases: make([]junitTestCase, 0, len(r.Results)),
+	}
+
+	var total time.Duration
+
+	for _, res := range r.Results {
+		tc := newJUnitTestCase(name, res)
+
+		switch {
+		case tc.Error != nil:
+			suite.Errors++
+		case tc.Failure != nil:
+			suite.Failures++
+		case tc.Skipped != nil:
+			suite.Skipped++
+		}
+
+		total += res.Duration
+
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+
+	suite.Time = junitTime(total)
+
+	suites := junitTestSuites{
+		Name:     "mtcli validate",
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Errors:   suite.Errors,
+		Time:     suite.Time,
+		Suites:   []junitTestSuite{suite},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	if err := enc.Encode(suites); err != nil {
+		return fmt.Errorf("encoding junit report: %w", err)
+	}
+
+	_, err := io.WriteString(w, "\n")
+
+	return err
+}
+
+func newJUnitTestCase(suite string, res validator.Result) junitTestCase {
+	tc := junitTestCase{
+		Name:      fmt.Sprintf("%s %s", res.Code, res.Name),
+		ClassName: suite,
+		Time:      junitTime(res.Duration),
+	}
+
+	switch {
+	case res.IsSuccess():
+	case res.IsSkipped():
+		tc.Skipped = &junitSkipped{Message: res.SkipReason}
+	case res.IsError():
+		tc.Error = &junitProblem{
+			Message: res.Error.Error(),
+			Type:    string(ResultStatusError),
+			Text:    res.Error.Error(),
+		}
+
+		if res.IsTimeout() {
+			tc.Error.Type = string(ResultStatusTimeout)
+		}
+	default:
+		tc.Failure = &junitProblem{
+			Message: res.Description,
+			Type:    string(ResultStatusFailure),
+			Text:    strings.Join(res.Messages(), "\n"),
+		}
+	}
+
+	return tc
+}
+
+// junitTime formats d in seconds as expected by JUnit consumers.
+func junitTime(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
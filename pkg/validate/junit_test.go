@@ -0,0 +1,49 @@
+package validate_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validate"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportWriteJUnit(t *testing.T) {
+	t.Parallel()
+
+	base, err := validator.NewBase(1, validator.BaseName("name"), validator.BaseDesc("desc"))
+	require.NoError(t, err)
+
+	success := base.Success()
+	success.Duration = 1500 * time.Millisecond
+
+	report := validate.Report{
+		Results: validator.ResultList{
+			success,
+			base.Fail("first", "<second>"),
+			base.Error(errors.New("boom")),
+		},
+	}
+
+	var buf bytes.Buffer
+
+	require.NoError(t, report.WriteJUnit(&buf, "reference-addon"))
+
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="mtcli validate" tests="3" failures="1" errors="1" time="1.500">
+  <testsuite name="reference-addon" tests="3" failures="1" errors="1" time="1.500">
+    <testcase name="AM0001 name" classname="reference-addon" time="1.500"></testcase>
+    <testcase name="AM0001 name" classname="reference-addon" time="0.000">
+      <failure message="desc" type="failure">first&#xA;&lt;second&gt;</failure>
+    </testcase>
+    <testcase name="AM0001 name" classname="reference-addon" time="0.000">
+      <error message="validator internal error: boom" type="error">validator internal error: boom</error>
+    </testcase>
+  </testsuite>
+</testsuites>
+`, buf.String())
+}
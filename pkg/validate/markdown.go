@@ -0,0 +1,147 @@
+package validate
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+// WriteMarkdown renders the Report as Markdown suitable for merge request
+// comments: a heading with the given title and the overall outcome, a
+// table with one row per validator and a collapsible section holding
+// all failure messages and errors.
+func (r Report) WriteMarkdown(w io.Writer, title string) error {
+	var (
+		sb                               strings.Builder
+		passed, failed, errored, skipped int
+		details                          []validator.Result
+	)
+
+	for _, res := range r.Results {
+		switch {
+		case res.IsSuccess():
+			passed++
+		case res.IsSkipped():
+			skipped++
+		case res.IsError():
+			errored++
+		default:
+			failed++
+		}
+
+		if !res.IsSuccess() && !res.IsSkipped() {
+			details = append(details, res)
+		}
+	}
+
+	outcome := ":white_check_mark: passed"
+	if !r.Passed() {
+		outcome = ":x: failed"
+	}
+
+	fmt.Fprintf(&sb, "### %s %s\n\n", escapeMarkdown(title), outcome)
+	fmt.Fprintf(&sb, "%d passed, %d failed, %d errored", passed, failed, errored)
+
+	if skipped > 0 {
+		fmt.Fprintf(&sb, ", %d skipped", skipped)
+	}
+
+	sb.WriteString("\n\n")
+
+	sb.WriteString("| Status | Code | Name | Message |\n")
+	sb.WriteString("| --- | --- | --- | --- |\n")
+
+	for _, res := range r.Results {
+		fmt.Fprintf(&sb, "| %s | [%s](%s) | %s | %s |\n",
+			markdownStatus(res),
+			res.Code, validator.Docs{DocsURL: res.DocsURL}.EffectiveDocsURL(res.Code),
+			escapeMarkdown(res.Name),
+			escapeMarkdown(markdownMessage(res)),
+		)
+	}
+
+	if len(details) > 0 {
+		sb.WriteString("\n<details>\n<summary>Failure details</summary>\n\n")
+
+		for _, res := range details {
+			fmt.Fprintf(&sb, "#### %s %s\n\n", res.Code, escapeMarkdown(res.Name))
+
+			msgs := res.Messages()
+			if res.IsError() {
+				msgs = []string{res.Error.Error()}
+			}
+
+			for _, msg := range msgs {
+				fmt.Fprintf(&sb, "- %s\n", escapeMarkdown(msg))
+			}
+
+			if res.Remediation != "" && !res.IsError() {
+				fmt.Fprintf(&sb, "\n**How to fix:** %s\n", escapeMarkdown(res.Remediation))
+			}
+
+			sb.WriteString("\n")
+		}
+
+		sb.WriteString("</details>\n")
+	}
+
+	_, err := io.WriteString(w, sb.String())
+
+	return err
+}
+
+func markdownStatus(res validator.Result) string {
+	switch {
+	case res.IsSuccess():
+		return ":white_check_mark: Success"
+	case res.IsSkipped():
+		return ":fast_forward: Skipped"
+	case res.IsTimeout():
+		return ":hourglass: Timeout"
+	case res.IsError():
+		return ":warning: Error"
+	case res.Severity == validator.SeverityInfo:
+		return ":information_source: Info"
+	case !res.IsBlocking():
+		return ":large_orange_diamond: Warning"
+	default:
+		return ":x: Failed"
+	}
+}
+
+// markdownMessage returns a single line describing res;
+// all messages are listed in the details section.
+func markdownMessage(res validator.Result) string {
+	msgs := res.Messages()
+
+	switch {
+	case res.IsSuccess():
+		return ""
+	case res.IsSkipped():
+		return res.SkipReason
+	case res.IsError():
+		return res.Error.Error()
+	case len(msgs) == 1:
+		return msgs[0]
+	case len(msgs) > 1:
+		return fmt.Sprintf("%s (and %d more)", msgs[0], len(msgs)-1)
+	default:
+		return res.Description
+	}
+}
+
+var markdownEscaper = strings.NewReplacer(
+	"|", `\|`,
+	"\r\n", " ",
+	"\n", " ",
+	"<", "&lt;",
+	">", "&gt;",
+)
+
+// escapeMarkdown keeps text within a single table cell or
+// list item and prevents it from being rendered as HTML.
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
@@ -0,0 +1,93 @@
+package validate_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validate"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportWriteMarkdown(t *testing.T) {
+	t.Parallel()
+
+	base, err := validator.NewBase(1, validator.BaseName("name"), validator.BaseDesc("desc"))
+	require.NoError(t, err)
+
+	report := validate.Report{
+		Results: validator.ResultList{
+			base.Success(),
+			base.Fail("first | <b>", "second"),
+			base.Error(errors.New("boom")),
+		},
+	}
+
+	var buf bytes.Buffer
+
+	require.NoError(t, report.WriteMarkdown(&buf, "reference-addon"))
+
+	assert.Equal(t, "### reference-addon :x: failed\n"+
+		"\n"+
+		"1 passed, 1 failed, 1 errored\n"+
+		"\n"+
+		"| Status | Code | Name | Message |\n"+
+		"| --- | --- | --- | --- |\n"+
+		"| :white_check_mark: Success | [AM0001](https://github.com/mt-sre/addon-metadata-operator/wiki/AM0001) | name |  |\n"+
+		"| :x: Failed | [AM0001](https://github.com/mt-sre/addon-metadata-operator/wiki/AM0001) | name | first \\| &lt;b&gt; (and 1 more) |\n"+
+		"| :warning: Error | [AM0001](https://github.com/mt-sre/addon-metadata-operator/wiki/AM0001) | name | validator internal error: boom |\n"+
+		"\n"+
+		"<details>\n"+
+		"<summary>Failure details</summary>\n"+
+		"\n"+
+		"#### AM0001 name\n"+
+		"\n"+
+		"- first \\| &lt;b&gt;\n"+
+		"- second\n"+
+		"\n"+
+		"#### AM0001 name\n"+
+		"\n"+
+		"- validator internal error: boom\n"+
+		"\n"+
+		"</details>\n", buf.String())
+}
+
+func TestReportWriteMarkdownPassed(t *testing.T) {
+	t.Parallel()
+
+	base, err := validator.NewBase(1, validator.BaseName("name"), validator.BaseDesc("desc"))
+	require.NoError(t, err)
+
+	report := validate.Report{Results: validator.ResultList{base.Success()}}
+
+	var buf bytes.Buffer
+
+	require.NoError(t, report.WriteMarkdown(&buf, "reference-addon"))
+
+	assert.Contains(t, buf.String(), "### reference-addon :white_check_mark: passed\n")
+	assert.NotContains(t, buf.String(), "<details>")
+}
+
+func TestReportWriteMarkdownRemediation(t *testing.T) {
+	t.Parallel()
+
+	base, err := validator.NewBase(1,
+		validator.BaseName("name"),
+		validator.BaseDocs(validator.Docs{
+			Remediation: "Encode the icon with 'base64 -w0'.",
+			DocsURL:     "https://example.com/icons",
+		}),
+	)
+	require.NoError(t, err)
+
+	report := validate.Report{Results: validator.ResultList{base.Fail("invalid icon")}}
+
+	var buf bytes.Buffer
+
+	require.NoError(t, report.WriteMarkdown(&buf, "reference-addon"))
+
+	assert.Contains(t, buf.String(), "| :x: Failed | [AM0001](https://example.com/icons) | name | invalid icon |\n")
+	assert.Contains(t, buf.String(), "- invalid icon\n\n**How to fix:** Encode the icon with 'base64 -w0'.\n")
+}
@@ -0,0 +1,153 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+type Config struct {
+	// Cache records the results of validators; see WithCache.
+	Cache WithCache
+	// Concurrency limits the number of validators running at the
+	// same time. Values less than one mean no limit.
+	Concurrency int
+	// FailFast stops the remaining validators once
+	// the first one is blocking.
+	FailFast bool
+	// FailOn is the lowest severity of blocking failures; see WithFailOn.
+	FailOn    validator.Severity
+	Filters   []validator.Filter
+	Reporters []Reporter
+	// Runner runs the validators instead of a runner created
+	// for each run; see WithRunner.
+	Runner        *validator.Runner
+	RunnerOptions []validator.RunnerOption
+	// Severities maps the severities of all results; see WithSeverities.
+	Severities validator.SeverityFunc
+	// ValidatorTimeout limits the time each validator may
+	// take. Values less than one mean no limit.
+	ValidatorTimeout time.Duration
+}
+
+func (c *Config) Option(opts ...Option) {
+	for _, opt := range opts {
+		opt.ConfigureValidate(c)
+	}
+}
+
+type Option interface {
+	ConfigureValidate(*Config)
+}
+
+// WithSeverities maps the severities of all results, e.g. to report
+// warnings as errors. Results are cached with their original severities.
+type WithSeverities validator.SeverityFunc
+
+func (w WithSeverities) ConfigureValidate(c *Config) {
+	c.Severities = validator.SeverityFunc(w)
+}
+
+// WithConcurrency limits the number of validators running at the same time.
+type WithConcurrency int
+
+func (w WithConcurrency) ConfigureValidate(c *Config) {
+	c.Concurrency = int(w)
+}
+
+// WithValidatorTimeout limits the time each validator may take. Validators
+// exceeding it are reported with an error wrapping validator.ErrTimeout.
+type WithValidatorTimeout time.Duration
+
+func (w WithValidatorTimeout) ConfigureValidate(c *Config) {
+	c.ValidatorTimeout = time.Duration(w)
+}
+
+// WithFailFast cancels the remaining validators once the first one failed
+// with severity validator.SeverityError or encountered an error. The report only holds the results of validators
+// which finished until then and is marked as incomplete.
+type WithFailFast bool
+
+func (w WithFailFast) ConfigureValidate(c *Config) {
+	c.FailFast = bool(w)
+}
+
+// WithFailOn sets the lowest severity of failures which are blocking
+// for WithFailFast, e.g. validator.SeverityWarning to also stop at
+// warnings. Defaults to validator.SeverityError.
+type WithFailOn validator.Severity
+
+func (w WithFailOn) ConfigureValidate(c *Config) {
+	c.FailOn = validator.Severity(w)
+}
+
+// WithFilters selects the validators which are run. All filters
+// must be satisfied for a validator to be run.
+type WithFilters []validator.Filter
+
+func (w WithFilters) ConfigureValidate(c *Config) {
+	c.Filters = append(c.Filters, w...)
+}
+
+// WithReporters applies reporters which receive each result as it arrives.
+type WithReporters []Reporter
+
+func (w WithReporters) ConfigureValidate(c *Config) {
+	c.Reporters = append(c.Reporters, w...)
+}
+
+// WithRunnerOptions passes options through to the underlying
+// validator.Runner (e.g. clients, middleware or initializers).
+type WithRunnerOptions []validator.RunnerOption
+
+func (w WithRunnerOptions) ConfigureValidate(c *Config) {
+	c.RunnerOptions = append(c.RunnerOptions, w...)
+}
+
+// WithRunner runs the validators of Runner instead of initializing a
+// validator.Runner for each run, e.g. to share one between the runs of a
+// command. WithConcurrency, WithValidatorTimeout and WithRunnerOptions
+// are ignored as they only apply when initializing a runner.
+type WithRunner struct {
+	Runner *validator.Runner
+}
+
+func (w WithRunner) ConfigureValidate(c *Config) {
+	c.Runner = w.Runner
+}
+
+// WithCache reuses the results recorded in Cache for validators whose
+// CacheKey matches instead of running them and records the results of
+// the validators which are run. Errors are not recorded and validators
+// tagged validator.TagService are always run as their results depend on
+// remote services. Reused results are marked as cached.
+type WithCache struct {
+	Cache ResultCache
+	// Version identifies the implementation of the validators.
+	Version string
+	// IndexDigest is the digest of the index image the bundles
+	// were extracted from; empty if no bundles were extracted.
+	IndexDigest string
+}
+
+func (w WithCache) ConfigureValidate(c *Config) {
+	c.Cache = w
+}
+
+// newRunner returns the configured runner or initializes one using the
+// configured runner options preceded by the given defaults.
+func (c *Config) newRunner(ctx context.Context, defaults ...validator.RunnerOption) (*validator.Runner, error) {
+	if c.Runner != nil {
+		return c.Runner, nil
+	}
+
+	// options given by the caller take precedence
+	runner, err := validator.NewRunner(ctx, append(defaults, c.RunnerOptions...)...)
+	if err != nil {
+		return nil, fmt.Errorf("initializing validators: %w", err)
+	}
+
+	return runner, nil
+}
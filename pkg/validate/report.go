@@ -0,0 +1,142 @@
+package validate
+
+import (
+	_ "embed"
+	"encoding/json"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+// ReportVersion is the version of the JSON encoding of a Report. Within a
+// version fields are only ever added and existing fields keep their meaning,
+// so consumers must ignore unknown fields. Removing or changing a field
+// requires a new version.
+const ReportVersion = "v1"
+
+//go:embed report.schema.json
+var reportSchema []byte
+
+// ReportSchema returns the JSON Schema describing the
+// JSON encoding of a Report at ReportVersion.
+func ReportSchema() []byte {
+	res := make([]byte, len(reportSchema))
+	copy(res, reportSchema)
+
+	return res
+}
+
+// ResultStatus is the outcome of a single validator in a JSON report.
+type ResultStatus string
+
+const (
+	ResultStatusSuccess ResultStatus = "success"
+	ResultStatusFailure ResultStatus = "failure"
+	ResultStatusError   ResultStatus = "error"
+	// ResultStatusTimeout is reported for validators which exceeded
+	// their deadline. Timeouts are also counted as errors.
+	ResultStatusTimeout ResultStatus = "timeout"
+	// ResultStatusSkipped is reported for validators which were not
+	// run as a prerequisite did not succeed.
+	ResultStatusSkipped ResultStatus = "skipped"
+)
+
+type jsonReport struct {
+	Version    string       `json:"version"`
+	Addon      string       `json:"addon,omitempty"`
+	Passed     bool         `json:"passed"`
+	Incomplete bool         `json:"incomplete,omitempty"`
+	Results    []jsonResult `json:"results"`
+}
+
+type jsonResult struct {
+	Code            string        `json:"code"`
+	Name            string        `json:"name"`
+	Description     string        `json:"description"`
+	Status          ResultStatus  `json:"status"`
+	Severity        string        `json:"severity"`
+	FailureMessages []string      `json:"failureMessages,omitempty"`
+	Findings        []jsonFinding `json:"findings,omitempty"`
+	Error           string        `json:"error,omitempty"`
+	Retryable       bool          `json:"retryable,omitempty"`
+	Remediation     string        `json:"remediation,omitempty"`
+	DocsURL         string        `json:"docsUrl,omitempty"`
+	DurationSeconds float64       `json:"durationSeconds"`
+	Cached          bool          `json:"cached,omitempty"`
+	SkipReason      string        `json:"skipReason,omitempty"`
+}
+
+type jsonFinding struct {
+	Message  string `json:"message"`
+	Severity string `json:"severity"`
+	File     string `json:"file,omitempty"`
+	Field    string `json:"field,omitempty"`
+}
+
+// MarshalJSON encodes the Report as described by ReportSchema.
+func (r Report) MarshalJSON() ([]byte, error) {
+	report := jsonReport{
+		Version:    ReportVersion,
+		Addon:      r.Addon,
+		Passed:     r.Passed(),
+		Incomplete: r.Incomplete,
+		Results:    make([]jsonResult, 0, len(r.Results)),
+	}
+
+	for _, res := range r.Results {
+		report.Results = append(report.Results, newJSONResult(res))
+	}
+
+	return json.Marshal(report)
+}
+
+// severityOf returns the severity of res defaulting to SeverityError
+// for results which were not created by a validator.Base.
+func severityOf(res validator.Result) validator.Severity {
+	if res.Severity == "" {
+		return validator.SeverityError
+	}
+
+	return res.Severity
+}
+
+func newJSONResult(res validator.Result) jsonResult {
+	out := jsonResult{
+		Code:            res.Code.String(),
+		Name:            res.Name,
+		Description:     res.Description,
+		Severity:        string(severityOf(res)),
+		FailureMessages: res.Messages(),
+		DurationSeconds: res.Duration.Seconds(),
+		Cached:          res.Cached,
+	}
+
+	for _, f := range res.Findings {
+		out.Findings = append(out.Findings, jsonFinding{
+			Message:  f.Message,
+			Severity: string(res.SeverityOf(f)),
+			File:     f.File,
+			Field:    f.Field,
+		})
+	}
+
+	switch {
+	case res.IsSuccess():
+		out.Status = ResultStatusSuccess
+	case res.IsSkipped():
+		out.Status = ResultStatusSkipped
+		out.SkipReason = res.SkipReason
+	case res.IsTimeout():
+		out.Status = ResultStatusTimeout
+		out.Error = res.Error.Error()
+	case res.IsError():
+		out.Status = ResultStatusError
+		out.Error = res.Error.Error()
+		out.Retryable = res.IsRetryableError()
+	default:
+		out.Status = ResultStatusFailure
+		out.Remediation = res.Remediation
+		out.DocsURL = validator.Docs{DocsURL: res.DocsURL}.EffectiveDocsURL(res.Code)
+	}
+
+	return out
+}
@@ -0,0 +1,184 @@
+package validate_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validate"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportMarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	base, err := validator.NewBase(1, validator.BaseName("name"), validator.BaseDesc("desc"))
+	require.NoError(t, err)
+
+	warningBase, err := validator.NewBase(2,
+		validator.BaseName("warning"),
+		validator.BaseDesc("desc"),
+		validator.BaseDocs(validator.Docs{
+			Severity:    validator.SeverityWarning,
+			Remediation: "Use a larger icon.",
+			DocsURL:     "https://example.com/icons",
+		}),
+	)
+	require.NoError(t, err)
+
+	success := base.Success()
+	success.Duration = 1500 * time.Millisecond
+
+	report := validate.Report{
+		Addon: "reference-addon",
+		Results: validator.ResultList{
+			success,
+			base.Fail("first", "second"),
+			base.Error(errors.New("boom")),
+			base.RetryableError(errors.New("unavailable")),
+			{Code: 1, Name: "name", Description: "desc", Error: fmt.Errorf("%w after 1m0s", validator.ErrTimeout)},
+			warningBase.FailWith(
+				validator.Finding{Message: "third"},
+				validator.Finding{Message: "fourth", Severity: validator.SeverityError, File: "addon.yaml", Field: "icon"},
+			),
+			{Code: 3, Name: "skipped", Description: "desc", SkipReason: "prerequisite failed: AM0001"},
+		},
+	}
+
+	data, err := json.Marshal(report)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+  "version": "v1",
+  "addon": "reference-addon",
+  "passed": false,
+  "results": [
+    {"code": "AM0001", "name": "name", "description": "desc", "status": "success", "severity": "error", "durationSeconds": 1.5},
+    {"code": "AM0001", "name": "name", "description": "desc", "status": "failure", "severity": "error", "failureMessages": ["first", "second"], "findings": [{"message": "first", "severity": "error"}, {"message": "second", "severity": "error"}], "docsUrl": "https://github.com/mt-sre/addon-metadata-operator/wiki/AM0001", "durationSeconds": 0},
+    {"code": "AM0001", "name": "name", "description": "desc", "status": "error", "severity": "error", "error": "validator internal error: boom", "durationSeconds": 0},
+    {"code": "AM0001", "name": "name", "description": "desc", "status": "error", "severity": "error", "error": "validator internal error: unavailable", "retryable": true, "durationSeconds": 0},
+    {"code": "AM0001", "name": "name", "description": "desc", "status": "timeout", "severity": "error", "error": "validator timed out after 1m0s", "durationSeconds": 0},
+    {"code": "AM0002", "name": "warning", "description": "desc", "status": "failure", "severity": "warning", "failureMessages": ["third", "addon.yaml (icon): fourth"], "findings": [{"message": "third", "severity": "warning"}, {"message": "fourth", "severity": "error", "file": "addon.yaml", "field": "icon"}], "remediation": "Use a larger icon.", "docsUrl": "https://example.com/icons", "durationSeconds": 0},
+    {"code": "AM0003", "name": "skipped", "description": "desc", "status": "skipped", "severity": "error", "skipReason": "prerequisite failed: AM0001", "durationSeconds": 0}
+  ]
+}`, string(data))
+
+	assertMatchesSchema(t, data)
+}
+
+func TestReportPassed(t *testing.T) {
+	t.Parallel()
+
+	newBase := func(code validator.Code, sev validator.Severity) *validator.Base {
+		base, err := validator.NewBase(code, validator.BaseDocs(validator.Docs{Severity: sev}))
+		require.NoError(t, err)
+
+		return base
+	}
+
+	for name, tc := range map[string]struct {
+		Results  validator.ResultList
+		Expected bool
+	}{
+		"success": {
+			Results:  validator.ResultList{newBase(1, "").Success()},
+			Expected: true,
+		},
+		"failure without severity": {
+			Results: validator.ResultList{newBase(1, "").Fail("failed")},
+		},
+		"error failure": {
+			Results: validator.ResultList{newBase(1, validator.SeverityError).Fail("failed")},
+		},
+		"warning failure": {
+			Results:  validator.ResultList{newBase(1, validator.SeverityWarning).Fail("failed")},
+			Expected: true,
+		},
+		"warning failure with error finding": {
+			Results: validator.ResultList{newBase(1, validator.SeverityWarning).FailWith(
+				validator.Finding{Message: "failed"},
+				validator.Finding{Message: "failed", Severity: validator.SeverityError},
+			)},
+		},
+		"error failure with warning findings": {
+			Results: validator.ResultList{newBase(1, validator.SeverityError).FailWith(
+				validator.Finding{Message: "failed", Severity: validator.SeverityWarning},
+			)},
+			Expected: true,
+		},
+		"info failure": {
+			Results:  validator.ResultList{newBase(1, validator.SeverityInfo).Fail("failed")},
+			Expected: true,
+		},
+		"warning error": {
+			Results: validator.ResultList{newBase(1, validator.SeverityWarning).Error(errors.New("boom"))},
+		},
+		"skipped": {
+			Results:  validator.ResultList{{Code: 1, SkipReason: "prerequisite failed: AM0002"}},
+			Expected: true,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.Expected, validate.Report{Results: tc.Results}.Passed())
+		})
+	}
+}
+
+// assertMatchesSchema verifies that every field of the encoded report is
+// described by the schema and that all required fields are present.
+func assertMatchesSchema(t *testing.T, data []byte) {
+	t.Helper()
+
+	type objectSchema struct {
+		Const      string                     `json:"const"`
+		Required   []string                   `json:"required"`
+		Properties map[string]json.RawMessage `json:"properties"`
+		Items      *struct {
+			Required   []string                   `json:"required"`
+			Properties map[string]json.RawMessage `json:"properties"`
+		} `json:"items"`
+	}
+
+	var schema objectSchema
+	require.NoError(t, json.Unmarshal(validate.ReportSchema(), &schema))
+
+	var report map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(data, &report))
+
+	for _, key := range schema.Required {
+		assert.Contains(t, report, key)
+	}
+
+	for key := range report {
+		assert.Contains(t, schema.Properties, key)
+	}
+
+	var version objectSchema
+	require.NoError(t, json.Unmarshal(schema.Properties["version"], &version))
+	assert.Equal(t, validate.ReportVersion, version.Const)
+
+	var results objectSchema
+	require.NoError(t, json.Unmarshal(schema.Properties["results"], &results))
+	require.NotNil(t, results.Items)
+
+	var items []map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(report["results"], &items))
+
+	for _, item := range items {
+		for _, key := range results.Items.Required {
+			assert.Contains(t, item, key)
+		}
+
+		for key := range item {
+			assert.Contains(t, results.Items.Properties, key)
+		}
+	}
+}
@@ -0,0 +1,293 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	toolName = "mtcli"
+	toolURI  = "https://github.com/mt-sre/addon-metadata-operator"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool        sarifTool         `json:"tool"`
+	Invocations []sarifInvocation `json:"invocations"`
+	Results     []sarifResult     `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	HelpURI          string       `json:"helpUri"`
+	Help             sarifHelp    `json:"help"`
+}
+
+type sarifHelp struct {
+	Text     string `json:"text"`
+	Markdown string `json:"markdown"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifInvocation struct {
+	ExecutionSuccessful        bool                `json:"executionSuccessful"`
+	ToolExecutionNotifications []sarifNotification `json:"toolExecutionNotifications,omitempty"`
+}
+
+type sarifNotification struct {
+	Level      string                  `json:"level"`
+	Message    sarifMessage            `json:"message"`
+	Descriptor sarifReportingReference `json:"descriptor"`
+}
+
+type sarifReportingReference struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// WriteSARIF encodes the Report as a SARIF 2.1.0 log to w, e.g. for
+// GitHub code scanning. Every validator is a rule linking to its wiki
+// page and every finding is a result. Findings are reported at the file
+// they point to or, if their message is prefixed with it, the
+// '<file>:<line>:<column>' location of the offending metadata field.
+func (r Report) WriteSARIF(w io.Writer, opts ...SARIFOption) error {
+	var cfg SARIFConfig
+
+	cfg.Option(opts...)
+
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           toolName,
+				Version:        cfg.ToolVersion,
+				InformationURI: toolURI,
+				Rules:          []sarifRule{},
+			},
+		},
+		Invocations: []sarifInvocation{{ExecutionSuccessful: true}},
+		Results:     []sarifResult{},
+	}
+
+	ruleIndices := make(map[validator.Code]int)
+
+	for _, res := range r.Results {
+		idx, ok := ruleIndices[res.Code]
+		if !ok {
+			idx = len(run.Tool.Driver.Rules)
+			ruleIndices[res.Code] = idx
+
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, newSARIFRule(res))
+		}
+
+		switch {
+		// skipped validators have no findings
+		case res.IsSuccess(), res.IsSkipped():
+		case res.IsError():
+			inv := &run.Invocations[0]
+			inv.ExecutionSuccessful = false
+			inv.ToolExecutionNotifications = append(inv.ToolExecutionNotifications, sarifNotification{
+				Level:      "error",
+				Message:    sarifMessage{Text: res.Error.Error()},
+				Descriptor: sarifReportingReference{ID: res.Code.String()},
+			})
+		default:
+			for _, f := range res.Findings {
+				run.Results = append(run.Results, cfg.newResult(res.Code, idx, sarifLevel(res.SeverityOf(f)), f))
+			}
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	}); err != nil {
+		return fmt.Errorf("encoding sarif report: %w", err)
+	}
+
+	return nil
+}
+
+func newSARIFRule(res validator.Result) sarifRule {
+	code := res.Code.String()
+	docs := validator.Docs{DocsURL: res.DocsURL}.EffectiveDocsURL(res.Code)
+
+	help := sarifHelp{
+		Text:     fmt.Sprintf("%s. See %s for how to resolve findings.", res.Description, docs),
+		Markdown: fmt.Sprintf("%s. See the [%s documentation](%s) for how to resolve findings.", res.Description, code, docs),
+	}
+
+	if res.Remediation != "" {
+		help.Text = fmt.Sprintf("%s. %s See %s for details.", res.Description, res.Remediation, docs)
+		help.Markdown = fmt.Sprintf("%s. %s See the [%s documentation](%s) for details.", res.Description, res.Remediation, code, docs)
+	}
+
+	return sarifRule{
+		ID:               code,
+		Name:             res.Name,
+		ShortDescription: sarifMessage{Text: res.Description},
+		HelpURI:          docs,
+		Help:             help,
+	}
+}
+
+// sourcePrefix matches the 'types.Source' prefix of failure messages.
+var sourcePrefix = regexp.MustCompile(`^([^\s:]+):(\d+):(\d+): (.+)$`)
+
+// sarifLevel returns the SARIF level of failures of the given severity.
+func sarifLevel(sev validator.Severity) string {
+	switch sev {
+	case validator.SeverityWarning:
+		return "warning"
+	case validator.SeverityInfo:
+		return "note"
+	default:
+		return "error"
+	}
+}
+
+func (c SARIFConfig) newResult(code validator.Code, ruleIdx int, level string, f validator.Finding) sarifResult {
+	res := sarifResult{
+		RuleID:    code.String(),
+		RuleIndex: ruleIdx,
+		Level:     level,
+		Message:   sarifMessage{Text: f.Message},
+	}
+
+	switch m := sourcePrefix.FindStringSubmatch(f.Message); {
+	case f.File != "":
+		res.Locations = []sarifLocation{c.location(f.File, nil)}
+	case m != nil:
+		line, _ := strconv.Atoi(m[2])
+		col, _ := strconv.Atoi(m[3])
+
+		res.Message.Text = m[4]
+		res.Locations = []sarifLocation{c.location(m[1], &sarifRegion{StartLine: line, StartColumn: col})}
+	case c.DefaultFile != "":
+		res.Locations = []sarifLocation{c.location(c.DefaultFile, nil)}
+	case f.Field != "":
+		// fields are only reported as logical locations of files
+		res.Message.Text = f.String()
+	}
+
+	if f.Field != "" && len(res.Locations) > 0 {
+		res.Locations[0].LogicalLocations = []sarifLogicalLocation{{FullyQualifiedName: f.Field}}
+	}
+
+	return res
+}
+
+func (c SARIFConfig) location(file string, region *sarifRegion) sarifLocation {
+	return sarifLocation{
+		PhysicalLocation: sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: path.Join(c.BaseDir, file)},
+			Region:           region,
+		},
+	}
+}
+
+type SARIFConfig struct {
+	// ToolVersion is the version of mtcli reported as tool.
+	ToolVersion string
+	// BaseDir is prepended to the addon relative files of all
+	// locations, e.g. the addon directory relative to the repository.
+	BaseDir string
+	// DefaultFile is the addon relative file findings
+	// without a known location are reported at.
+	DefaultFile string
+}
+
+func (c *SARIFConfig) Option(opts ...SARIFOption) {
+	for _, opt := range opts {
+		opt.ConfigureSARIF(c)
+	}
+}
+
+type SARIFOption interface {
+	ConfigureSARIF(*SARIFConfig)
+}
+
+// WithToolVersion applies the version of mtcli reported as tool.
+type WithToolVersion string
+
+func (w WithToolVersion) ConfigureSARIF(c *SARIFConfig) {
+	c.ToolVersion = string(w)
+}
+
+// WithBaseDir applies the directory locations are relative to.
+type WithBaseDir string
+
+func (w WithBaseDir) ConfigureSARIF(c *SARIFConfig) {
+	c.BaseDir = string(w)
+}
+
+// WithDefaultFile applies the file findings without
+// a known location are reported at.
+type WithDefaultFile string
+
+func (w WithDefaultFile) ConfigureSARIF(c *SARIFConfig) {
+	c.DefaultFile = string(w)
+}
@@ -0,0 +1,121 @@
+package validate_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validate"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportWriteSARIF(t *testing.T) {
+	t.Parallel()
+
+	first, err := validator.NewBase(1, validator.BaseName("first"), validator.BaseDesc("first desc"))
+	require.NoError(t, err)
+
+	second, err := validator.NewBase(2, validator.BaseName("second"), validator.BaseDesc("second desc"))
+	require.NoError(t, err)
+
+	third, err := validator.NewBase(3, validator.BaseName("third"), validator.BaseDesc("third desc"))
+	require.NoError(t, err)
+
+	report := validate.Report{
+		Results: validator.ResultList{
+			first.Success(),
+			second.FailWith(
+				validator.Finding{Message: "metadata/stage/addon.yaml:3:8: invalid label"},
+				validator.Finding{Message: "no location"},
+				validator.Finding{
+					Message:  "invalid default",
+					Severity: validator.SeverityWarning,
+					File:     "metadata/stage/parameters.yaml",
+					Field:    "addOnParameters[0].defaultValue",
+				},
+			),
+			third.Error(errors.New("boom")),
+		},
+	}
+
+	var buf bytes.Buffer
+
+	require.NoError(t, report.WriteSARIF(&buf,
+		validate.WithToolVersion("1.2.3"),
+		validate.WithBaseDir("addons/reference-addon"),
+		validate.WithDefaultFile("metadata/stage/addon.yaml"),
+	))
+
+	assert.JSONEq(t, `{
+  "$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+  "version": "2.1.0",
+  "runs": [{
+    "tool": {"driver": {
+      "name": "mtcli",
+      "version": "1.2.3",
+      "informationUri": "https://github.com/mt-sre/addon-metadata-operator",
+      "rules": [
+        {
+          "id": "AM0001", "name": "first", "shortDescription": {"text": "first desc"},
+          "helpUri": "https://github.com/mt-sre/addon-metadata-operator/wiki/AM0001",
+          "help": {
+            "text": "first desc. See https://github.com/mt-sre/addon-metadata-operator/wiki/AM0001 for how to resolve findings.",
+            "markdown": "first desc. See the [AM0001 documentation](https://github.com/mt-sre/addon-metadata-operator/wiki/AM0001) for how to resolve findings."
+          }
+        },
+        {
+          "id": "AM0002", "name": "second", "shortDescription": {"text": "second desc"},
+          "helpUri": "https://github.com/mt-sre/addon-metadata-operator/wiki/AM0002",
+          "help": {
+            "text": "second desc. See https://github.com/mt-sre/addon-metadata-operator/wiki/AM0002 for how to resolve findings.",
+            "markdown": "second desc. See the [AM0002 documentation](https://github.com/mt-sre/addon-metadata-operator/wiki/AM0002) for how to resolve findings."
+          }
+        },
+        {
+          "id": "AM0003", "name": "third", "shortDescription": {"text": "third desc"},
+          "helpUri": "https://github.com/mt-sre/addon-metadata-operator/wiki/AM0003",
+          "help": {
+            "text": "third desc. See https://github.com/mt-sre/addon-metadata-operator/wiki/AM0003 for how to resolve findings.",
+            "markdown": "third desc. See the [AM0003 documentation](https://github.com/mt-sre/addon-metadata-operator/wiki/AM0003) for how to resolve findings."
+          }
+        }
+      ]
+    }},
+    "invocations": [{
+      "executionSuccessful": false,
+      "toolExecutionNotifications": [
+        {"level": "error", "message": {"text": "validator internal error: boom"}, "descriptor": {"id": "AM0003"}}
+      ]
+    }],
+    "results": [
+      {
+        "ruleId": "AM0002", "ruleIndex": 1, "level": "error",
+        "message": {"text": "invalid label"},
+        "locations": [{"physicalLocation": {
+          "artifactLocation": {"uri": "addons/reference-addon/metadata/stage/addon.yaml"},
+          "region": {"startLine": 3, "startColumn": 8}
+        }}]
+      },
+      {
+        "ruleId": "AM0002", "ruleIndex": 1, "level": "error",
+        "message": {"text": "no location"},
+        "locations": [{"physicalLocation": {
+          "artifactLocation": {"uri": "addons/reference-addon/metadata/stage/addon.yaml"}
+        }}]
+      },
+      {
+        "ruleId": "AM0002", "ruleIndex": 1, "level": "warning",
+        "message": {"text": "invalid default"},
+        "locations": [{
+          "physicalLocation": {
+            "artifactLocation": {"uri": "addons/reference-addon/metadata/stage/parameters.yaml"}
+          },
+          "logicalLocations": [{"fullyQualifiedName": "addOnParameters[0].defaultValue"}]
+        }]
+      }
+    ]
+  }]
+}`, buf.String())
+}
@@ -0,0 +1,262 @@
+// Package validate is the public entrypoint for running the registered
+// addon validators against a MetaBundle from within other programs.
+package validate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+
+	// registers all validators with the default initializers
+	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/register"
+)
+
+// Run validates mb using all registered validators unless they are
+// excluded by the configured filters or the 'excludedValidators' of the
+// addon metadata. Every result is passed to the configured reporters as
+// soon as it is available and the complete Report is returned once all
+// validators finished, ordered by code. Validators which panic yield an
+// error result wrapping validator.ErrPanic. An error is returned if the
+// validators could not be initialized or ctx is cancelled.
+func Run(ctx context.Context, mb types.MetaBundle, opts ...Option) (Report, error) {
+	var cfg Config
+
+	cfg.Option(opts...)
+
+	// cancelling stops the remaining validators if FailFast is set
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results, err := Stream(runCtx, mb, opts...)
+	if err != nil {
+		return Report{}, err
+	}
+
+	var report Report
+
+	if mb.AddonMeta != nil {
+		report.Addon = mb.AddonMeta.ID
+	}
+
+	for res := range results {
+		for _, r := range cfg.Reporters {
+			r.Report(res)
+		}
+
+		report.Results = append(report.Results, res)
+
+		if cfg.FailFast && res.IsBlockingAt(cfg.FailOn) {
+			// validators still running do not send once cancelled
+			cancel()
+
+			report.Incomplete = true
+
+			break
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return Report{}, err
+	}
+
+	sort.Sort(report.Results)
+
+	return report, nil
+}
+
+// Stream starts validating mb like Run, but returns the results in the
+// order validators finish through a channel which is closed once all
+// validators finished or ctx is cancelled. Callers must drain the channel
+// or cancel ctx. Reporters are not used. Validators use the logger, image
+// policy and OCM client carried by ctx unless others are given using
+// WithRunnerOptions. Results reused from the cache given by WithCache
+// are sent first. Severities are mapped as configured by WithSeverities.
+func Stream(ctx context.Context, mb types.MetaBundle, opts ...Option) (<-chan validator.Result, error) {
+	var cfg Config
+
+	cfg.Option(opts...)
+
+	runner, err := cfg.newRunner(ctx,
+		validator.WithConcurrency(cfg.Concurrency),
+		validator.WithTimeout(cfg.ValidatorTimeout),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	filters := filtersFor(mb, cfg)
+
+	if cfg.Cache.Cache == nil {
+		return withSeverities(ctx, runner.Run(ctx, mb, filters...), cfg.Severities), nil
+	}
+
+	results, err := streamCached(ctx, runner, mb, cfg.Cache, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	return withSeverities(ctx, results, cfg.Severities), nil
+}
+
+// withSeverities returns the results sent through in with their
+// severities mapped by f. It returns in unchanged if f is nil.
+func withSeverities(ctx context.Context, in <-chan validator.Result, f validator.SeverityFunc) <-chan validator.Result {
+	if f == nil {
+		return in
+	}
+
+	out := make(chan validator.Result)
+
+	go func() {
+		defer close(out)
+
+		for res := range in {
+			select {
+			case out <- res.WithSeverities(f):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// streamCached sends the results recorded in the cache of w before
+// running the remaining validators selected by filters and recording
+// their results. Failing to access the cache is logged, but does not
+// fail the validation.
+func streamCached(ctx context.Context, runner *validator.Runner, mb types.MetaBundle, w WithCache, filters []validator.Filter) (<-chan validator.Result, error) {
+	hash, err := MetadataHash(mb)
+	if err != nil {
+		return nil, fmt.Errorf("hashing addon metadata: %w", err)
+	}
+
+	log := logr.FromContextOrDiscard(ctx).WithValues("source", "resultCache")
+
+	keyOf := func(code validator.Code) CacheKey {
+		return CacheKey{
+			Code:         code,
+			Version:      w.Version,
+			IndexDigest:  w.IndexDigest,
+			MetadataHash: hash,
+		}
+	}
+
+	var cached validator.ResultList
+
+	cacheable := make(map[validator.Code]bool)
+
+	for _, v := range runner.GetValidators(filters...) {
+		if validator.DocsOf(v).HasTag(validator.TagService) {
+			continue
+		}
+
+		cacheable[v.Code()] = true
+
+		entry, ok, err := w.Cache.Get(keyOf(v.Code()))
+		if err != nil {
+			log.Error(err, "reading cached result", "code", v.Code().String())
+
+			continue
+		}
+
+		if !ok {
+			continue
+		}
+
+		cached = append(cached, validator.RestoreResult(v, entry.Success, entry.Findings...))
+	}
+
+	// dependents of cached failures are skipped like they are without cache
+	results := runner.Resume(ctx, mb, cached, filters...)
+	out := make(chan validator.Result)
+
+	send := func(res validator.Result) bool {
+		select {
+		case out <- res:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	go func() {
+		defer close(out)
+
+		for _, res := range cached {
+			if !send(res) {
+				return
+			}
+		}
+
+		for res := range results {
+			if cacheable[res.Code] && !res.IsError() && !res.IsSkipped() {
+				entry := CacheEntry{
+					Success:    res.IsSuccess(),
+					Findings:   res.Findings,
+					RecordedAt: time.Now().UTC(),
+				}
+
+				if err := w.Cache.Put(keyOf(res.Code), entry); err != nil {
+					log.Error(err, "recording result", "code", res.Code.String())
+				}
+			}
+
+			if !send(res) {
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// filtersFor returns the configured filters extended by a filter
+// skipping the validators excluded by the addon metadata of mb.
+func filtersFor(mb types.MetaBundle, cfg Config) []validator.Filter {
+	excluded := validator.ExcludedCodes(mb.AddonMeta)
+	if len(excluded) == 0 {
+		return cfg.Filters
+	}
+
+	filters := make([]validator.Filter, 0, len(cfg.Filters)+1)
+	filters = append(filters, cfg.Filters...)
+
+	return append(filters, validator.Not(validator.MatchesCodes(excluded...)))
+}
+
+// Report holds the results of all validators which were run.
+type Report struct {
+	// Addon is the ID of the validated addon.
+	Addon string
+	// Results are ordered by validator code.
+	Results validator.ResultList
+	// Incomplete is true if the remaining validators were
+	// cancelled after the first unsuccessful one (see WithFailFast).
+	Incomplete bool
+}
+
+// Passed returns 'true' if no validator encountered an error
+// or failed with severity validator.SeverityError.
+func (r Report) Passed() bool { return !r.Results.HasBlocking() }
+
+// Errors returns the errors encountered by validators.
+func (r Report) Errors() []error { return r.Results.Errors() }
+
+// Reporter receives the result of each validator as soon as it
+// finished. Reporters are called sequentially from a single goroutine.
+type Reporter interface {
+	Report(validator.Result)
+}
+
+// ReporterFunc adapts a function to the Reporter interface.
+type ReporterFunc func(validator.Result)
+
+func (f ReporterFunc) Report(res validator.Result) { f(res) }
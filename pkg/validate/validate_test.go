@@ -0,0 +1,314 @@
+package validate_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validate"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	var reported []validator.Code
+
+	mb := types.MetaBundle{
+		AddonMeta: &v1alpha1.AddonMetadataSpec{ID: "reference-addon"},
+	}
+
+	report, err := validate.Run(context.Background(), mb,
+		validate.WithConcurrency(1),
+		validate.WithFilters{validator.Not(validator.MatchesCodes(3))},
+		validate.WithReporters{
+			validate.ReporterFunc(func(res validator.Result) {
+				reported = append(reported, res.Code)
+			}),
+		},
+		validate.WithRunnerOptions{
+			validator.WithInitializers{
+				newValidator(2, true),
+				newValidator(1, true),
+				newValidator(3, false),
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "reference-addon", report.Addon)
+	require.Len(t, report.Results, 2)
+	assert.Equal(t, validator.Code(1), report.Results[0].Code)
+	assert.Equal(t, validator.Code(2), report.Results[1].Code)
+	assert.True(t, report.Passed())
+	assert.Empty(t, report.Errors())
+	assert.ElementsMatch(t, []validator.Code{1, 2}, reported)
+}
+
+func TestRunWithRunner(t *testing.T) {
+	t.Parallel()
+
+	var runs atomic.Int32
+
+	runner, err := validator.NewRunner(context.Background(),
+		validator.WithInitializers{
+			newCountingValidator(1, true, &runs),
+		},
+	)
+	require.NoError(t, err)
+
+	for range 2 {
+		report, err := validate.Run(context.Background(), types.MetaBundle{},
+			validate.WithRunner{Runner: runner},
+			// only apply when initializing a runner
+			validate.WithRunnerOptions{
+				validator.WithInitializers{newValidator(2, false)},
+			},
+		)
+		require.NoError(t, err)
+		require.Len(t, report.Results, 1)
+		assert.Equal(t, validator.Code(1), report.Results[0].Code)
+	}
+
+	assert.Equal(t, int32(2), runs.Load())
+}
+
+func TestRunFailFast(t *testing.T) {
+	t.Parallel()
+
+	report, err := validate.Run(context.Background(), types.MetaBundle{},
+		validate.WithFailFast(true),
+		validate.WithRunnerOptions{
+			validator.WithInitializers{
+				newValidator(1, false),
+				newBlockingValidator(2),
+				newBlockingValidator(3),
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	require.Len(t, report.Results, 1)
+	assert.Equal(t, validator.Code(1), report.Results[0].Code)
+	assert.True(t, report.Incomplete)
+	assert.False(t, report.Passed())
+}
+
+func TestRunFailFastOn(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		FailOn validator.Severity
+		// Next runs after the failing validator
+		Next               validator.Initializer
+		ExpectedResults    int
+		ExpectedIncomplete bool
+	}{
+		"default ignores warnings": {
+			Next:            newValidator(2, true),
+			ExpectedResults: 2,
+		},
+		"error ignores warnings": {
+			FailOn:          validator.SeverityError,
+			Next:            newValidator(2, true),
+			ExpectedResults: 2,
+		},
+		"warning stops at warnings": {
+			FailOn:             validator.SeverityWarning,
+			Next:               newBlockingValidator(2),
+			ExpectedResults:    1,
+			ExpectedIncomplete: true,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			report, err := validate.Run(context.Background(), types.MetaBundle{},
+				validate.WithFailFast(true),
+				validate.WithFailOn(tc.FailOn),
+				validate.WithSeverities(func(validator.Code, validator.Severity) validator.Severity {
+					return validator.SeverityWarning
+				}),
+				validate.WithRunnerOptions{
+					validator.WithInitializers{
+						newValidator(1, false),
+						tc.Next,
+					},
+				},
+			)
+			require.NoError(t, err)
+
+			assert.Len(t, report.Results, tc.ExpectedResults)
+			assert.Equal(t, tc.ExpectedIncomplete, report.Incomplete)
+			assert.True(t, report.Passed())
+		})
+	}
+}
+
+func TestRunSeverities(t *testing.T) {
+	t.Parallel()
+
+	report, err := validate.Run(context.Background(), types.MetaBundle{},
+		validate.WithSeverities(func(code validator.Code, sev validator.Severity) validator.Severity {
+			if code == 1 {
+				return validator.SeverityWarning
+			}
+
+			return sev
+		}),
+		validate.WithRunnerOptions{
+			validator.WithInitializers{
+				newValidator(1, false),
+				newValidator(2, true),
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	require.Len(t, report.Results, 2)
+	assert.Equal(t, validator.SeverityWarning, report.Results[0].Severity)
+	assert.Equal(t, validator.SeverityError, report.Results[1].Severity)
+	assert.True(t, report.Passed())
+}
+
+func TestRunExcludedValidators(t *testing.T) {
+	t.Parallel()
+
+	mb := types.MetaBundle{
+		AddonMeta: &v1alpha1.AddonMetadataSpec{
+			ID: "reference-addon",
+			ExcludedValidators: &[]v1alpha1.ExcludedValidator{
+				{Code: "AM0002", Justification: "accepted exception"},
+				// not honored without justification
+				{Code: "AM0003"},
+			},
+		},
+	}
+
+	report, err := validate.Run(context.Background(), mb,
+		validate.WithRunnerOptions{
+			validator.WithInitializers{
+				newValidator(1, true),
+				newValidator(2, false),
+				newValidator(3, false),
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	require.Len(t, report.Results, 2)
+	assert.Equal(t, validator.Code(1), report.Results[0].Code)
+	assert.Equal(t, validator.Code(3), report.Results[1].Code)
+}
+
+func TestRunCancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := validate.Run(ctx, types.MetaBundle{},
+		validate.WithRunnerOptions{
+			validator.WithInitializers{newValidator(1, true)},
+		},
+	)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func newValidator(code validator.Code, success bool) validator.Initializer {
+	return func(validator.Dependencies) (validator.Validator, error) {
+		base, err := validator.NewBase(
+			code,
+			validator.BaseName(fmt.Sprintf("validator_%d", code)),
+			validator.BaseDesc("test validator"),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return &testValidator{Base: base, success: success}, nil
+	}
+}
+
+// newBlockingValidator returns a validator which
+// only returns once its context is cancelled.
+func newBlockingValidator(code validator.Code) validator.Initializer {
+	return func(validator.Dependencies) (validator.Validator, error) {
+		base, err := validator.NewBase(
+			code,
+			validator.BaseName(fmt.Sprintf("validator_%d", code)),
+			validator.BaseDesc("test validator"),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return &blockingValidator{Base: base}, nil
+	}
+}
+
+type blockingValidator struct {
+	*validator.Base
+}
+
+func (v *blockingValidator) Run(ctx context.Context, _ types.MetaBundle) validator.Result {
+	<-ctx.Done()
+
+	return v.Error(ctx.Err())
+}
+
+type testValidator struct {
+	*validator.Base
+	success bool
+}
+
+func (v *testValidator) Run(context.Context, types.MetaBundle) validator.Result {
+	if v.success {
+		return v.Success()
+	}
+
+	return v.Fail("failed")
+}
+
+func TestStream(t *testing.T) {
+	t.Parallel()
+
+	results, err := validate.Stream(context.Background(), types.MetaBundle{},
+		validate.WithRunnerOptions{
+			validator.WithInitializers{
+				newValidator(1, true),
+				newValidator(2, false),
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	var codes []validator.Code
+
+	for res := range results {
+		codes = append(codes, res.Code)
+	}
+
+	assert.ElementsMatch(t, []validator.Code{1, 2}, codes)
+}
+
+func TestStreamInitializationError(t *testing.T) {
+	t.Parallel()
+
+	_, err := validate.Stream(context.Background(), types.MetaBundle{},
+		validate.WithRunnerOptions{
+			validator.WithInitializers{
+				newValidator(1, true),
+				newValidator(1, true),
+			},
+		},
+	)
+	require.Error(t, err)
+}
@@ -20,11 +20,23 @@ const (
 	desc = "Ensure defaultChannel is present in list of channels"
 )
 
+var docs = validator.Docs{
+	Details: "The defaultChannel must be one of alpha, beta, stable, edge or rc and be listed in channels. When bundles are available it must also match the bundle annotation operators.operatorframework.io.bundle.channel.default.v1 and be part of operators.operatorframework.io.bundle.channels.v1. The default channel the index declares through that annotation of the head bundle must contain at least one bundle.",
+	ExampleFailures: []string{
+		"The defaultChannel 'fast' is not part of the accepted values: alpha, beta, stable, edge or rc.",
+		"The defaultChannel 'beta' does not match annotation operators.operatorframework.io.bundle.channel.default.v1 'alpha'.",
+		"The default channel 'stable' declared by the index does not contain any bundle.",
+	},
+	Remediation: "Set defaultChannel to an accepted channel listed in channels and keep it in sync with the channel annotations of the bundles.",
+	Tags:        []string{validator.TagBundle, validator.TagConsistency},
+}
+
 func NewDefaultChannel(deps validator.Dependencies) (validator.Validator, error) {
 	base, err := validator.NewBase(
 		code,
 		validator.BaseName(name),
 		validator.BaseDesc(desc),
+		validator.BaseDocs(docs),
 	)
 	if err != nil {
 		return nil, err
@@ -40,7 +52,8 @@ type DefaultChannel struct {
 }
 
 func (d *DefaultChannel) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
-	defaultChannel := mb.AddonMeta.DefaultChannel
+	resolved := mb.Resolved()
+	defaultChannel := resolved.DefaultChannel
 	channels := mb.AddonMeta.Channels
 
 	if res := d.isPartOfEnum(defaultChannel); !res.IsSuccess() {
@@ -51,7 +64,7 @@ func (d *DefaultChannel) Run(ctx context.Context, mb types.MetaBundle) validator
 		return res
 	}
 
-	if res := d.matchesBundleChannelAnnotations(defaultChannel, mb.Bundles); !res.IsSuccess() {
+	if res := d.matchesBundleChannelAnnotations(defaultChannel, resolved.HeadBundle, mb.BundlesByChannel()); !res.IsSuccess() {
 		return res
 	}
 
@@ -91,11 +104,13 @@ func (d *DefaultChannel) isListedInChannels(channels *[]opsv1alpha1.Channel, def
 	return d.Fail(msg)
 }
 
-func (d *DefaultChannel) matchesBundleChannelAnnotations(defaultChannel string, bundles []operator.Bundle) validator.Result {
+// matchesBundleChannelAnnotations verifies the defaultChannel against the
+// default channel the index declares for the package, which opm takes
+// from the head bundle, and that the declared channel contains bundles.
+func (d *DefaultChannel) matchesBundleChannelAnnotations(defaultChannel string, bundle *operator.Bundle, byChannel map[string][]operator.Bundle) validator.Result {
 	var message []string
 
-	bundle, ok := operator.HeadBundle(bundles...)
-	if !ok {
+	if bundle == nil {
 		return d.Success()
 	}
 
@@ -120,6 +135,11 @@ func (d *DefaultChannel) matchesBundleChannelAnnotations(defaultChannel string,
 		message = append(message, msg)
 	}
 
+	if declared := bundle.Annotations.DefaultChannelName; declared != "" && len(byChannel[declared]) == 0 {
+		msg := fmt.Sprintf("The default channel '%v' declared by the index does not contain any bundle.", declared)
+		message = append(message, msg)
+	}
+
 	if len(message) > 0 {
 		return d.Fail(message...)
 	}
@@ -118,3 +118,76 @@ func TestDefaultChannelInvalid(t *testing.T) {
 		},
 	})
 }
+
+func TestDefaultChannelIndex(t *testing.T) {
+	t.Parallel()
+
+	meta := &v1alpha1.AddonMetadataSpec{
+		ID:             "random-operator",
+		DefaultChannel: "stable",
+	}
+
+	tester := testutils.NewValidatorTester(t, NewDefaultChannel)
+	tester.TestCases(map[string]testutils.TestCase{
+		"default channel contains bundles": {
+			MetaBundle: types.MetaBundle{
+				AddonMeta: meta,
+				Bundles: []operator.Bundle{
+					{
+						Version:  "1.0.0",
+						Channels: []string{"alpha"},
+						Annotations: operator.Annotations{
+							DefaultChannelName: "alpha",
+							Channels:           []string{"alpha"},
+						},
+					},
+					{
+						Version:  "1.1.0",
+						Channels: []string{"stable"},
+						Annotations: operator.Annotations{
+							DefaultChannelName: "stable",
+							Channels:           []string{"stable"},
+						},
+					},
+				},
+			},
+		},
+		"default channel without bundles in the index": {
+			MetaBundle: types.MetaBundle{
+				AddonMeta: meta,
+				Bundles: []operator.Bundle{
+					{
+						Version:  "1.1.0",
+						Channels: []string{"alpha"},
+						Annotations: operator.Annotations{
+							DefaultChannelName: "stable",
+							Channels:           []string{"stable"},
+						},
+					},
+				},
+			},
+			FailsWith: []string{
+				"The default channel 'stable' declared by the index does not contain any bundle.",
+			},
+		},
+		"mismatched default channel without bundles in the index": {
+			MetaBundle: types.MetaBundle{
+				AddonMeta: meta,
+				Bundles: []operator.Bundle{
+					{
+						Version:  "1.1.0",
+						Channels: []string{"stable"},
+						Annotations: operator.Annotations{
+							DefaultChannelName: "fast",
+							Channels:           []string{"stable"},
+						},
+					},
+				},
+			},
+			FailsWith: []string{
+				"The defaultChannel 'stable' does not match annotation operators.operatorframework.io.bundle.channel.default.v1 'fast'.",
+				"The default channel 'fast' declared by the index does not contain any bundle.",
+			},
+		},
+	})
+}
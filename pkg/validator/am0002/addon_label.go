@@ -18,11 +18,20 @@ const (
 	desc = "Validates whether label follows the format 'api.openshift.com/addon-<id>'"
 )
 
+var docs = validator.Docs{
+	Details: "The label of an addon must be 'api.openshift.com/addon-' followed by the addon id.",
+	ExampleFailures: []string{
+		"addon label 'api.openshift.com/addon-Reference-Addon' wasn't recognized to follow the 'api.openshift.com/addon-<id>' format",
+	},
+	Remediation: "Set label to 'api.openshift.com/addon-<id>'; 'mtcli fix' applies this automatically.",
+}
+
 func NewAddonLabel(deps validator.Dependencies) (validator.Validator, error) {
 	base, err := validator.NewBase(
 		code,
 		validator.BaseName(name),
 		validator.BaseDesc(desc),
+		validator.BaseDocs(docs),
 	)
 	if err != nil {
 		return nil, err
@@ -41,8 +50,25 @@ func (a *AddonLabel) Run(ctx context.Context, mb types.MetaBundle) validator.Res
 	operatorId, label := mb.AddonMeta.ID, mb.AddonMeta.Label
 	if label != "api.openshift.com/addon-"+operatorId {
 		msg := fmt.Sprintf("addon label '%s' wasn't recognized to follow the 'api.openshift.com/addon-<id>' format", label)
+		if src, ok := mb.Provenance.Lookup("label"); ok {
+			msg = fmt.Sprintf("%s: %s", src, msg)
+		}
 		return a.Fail(msg)
 	}
 
 	return a.Success()
 }
+
+// Fix sets the label to 'api.openshift.com/addon-<id>'.
+func (a *AddonLabel) Fix(ctx context.Context, mb types.MetaBundle) ([]types.Fix, error) {
+	expected := "api.openshift.com/addon-" + mb.AddonMeta.ID
+	if mb.AddonMeta.Label == expected {
+		return nil, nil
+	}
+
+	return []types.Fix{{
+		Path:        []string{"label"},
+		Value:       expected,
+		Description: fmt.Sprintf("set 'label' to '%s'", expected),
+	}}, nil
+}
@@ -1,46 +1,82 @@
 package am0002
 
 import (
+	"context"
 	"testing"
 
 	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
 	"github.com/mt-sre/addon-metadata-operator/pkg/types"
 	"github.com/mt-sre/addon-metadata-operator/pkg/validator/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-func TestAddonLabelValid(t *testing.T) {
+func TestAddonLabel(t *testing.T) {
 	t.Parallel()
 
+	withLabel := func(label string) func(*types.MetaBundle) {
+		return func(mb *types.MetaBundle) { mb.AddonMeta.Label = label }
+	}
+
 	tester := testutils.NewValidatorTester(t, NewAddonLabel)
 
-	tester.TestValidBundles(map[string]types.MetaBundle{
+	tester.TestCases(map[string]testutils.TestCase{
+		"reference addon": {
+			MetaBundle: testutils.ReferenceAddon(t),
+		},
 		"properly prefixed label": {
-			AddonMeta: &v1alpha1.AddonMetadataSpec{
-				ID:    "random-operator",
-				Label: "api.openshift.com/addon-random-operator",
+			MetaBundle: types.MetaBundle{
+				AddonMeta: &v1alpha1.AddonMetadataSpec{
+					ID:    "random-operator",
+					Label: "api.openshift.com/addon-random-operator",
+				},
+			},
+		},
+		"no prefix": {
+			MetaBundle: testutils.ReferenceAddonWith(t, withLabel("foo-bar")),
+			FailsWith:  []string{"addon label 'foo-bar' wasn't recognized"},
+		},
+		"non matching addon id": {
+			MetaBundle: testutils.ReferenceAddonWith(t, withLabel("api.openshift.com/addon-reference-addon-x")),
+			FailsWith:  []string{"'api.openshift.com/addon-reference-addon-x'"},
+		},
+		"with provenance": {
+			MetaBundle: types.MetaBundle{
+				AddonMeta: &v1alpha1.AddonMetadataSpec{
+					ID:    "random-operator",
+					Label: "foo-bar",
+				},
+				Provenance: types.Provenance{
+					"label": {File: "metadata/stage/addon.yaml", Line: 3, Column: 1},
+				},
 			},
+			FailsWith: []string{"metadata/stage/addon.yaml:3:1: addon label"},
 		},
 	})
-
 }
 
-func TestAddonLabelInvalid(t *testing.T) {
+func TestAddonLabelFix(t *testing.T) {
 	t.Parallel()
 
-	tester := testutils.NewValidatorTester(t, NewAddonLabel)
+	a := &AddonLabel{}
 
-	tester.TestInvalidBundles(map[string]types.MetaBundle{
-		"no prefix": {
-			AddonMeta: &v1alpha1.AddonMetadataSpec{
-				ID:    "random-operator",
-				Label: "foo-bar",
-			},
+	fixes, err := a.Fix(context.Background(), types.MetaBundle{
+		AddonMeta: &v1alpha1.AddonMetadataSpec{
+			ID:    "random-operator",
+			Label: "api.openshift.com/addon-Random-Operator",
 		},
-		"non matching addon id": {
-			AddonMeta: &v1alpha1.AddonMetadataSpec{
-				ID:    "random-operator",
-				Label: "api.openshift.com/addon-random-operator-x",
-			},
+	})
+	require.NoError(t, err)
+	require.Len(t, fixes, 1)
+	assert.Equal(t, []string{"label"}, fixes[0].Path)
+	assert.Equal(t, "api.openshift.com/addon-random-operator", fixes[0].Value)
+
+	fixes, err = a.Fix(context.Background(), types.MetaBundle{
+		AddonMeta: &v1alpha1.AddonMetadataSpec{
+			ID:    "random-operator",
+			Label: "api.openshift.com/addon-random-operator",
 		},
 	})
+	require.NoError(t, err)
+	assert.Empty(t, fixes)
 }
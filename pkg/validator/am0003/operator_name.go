@@ -17,6 +17,15 @@ const (
 	desc = "Validate the operatorName matches csv.Name, csv.Replaces and bundle package annotation."
 )
 
+var docs = validator.Docs{
+	Details: "The CSV of every bundle must be named '<operatorName>.<version>' with a valid semver version, and spec.replaces must follow the same format.",
+	ExampleFailures: []string{
+		"bundle \"reference-addon.v0.1.0\" failed validation on csv.Name: invalid operatorName for \"reference-addon.v0.1.0\"; expected \"other-addon\".",
+	},
+	Remediation: "Make operatorName match the name of the operator package and the CSV names and replaces of all bundles.",
+	Tags:        []string{validator.TagBundle, validator.TagConsistency},
+}
+
 func init() {
 	validator.Register(NewOperatorName)
 }
@@ -26,6 +35,7 @@ func NewOperatorName(deps validator.Dependencies) (validator.Validator, error) {
 		code,
 		validator.BaseName(name),
 		validator.BaseDesc(desc),
+		validator.BaseDocs(docs),
 	)
 	if err != nil {
 		return nil, err
@@ -6,6 +6,8 @@ import (
 	"encoding/base64"
 	"fmt"
 	"image/png"
+	"strings"
+	"unicode"
 
 	"github.com/mt-sre/addon-metadata-operator/pkg/types"
 	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
@@ -21,11 +23,31 @@ const (
 	desc = "Ensure that `icon` in Addon metadata is rightfully base64 encoded"
 )
 
+// minIconSize and maxIconSize bound the width of icons
+// which are rendered at around 200px by the OCM console.
+const (
+	minIconSize = 100
+	maxIconSize = 400
+)
+
+var docs = validator.Docs{
+	Details: "The icon must be a standard base64 encoded PNG image without a data URI prefix or whitespace. " +
+		fmt.Sprintf("Icons which are not square or not between %dpx and %dpx wide are reported as warnings ", minIconSize, maxIconSize) +
+		"as the OCM console renders them as square of around 200px.",
+	ExampleFailures: []string{
+		"`icon` found to be improperly base64 populated under the addon metadata of reference-addon",
+		"`icon`'s base64 value found to correspond to a non-png data under the addon metadata of reference-addon",
+		"`icon` of reference-addon is 192x145px; it should be square",
+	},
+	Remediation: "Encode a square PNG image of around 200px with 'base64 -w0'; 'mtcli fix' re-encodes icons which are only encoded differently.",
+}
+
 func NewIconBase64(deps validator.Dependencies) (validator.Validator, error) {
 	base, err := validator.NewBase(
 		code,
 		validator.BaseName(name),
 		validator.BaseDesc(desc),
+		validator.BaseDocs(docs),
 	)
 	if err != nil {
 		return nil, err
@@ -52,10 +74,94 @@ func (i *IconBase64) Run(ctx context.Context, mb types.MetaBundle) validator.Res
 		return i.Fail(fmt.Sprintf("`icon` found to be improperly base64 populated under the addon metadata of %s", mb.AddonMeta.ID))
 	}
 
-	_, err = png.Decode(bytes.NewReader(b64decoded))
+	img, err := png.Decode(bytes.NewReader(b64decoded))
 	if err != nil {
 		return i.Fail(fmt.Sprintf("`icon`'s base64 value found to correspond to a non-png data under the addon metadata of %s", mb.AddonMeta.ID))
 	}
 
+	if findings := dimensionFindings(mb, img.Bounds().Dx(), img.Bounds().Dy()); len(findings) > 0 {
+		return i.FailWith(findings...)
+	}
+
 	return i.Success()
 }
+
+// dimensionFindings reports icons which are not square or too small
+// or large to be rendered well as warnings; they do not block merges.
+func dimensionFindings(mb types.MetaBundle, width, height int) []validator.Finding {
+	var msgs []string
+
+	if width != height {
+		msgs = append(msgs, fmt.Sprintf("`icon` of %s is %dx%dpx; it should be square", mb.AddonMeta.ID, width, height))
+	}
+
+	if width < minIconSize || width > maxIconSize {
+		msgs = append(msgs, fmt.Sprintf("`icon` of %s is %dpx wide; it should be between %dpx and %dpx wide",
+			mb.AddonMeta.ID, width, minIconSize, maxIconSize))
+	}
+
+	findings := make([]validator.Finding, 0, len(msgs))
+
+	for _, msg := range msgs {
+		f := validator.FindingAt(mb, "icon", msg)
+		f.Severity = validator.SeverityWarning
+
+		findings = append(findings, f)
+	}
+
+	return findings
+}
+
+// Fix re-encodes icons which contain PNG data in another base64 variant,
+// wrapped across lines or as data URI using standard base64 encoding.
+func (i *IconBase64) Fix(ctx context.Context, mb types.MetaBundle) ([]types.Fix, error) {
+	icon := mb.AddonMeta.Icon
+	if icon == "" || isPNG(icon) {
+		return nil, nil
+	}
+
+	normalized := strings.TrimPrefix(strings.TrimSpace(icon), "data:image/png;base64,")
+	normalized = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+
+		return r
+	}, normalized)
+
+	for _, enc := range []*base64.Encoding{
+		base64.StdEncoding,
+		base64.RawStdEncoding,
+		base64.URLEncoding,
+		base64.RawURLEncoding,
+	} {
+		data, err := enc.DecodeString(normalized)
+		if err != nil {
+			continue
+		}
+
+		reencoded := base64.StdEncoding.EncodeToString(data)
+		if !isPNG(reencoded) {
+			continue
+		}
+
+		return []types.Fix{{
+			Path:        []string{"icon"},
+			Value:       reencoded,
+			Description: "re-encode 'icon' using standard base64 encoding",
+		}}, nil
+	}
+
+	return nil, nil
+}
+
+func isPNG(icon string) bool {
+	data, err := base64.StdEncoding.DecodeString(icon)
+	if err != nil {
+		return false
+	}
+
+	_, err = png.Decode(bytes.NewReader(data))
+
+	return err == nil
+}
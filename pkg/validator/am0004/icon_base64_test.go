@@ -1,12 +1,20 @@
 package am0004
 
 import (
+	"bytes"
+	"context"
 	_ "embed"
+	"encoding/base64"
+	"image"
+	"image/png"
+	"strings"
 	"testing"
 
 	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
 	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
 	"github.com/mt-sre/addon-metadata-operator/pkg/validator/testutils"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -58,3 +66,133 @@ func TestIconBase64Invalid(t *testing.T) {
 		},
 	})
 }
+
+func TestIconBase64Dimensions(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewIconBase64)
+
+	for name, tc := range map[string]struct {
+		Width, Height    int
+		ExpectedMessages []string
+	}{
+		"square": {
+			Width:  200,
+			Height: 200,
+		},
+		"not square": {
+			Width:            192,
+			Height:           145,
+			ExpectedMessages: []string{"`icon` of test-addon is 192x145px; it should be square"},
+		},
+		"too small": {
+			Width:            32,
+			Height:           32,
+			ExpectedMessages: []string{"`icon` of test-addon is 32px wide; it should be between 100px and 400px wide"},
+		},
+		"too large": {
+			Width:            1024,
+			Height:           512,
+			ExpectedMessages: []string{"it should be square", "is 1024px wide"},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			res := tester.TestSingleBundle(types.MetaBundle{
+				AddonMeta: &v1alpha1.AddonMetadataSpec{
+					ID:   "test-addon",
+					Icon: encodeIcon(t, tc.Width, tc.Height),
+				},
+			})
+
+			if len(tc.ExpectedMessages) == 0 {
+				testutils.AssertPasses(t, res)
+
+				return
+			}
+
+			testutils.AssertFailsWith(t, res, tc.ExpectedMessages...)
+
+			for _, f := range res.Findings {
+				assert.Equal(t, validator.SeverityWarning, res.SeverityOf(f))
+			}
+
+			// dimensions alone do not fail a validation
+			assert.False(t, res.IsBlocking())
+		})
+	}
+}
+
+func TestIconBase64Fix(t *testing.T) {
+	t.Parallel()
+
+	icon := strings.TrimSpace(validIcon)
+	raw := base64.RawURLEncoding.EncodeToString(mustDecode(t, icon))
+
+	i := &IconBase64{}
+
+	for name, tc := range map[string]struct {
+		Icon     string
+		Expected string
+	}{
+		"valid icon": {
+			Icon: icon,
+		},
+		"wrapped lines": {
+			Icon:     icon[:64] + "\n  " + icon[64:],
+			Expected: icon,
+		},
+		"data uri": {
+			Icon:     "data:image/png;base64," + icon,
+			Expected: icon,
+		},
+		"unpadded url encoding": {
+			Icon:     raw,
+			Expected: icon,
+		},
+		"invalid png": {
+			Icon: "dGhlIHF1aWNrIGJyb3duIGZveCBqdW1wcyBvdmVyIHRoZSBsYXp5IGRvZw==",
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			fixes, err := i.Fix(context.Background(), types.MetaBundle{
+				AddonMeta: &v1alpha1.AddonMetadataSpec{Icon: tc.Icon},
+			})
+			require.NoError(t, err)
+
+			if tc.Expected == "" {
+				assert.Empty(t, fixes)
+
+				return
+			}
+
+			require.Len(t, fixes, 1)
+			assert.Equal(t, tc.Expected, fixes[0].Value)
+		})
+	}
+}
+
+func encodeIcon(t *testing.T, width, height int) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height))))
+
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func mustDecode(t *testing.T, icon string) []byte {
+	t.Helper()
+
+	data, err := base64.StdEncoding.DecodeString(icon)
+	require.NoError(t, err)
+
+	return data
+}
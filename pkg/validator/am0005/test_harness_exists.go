@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/mt-sre/addon-metadata-operator/pkg/imagepolicy"
 	"github.com/mt-sre/addon-metadata-operator/pkg/types"
 	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
 	imageparser "github.com/novln/docker-parser"
@@ -15,6 +16,16 @@ const (
 	description = "Ensure that an addon has a valid testharness image"
 )
 
+var docs = validator.Docs{
+	Details: "The testHarness image must be hosted on quay.io, must exist and must satisfy the image policy when one is configured.",
+	ExampleFailures: []string{
+		"Testharness image is not in the quay.io registry",
+		"The testharness image \"quay.io/osd-addons/reference-addon-test-harness:latest\" does not exist",
+	},
+	Remediation: "Push the testharness image to quay.io and reference an existing tag or digest.",
+	Tags:        []string{validator.TagService},
+}
+
 func init() {
 	validator.Register(NewTestHarnessExists)
 }
@@ -24,20 +35,23 @@ func NewTestHarnessExists(deps validator.Dependencies) (validator.Validator, err
 		code,
 		validator.BaseName(name),
 		validator.BaseDesc(description),
+		validator.BaseDocs(docs),
 	)
 	if err != nil {
 		return nil, err
 	}
 
 	return &TestHarnessExists{
-		Base: base,
-		quay: deps.QuayClient,
+		Base:   base,
+		images: deps.ImageVerifier,
+		quay:   deps.QuayClient,
 	}, nil
 }
 
 type TestHarnessExists struct {
 	*validator.Base
-	quay validator.QuayClient
+	images validator.ImageVerifier
+	quay   validator.QuayClient
 }
 
 func (t *TestHarnessExists) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
@@ -59,5 +73,15 @@ func (t *TestHarnessExists) Run(ctx context.Context, mb types.MetaBundle) valida
 		return t.Fail(fmt.Sprintf("The testharness image %q does not exist", ref.Name()))
 	}
 
+	if t.images == nil {
+		return t.Success()
+	}
+
+	if err := t.images.Verify(ctx, ref.Remote()); imagepolicy.IsViolation(err) {
+		return t.Fail(fmt.Sprintf("The testharness image %q violates the image policy: %v", ref.Name(), err))
+	} else if err != nil {
+		return t.Error(err)
+	}
+
 	return t.Success()
 }
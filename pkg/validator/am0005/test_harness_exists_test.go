@@ -2,9 +2,12 @@ package am0005
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/imagepolicy"
 	"github.com/mt-sre/addon-metadata-operator/pkg/types"
 	"github.com/mt-sre/addon-metadata-operator/pkg/validator/testutils"
 	imageparser "github.com/novln/docker-parser"
@@ -117,6 +120,56 @@ func TestTestHarnessExistsInvalid(t *testing.T) {
 	})
 }
 
+func TestTestHarnessExistsImagePolicy(t *testing.T) {
+	t.Parallel()
+
+	const (
+		signed   = "quay.io/valid/signed:v1"
+		unsigned = "quay.io/valid/unsigned:v1"
+		missing  = "quay.io/valid/missing-signatures:v1"
+	)
+
+	quay := testutils.NewMockQuayClient()
+	images := testutils.NewMockImageVerifier()
+
+	for _, image := range []string{signed, unsigned, missing} {
+		quay.On("HasReference", context.Background(), getRef(t, image)).Return(true, nil)
+	}
+
+	images.
+		On("Verify", context.Background(), signed).
+		Return(nil).
+		On("Verify", context.Background(), unsigned).
+		Return(fmt.Errorf("%w: %s", imagepolicy.ErrUnsigned, unsigned)).
+		On("Verify", context.Background(), missing).
+		Return(errors.New("unexpected status '500 Internal Server Error'"))
+
+	tester := testutils.NewValidatorTester(t,
+		NewTestHarnessExists,
+		testutils.ValidatorTesterQuayClient(quay),
+		testutils.ValidatorTesterImageVerifier(images),
+	)
+
+	bundle := func(image string) types.MetaBundle {
+		return types.MetaBundle{
+			AddonMeta: &v1alpha1.AddonMetadataSpec{
+				ID:          "random-operator",
+				TestHarness: image,
+			},
+		}
+	}
+
+	tester.TestValidBundles(map[string]types.MetaBundle{
+		"signed harness image": bundle(signed),
+	})
+	tester.TestInvalidBundles(map[string]types.MetaBundle{
+		"unsigned harness image": bundle(unsigned),
+	})
+
+	res := tester.TestSingleBundle(bundle(missing))
+	require.True(t, res.IsError())
+}
+
 func getRef(t *testing.T, image string) *imageparser.Reference {
 	t.Helper()
 
@@ -19,11 +19,20 @@ const (
 	desc = "Ensure `deadmanssnitch.snitchNamePostFix` doesn't begin with 'hive-'"
 )
 
+var docs = validator.Docs{
+	Details: "The optional deadmanssnitch.snitchNamePostFix must not begin with 'hive-' as Hive prefixes snitch names itself.",
+	ExampleFailures: []string{
+		"`deadmanssnitch.snitchNamePostFix` in addon reference-addon found to begin with 'hive-'",
+	},
+	Remediation: "Remove the 'hive-' prefix from deadmanssnitch.snitchNamePostFix.",
+}
+
 func NewDMSSnitchNamePostFix(deps validator.Dependencies) (validator.Validator, error) {
 	base, err := validator.NewBase(
 		code,
 		validator.BaseName(name),
 		validator.BaseDesc(desc),
+		validator.BaseDocs(docs),
 	)
 
 	return &DMSSnitchNamePostFix{
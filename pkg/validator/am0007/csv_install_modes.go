@@ -19,11 +19,22 @@ const (
 	desc = "Validate installMode is supported."
 )
 
+var docs = validator.Docs{
+	Details: "The installMode of the addon must be supported by the CSV of every bundle. " +
+		"Each bundle whose CSV does not support it is reported as OLM cannot install the operator that way.",
+	ExampleFailures: []string{
+		"Bundle reference-addon.v0.1.0 failed CSV validation: Target installMode AllNamespaces is not supported. CSV only supports these installModes [OwnNamespace].",
+	},
+	Remediation: "Change installMode or add it to spec.installModes of the CSVs with supported set to true.",
+	Tags:        []string{validator.TagBundle, validator.TagConsistency},
+}
+
 func NewCSVInstallModes(deps validator.Dependencies) (validator.Validator, error) {
 	base, err := validator.NewBase(
 		code,
 		validator.BaseName(name),
 		validator.BaseDesc(desc),
+		validator.BaseDocs(docs),
 	)
 	if err != nil {
 		return nil, err
@@ -43,9 +54,13 @@ func (c *CSVInstallModes) Run(ctx context.Context, mb types.MetaBundle) validato
 
 	// allow only AllNamespaces and OwnNamespace install mode.
 	if indexOf(installMode, validInstallModes) == -1 {
-		return c.Fail(fmt.Sprintf("unsupported install mode %v", installMode))
+		return c.FailWith(validator.FindingAt(mb, "installMode", fmt.Sprintf("unsupported install mode %v", installMode)))
 	}
 
+	var findings []validator.Finding
+
+	// every bundle is reported as the addon cannot
+	// be installed in any of these versions
 	for _, bundle := range mb.Bundles {
 		var (
 			bundleName = bundle.GetNameVersion()
@@ -53,9 +68,16 @@ func (c *CSVInstallModes) Run(ctx context.Context, mb types.MetaBundle) validato
 		)
 
 		if success, failureMsg := isInstallModeSupported(modes, installMode); !success {
-			return c.Fail(fmt.Sprintf("Bundle %v failed CSV validation: %v.", bundleName, failureMsg))
+			findings = append(findings, validator.FindingAt(mb, "installMode",
+				fmt.Sprintf("Bundle %v failed CSV validation: %v.", bundleName, failureMsg),
+			))
 		}
 	}
+
+	if len(findings) > 0 {
+		return c.FailWith(findings...)
+	}
+
 	return c.Success()
 }
 
@@ -77,3 +77,52 @@ func TestCSVInstallModeInvalid(t *testing.T) {
 		},
 	})
 }
+
+func TestCSVInstallModeIncompatibleBundles(t *testing.T) {
+	t.Parallel()
+
+	loader := testutils.NewBundlerLoader(t)
+
+	tester := testutils.NewValidatorTester(t, NewCSVInstallModes)
+	tester.TestCases(map[string]testutils.TestCase{
+		"OwnNamespace/bundle only supporting OwnNamespace": {
+			MetaBundle: types.MetaBundle{
+				AddonMeta: &v1alpha1.AddonMetadataSpec{
+					InstallMode: "OwnNamespace",
+				},
+				Bundles: []operator.Bundle{
+					loader.LoadFromCSV(filepath.Join("test_csvs", "csv_own_namespace.yaml")),
+				},
+			},
+		},
+		"AllNamespaces/bundle only supporting OwnNamespace": {
+			MetaBundle: types.MetaBundle{
+				AddonMeta: &v1alpha1.AddonMetadataSpec{
+					InstallMode: "AllNamespaces",
+				},
+				Bundles: []operator.Bundle{
+					loader.LoadFromCSV(filepath.Join("test_csvs", "csv.yaml")),
+					loader.LoadFromCSV(filepath.Join("test_csvs", "csv_own_namespace.yaml")),
+				},
+			},
+			FailsWith: []string{
+				"Bundle test-bundle:0.1.7 failed CSV validation: Target installMode AllNamespaces is not supported.",
+			},
+		},
+		"AllNamespaces/every incompatible bundle is reported": {
+			MetaBundle: types.MetaBundle{
+				AddonMeta: &v1alpha1.AddonMetadataSpec{
+					InstallMode: "AllNamespaces",
+				},
+				Bundles: []operator.Bundle{
+					loader.LoadFromCSV(filepath.Join("test_csvs", "csv_own_namespace.yaml")),
+					loader.LoadFromCSV(filepath.Join("test_csvs", "csv_own_namespace_next.yaml")),
+				},
+			},
+			FailsWith: []string{
+				"Bundle test-bundle:0.1.7 failed CSV validation",
+				"Bundle test-bundle:0.1.8 failed CSV validation",
+			},
+		},
+	})
+}
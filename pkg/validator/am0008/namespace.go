@@ -20,11 +20,21 @@ const (
 	desc = "Ensure that the target namespace is listed in the set of channels listed"
 )
 
+var docs = validator.Docs{
+	Details: "The targetNamespace must be listed in namespaces and all namespaces must start with 'redhat-' unless they are excluded by the validator configuration.",
+	ExampleFailures: []string{
+		"Target namespace is not in the list of supplied namespaces",
+		"Some namespaces doesn't start with 'redhat-*' [openshift-reference]",
+	},
+	Remediation: "Add targetNamespace to namespaces and prefix the namespaces with 'redhat-'.",
+}
+
 func NewNamespace(deps validator.Dependencies) (validator.Validator, error) {
 	base, err := validator.NewBase(
 		code,
 		validator.BaseName(name),
 		validator.BaseDesc(desc),
+		validator.BaseDocs(docs),
 	)
 	if err != nil {
 		return nil, err
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"regexp"
 
+	ocmv1 "github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1"
 	"github.com/mt-sre/addon-metadata-operator/pkg/types"
 	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
 )
@@ -19,11 +20,22 @@ const (
 	desc = "Ensure `addOnParameters` section in the addon metadata is rightfully defined"
 )
 
+var docs = validator.Docs{
+	Details: "Each addOnParameter may either set validation or options. A defaultValue must match the validation regex or be one of the options.",
+	ExampleFailures: []string{
+		"validation and options can't both be set",
+		"defaultValue 'large' not found in `options`",
+	},
+	Remediation: "Define either validation or options per parameter and pick a defaultValue they accept.",
+	Tags:        []string{validator.TagImageSet},
+}
+
 func NewAddonParameters(deps validator.Dependencies) (validator.Validator, error) {
 	base, err := validator.NewBase(
 		code,
 		validator.BaseName(name),
 		validator.BaseDesc(desc),
+		validator.BaseDocs(docs),
 	)
 	if err != nil {
 		return nil, err
@@ -43,40 +55,62 @@ func (a *AddonParamters) Run(ctx context.Context, mb types.MetaBundle) validator
 	if addonParams == nil {
 		return a.Success()
 	}
-	for _, param := range *addonParams {
+
+	var findings []validator.Finding
+
+	for i, param := range *addonParams {
+		path := fmt.Sprintf("addOnParameters[%d]", i)
+
 		validation := param.Validation
 		options := param.Options
 		defaultValue := param.DefaultValue
 
 		if validation != nil && options != nil {
-			return a.Fail("validation and options can't both be set")
+			findings = append(findings, validator.FindingAt(mb, path, "validation and options can't both be set"))
+
+			continue
 		}
 
-		if defaultValue != nil {
-			if validation != nil {
-				r, err := regexp.Compile(*validation)
-				if err != nil {
-					return a.Error(fmt.Errorf("failed parse `validation` as regex: %w", err))
-				}
-				if !r.MatchString(*defaultValue) {
-					msg := fmt.Sprintf("defaultValue %s didn't match its validation", *defaultValue)
-					if param.ValidationErrMsg != nil {
-						return a.Fail(fmt.Sprintf("%s: %s", msg, *param.ValidationErrMsg))
-					}
-					return a.Fail(msg)
-				}
-				return a.Success()
+		if defaultValue == nil {
+			continue
+		}
+
+		if validation != nil {
+			r, err := regexp.Compile(*validation)
+			if err != nil {
+				return a.Error(fmt.Errorf("failed parse `validation` as regex: %w", err))
 			}
 
-			if options != nil {
-				for _, opt := range *options {
-					if *defaultValue == opt.Value {
-						return a.Success()
-					}
+			if !r.MatchString(*defaultValue) {
+				msg := fmt.Sprintf("defaultValue %s didn't match its validation", *defaultValue)
+				if param.ValidationErrMsg != nil {
+					msg = fmt.Sprintf("%s: %s", msg, *param.ValidationErrMsg)
 				}
-				return a.Fail(fmt.Sprintf("defaultValue '%s' not found in `options`", *defaultValue))
+
+				findings = append(findings, validator.FindingAt(mb, path+".defaultValue", msg))
 			}
 		}
+
+		if options != nil && !hasOption(*options, *defaultValue) {
+			msg := fmt.Sprintf("defaultValue '%s' not found in `options`", *defaultValue)
+
+			findings = append(findings, validator.FindingAt(mb, path+".defaultValue", msg))
+		}
 	}
+
+	if len(findings) > 0 {
+		return a.FailWith(findings...)
+	}
+
 	return a.Success()
 }
+
+func hasOption(options []ocmv1.AddOnParameterOption, value string) bool {
+	for _, opt := range options {
+		if opt.Value == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/mt-sre/addon-metadata-operator/internal/testutils"
 	ocmv1 "github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1"
 	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
 	utils "github.com/mt-sre/addon-metadata-operator/pkg/validator/testutils"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -142,3 +144,49 @@ func TestAddonParametersInvalid(t *testing.T) {
 		},
 	})
 }
+
+func TestAddonParametersReportsAllFindings(t *testing.T) {
+	t.Parallel()
+
+	mb := *types.NewMetaBundleBuilder().
+		WithMeta(&v1alpha1.AddonMetadataSpec{
+			ID: "multiple-invalid-parameters",
+			AddOnParameters: &[]ocmv1.AddOnParameter{
+				{
+					ID:           "size",
+					Options:      &[]ocmv1.AddOnParameterOption{{Name: "1 TiB", Value: "1"}},
+					DefaultValue: testutils.GetStringLiteralRef("1"),
+				},
+				{
+					ID:           "replicas",
+					Validation:   testutils.GetStringLiteralRef("^[0-9]+$"),
+					DefaultValue: testutils.GetStringLiteralRef("many"),
+				},
+				{
+					ID:           "tier",
+					Options:      &[]ocmv1.AddOnParameterOption{{Name: "Gold", Value: "gold"}},
+					DefaultValue: testutils.GetStringLiteralRef("silver"),
+				},
+			},
+		}).
+		WithProvenance(types.Provenance{
+			"addOnParameters[1]": {File: "metadata/stage/addon.yaml", Line: 12, Column: 5},
+		}).
+		Build()
+
+	tester := utils.NewValidatorTester(t, NewAddonParameters)
+
+	res := tester.TestSingleBundle(mb)
+	require.False(t, res.IsSuccess())
+	assert.Equal(t, []validator.Finding{
+		{
+			Message: "defaultValue many didn't match its validation",
+			File:    "metadata/stage/addon.yaml",
+			Field:   "addOnParameters[1].defaultValue",
+		},
+		{
+			Message: "defaultValue 'silver' not found in `options`",
+			Field:   "addOnParameters[2].defaultValue",
+		},
+	}, res.Findings)
+}
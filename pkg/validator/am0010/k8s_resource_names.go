@@ -19,11 +19,20 @@ const (
 	desc = "Validates k8s namespaces, labels, and annotations within Addon metadata against k8s standards"
 )
 
+var docs = validator.Docs{
+	Details: "The label, targetNamespace, namespaces, namespace labels and annotations, commonLabels, commonAnnotations and pagerduty fields must be valid Kubernetes names.",
+	ExampleFailures: []string{
+		"targetNamespace: \"Redhat_Reference\" is not a valid kubernetes namespace name: a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-'",
+	},
+	Remediation: "Rename the reported fields following the Kubernetes naming rules.",
+}
+
 func NewK8SResourceAndFieldNames(deps validator.Dependencies) (validator.Validator, error) {
 	base, err := validator.NewBase(
 		code,
 		validator.BaseName(name),
 		validator.BaseDesc(desc),
+		validator.BaseDocs(docs),
 	)
 	if err != nil {
 		return nil, err
@@ -18,11 +18,21 @@ const (
 	desc = "Validates whether a SKU Rule exists in OCM for quota provided in addon metadata"
 )
 
+var docs = validator.Docs{
+	Details: "The ocmQuotaName must have a matching QuotaRule in OCM.",
+	ExampleFailures: []string{
+		"no QuotaRule exists for ocmQuotaName 'addon-reference-addon'",
+	},
+	Remediation: "Request a QuotaRule for the addon in OCM or correct ocmQuotaName.",
+	Tags:        []string{validator.TagService},
+}
+
 func NewOCMSKURuleExists(deps validator.Dependencies) (validator.Validator, error) {
 	base, err := validator.NewBase(
 		code,
 		validator.BaseName(name),
 		validator.BaseDesc(desc),
+		validator.BaseDocs(docs),
 	)
 	if err != nil {
 		return nil, err
@@ -4,7 +4,6 @@ import (
 	"context"
 	"strings"
 
-	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
 	"github.com/mt-sre/addon-metadata-operator/pkg/types"
 	"github.com/mt-sre/addon-metadata-operator/pkg/utils/csvutils"
 	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
@@ -20,11 +19,21 @@ const (
 	desc = "Validates the permissions specified in the csv"
 )
 
+var docs = validator.Docs{
+	Details: "The permissions of the head bundle CSV must not use wildcard API groups, wildcard resources not owned by the operator or cluster scoped access to configmaps and secrets.",
+	ExampleFailures: []string{
+		"CSV rbac validation errors: \nWild card string used under api group/s",
+	},
+	Remediation: "List the API groups and resources explicitly and scope access to configmaps and secrets to namespaces.",
+	Tags:        []string{validator.TagBundle, validator.TagSecurity},
+}
+
 func NewCSVRBAC(opt validator.Dependencies) (validator.Validator, error) {
 	base, err := validator.NewBase(
 		code,
 		validator.BaseName(name),
 		validator.BaseDesc(desc),
+		validator.BaseDocs(docs),
 	)
 	if err != nil {
 		return nil, err
@@ -40,7 +49,7 @@ type CSVRBAC struct {
 }
 
 func (v *CSVRBAC) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
-	bundle, ok := operator.HeadBundle(mb.Bundles...)
+	bundle, ok := mb.HeadBundle()
 	if !ok {
 		return v.Success()
 	}
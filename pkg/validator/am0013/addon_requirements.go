@@ -18,11 +18,21 @@ const (
 	desc = "Ensure `addOnRequirements` section in the addon metadata is rightfully defined"
 )
 
+var docs = validator.Docs{
+	Details: "Each addOnRequirement must define data.",
+	ExampleFailures: []string{
+		"requirement \"cluster-size\" has no data",
+	},
+	Remediation: "Add data to the reported requirements or remove them.",
+	Tags:        []string{validator.TagImageSet},
+}
+
 func NewAddonRequirements(deps validator.Dependencies) (validator.Validator, error) {
 	base, err := validator.NewBase(
 		code,
 		validator.BaseName(name),
 		validator.BaseDesc(desc),
+		validator.BaseDocs(docs),
 	)
 	if err != nil {
 		return nil, err
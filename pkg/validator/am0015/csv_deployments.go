@@ -4,7 +4,6 @@ import (
 	"context"
 
 	"github.com/mt-sre/addon-metadata-operator/internal/kube"
-	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
 	"github.com/mt-sre/addon-metadata-operator/pkg/types"
 	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
 	appsv1 "k8s.io/api/apps/v1"
@@ -20,11 +19,21 @@ const (
 	desc = "Ensure all deployment in CSV must have valid resource requests, livenessprobe and readinessprobe"
 )
 
+var docs = validator.Docs{
+	Details: "Every deployment of the head bundle CSV must define CPU and memory requests and limits, a liveness probe and a readiness probe.",
+	ExampleFailures: []string{
+		"container \"manager\" is missing a readiness probe",
+	},
+	Remediation: "Add CPU and memory requests and limits and liveness and readiness probes to all containers of the CSV deployments.",
+	Tags:        []string{validator.TagBundle},
+}
+
 func NewCSVDeployment(deps validator.Dependencies) (validator.Validator, error) {
 	base, err := validator.NewBase(
 		code,
 		validator.BaseName(name),
 		validator.BaseDesc(desc),
+		validator.BaseDocs(docs),
 	)
 	if err != nil {
 		return nil, err
@@ -44,7 +53,7 @@ type CSVDeployment struct {
 func (c *CSVDeployment) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
 	var msgs []string
 
-	bundle, ok := operator.HeadBundle(mb.Bundles...)
+	bundle, ok := mb.HeadBundle()
 	if !ok {
 		return c.Success()
 	}
@@ -19,11 +19,20 @@ const (
 	desc = "Ensure that addon additional catalog source, secrets and credential requests names are unique"
 )
 
+var docs = validator.Docs{
+	Details: "The names of additionalCatalogSources, secrets and credentialsRequests must be unique.",
+	ExampleFailures: []string{
+		"secrets: secret name pull-secret is already present and not unique.",
+	},
+	Remediation: "Rename or remove the duplicated entries.",
+}
+
 func NewUniqueResource(deps validator.Dependencies) (validator.Validator, error) {
 	base, err := validator.NewBase(
 		code,
 		validator.BaseName(name),
 		validator.BaseDesc(desc),
+		validator.BaseDocs(docs),
 	)
 	if err != nil {
 		return nil, err
@@ -18,11 +18,21 @@ const (
 	desc = "Ensure that pullSecretName if not nil is present in Secrets"
 )
 
+var docs = validator.Docs{
+	Details: "A pullSecretName must reference one of the secrets of the addon config.",
+	ExampleFailures: []string{
+		"pullSecretName pull-secret is not present in addon secrets",
+	},
+	Remediation: "Add the secret to config.secrets or correct pullSecretName.",
+	Tags:        []string{validator.TagImageSet},
+}
+
 func NewPullSecretname(deps validator.Dependencies) (validator.Validator, error) {
 	base, err := validator.NewBase(
 		code,
 		validator.BaseName(name),
 		validator.BaseDesc(desc),
+		validator.BaseDocs(docs),
 	)
 	if err != nil {
 		return nil, err
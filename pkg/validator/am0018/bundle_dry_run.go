@@ -0,0 +1,108 @@
+package am0018
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+func init() {
+	validator.Register(NewBundleDryRun)
+}
+
+const (
+	code = 18
+	name = "bundle_dry_run"
+	desc = "Ensure all bundle manifests pass server-side dry-run applies against a live cluster; skipped unless a cluster is configured"
+)
+
+var docs = validator.Docs{
+	Details: "The manifests of every bundle are applied to the configured cluster as server-side dry-runs; the validator is skipped unless a cluster is configured.",
+	ExampleFailures: []string{
+		"ConfigMap 'invalid' is invalid: admission webhook denied the request",
+	},
+	Remediation: "Correct the manifests rejected by the API server or its admission webhooks.",
+	Tags:        []string{validator.TagBundle, validator.TagService},
+}
+
+func NewBundleDryRun(deps validator.Dependencies) (validator.Validator, error) {
+	base, err := validator.NewBase(
+		code,
+		validator.BaseName(name),
+		validator.BaseDesc(desc),
+		validator.BaseDocs(docs),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BundleDryRun{
+		Base:    base,
+		cluster: deps.ClusterClient,
+	}, nil
+}
+
+type BundleDryRun struct {
+	*validator.Base
+	cluster validator.ClusterClient
+}
+
+func (b *BundleDryRun) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
+	if b.cluster == nil {
+		return b.Success()
+	}
+
+	var msgs []string
+
+	for _, bundle := range mb.Bundles {
+		for _, obj := range bundle.Manifests {
+			err := b.cluster.DryRunApply(ctx, obj)
+			if err == nil {
+				continue
+			}
+
+			if !isRejection(err) {
+				if apierrors.IsServerTimeout(err) || apierrors.IsTooManyRequests(err) {
+					return b.RetryableError(err)
+				}
+
+				return b.Error(fmt.Errorf("dry-run applying %s '%s': %w", obj.GetKind(), obj.GetName(), err))
+			}
+
+			msgs = append(msgs, fmt.Sprintf(
+				"bundle '%s': %s '%s' was rejected: %v", bundle.Name, obj.GetKind(), obj.GetName(), err,
+			))
+		}
+	}
+
+	if len(msgs) > 0 {
+		return b.Fail(msgs...)
+	}
+
+	return b.Success()
+}
+
+// isRejection reports whether err signals that the cluster rejected the
+// object itself, e.g. through schema validation, admission or because
+// its kind is not served, rather than a failure to reach the cluster.
+func isRejection(err error) bool {
+	if meta.IsNoMatchError(err) {
+		return true
+	}
+
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) {
+		return false
+	}
+
+	return !apierrors.IsServerTimeout(err) &&
+		!apierrors.IsTooManyRequests(err) &&
+		!apierrors.IsUnauthorized(err) &&
+		!apierrors.IsInternalError(err) &&
+		!apierrors.IsServiceUnavailable(err)
+}
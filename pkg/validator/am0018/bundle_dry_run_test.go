@@ -0,0 +1,105 @@
+package am0018
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestBundleDryRunValid(t *testing.T) {
+	t.Parallel()
+
+	cluster := testutils.NewMockClusterClient()
+	cluster.On("DryRunApply", mock.Anything, mock.Anything).Return(nil)
+
+	tester := testutils.NewValidatorTester(
+		t, NewBundleDryRun,
+		testutils.ValidatorTesterClusterClient(cluster),
+	)
+	tester.TestValidBundles(map[string]types.MetaBundle{
+		"accepted manifests": newMetaBundle(newObject("ConfigMap", "accepted")),
+		"no manifests":       newMetaBundle(),
+	})
+}
+
+func TestBundleDryRunWithoutCluster(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewBundleDryRun)
+	tester.TestValidBundles(map[string]types.MetaBundle{
+		"skipped": newMetaBundle(newObject("ConfigMap", "skipped")),
+	})
+}
+
+func TestBundleDryRunInvalid(t *testing.T) {
+	t.Parallel()
+
+	cluster := testutils.NewMockClusterClient()
+	cluster.
+		On("DryRunApply", mock.Anything, objectNamed("invalid")).
+		Return(apierrors.NewInvalid(schema.GroupKind{Kind: "ConfigMap"}, "invalid", nil)).
+		On("DryRunApply", mock.Anything, objectNamed("denied")).
+		Return(apierrors.NewForbidden(schema.GroupResource{Resource: "configmaps"}, "denied", errors.New("denied by policy"))).
+		On("DryRunApply", mock.Anything, mock.Anything).
+		Return(nil)
+
+	tester := testutils.NewValidatorTester(
+		t, NewBundleDryRun,
+		testutils.ValidatorTesterClusterClient(cluster),
+	)
+	tester.TestInvalidBundles(map[string]types.MetaBundle{
+		"schema error":     newMetaBundle(newObject("ConfigMap", "ok"), newObject("ConfigMap", "invalid")),
+		"admission denied": newMetaBundle(newObject("ConfigMap", "denied")),
+	})
+}
+
+func TestBundleDryRunClusterError(t *testing.T) {
+	t.Parallel()
+
+	cluster := testutils.NewMockClusterClient()
+	cluster.On("DryRunApply", mock.Anything, mock.Anything).Return(errors.New("connection refused"))
+
+	tester := testutils.NewValidatorTester(
+		t, NewBundleDryRun,
+		testutils.ValidatorTesterClusterClient(cluster),
+	)
+
+	res := tester.TestSingleBundle(newMetaBundle(newObject("ConfigMap", "unreachable")))
+	require.True(t, res.IsError())
+	assert.False(t, res.IsRetryableError())
+}
+
+func newMetaBundle(objs ...*unstructured.Unstructured) types.MetaBundle {
+	return *types.NewMetaBundleBuilder().
+		WithBundles(operator.Bundle{
+			Name:      "test.v1.0.0",
+			Version:   "1.0.0",
+			Manifests: objs,
+		}).
+		Build()
+}
+
+func newObject(kind, name string) *unstructured.Unstructured {
+	var obj unstructured.Unstructured
+
+	obj.SetAPIVersion("v1")
+	obj.SetKind(kind)
+	obj.SetName(name)
+
+	return &obj
+}
+
+func objectNamed(name string) interface{} {
+	return mock.MatchedBy(func(obj *unstructured.Unstructured) bool {
+		return obj.GetName() == name
+	})
+}
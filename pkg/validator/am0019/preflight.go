@@ -0,0 +1,158 @@
+package am0019
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/imagepolicy"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+func init() {
+	validator.Register(NewPreflight)
+}
+
+const (
+	code = 19
+	name = "preflight_certification"
+	desc = "Ensure operator and operand images pass the Red Hat preflight container certification checks; skipped unless preflight is enabled"
+)
+
+var docs = validator.Docs{
+	Details: "The operator and related images of the head bundle are checked by preflight and must satisfy the image policy when one is configured; the validator is skipped unless preflight is enabled.",
+	ExampleFailures: []string{
+		"image 'quay.io/osd-addons/reference-addon:v0.1.0' failed check 'RunAsNonRoot': Checking if container runs as the root user",
+	},
+	Remediation: "Resolve the failed checks following the suggestions of preflight and rebuild the images.",
+	Tags:        []string{validator.TagBundle, validator.TagImageSet, validator.TagService},
+}
+
+func NewPreflight(deps validator.Dependencies) (validator.Validator, error) {
+	base, err := validator.NewBase(
+		code,
+		validator.BaseName(name),
+		validator.BaseDesc(desc),
+		validator.BaseDocs(docs),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Preflight{
+		Base:      base,
+		images:    deps.ImageVerifier,
+		preflight: deps.PreflightRunner,
+	}, nil
+}
+
+type Preflight struct {
+	*validator.Base
+	images    validator.ImageVerifier
+	preflight validator.PreflightRunner
+}
+
+func (p *Preflight) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
+	if p.preflight == nil {
+		return p.Success()
+	}
+
+	var findings []validator.Finding
+
+	for _, img := range images(mb) {
+		finding := func(msg string) validator.Finding {
+			if img.field == "" {
+				return validator.Finding{Message: msg}
+			}
+
+			return validator.FindingAt(mb, img.field, msg)
+		}
+
+		// preflight pulls the image so it must satisfy the image policy
+		if p.images != nil {
+			if err := p.images.Verify(ctx, img.ref); imagepolicy.IsViolation(err) {
+				findings = append(findings, finding(fmt.Sprintf("image '%s' violates the image policy: %v", img.ref, err)))
+
+				continue
+			} else if err != nil {
+				return p.Error(fmt.Errorf("verifying image '%s': %w", img.ref, err))
+			}
+		}
+
+		res, err := p.preflight.CheckContainer(ctx, img.ref)
+		if err != nil {
+			return p.Error(fmt.Errorf("checking image '%s': %w", img.ref, err))
+		}
+
+		for _, check := range res.FailedChecks {
+			msg := fmt.Sprintf("image '%s' failed check '%s': %s", img.ref, check.Name, check.Description)
+			if check.Suggestion != "" {
+				msg += fmt.Sprintf(" (%s)", check.Suggestion)
+			}
+
+			findings = append(findings, finding(msg))
+		}
+
+		for _, check := range res.ErroredChecks {
+			findings = append(findings, finding(fmt.Sprintf("image '%s' could not complete check '%s': %s", img.ref, check.Name, check.Description)))
+		}
+	}
+
+	if len(findings) > 0 {
+		return p.FailWith(findings...)
+	}
+
+	return p.Success()
+}
+
+type image struct {
+	ref string
+	// field is the imageset field listing the image
+	// or empty for images of the head bundle.
+	field string
+}
+
+// images returns the operator images deployed by the CSV of the head
+// bundle and the operand images listed as related images by the
+// imageset and that CSV.
+func images(mb types.MetaBundle) []image {
+	set := make(map[string]string)
+
+	if mb.ImageSet != nil {
+		for i, img := range mb.ImageSet.RelatedImages {
+			if _, ok := set[img]; !ok {
+				set[img] = fmt.Sprintf("relatedImages[%d]", i)
+			}
+		}
+	}
+
+	if bundle, ok := mb.HeadBundle(); ok {
+		spec := bundle.ClusterServiceVersion.Spec
+
+		for _, deployment := range spec.InstallStrategy.StrategySpec.DeploymentSpecs {
+			for _, c := range deployment.Spec.Template.Spec.Containers {
+				if _, ok := set[c.Image]; !ok {
+					set[c.Image] = ""
+				}
+			}
+		}
+
+		for _, related := range spec.RelatedImages {
+			if _, ok := set[related.Image]; !ok {
+				set[related.Image] = ""
+			}
+		}
+	}
+
+	delete(set, "")
+
+	res := make([]image, 0, len(set))
+	for ref, field := range set {
+		res = append(res, image{ref: ref, field: field})
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].ref < res[j].ref })
+
+	return res
+}
@@ -0,0 +1,159 @@
+package am0019
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/imagepolicy"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator/testutils"
+	opsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestPreflightValid(t *testing.T) {
+	t.Parallel()
+
+	preflight := testutils.NewMockPreflightRunner()
+	preflight.
+		On("CheckContainer", mock.Anything, mock.Anything).
+		Return(validator.PreflightResult{Passed: true}, nil)
+
+	tester := testutils.NewValidatorTester(
+		t, NewPreflight,
+		testutils.ValidatorTesterPreflightRunner(preflight),
+	)
+	tester.TestValidBundles(map[string]types.MetaBundle{
+		"passing images": newMetaBundle(),
+	})
+}
+
+func TestPreflightDisabled(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewPreflight)
+	tester.TestValidBundles(map[string]types.MetaBundle{
+		"skipped": newMetaBundle(),
+	})
+}
+
+func TestPreflightInvalid(t *testing.T) {
+	t.Parallel()
+
+	preflight := testutils.NewMockPreflightRunner()
+	preflight.
+		On("CheckContainer", mock.Anything, "quay.io/test/operand:v1").
+		Return(validator.PreflightResult{
+			FailedChecks: []validator.PreflightCheck{
+				{Name: "HasLicense", Description: "Checking if terms and conditions are present.", Suggestion: "Create a directory named /licenses"},
+			},
+		}, nil).
+		On("CheckContainer", mock.Anything, mock.Anything).
+		Return(validator.PreflightResult{Passed: true}, nil)
+
+	tester := testutils.NewValidatorTester(
+		t, NewPreflight,
+		testutils.ValidatorTesterPreflightRunner(preflight),
+	)
+
+	res := tester.TestSingleBundle(newMetaBundle())
+	require.False(t, res.IsSuccess())
+	require.False(t, res.IsError())
+	assert.Equal(t, []validator.Finding{
+		{
+			Message: "image 'quay.io/test/operand:v1' failed check 'HasLicense': Checking if terms and conditions are present. (Create a directory named /licenses)",
+			Field:   "relatedImages[0]",
+		},
+	}, res.Findings)
+}
+
+func TestPreflightError(t *testing.T) {
+	t.Parallel()
+
+	preflight := testutils.NewMockPreflightRunner()
+	preflight.
+		On("CheckContainer", mock.Anything, mock.Anything).
+		Return(validator.PreflightResult{}, errors.New("executable file not found"))
+
+	tester := testutils.NewValidatorTester(
+		t, NewPreflight,
+		testutils.ValidatorTesterPreflightRunner(preflight),
+	)
+
+	res := tester.TestSingleBundle(newMetaBundle())
+	require.True(t, res.IsError())
+}
+
+func TestPreflightImagePolicy(t *testing.T) {
+	t.Parallel()
+
+	images := testutils.NewMockImageVerifier()
+	images.
+		On("Verify", mock.Anything, "quay.io/test/related:v1").
+		Return(fmt.Errorf("%w: quay.io/test/related:v1", imagepolicy.ErrRejected)).
+		On("Verify", mock.Anything, mock.Anything).
+		Return(nil)
+
+	preflight := testutils.NewMockPreflightRunner()
+	preflight.
+		On("CheckContainer", mock.Anything, mock.Anything).
+		Return(validator.PreflightResult{Passed: true}, nil)
+
+	tester := testutils.NewValidatorTester(
+		t, NewPreflight,
+		testutils.ValidatorTesterImageVerifier(images),
+		testutils.ValidatorTesterPreflightRunner(preflight),
+	)
+
+	res := tester.TestSingleBundle(newMetaBundle())
+	require.False(t, res.IsSuccess())
+	require.False(t, res.IsError())
+	assert.Equal(t, []string{
+		"image 'quay.io/test/related:v1' violates the image policy: image rejected by policy: quay.io/test/related:v1",
+	}, res.Messages())
+
+	preflight.AssertNotCalled(t, "CheckContainer", mock.Anything, "quay.io/test/related:v1")
+}
+
+func TestImages(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []image{
+		{ref: "quay.io/test/operand:v1", field: "relatedImages[0]"},
+		{ref: "quay.io/test/operator:v1"},
+		{ref: "quay.io/test/related:v1"},
+	}, images(newMetaBundle()))
+}
+
+func newMetaBundle() types.MetaBundle {
+	var csv operator.ClusterServiceVersion
+
+	csv.Spec.InstallStrategy.StrategySpec.DeploymentSpecs = []opsv1alpha1.StrategyDeploymentSpec{
+		{Name: "operator"},
+	}
+	csv.Spec.InstallStrategy.StrategySpec.DeploymentSpecs[0].Spec.Template.Spec.Containers = []corev1.Container{
+		{Name: "manager", Image: "quay.io/test/operator:v1"},
+	}
+	csv.Spec.RelatedImages = []opsv1alpha1.RelatedImage{
+		{Name: "related", Image: "quay.io/test/related:v1"},
+		{Name: "operator", Image: "quay.io/test/operator:v1"},
+	}
+
+	return *types.NewMetaBundleBuilder().
+		WithImageSet(&v1alpha1.AddonImageSetSpec{
+			RelatedImages: []string{"quay.io/test/operand:v1"},
+		}).
+		WithBundles(operator.Bundle{
+			Name:                  "test.v1.0.0",
+			Version:               "1.0.0",
+			ClusterServiceVersion: csv,
+		}).
+		Build()
+}
@@ -0,0 +1,85 @@
+package am0020
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+func init() {
+	validator.Register(NewExcludedValidators)
+}
+
+const (
+	code = 20
+	name = "excluded_validators"
+	desc = "Ensure that every excluded validator has a valid code and a justification"
+)
+
+var docs = validator.Docs{
+	Details: "Validators listed under excludedValidators are not run for the addon. Exclusions with an invalid code or without justification are not honored, so the validator is still run.",
+	ExampleFailures: []string{
+		"excludedValidators[0]: 'AM0006' has no justification",
+		"excludedValidators[1]: unable to parse code from 'AM000x'",
+		"excludedValidators[2]: 'AM0006' is already excluded",
+	},
+	Remediation: "Give every exclusion a code like 'AM0006' and a justification why the validator does not apply, or remove the exclusion.",
+}
+
+func NewExcludedValidators(deps validator.Dependencies) (validator.Validator, error) {
+	base, err := validator.NewBase(
+		code,
+		validator.BaseName(name),
+		validator.BaseDesc(desc),
+		validator.BaseDocs(docs),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExcludedValidators{
+		Base: base,
+	}, nil
+}
+
+type ExcludedValidators struct {
+	*validator.Base
+}
+
+func (e *ExcludedValidators) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
+	if mb.AddonMeta.ExcludedValidators == nil {
+		return e.Success()
+	}
+
+	var msgs []string
+
+	seen := make(map[validator.Code]bool)
+
+	for i, excl := range *mb.AddonMeta.ExcludedValidators {
+		c, err := validator.ParseCode(excl.Code)
+		if err != nil {
+			msgs = append(msgs, fmt.Sprintf("excludedValidators[%d]: %v", i, err))
+
+			continue
+		}
+
+		if strings.TrimSpace(excl.Justification) == "" {
+			msgs = append(msgs, fmt.Sprintf("excludedValidators[%d]: '%s' has no justification", i, c))
+		}
+
+		if seen[c] {
+			msgs = append(msgs, fmt.Sprintf("excludedValidators[%d]: '%s' is already excluded", i, c))
+		}
+
+		seen[c] = true
+	}
+
+	if len(msgs) > 0 {
+		return e.Fail(msgs...)
+	}
+
+	return e.Success()
+}
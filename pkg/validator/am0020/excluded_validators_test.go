@@ -0,0 +1,65 @@
+package am0020
+
+import (
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator/testutils"
+)
+
+func TestExcludedValidatorsValid(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewExcludedValidators)
+	tester.TestValidBundles(map[string]types.MetaBundle{
+		"no exclusions": {
+			AddonMeta: &v1alpha1.AddonMetadataSpec{},
+		},
+		"justified exclusions": {
+			AddonMeta: &v1alpha1.AddonMetadataSpec{
+				ExcludedValidators: &[]v1alpha1.ExcludedValidator{
+					{Code: "AM0006", Justification: "the addon does not use DMS"},
+					{Code: "am0011", Justification: "the SKU is created by OCM itself"},
+				},
+			},
+		},
+	})
+}
+
+func TestExcludedValidatorsInvalid(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewExcludedValidators)
+	tester.TestInvalidBundles(map[string]types.MetaBundle{
+		"missing justification": {
+			AddonMeta: &v1alpha1.AddonMetadataSpec{
+				ExcludedValidators: &[]v1alpha1.ExcludedValidator{
+					{Code: "AM0006"},
+				},
+			},
+		},
+		"blank justification": {
+			AddonMeta: &v1alpha1.AddonMetadataSpec{
+				ExcludedValidators: &[]v1alpha1.ExcludedValidator{
+					{Code: "AM0006", Justification: "  "},
+				},
+			},
+		},
+		"invalid code": {
+			AddonMeta: &v1alpha1.AddonMetadataSpec{
+				ExcludedValidators: &[]v1alpha1.ExcludedValidator{
+					{Code: "dms", Justification: "the addon does not use DMS"},
+				},
+			},
+		},
+		"duplicate code": {
+			AddonMeta: &v1alpha1.AddonMetadataSpec{
+				ExcludedValidators: &[]v1alpha1.ExcludedValidator{
+					{Code: "AM0006", Justification: "the addon does not use DMS"},
+					{Code: "AM0006", Justification: "still no DMS"},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,149 @@
+package am0021
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	imageparser "github.com/novln/docker-parser"
+	"github.com/opencontainers/go-digest"
+)
+
+func init() {
+	validator.Register(NewImageDigests)
+}
+
+const (
+	code = 21
+	name = "image_digests"
+	desc = "Ensure the index image and all operator and operand images are pinned by digest in production"
+)
+
+var docs = validator.Docs{
+	Details: "The index image, the images of the deployments and the related images of the head bundle CSV and the related images " +
+		"of the imageset must be referenced by digest. Tags are mutable so a tag-only reference may resolve to different " +
+		"images over time and installs of the addon are not reproducible.",
+	Envs: []string{"production"},
+	ExampleFailures: []string{
+		"image 'quay.io/osd-addons/reference-addon-index:v0.1.0' is not pinned by digest",
+	},
+	Remediation: "Reference the images by digest, e.g. 'quay.io/osd-addons/reference-addon@sha256:<digest>'.",
+	Tags:        []string{validator.TagBundle, validator.TagImageSet},
+}
+
+func NewImageDigests(deps validator.Dependencies) (validator.Validator, error) {
+	base, err := validator.NewBase(
+		code,
+		validator.BaseName(name),
+		validator.BaseDesc(desc),
+		validator.BaseDocs(docs),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ImageDigests{
+		Base: base,
+	}, nil
+}
+
+type ImageDigests struct {
+	*validator.Base
+}
+
+func (i *ImageDigests) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
+	var findings []validator.Finding
+
+	for _, img := range images(mb) {
+		if isPinned(img.ref) {
+			continue
+		}
+
+		msg := fmt.Sprintf("image '%s' is not pinned by digest", img.ref)
+
+		if img.field == "" {
+			findings = append(findings, validator.Finding{Message: msg})
+		} else {
+			findings = append(findings, validator.FindingAt(mb, img.field, msg))
+		}
+	}
+
+	if len(findings) > 0 {
+		return i.FailWith(findings...)
+	}
+
+	return i.Success()
+}
+
+// isPinned returns true if ref references an image by digest.
+// References which can not be parsed are not pinned.
+func isPinned(ref string) bool {
+	parsed, err := imageparser.Parse(ref)
+	if err != nil {
+		return false
+	}
+
+	// Tag returns the digest for references by digest
+	_, err = digest.Parse(parsed.Tag())
+
+	return err == nil
+}
+
+type image struct {
+	ref string
+	// field is the metadata field referencing the image
+	// or empty for images of the head bundle.
+	field string
+}
+
+// images returns the index image, the images deployed by the CSV of the
+// head bundle and the related images listed by the imageset and that CSV.
+func images(mb types.MetaBundle) []image {
+	set := make(map[string]string)
+
+	if mb.AddonMeta != nil && mb.AddonMeta.IndexImage != nil {
+		set[*mb.AddonMeta.IndexImage] = "indexImage"
+	}
+
+	if mb.ImageSet != nil {
+		for i, img := range mb.ImageSet.RelatedImages {
+			if _, ok := set[img]; !ok {
+				set[img] = fmt.Sprintf("relatedImages[%d]", i)
+			}
+		}
+	}
+
+	if bundle, ok := mb.HeadBundle(); ok {
+		spec := bundle.ClusterServiceVersion.Spec
+
+		for _, deployment := range spec.InstallStrategy.StrategySpec.DeploymentSpecs {
+			pod := deployment.Spec.Template.Spec
+
+			for _, c := range slices.Concat(pod.InitContainers, pod.Containers) {
+				if _, ok := set[c.Image]; !ok {
+					set[c.Image] = ""
+				}
+			}
+		}
+
+		for _, related := range spec.RelatedImages {
+			if _, ok := set[related.Image]; !ok {
+				set[related.Image] = ""
+			}
+		}
+	}
+
+	delete(set, "")
+
+	res := make([]image, 0, len(set))
+	for ref, field := range set {
+		res = append(res, image{ref: ref, field: field})
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].ref < res[j].ref })
+
+	return res
+}
@@ -0,0 +1,121 @@
+package am0021
+
+import (
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator/testutils"
+	opsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	digest1 = "@sha256:0c8b02008f2c2faeb681ae8cd454821266a794435aea4b3f7ae28c74bc2e280d"
+	digest2 = "@sha256:3f4b1a2c5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f708"
+)
+
+func TestImageDigestsValid(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewImageDigests)
+	tester.TestValidBundles(map[string]types.MetaBundle{
+		"no images": *types.NewMetaBundleBuilder().
+			WithMeta(&v1alpha1.AddonMetadataSpec{}).
+			Build(),
+		"pinned images": newMetaBundle(
+			"quay.io/test/index"+digest1,
+			"quay.io/test/operator"+digest1,
+			"quay.io/test/related"+digest2,
+			"quay.io/test/operand"+digest2,
+		),
+		"pinned images with tags": newMetaBundle(
+			"quay.io/test/index:v1"+digest1,
+			"quay.io/test/operator:v1"+digest1,
+			"quay.io/test/related:v1"+digest2,
+			"quay.io/test/operand:v1"+digest2,
+		),
+	})
+}
+
+func TestImageDigestsInvalid(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewImageDigests)
+	tester.TestCases(map[string]testutils.TestCase{
+		"tagged index image": {
+			MetaBundle: newMetaBundle(
+				"quay.io/test/index:v1",
+				"quay.io/test/operator"+digest1,
+				"quay.io/test/related"+digest2,
+				"quay.io/test/operand"+digest2,
+			),
+			FailsWith: []string{
+				"image 'quay.io/test/index:v1' is not pinned by digest",
+			},
+		},
+		"untagged operator image": {
+			MetaBundle: newMetaBundle(
+				"quay.io/test/index"+digest1,
+				"quay.io/test/operator",
+				"quay.io/test/related"+digest2,
+				"quay.io/test/operand"+digest2,
+			),
+			FailsWith: []string{
+				"image 'quay.io/test/operator' is not pinned by digest",
+			},
+		},
+		"tagged related images": {
+			MetaBundle: newMetaBundle(
+				"quay.io/test/index"+digest1,
+				"quay.io/test/operator"+digest1,
+				"quay.io/test/related:v1",
+				"quay.io/test/operand:latest",
+			),
+			FailsWith: []string{
+				"image 'quay.io/test/related:v1' is not pinned by digest",
+				"image 'quay.io/test/operand:latest' is not pinned by digest",
+			},
+		},
+		"invalid reference": {
+			MetaBundle: newMetaBundle(
+				"quay.io/test/index"+digest1,
+				"quay.io/test/operator"+digest1,
+				"quay.io/test/related"+digest2,
+				"quay.io/test/Operand@sha256:invalid",
+			),
+			FailsWith: []string{
+				"image 'quay.io/test/Operand@sha256:invalid' is not pinned by digest",
+			},
+		},
+	})
+}
+
+func newMetaBundle(index, operatorImg, related, operand string) types.MetaBundle {
+	var csv operator.ClusterServiceVersion
+
+	csv.Spec.InstallStrategy.StrategySpec.DeploymentSpecs = []opsv1alpha1.StrategyDeploymentSpec{
+		{Name: "operator"},
+	}
+	csv.Spec.InstallStrategy.StrategySpec.DeploymentSpecs[0].Spec.Template.Spec.Containers = []corev1.Container{
+		{Name: "manager", Image: operatorImg},
+	}
+	csv.Spec.RelatedImages = []opsv1alpha1.RelatedImage{
+		{Name: "related", Image: related},
+	}
+
+	return *types.NewMetaBundleBuilder().
+		WithMeta(&v1alpha1.AddonMetadataSpec{
+			IndexImage: &index,
+		}).
+		WithImageSet(&v1alpha1.AddonImageSetSpec{
+			RelatedImages: []string{operand},
+		}).
+		WithBundles(operator.Bundle{
+			Name:                  "test.v1.0.0",
+			Version:               "1.0.0",
+			ClusterServiceVersion: csv,
+		}).
+		Build()
+}
@@ -0,0 +1,126 @@
+package am0022
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+func init() {
+	validator.Register(NewRelatedImages)
+}
+
+const (
+	code = 22
+	name = "related_images"
+	desc = "Ensure the relatedImages of the imageset match the images referenced by the CSV of the head bundle"
+)
+
+var docs = validator.Docs{
+	Details: "Every image of the deployments and the related images of the head bundle CSV must be listed under relatedImages " +
+		"of the imageset and every image listed there must be referenced by that CSV. Images missing from the imageset are not " +
+		"mirrored for disconnected clusters; the validator is skipped for addons without an imageset.",
+	ExampleFailures: []string{
+		"image 'quay.io/osd-addons/reference-addon-manager@sha256:...' of bundle reference-addon:0.1.6 is not listed",
+		"image 'quay.io/osd-addons/reference-addon-manager@sha256:...' is not referenced by bundle reference-addon:0.1.6",
+	},
+	Remediation: "List exactly the images referenced by the CSV of the head bundle under relatedImages of the imageset.",
+	Tags:        []string{validator.TagBundle, validator.TagConsistency, validator.TagImageSet},
+}
+
+func NewRelatedImages(deps validator.Dependencies) (validator.Validator, error) {
+	base, err := validator.NewBase(
+		code,
+		validator.BaseName(name),
+		validator.BaseDesc(desc),
+		validator.BaseDocs(docs),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RelatedImages{
+		Base: base,
+	}, nil
+}
+
+type RelatedImages struct {
+	*validator.Base
+}
+
+func (r *RelatedImages) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
+	if mb.ImageSet == nil {
+		return r.Success()
+	}
+
+	bundle, ok := mb.HeadBundle()
+	if !ok {
+		return r.Success()
+	}
+
+	var (
+		bundleName = bundle.GetNameVersion()
+		csvImages  = images(bundle)
+		listed     = make(map[string]bool)
+		findings   []validator.Finding
+	)
+
+	for i, img := range mb.ImageSet.RelatedImages {
+		listed[img] = true
+
+		if !csvImages[img] {
+			findings = append(findings, validator.FindingAt(mb, fmt.Sprintf("relatedImages[%d]", i),
+				fmt.Sprintf("image '%s' is not referenced by bundle %s", img, bundleName),
+			))
+		}
+	}
+
+	missing := make([]string, 0, len(csvImages))
+
+	for img := range csvImages {
+		if !listed[img] {
+			missing = append(missing, img)
+		}
+	}
+
+	sort.Strings(missing)
+
+	for _, img := range missing {
+		findings = append(findings, validator.FindingAt(mb, "relatedImages",
+			fmt.Sprintf("image '%s' of bundle %s is not listed", img, bundleName),
+		))
+	}
+
+	if len(findings) > 0 {
+		return r.FailWith(findings...)
+	}
+
+	return r.Success()
+}
+
+// images returns the set of images deployed by the
+// CSV of bundle and listed as its related images.
+func images(bundle operator.Bundle) map[string]bool {
+	res := make(map[string]bool)
+
+	for _, deployment := range bundle.ClusterServiceVersion.Spec.InstallStrategy.StrategySpec.DeploymentSpecs {
+		pod := deployment.Spec.Template.Spec
+
+		for _, c := range slices.Concat(pod.InitContainers, pod.Containers) {
+			res[c.Image] = true
+		}
+	}
+
+	for _, img := range bundle.RelatedImages() {
+		res[img] = true
+	}
+
+	delete(res, "")
+
+	return res
+}
@@ -0,0 +1,108 @@
+package am0022
+
+import (
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator/testutils"
+	opsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestRelatedImagesValid(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewRelatedImages)
+	tester.TestValidBundles(map[string]types.MetaBundle{
+		"no imageset": *types.NewMetaBundleBuilder().
+			WithMeta(&v1alpha1.AddonMetadataSpec{}).
+			WithBundles(newBundle("quay.io/test/operator:v1", "quay.io/test/operand:v1")).
+			Build(),
+		"no bundles": *types.NewMetaBundleBuilder().
+			WithImageSet(&v1alpha1.AddonImageSetSpec{
+				RelatedImages: []string{"quay.io/test/operator:v1"},
+			}).
+			Build(),
+		"matching images": newMetaBundle(
+			[]string{"quay.io/test/operand:v1", "quay.io/test/operator:v1"},
+			newBundle("quay.io/test/operator:v1", "quay.io/test/operand:v1", "quay.io/test/operator:v1"),
+		),
+	})
+}
+
+func TestRelatedImagesInvalid(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewRelatedImages)
+	tester.TestCases(map[string]testutils.TestCase{
+		"operator image not listed": {
+			MetaBundle: newMetaBundle(
+				[]string{"quay.io/test/operand:v1"},
+				newBundle("quay.io/test/operator:v1", "quay.io/test/operand:v1"),
+			),
+			FailsWith: []string{
+				"image 'quay.io/test/operator:v1' of bundle test:1.0.0 is not listed",
+			},
+		},
+		"related image not listed": {
+			MetaBundle: newMetaBundle(
+				[]string{"quay.io/test/operator:v1"},
+				newBundle("quay.io/test/operator:v1", "quay.io/test/operand:v1"),
+			),
+			FailsWith: []string{
+				"image 'quay.io/test/operand:v1' of bundle test:1.0.0 is not listed",
+			},
+		},
+		"listed image not referenced": {
+			MetaBundle: newMetaBundle(
+				[]string{"quay.io/test/operand:v1", "quay.io/test/operator:v1", "quay.io/test/stale:v0"},
+				newBundle("quay.io/test/operator:v1", "quay.io/test/operand:v1"),
+			),
+			FailsWith: []string{
+				"image 'quay.io/test/stale:v0' is not referenced by bundle test:1.0.0",
+			},
+		},
+		"drifted versions": {
+			MetaBundle: newMetaBundle(
+				[]string{"quay.io/test/operand:v1", "quay.io/test/operator:v1"},
+				newBundle("quay.io/test/operator:v2", "quay.io/test/operand:v1"),
+			),
+			FailsWith: []string{
+				"image 'quay.io/test/operator:v1' is not referenced by bundle test:1.0.0",
+				"image 'quay.io/test/operator:v2' of bundle test:1.0.0 is not listed",
+			},
+		},
+	})
+}
+
+func newMetaBundle(relatedImages []string, bundle operator.Bundle) types.MetaBundle {
+	return *types.NewMetaBundleBuilder().
+		WithImageSet(&v1alpha1.AddonImageSetSpec{
+			RelatedImages: relatedImages,
+		}).
+		WithBundles(bundle).
+		Build()
+}
+
+func newBundle(operatorImg string, related ...string) operator.Bundle {
+	var csv operator.ClusterServiceVersion
+
+	csv.Spec.InstallStrategy.StrategySpec.DeploymentSpecs = []opsv1alpha1.StrategyDeploymentSpec{
+		{Name: "operator"},
+	}
+	csv.Spec.InstallStrategy.StrategySpec.DeploymentSpecs[0].Spec.Template.Spec.Containers = []corev1.Container{
+		{Name: "manager", Image: operatorImg},
+	}
+
+	for _, img := range related {
+		csv.Spec.RelatedImages = append(csv.Spec.RelatedImages, opsv1alpha1.RelatedImage{Image: img})
+	}
+
+	return operator.Bundle{
+		Name:                  "test",
+		Version:               "1.0.0",
+		ClusterServiceVersion: csv,
+	}
+}
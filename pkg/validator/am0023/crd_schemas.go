@@ -0,0 +1,158 @@
+package am0023
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func init() {
+	validator.Register(NewCRDSchemas)
+}
+
+const (
+	code = 23
+	name = "crd_schemas"
+	desc = "Ensure all CRDs of the bundles are apiextensions.k8s.io/v1 with structural schemas"
+)
+
+var docs = validator.Docs{
+	Details: "Every CustomResourceDefinition of the bundles must be of apiVersion apiextensions.k8s.io/v1, serve at least one " +
+		"version and store exactly one. Every version must define a structural openAPIV3Schema which does not preserve " +
+		"unknown fields at its root, as clusters do not prune or validate custom resources otherwise.",
+	ExampleFailures: []string{
+		"bundle reference-addon:0.1.6: CRD 'referenceaddons.addons.managed.openshift.io' has apiVersion apiextensions.k8s.io/v1beta1; must be apiextensions.k8s.io/v1",
+		"bundle reference-addon:0.1.6: CRD 'referenceaddons.addons.managed.openshift.io' version v1alpha1 has no openAPIV3Schema",
+		"bundle reference-addon:0.1.6: CRD 'referenceaddons.addons.managed.openshift.io' version v1alpha1 schema is not structural: spec.type: Required value: must not be empty for specified object fields",
+	},
+	Remediation: "Generate the CRDs as apiextensions.k8s.io/v1 (e.g. with controller-gen) and limit " +
+		"'x-kubernetes-preserve-unknown-fields' to the fields which hold arbitrary content.",
+	Tags: []string{validator.TagBundle},
+}
+
+func NewCRDSchemas(deps validator.Dependencies) (validator.Validator, error) {
+	base, err := validator.NewBase(
+		code,
+		validator.BaseName(name),
+		validator.BaseDesc(desc),
+		validator.BaseDocs(docs),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CRDSchemas{
+		Base: base,
+	}, nil
+}
+
+type CRDSchemas struct {
+	*validator.Base
+}
+
+func (c *CRDSchemas) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
+	var msgs []string
+
+	for _, bundle := range mb.Bundles {
+		for _, obj := range bundle.Manifests {
+			if obj.GroupVersionKind().GroupKind() != apiextensionsv1.Kind("CustomResourceDefinition") {
+				continue
+			}
+
+			for _, msg := range validateCRD(obj) {
+				msgs = append(msgs, fmt.Sprintf("bundle %s: CRD '%s' %s", bundle.GetNameVersion(), obj.GetName(), msg))
+			}
+		}
+	}
+
+	if len(msgs) > 0 {
+		return c.Fail(msgs...)
+	}
+
+	return c.Success()
+}
+
+// validateCRD returns the problems of the CustomResourceDefinition obj.
+func validateCRD(obj *unstructured.Unstructured) []string {
+	if gv := obj.GetAPIVersion(); gv != apiextensionsv1.SchemeGroupVersion.String() {
+		return []string{fmt.Sprintf("has apiVersion %s; must be %s", gv, apiextensionsv1.SchemeGroupVersion)}
+	}
+
+	var crd apiextensionsv1.CustomResourceDefinition
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &crd); err != nil {
+		return []string{fmt.Sprintf("can not be decoded: %v", err)}
+	}
+
+	var msgs []string
+
+	if crd.Spec.PreserveUnknownFields {
+		msgs = append(msgs, "sets spec.preserveUnknownFields; unknown fields must be preserved per field with 'x-kubernetes-preserve-unknown-fields'")
+	}
+
+	var served, stored int
+
+	for _, ver := range crd.Spec.Versions {
+		if ver.Served {
+			served++
+		}
+
+		if ver.Storage {
+			stored++
+		}
+
+		msgs = append(msgs, validateVersion(ver)...)
+	}
+
+	if served == 0 {
+		msgs = append(msgs, "serves no version")
+	}
+
+	if stored != 1 {
+		msgs = append(msgs, fmt.Sprintf("stores %d versions; exactly one version must be the storage version", stored))
+	}
+
+	return msgs
+}
+
+// validateVersion returns the problems of the schema of ver.
+func validateVersion(ver apiextensionsv1.CustomResourceDefinitionVersion) []string {
+	if ver.Schema == nil || ver.Schema.OpenAPIV3Schema == nil {
+		return []string{fmt.Sprintf("version %s has no openAPIV3Schema", ver.Name)}
+	}
+
+	props := ver.Schema.OpenAPIV3Schema
+
+	var internal apiextensions.JSONSchemaProps
+	if err := apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(props, &internal, nil); err != nil {
+		return []string{fmt.Sprintf("version %s schema can not be converted: %v", ver.Name, err)}
+	}
+
+	structural, err := schema.NewStructural(&internal)
+	if err != nil {
+		return []string{fmt.Sprintf("version %s schema is not structural: %v", ver.Name, err)}
+	}
+
+	var msgs []string
+
+	for _, err := range schema.ValidateStructural(field.NewPath("openAPIV3Schema"), structural) {
+		msgs = append(msgs, fmt.Sprintf("version %s schema is not structural: %v", ver.Name, err))
+	}
+
+	// a root preserving unknown fields without any properties
+	// disables pruning and validation of the whole resource
+	if isTrue(props.XPreserveUnknownFields) && len(props.Properties) == 0 {
+		msgs = append(msgs, fmt.Sprintf("version %s schema preserves unknown fields without defining any properties", ver.Name))
+	}
+
+	return msgs
+}
+
+func isTrue(b *bool) bool { return b != nil && *b }
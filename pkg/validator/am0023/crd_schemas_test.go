@@ -0,0 +1,92 @@
+package am0023
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator/testutils"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+func TestCRDSchemasValid(t *testing.T) {
+	t.Parallel()
+
+	configMap := &unstructured.Unstructured{}
+	configMap.SetAPIVersion("v1")
+	configMap.SetKind("ConfigMap")
+	configMap.SetName("config")
+
+	tester := testutils.NewValidatorTester(t, NewCRDSchemas)
+	tester.TestValidBundles(map[string]types.MetaBundle{
+		"no bundles":    {},
+		"no CRDs":       newMetaBundle(configMap),
+		"v1 structural": newMetaBundle(loadCRD(t, "valid.yaml"), configMap),
+	})
+}
+
+func TestCRDSchemasInvalid(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewCRDSchemas)
+	tester.TestCases(map[string]testutils.TestCase{
+		"v1beta1": {
+			MetaBundle: newMetaBundle(loadCRD(t, "v1beta1.yaml")),
+			FailsWith: []string{
+				"bundle test:1.0.0: CRD 'legacyaddons.addons.managed.openshift.io' has apiVersion apiextensions.k8s.io/v1beta1; must be apiextensions.k8s.io/v1",
+			},
+		},
+		"missing schema": {
+			MetaBundle: newMetaBundle(loadCRD(t, "missing_schema.yaml")),
+			FailsWith: []string{
+				"version v1alpha1 has no openAPIV3Schema",
+			},
+		},
+		"non-structural schema": {
+			MetaBundle: newMetaBundle(loadCRD(t, "non_structural.yaml")),
+			FailsWith: []string{
+				"version v1alpha1 schema is not structural: openAPIV3Schema.properties[spec].type: Required value",
+			},
+		},
+		"no served or storage version": {
+			MetaBundle: newMetaBundle(loadCRD(t, "no_storage_version.yaml")),
+			FailsWith: []string{
+				"serves no version",
+				"stores 0 versions",
+			},
+		},
+		"preserved unknown fields": {
+			MetaBundle: newMetaBundle(loadCRD(t, "preserve_unknown_fields.yaml")),
+			FailsWith: []string{
+				"sets spec.preserveUnknownFields",
+				"version v1alpha1 schema preserves unknown fields without defining any properties",
+			},
+		},
+	})
+}
+
+func newMetaBundle(manifests ...*unstructured.Unstructured) types.MetaBundle {
+	return *types.NewMetaBundleBuilder().
+		WithBundles(operator.Bundle{
+			Name:      "test",
+			Version:   "1.0.0",
+			Manifests: manifests,
+		}).
+		Build()
+}
+
+func loadCRD(t *testing.T, name string) *unstructured.Unstructured {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("test_crds", name))
+	require.NoError(t, err)
+
+	var obj unstructured.Unstructured
+	require.NoError(t, yaml.Unmarshal(data, &obj.Object))
+
+	return &obj
+}
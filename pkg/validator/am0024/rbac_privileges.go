@@ -0,0 +1,225 @@
+package am0024
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	operatorv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func init() {
+	validator.Register(NewRBACPrivileges)
+}
+
+const (
+	code = 24
+	name = "rbac_privileges"
+	desc = "Ensure the operator is not granted wildcard, cluster-admin or cluster-wide secret access unless allowlisted"
+)
+
+var docs = validator.Docs{
+	Details: "The permissions and clusterPermissions of the head bundle CSV must not grant all verbs or all resources of an " +
+		"API group and clusterPermissions must not allow reading secrets of all namespaces. Resources passed with " +
+		"'--rbac-allowlist' as 'resource[.group]' are exempt. Bindings of the cluster-admin role shipped with the " +
+		"bundle are always reported.",
+	ExampleFailures: []string{
+		"clusterPermissions[0].rules[1]: service account 'manager' may perform all verbs on 'deployments.apps' cluster-wide",
+		"clusterPermissions[0].rules[2]: service account 'manager' may read secrets in all namespaces",
+		"ClusterRoleBinding 'manager-admin' binds the cluster-admin role",
+	},
+	Remediation: "Grant only the verbs and resources the operator needs and scope access to secrets to its namespace " +
+		"or to resource names. Have SRE review privileged access which can not be avoided before it is allowlisted.",
+	Tags: []string{validator.TagBundle, validator.TagSecurity},
+}
+
+func NewRBACPrivileges(deps validator.Dependencies) (validator.Validator, error) {
+	base, err := validator.NewBase(
+		code,
+		validator.BaseName(name),
+		validator.BaseDesc(desc),
+		validator.BaseDocs(docs),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RBACPrivileges{
+		Base:      base,
+		Allowlist: deps.ValidatorConfig.RBACAllowlist,
+	}, nil
+}
+
+type RBACPrivileges struct {
+	*validator.Base
+	// Allowlist lists resources as 'resource[.group]'
+	// which may be accessed with privileges.
+	Allowlist []string
+}
+
+func (r *RBACPrivileges) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
+	bundle, ok := mb.HeadBundle()
+	if !ok {
+		return r.Success()
+	}
+
+	strategy := bundle.ClusterServiceVersion.Spec.InstallStrategy.StrategySpec
+
+	var msgs []string
+
+	msgs = append(msgs, r.checkPermissions("clusterPermissions", strategy.ClusterPermissions, true)...)
+	msgs = append(msgs, r.checkPermissions("permissions", strategy.Permissions, false)...)
+
+	for _, obj := range bundle.Manifests {
+		msg, err := checkBinding(obj)
+		if err != nil {
+			return r.Error(err)
+		}
+
+		if msg != "" {
+			msgs = append(msgs, msg)
+		}
+	}
+
+	if len(msgs) > 0 {
+		return r.Fail(msgs...)
+	}
+
+	return r.Success()
+}
+
+func (r *RBACPrivileges) checkPermissions(field string, perms []operatorv1alpha1.StrategyDeploymentPermissions, clusterScoped bool) []string {
+	scope := "in its namespace"
+	if clusterScoped {
+		scope = "cluster-wide"
+	}
+
+	var msgs []string
+
+	for i, perm := range perms {
+		for j, rule := range perm.Rules {
+			prefix := fmt.Sprintf("%s[%d].rules[%d]: service account '%s'", field, i, j, perm.ServiceAccountName)
+
+			resources := r.privilegedResources(rule)
+			if len(resources) == 0 {
+				continue
+			}
+
+			if slices.Contains(rule.Verbs, rbacv1.VerbAll) {
+				msgs = append(msgs, fmt.Sprintf("%s may perform all verbs on %s %s", prefix, quote(resources), scope))
+			}
+
+			if wildcards := filter(resources, isWildcard); len(wildcards) > 0 {
+				msgs = append(msgs, fmt.Sprintf("%s may access all resources matching %s %s", prefix, quote(wildcards), scope))
+			}
+
+			if clusterScoped && readsSecrets(rule, resources) {
+				msgs = append(msgs, fmt.Sprintf("%s may read secrets in all namespaces", prefix))
+			}
+		}
+	}
+
+	return msgs
+}
+
+// privilegedResources returns the resources of rule as 'resource[.group]'
+// which are not allowlisted. Non-resource URLs are not considered.
+func (r *RBACPrivileges) privilegedResources(rule rbacv1.PolicyRule) []string {
+	var res []string
+
+	for _, group := range rule.APIGroups {
+		for _, resource := range rule.Resources {
+			id := resourceID(resource, group)
+
+			if slices.Contains(r.Allowlist, id) || slices.Contains(res, id) {
+				continue
+			}
+
+			res = append(res, id)
+		}
+	}
+
+	return res
+}
+
+func resourceID(resource, group string) string {
+	if group == "" {
+		return resource
+	}
+
+	return resource + "." + group
+}
+
+func isWildcard(id string) bool {
+	return id == rbacv1.ResourceAll || strings.HasPrefix(id, rbacv1.ResourceAll+".")
+}
+
+// readsSecrets returns true if rule allows reading secrets of any
+// name; resources are the privileged resources of rule.
+func readsSecrets(rule rbacv1.PolicyRule, resources []string) bool {
+	if len(rule.ResourceNames) > 0 {
+		return false
+	}
+
+	if !slices.Contains(resources, "secrets") && !slices.Contains(resources, "secrets."+rbacv1.APIGroupAll) {
+		return false
+	}
+
+	for _, verb := range []string{rbacv1.VerbAll, "get", "list", "watch"} {
+		if slices.Contains(rule.Verbs, verb) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkBinding returns a message if obj binds the cluster-admin role.
+func checkBinding(obj *unstructured.Unstructured) (string, error) {
+	gk := obj.GroupVersionKind().GroupKind()
+	if gk.Group != rbacv1.GroupName || (gk.Kind != "ClusterRoleBinding" && gk.Kind != "RoleBinding") {
+		return "", nil
+	}
+
+	var binding struct {
+		RoleRef rbacv1.RoleRef `json:"roleRef"`
+	}
+
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &binding); err != nil {
+		return "", fmt.Errorf("decoding %s '%s': %w", gk.Kind, obj.GetName(), err)
+	}
+
+	if binding.RoleRef.Kind != "ClusterRole" || binding.RoleRef.Name != "cluster-admin" {
+		return "", nil
+	}
+
+	return fmt.Sprintf("%s '%s' binds the cluster-admin role", gk.Kind, obj.GetName()), nil
+}
+
+func filter(ids []string, pred func(string) bool) []string {
+	var res []string
+
+	for _, id := range ids {
+		if pred(id) {
+			res = append(res, id)
+		}
+	}
+
+	return res
+}
+
+func quote(ids []string) string {
+	quoted := make([]string, 0, len(ids))
+
+	for _, id := range ids {
+		quoted = append(quoted, "'"+id+"'")
+	}
+
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,166 @@
+package am0024
+
+import (
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator/testutils"
+	opsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestRBACPrivilegesValid(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewRBACPrivileges)
+	tester.TestValidBundles(map[string]types.MetaBundle{
+		"no bundles": {},
+		"least privilege": newMetaBundle(
+			[]rbacv1.PolicyRule{
+				{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"get", "list", "watch"}},
+				{APIGroups: []string{""}, Resources: []string{"secrets"}, ResourceNames: []string{"pull-secret"}, Verbs: []string{"get"}},
+				{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"create"}},
+			},
+			[]rbacv1.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"secrets", "configmaps"}, Verbs: []string{"get", "list", "watch"}},
+			},
+			newBinding("ClusterRoleBinding", "manager-view", "view"),
+		),
+	})
+}
+
+func TestRBACPrivilegesInvalid(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewRBACPrivileges)
+	tester.TestCases(map[string]testutils.TestCase{
+		"wildcard verbs": {
+			MetaBundle: newMetaBundle(
+				[]rbacv1.PolicyRule{
+					{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"*"}},
+				},
+				nil,
+			),
+			FailsWith: []string{
+				"clusterPermissions[0].rules[0]: service account 'manager' may perform all verbs on 'deployments.apps' cluster-wide",
+			},
+		},
+		"wildcard resources": {
+			MetaBundle: newMetaBundle(
+				nil,
+				[]rbacv1.PolicyRule{
+					{APIGroups: []string{"", "apps"}, Resources: []string{"*"}, Verbs: []string{"get"}},
+				},
+			),
+			FailsWith: []string{
+				"permissions[0].rules[0]: service account 'manager' may access all resources matching '*', '*.apps' in its namespace",
+			},
+		},
+		"cluster-wide secrets": {
+			MetaBundle: newMetaBundle(
+				[]rbacv1.PolicyRule{
+					{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"get"}},
+					{APIGroups: []string{""}, Resources: []string{"configmaps", "secrets"}, Verbs: []string{"list"}},
+				},
+				nil,
+			),
+			FailsWith: []string{
+				"clusterPermissions[0].rules[1]: service account 'manager' may read secrets in all namespaces",
+			},
+		},
+		"cluster-admin binding": {
+			MetaBundle: newMetaBundle(
+				nil,
+				nil,
+				newBinding("ClusterRoleBinding", "manager-admin", "cluster-admin"),
+				newBinding("RoleBinding", "namespace-admin", "cluster-admin"),
+			),
+			FailsWith: []string{
+				"ClusterRoleBinding 'manager-admin' binds the cluster-admin role",
+				"RoleBinding 'namespace-admin' binds the cluster-admin role",
+			},
+		},
+	})
+}
+
+func TestRBACPrivilegesAllowlist(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(
+		t, NewRBACPrivileges,
+		testutils.ValidatorTesterValidatorConfig(
+			validator.WithRBACAllowlist{"secrets", "*.example.com"},
+		),
+	)
+	tester.TestCases(map[string]testutils.TestCase{
+		"allowlisted resources": {
+			MetaBundle: newMetaBundle(
+				[]rbacv1.PolicyRule{
+					{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"*"}},
+					{APIGroups: []string{"example.com"}, Resources: []string{"*"}, Verbs: []string{"*"}},
+				},
+				nil,
+			),
+		},
+		"resources not allowlisted": {
+			MetaBundle: newMetaBundle(
+				[]rbacv1.PolicyRule{
+					{APIGroups: []string{""}, Resources: []string{"secrets", "configmaps"}, Verbs: []string{"*"}},
+				},
+				nil,
+				newBinding("ClusterRoleBinding", "manager-admin", "cluster-admin"),
+			),
+			FailsWith: []string{
+				"may perform all verbs on 'configmaps' cluster-wide",
+				"ClusterRoleBinding 'manager-admin' binds the cluster-admin role",
+			},
+		},
+	})
+}
+
+func newMetaBundle(clusterRules, rules []rbacv1.PolicyRule, manifests ...*unstructured.Unstructured) types.MetaBundle {
+	var csv operator.ClusterServiceVersion
+
+	strategy := &csv.Spec.InstallStrategy.StrategySpec
+
+	if clusterRules != nil {
+		strategy.ClusterPermissions = []opsv1alpha1.StrategyDeploymentPermissions{
+			{ServiceAccountName: "manager", Rules: clusterRules},
+		}
+	}
+
+	if rules != nil {
+		strategy.Permissions = []opsv1alpha1.StrategyDeploymentPermissions{
+			{ServiceAccountName: "manager", Rules: rules},
+		}
+	}
+
+	return *types.NewMetaBundleBuilder().
+		WithBundles(operator.Bundle{
+			Name:                  "test",
+			Version:               "1.0.0",
+			ClusterServiceVersion: csv,
+			Manifests:             manifests,
+		}).
+		Build()
+}
+
+func newBinding(kind, name, role string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"roleRef": map[string]interface{}{
+				"apiGroup": rbacv1.GroupName,
+				"kind":     "ClusterRole",
+				"name":     role,
+			},
+		},
+	}
+	obj.SetAPIVersion(rbacv1.SchemeGroupVersion.String())
+	obj.SetKind(kind)
+	obj.SetName(name)
+
+	return obj
+}
@@ -0,0 +1,84 @@
+package am0025
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/kube"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+func init() {
+	validator.Register(NewPodSecurity)
+}
+
+const (
+	code = 25
+	name = "pod_security"
+	desc = "Ensure all deployments in the CSV satisfy the restricted Pod Security Standard"
+)
+
+var docs = validator.Docs{
+	Details: "Every deployment of the head bundle CSV must be admitted by clusters enforcing the 'restricted' Pod Security " +
+		"Standard: containers must not be privileged, the pod must not share the host network, PID or IPC namespace and " +
+		"every container must run as non-root with the RuntimeDefault or a Localhost seccomp profile.",
+	ExampleFailures: []string{
+		"deployment \"reference-addon\": container \"manager\" is missing runAsNonRoot",
+		"deployment \"reference-addon\": pod is sharing the host network namespace",
+	},
+	Remediation: "Set 'runAsNonRoot: true' and 'seccompProfile: {type: RuntimeDefault}' in the pod security context " +
+		"and remove 'privileged' and the host namespace settings.",
+	Tags: []string{validator.TagBundle, validator.TagSecurity},
+}
+
+func NewPodSecurity(deps validator.Dependencies) (validator.Validator, error) {
+	base, err := validator.NewBase(
+		code,
+		validator.BaseName(name),
+		validator.BaseDesc(desc),
+		validator.BaseDocs(docs),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PodSecurity{
+		Base: base,
+		linter: kube.NewDeploymentLinterImpl(
+			kube.WithDeploymentChecks(kube.PodSecurityChecks),
+		),
+	}, nil
+}
+
+type PodSecurity struct {
+	*validator.Base
+	linter kube.DeploymentLinter
+}
+
+func (p *PodSecurity) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
+	var msgs []string
+
+	bundle, ok := mb.HeadBundle()
+	if !ok {
+		return p.Success()
+	}
+
+	csv := bundle.ClusterServiceVersion
+
+	for _, deploymentSpec := range csv.Spec.InstallStrategy.StrategySpec.DeploymentSpecs {
+		deployment := appsv1.Deployment{Spec: deploymentSpec.Spec}
+
+		res := p.linter.Lint(deployment)
+		for _, reason := range res.Reasons {
+			msgs = append(msgs, fmt.Sprintf("deployment %q: %s", deploymentSpec.Name, reason))
+		}
+	}
+
+	if len(msgs) > 0 {
+		return p.Fail(msgs...)
+	}
+
+	return p.Success()
+}
@@ -0,0 +1,129 @@
+package am0025
+
+import (
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator/testutils"
+	opsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestPodSecurityValid(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewPodSecurity)
+	tester.TestValidBundles(map[string]types.MetaBundle{
+		"no bundles": {},
+		"restricted pod": newMetaBundle(corev1.PodSpec{
+			SecurityContext: restricted(),
+			Containers:      []corev1.Container{{Name: "manager"}},
+		}),
+		"restricted containers": newMetaBundle(corev1.PodSpec{
+			InitContainers: []corev1.Container{{
+				Name: "init",
+				SecurityContext: &corev1.SecurityContext{
+					RunAsNonRoot:   ptr.To(true),
+					SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeLocalhost},
+				},
+			}},
+			Containers: []corev1.Container{{
+				Name: "manager",
+				SecurityContext: &corev1.SecurityContext{
+					RunAsNonRoot:   ptr.To(true),
+					SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+				},
+			}},
+		}),
+	})
+}
+
+func TestPodSecurityInvalid(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewPodSecurity)
+	tester.TestCases(map[string]testutils.TestCase{
+		"privileged container": {
+			MetaBundle: newMetaBundle(corev1.PodSpec{
+				SecurityContext: restricted(),
+				Containers: []corev1.Container{{
+					Name:            "manager",
+					SecurityContext: &corev1.SecurityContext{Privileged: ptr.To(true)},
+				}},
+			}),
+			FailsWith: []string{
+				`deployment "operator": container "manager" is privileged`,
+			},
+		},
+		"host namespaces": {
+			MetaBundle: newMetaBundle(corev1.PodSpec{
+				SecurityContext: restricted(),
+				HostNetwork:     true,
+				HostPID:         true,
+				Containers:      []corev1.Container{{Name: "manager"}},
+			}),
+			FailsWith: []string{
+				"pod is sharing the host network namespace",
+				"pod is sharing the host PID namespace",
+			},
+		},
+		"missing security context": {
+			MetaBundle: newMetaBundle(corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "manager"}},
+			}),
+			FailsWith: []string{
+				`container "manager" is missing runAsNonRoot`,
+				`container "manager" is missing a seccomp profile`,
+			},
+		},
+		"container overriding pod": {
+			MetaBundle: newMetaBundle(corev1.PodSpec{
+				SecurityContext: restricted(),
+				InitContainers: []corev1.Container{{
+					Name: "init",
+					SecurityContext: &corev1.SecurityContext{
+						RunAsNonRoot: ptr.To(false),
+						RunAsUser:    ptr.To[int64](0),
+					},
+				}},
+				Containers: []corev1.Container{{
+					Name: "manager",
+					SecurityContext: &corev1.SecurityContext{
+						SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeUnconfined},
+					},
+				}},
+			}),
+			FailsWith: []string{
+				`container "init" is missing runAsNonRoot`,
+				`container "init" is running as root user (runAsUser 0)`,
+				`container "manager" is using seccomp profile "Unconfined"`,
+			},
+		},
+	})
+}
+
+func restricted() *corev1.PodSecurityContext {
+	return &corev1.PodSecurityContext{
+		RunAsNonRoot:   ptr.To(true),
+		SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}
+}
+
+func newMetaBundle(spec corev1.PodSpec) types.MetaBundle {
+	var csv operator.ClusterServiceVersion
+
+	csv.Spec.InstallStrategy.StrategySpec.DeploymentSpecs = []opsv1alpha1.StrategyDeploymentSpec{
+		{Name: "operator"},
+	}
+	csv.Spec.InstallStrategy.StrategySpec.DeploymentSpecs[0].Spec.Template.Spec = spec
+
+	return *types.NewMetaBundleBuilder().
+		WithBundles(operator.Bundle{
+			Name:                  "test",
+			Version:               "1.0.0",
+			ClusterServiceVersion: csv,
+		}).
+		Build()
+}
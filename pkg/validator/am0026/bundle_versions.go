@@ -0,0 +1,148 @@
+package am0026
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/blang/semver/v4"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+func init() {
+	validator.Register(NewBundleVersions)
+}
+
+const (
+	code = 26
+	name = "bundle_versions"
+	desc = "Ensure bundle versions are valid semver, increase along the upgrade path and include the imageset version"
+)
+
+var docs = validator.Docs{
+	Details: "The version of every bundle must be valid semver. Within each channel a bundle must have a strictly higher " +
+		"version than the bundle it replaces so that upgrades never downgrade the operator. The version of the imageset " +
+		"being validated must be the version of one of the bundles in the index.",
+	ExampleFailures: []string{
+		"bundle \"reference-addon:v0.1\" has invalid semver version \"v0.1\": No Major.Minor.Patch elements found",
+		"channel \"alpha\": bundle \"reference-addon:0.1.5\" replaces \"reference-addon.v0.1.6\" of version 0.1.6 which is not lower",
+		"addonImageSetVersion: imageset version \"0.1.7\" does not match any bundle in the index",
+	},
+	Remediation: "Version bundles with semver, let every bundle replace the previous version of its channel " +
+		"and publish the bundle of the imageset version to the index.",
+	Tags: []string{validator.TagBundle, validator.TagConsistency, validator.TagImageSet},
+}
+
+func NewBundleVersions(deps validator.Dependencies) (validator.Validator, error) {
+	base, err := validator.NewBase(
+		code,
+		validator.BaseName(name),
+		validator.BaseDesc(desc),
+		validator.BaseDocs(docs),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BundleVersions{
+		Base: base,
+	}, nil
+}
+
+type BundleVersions struct {
+	*validator.Base
+}
+
+func (b *BundleVersions) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
+	if len(mb.Bundles) == 0 {
+		return b.Success()
+	}
+
+	var findings []validator.Finding
+
+	for _, bundle := range mb.Bundles {
+		if _, err := semver.Parse(bundle.Version); err != nil {
+			findings = append(findings, validator.Finding{
+				Message: fmt.Sprintf("bundle %q has invalid semver version %q: %v", bundle.GetNameVersion(), bundle.Version, err),
+			})
+		}
+	}
+
+	byChannel := mb.BundlesByChannel()
+
+	channels := make([]string, 0, len(byChannel))
+	for ch := range byChannel {
+		channels = append(channels, ch)
+	}
+
+	sort.Strings(channels)
+
+	for _, ch := range channels {
+		for _, msg := range checkUpgradePath(byChannel[ch]) {
+			findings = append(findings, validator.Finding{
+				Message: fmt.Sprintf("channel %q: %s", ch, msg),
+			})
+		}
+	}
+
+	if version := mb.Resolved().ImageSetVersion; version != "" {
+		if _, ok := mb.CSVFor(version); !ok {
+			findings = append(findings, validator.FindingAt(mb, "addonImageSetVersion",
+				fmt.Sprintf("imageset version %q does not match any bundle in the index", version),
+			))
+		}
+	}
+
+	if len(findings) > 0 {
+		return b.FailWith(findings...)
+	}
+
+	return b.Success()
+}
+
+// checkUpgradePath verifies that each of bundles has a strictly higher
+// version than the bundle of the same channel it replaces. Bundles
+// with invalid versions and replaced bundles missing from the
+// channel are ignored.
+func checkUpgradePath(bundles []operator.Bundle) []string {
+	byCSV := make(map[string]operator.Bundle, len(bundles))
+	for _, bundle := range bundles {
+		byCSV[bundle.ClusterServiceVersion.Name] = bundle
+	}
+
+	var msgs []string
+
+	for _, bundle := range bundles {
+		replaces := bundle.ClusterServiceVersion.Spec.Replaces
+		if replaces == "" {
+			continue
+		}
+
+		replaced, ok := byCSV[replaces]
+		if !ok {
+			continue
+		}
+
+		ver, err := semver.Parse(bundle.Version)
+		if err != nil {
+			continue
+		}
+
+		replacedVer, err := semver.Parse(replaced.Version)
+		if err != nil {
+			continue
+		}
+
+		if replacedVer.LT(ver) {
+			continue
+		}
+
+		msgs = append(msgs, fmt.Sprintf("bundle %q replaces %q of version %s which is not lower",
+			bundle.GetNameVersion(), replaces, replacedVer,
+		))
+	}
+
+	return msgs
+}
@@ -0,0 +1,109 @@
+package am0026
+
+import (
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator/testutils"
+)
+
+func TestBundleVersionsValid(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewBundleVersions)
+	tester.TestValidBundles(map[string]types.MetaBundle{
+		"no bundles": *types.NewMetaBundleBuilder().
+			WithMeta(&v1alpha1.AddonMetadataSpec{ImageSetVersion: ptr("1.0.0")}).
+			Build(),
+		"increasing versions": newMetaBundle("1.1.0",
+			newBundle("1.0.0", "", "alpha"),
+			newBundle("1.1.0", "1.0.0", "alpha"),
+			newBundle("1.2.0-rc.1", "1.1.0", "alpha"),
+		),
+		"imageset version with leading v": newMetaBundle("v1.0.0",
+			newBundle("1.0.0", "", "alpha"),
+		),
+		"independent channels": newMetaBundle("",
+			newBundle("2.0.0", "", "stable"),
+			newBundle("1.0.0", "", "alpha"),
+			newBundle("1.1.0", "1.0.0", "alpha"),
+		),
+	})
+}
+
+func TestBundleVersionsInvalid(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewBundleVersions)
+	tester.TestCases(map[string]testutils.TestCase{
+		"invalid semver": {
+			MetaBundle: newMetaBundle("",
+				newBundle("1.0.0", "", "alpha"),
+				newBundle("v1.1", "1.0.0", "alpha"),
+			),
+			FailsWith: []string{
+				`bundle "test:v1.1" has invalid semver version "v1.1"`,
+			},
+		},
+		"downgrade": {
+			MetaBundle: newMetaBundle("",
+				newBundle("1.1.0", "", "alpha"),
+				newBundle("1.0.0", "1.1.0", "alpha"),
+			),
+			FailsWith: []string{
+				`channel "alpha": bundle "test:1.0.0" replaces "test.v1.1.0" of version 1.1.0 which is not lower`,
+			},
+		},
+		"same version": {
+			MetaBundle: newMetaBundle("",
+				newBundle("1.0.0", "", "stable"),
+				newBundle("1.0.0+build", "1.0.0", "stable"),
+			),
+			FailsWith: []string{
+				`channel "stable": bundle "test:1.0.0+build" replaces "test.v1.0.0" of version 1.0.0 which is not lower`,
+			},
+		},
+		"imageset version without bundle": {
+			MetaBundle: newMetaBundle("1.2.0",
+				newBundle("1.0.0", "", "alpha"),
+				newBundle("1.1.0", "1.0.0", "alpha"),
+			),
+			FailsWith: []string{
+				`imageset version "1.2.0" does not match any bundle in the index`,
+			},
+		},
+	})
+}
+
+func newMetaBundle(imageSetVersion string, bundles ...operator.Bundle) types.MetaBundle {
+	meta := &v1alpha1.AddonMetadataSpec{}
+	if imageSetVersion != "" {
+		meta.ImageSetVersion = ptr(imageSetVersion)
+	}
+
+	return *types.NewMetaBundleBuilder().
+		WithMeta(meta).
+		WithBundles(bundles...).
+		Build()
+}
+
+func newBundle(version, replaces string, channels ...string) operator.Bundle {
+	var csv operator.ClusterServiceVersion
+
+	csv.Name = "test.v" + version
+
+	if replaces != "" {
+		csv.Spec.Replaces = "test.v" + replaces
+	}
+
+	return operator.Bundle{
+		Name:                  "test",
+		Version:               version,
+		Channels:              channels,
+		ClusterServiceVersion: csv,
+	}
+}
+
+func ptr(s string) *string { return &s }
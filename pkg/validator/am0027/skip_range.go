@@ -0,0 +1,204 @@
+package am0027
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+func init() {
+	validator.Register(NewSkipRange)
+}
+
+const (
+	code = 27
+	name = "skip_range"
+	desc = "Ensure olm.skipRange annotations are valid semver ranges which keep every version upgradeable"
+)
+
+// skipRangeAnnotation is the CSV annotation listing the
+// versions a bundle can directly be upgraded from.
+const skipRangeAnnotation = "olm.skipRange"
+
+var docs = validator.Docs{
+	Details: "The olm.skipRange annotation of every bundle CSV must be a valid semver range which includes the version of " +
+		"the bundle it replaces. In channels using skip ranges every bundle except the channel head must be replaced, " +
+		"skipped or included in the skip range of a newer bundle so that clusters running it are not stranded.",
+	ExampleFailures: []string{
+		"bundle \"reference-addon:0.1.6\": olm.skipRange \">=0.1.0 <=>0.1.6\" is invalid: Could not parse Range",
+		"bundle \"reference-addon:0.1.6\": olm.skipRange \">=0.1.0 <0.1.5\" excludes version 0.1.5 of the replaced bundle \"reference-addon.v0.1.5\"",
+		"channel \"alpha\": bundle \"reference-addon:0.1.2\" can not be upgraded; no newer bundle replaces or skips it or includes it in its olm.skipRange",
+	},
+	Remediation: "Fix the syntax of the skip ranges (e.g. '>=0.1.0 <0.1.6') and widen them so that they include " +
+		"the replaced bundle and every older version of the channel.",
+	Tags: []string{validator.TagBundle, validator.TagConsistency},
+}
+
+func NewSkipRange(deps validator.Dependencies) (validator.Validator, error) {
+	base, err := validator.NewBase(
+		code,
+		validator.BaseName(name),
+		validator.BaseDesc(desc),
+		validator.BaseDocs(docs),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SkipRange{
+		Base: base,
+	}, nil
+}
+
+type SkipRange struct {
+	*validator.Base
+}
+
+func (s *SkipRange) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
+	var msgs []string
+
+	// valid skip ranges by bundle name and version
+	ranges := make(map[string]semver.Range)
+
+	for _, bundle := range mb.Bundles {
+		raw, ok := skipRangeOf(bundle)
+		if !ok {
+			continue
+		}
+
+		nameVer := bundle.GetNameVersion()
+
+		rng, err := semver.ParseRange(raw)
+		if err != nil {
+			msgs = append(msgs, fmt.Sprintf("bundle %q: %s %q is invalid: %v", nameVer, skipRangeAnnotation, raw, err))
+
+			continue
+		}
+
+		ranges[nameVer] = rng
+
+		replaces := bundle.ClusterServiceVersion.Spec.Replaces
+		if replaces == "" {
+			continue
+		}
+
+		if ver, ok := replacedVersion(mb.Bundles, replaces); ok && !rng(ver) {
+			msgs = append(msgs, fmt.Sprintf("bundle %q: %s %q excludes version %s of the replaced bundle %q",
+				nameVer, skipRangeAnnotation, raw, ver, replaces,
+			))
+		}
+	}
+
+	byChannel := mb.BundlesByChannel()
+
+	channels := make([]string, 0, len(byChannel))
+	for ch := range byChannel {
+		channels = append(channels, ch)
+	}
+
+	sort.Strings(channels)
+
+	for _, ch := range channels {
+		for _, nameVer := range strandedBundles(byChannel[ch], ranges) {
+			msgs = append(msgs, fmt.Sprintf(
+				"channel %q: bundle %q can not be upgraded; no newer bundle replaces or skips it or includes it in its %s",
+				ch, nameVer, skipRangeAnnotation,
+			))
+		}
+	}
+
+	if len(msgs) > 0 {
+		return s.Fail(msgs...)
+	}
+
+	return s.Success()
+}
+
+// skipRangeOf returns the olm.skipRange annotation of the CSV of bundle.
+func skipRangeOf(bundle operator.Bundle) (string, bool) {
+	rng, ok := bundle.CSVAnnotations()[skipRangeAnnotation]
+
+	return rng, ok
+}
+
+// replacedVersion returns the version of the bundle whose CSV is named
+// replaces. The version is taken from the name ('<package>.v<version>')
+// if the replaced bundle is not part of bundles. As package names may
+// contain dots, the version starts after the first dot it parses from.
+func replacedVersion(bundles []operator.Bundle, replaces string) (semver.Version, bool) {
+	for _, bundle := range bundles {
+		if bundle.ClusterServiceVersion.Name != replaces {
+			continue
+		}
+
+		ver, err := semver.ParseTolerant(bundle.Version)
+
+		return ver, err == nil
+	}
+
+	for rest := replaces; ; {
+		_, raw, ok := strings.Cut(rest, ".")
+		if !ok {
+			return semver.Version{}, false
+		}
+
+		if ver, err := semver.ParseTolerant(raw); err == nil {
+			return ver, true
+		}
+
+		rest = raw
+	}
+}
+
+// strandedBundles returns the names and versions of bundles which no
+// newer bundle of the channel replaces, skips or includes in its skip
+// range. Channels without skip ranges are upgraded through 'replaces'
+// alone and are not checked. bundles are ordered from the highest to
+// the lowest version so that the channel head comes first.
+func strandedBundles(bundles []operator.Bundle, ranges map[string]semver.Range) []string {
+	usesRanges := slices.ContainsFunc(bundles, func(b operator.Bundle) bool {
+		_, ok := ranges[b.GetNameVersion()]
+
+		return ok
+	})
+
+	if !usesRanges || len(bundles) < 2 {
+		return nil
+	}
+
+	var res []string
+
+	for i, old := range bundles[1:] {
+		oldVer, err := semver.ParseTolerant(old.Version)
+		if err != nil {
+			continue
+		}
+
+		newer := bundles[:i+1]
+
+		upgradeable := slices.ContainsFunc(newer, func(b operator.Bundle) bool {
+			spec := b.ClusterServiceVersion.Spec
+
+			if spec.Replaces == old.ClusterServiceVersion.Name || slices.Contains(spec.Skips, old.ClusterServiceVersion.Name) {
+				return true
+			}
+
+			rng, ok := ranges[b.GetNameVersion()]
+
+			return ok && rng(oldVer)
+		})
+
+		if !upgradeable {
+			res = append(res, old.GetNameVersion())
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,128 @@
+package am0027
+
+import (
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator/testutils"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestSkipRangeValid(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewSkipRange)
+	tester.TestValidBundles(map[string]types.MetaBundle{
+		"no bundles": {},
+		"replaces only": newMetaBundle(
+			newBundle("1.0.0", "", ""),
+			newBundle("1.1.0", "1.0.0", ""),
+		),
+		"skip range covering all versions": newMetaBundle(
+			newBundle("1.0.0", "", ""),
+			newBundle("1.1.0", "", ""),
+			newBundle("1.2.0", "1.1.0", ">=1.0.0 <1.2.0"),
+		),
+		"replaced bundle not in index": newMetaBundle(
+			newBundle("1.2.0", "1.1.0", ">=1.0.0 <1.2.0"),
+		),
+		"chained skip ranges": newMetaBundle(
+			newBundle("1.0.0", "", ""),
+			newBundle("1.1.0", "", ">=1.0.0 <1.1.0"),
+			newBundle("1.2.0", "", ">=1.1.0 <1.2.0"),
+		),
+	})
+}
+
+func TestSkipRangeInvalid(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewSkipRange)
+	tester.TestCases(map[string]testutils.TestCase{
+		"invalid range": {
+			MetaBundle: newMetaBundle(
+				newBundle("1.0.0", "", ""),
+				newBundle("1.1.0", "1.0.0", ">=1.0.0 <=>1.1.0"),
+			),
+			FailsWith: []string{
+				`bundle "test:1.1.0": olm.skipRange ">=1.0.0 <=>1.1.0" is invalid`,
+			},
+		},
+		"range excluding replaced bundle": {
+			MetaBundle: newMetaBundle(
+				newBundle("1.0.0", "", ""),
+				newBundle("1.1.0", "", ""),
+				newBundle("1.2.0", "1.1.0", ">=1.0.0 <1.1.0"),
+			),
+			FailsWith: []string{
+				`bundle "test:1.2.0": olm.skipRange ">=1.0.0 <1.1.0" excludes version 1.1.0 of the replaced bundle "test.v1.1.0"`,
+			},
+		},
+		"range excluding replaced bundle not in index": {
+			MetaBundle: newMetaBundle(
+				newBundle("1.2.0", "1.1.5", ">=1.0.0 <1.1.0"),
+			),
+			FailsWith: []string{
+				`excludes version 1.1.5 of the replaced bundle "test.v1.1.5"`,
+			},
+		},
+		"range excluding replaced bundle of dotted package": {
+			MetaBundle: newMetaBundle(
+				withReplaces(newBundle("1.2.0", "", ">=1.0.0 <1.1.0"), "addon.operator.v1.1.5"),
+			),
+			FailsWith: []string{
+				`excludes version 1.1.5 of the replaced bundle "addon.operator.v1.1.5"`,
+			},
+		},
+		"gap in skip ranges": {
+			MetaBundle: newMetaBundle(
+				newBundle("1.0.0", "", ""),
+				newBundle("1.1.0", "", ""),
+				newBundle("1.2.0", "", ">=1.1.0 <1.2.0"),
+			),
+			FailsWith: []string{
+				`channel "alpha": bundle "test:1.0.0" can not be upgraded`,
+			},
+		},
+	})
+}
+
+func newMetaBundle(bundles ...operator.Bundle) types.MetaBundle {
+	return *types.NewMetaBundleBuilder().
+		WithBundles(bundles...).
+		Build()
+}
+
+func newBundle(version, replaces, skipRange string) operator.Bundle {
+	var csv operator.ClusterServiceVersion
+
+	csv.Name = "test.v" + version
+
+	if replaces != "" {
+		csv.Spec.Replaces = "test.v" + replaces
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("operators.coreos.com/v1alpha1")
+	obj.SetKind("ClusterServiceVersion")
+	obj.SetName(csv.Name)
+
+	if skipRange != "" {
+		obj.SetAnnotations(map[string]string{skipRangeAnnotation: skipRange})
+	}
+
+	return operator.Bundle{
+		Name:                  "test",
+		Version:               version,
+		Channels:              []string{"alpha"},
+		ClusterServiceVersion: csv,
+		Manifests:             []*unstructured.Unstructured{obj},
+	}
+}
+
+func withReplaces(bundle operator.Bundle, replaces string) operator.Bundle {
+	bundle.ClusterServiceVersion.Spec.Replaces = replaces
+
+	return bundle
+}
@@ -0,0 +1,120 @@
+package am0028
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+func init() {
+	validator.Register(NewCSVAnnotations)
+}
+
+const (
+	code = 28
+	name = "csv_annotations"
+	desc = "Ensure the CSV declares a valid capability level and at least one category"
+)
+
+const (
+	capabilitiesAnnotation = "capabilities"
+	categoriesAnnotation   = "categories"
+)
+
+// capabilityLevels are the operator capability levels known to OLM.
+var capabilityLevels = []string{
+	"Basic Install",
+	"Seamless Upgrades",
+	"Full Lifecycle",
+	"Deep Insights",
+	"Auto Pilot",
+}
+
+var docs = validator.Docs{
+	Details: "The OCM console renders the head bundle CSV using its 'capabilities' and 'categories' annotations. The 'capabilities' annotation must be one " +
+		"of the OLM capability levels (" + strings.Join(capabilityLevels, ", ") + ") and 'categories' must list at " +
+		"least one comma separated category.",
+	ExampleFailures: []string{
+		"bundle \"reference-addon:0.1.6\": capabilities annotation \"Basic\" is not one of 'Basic Install', 'Seamless Upgrades', 'Full Lifecycle', 'Deep Insights', 'Auto Pilot'",
+		"bundle \"reference-addon:0.1.6\": categories annotation is empty",
+	},
+	Remediation: "Set the 'capabilities' annotation of the CSV to the capability level of the operator and list " +
+		"its categories (e.g. 'Monitoring, Logging & Tracing') in the 'categories' annotation.",
+	Tags: []string{validator.TagBundle},
+}
+
+func NewCSVAnnotations(deps validator.Dependencies) (validator.Validator, error) {
+	base, err := validator.NewBase(
+		code,
+		validator.BaseName(name),
+		validator.BaseDesc(desc),
+		validator.BaseDocs(docs),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CSVAnnotations{
+		Base: base,
+	}, nil
+}
+
+type CSVAnnotations struct {
+	*validator.Base
+}
+
+func (c *CSVAnnotations) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
+	head, ok := mb.HeadBundle()
+	if !ok {
+		return c.Success()
+	}
+
+	nameVer := head.GetNameVersion()
+	annotations := head.CSVAnnotations()
+
+	var msgs []string
+
+	capability, ok := annotations[capabilitiesAnnotation]
+	if !ok {
+		msgs = append(msgs, fmt.Sprintf("bundle %q: %s annotation is missing", nameVer, capabilitiesAnnotation))
+	} else if !isCapabilityLevel(capability) {
+		msgs = append(msgs, fmt.Sprintf("bundle %q: %s annotation %q is not one of '%s'",
+			nameVer, capabilitiesAnnotation, capability, strings.Join(capabilityLevels, "', '"),
+		))
+	}
+
+	if !hasCategory(annotations[categoriesAnnotation]) {
+		msgs = append(msgs, fmt.Sprintf("bundle %q: %s annotation is empty", nameVer, categoriesAnnotation))
+	}
+
+	if len(msgs) > 0 {
+		return c.Fail(msgs...)
+	}
+
+	return c.Success()
+}
+
+func isCapabilityLevel(capability string) bool {
+	for _, level := range capabilityLevels {
+		if capability == level {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasCategory reports whether the comma separated categories
+// contain at least one non-blank entry.
+func hasCategory(categories string) bool {
+	for _, category := range strings.Split(categories, ",") {
+		if strings.TrimSpace(category) != "" {
+			return true
+		}
+	}
+
+	return false
+}
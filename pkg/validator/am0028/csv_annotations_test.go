@@ -0,0 +1,88 @@
+package am0028
+
+import (
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator/testutils"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestCSVAnnotationsValid(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewCSVAnnotations)
+	tester.TestValidBundles(map[string]types.MetaBundle{
+		"no bundles": {},
+		"single category": newMetaBundle(newBundle("1.0.0", map[string]string{
+			capabilitiesAnnotation: "Basic Install",
+			categoriesAnnotation:   "Monitoring",
+		})),
+		"multiple categories": newMetaBundle(newBundle("1.0.0", map[string]string{
+			capabilitiesAnnotation: "Deep Insights",
+			categoriesAnnotation:   "Monitoring, Logging & Tracing",
+		})),
+		"older bundle without annotations": newMetaBundle(
+			newBundle("1.0.0", nil),
+			newBundle("1.1.0", map[string]string{
+				capabilitiesAnnotation: "Auto Pilot",
+				categoriesAnnotation:   "Security",
+			}),
+		),
+	})
+}
+
+func TestCSVAnnotationsInvalid(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewCSVAnnotations)
+	tester.TestCases(map[string]testutils.TestCase{
+		"missing annotations": {
+			MetaBundle: newMetaBundle(newBundle("1.0.0", nil)),
+			FailsWith: []string{
+				`bundle "test:1.0.0": capabilities annotation is missing`,
+				`bundle "test:1.0.0": categories annotation is empty`,
+			},
+		},
+		"unknown capability level": {
+			MetaBundle: newMetaBundle(newBundle("1.0.0", map[string]string{
+				capabilitiesAnnotation: "Basic",
+				categoriesAnnotation:   "Monitoring",
+			})),
+			FailsWith: []string{
+				`bundle "test:1.0.0": capabilities annotation "Basic" is not one of`,
+			},
+		},
+		"blank categories": {
+			MetaBundle: newMetaBundle(newBundle("1.0.0", map[string]string{
+				capabilitiesAnnotation: "Full Lifecycle",
+				categoriesAnnotation:   " , ",
+			})),
+			FailsWith: []string{
+				`bundle "test:1.0.0": categories annotation is empty`,
+			},
+		},
+	})
+}
+
+func newMetaBundle(bundles ...operator.Bundle) types.MetaBundle {
+	return *types.NewMetaBundleBuilder().
+		WithBundles(bundles...).
+		Build()
+}
+
+func newBundle(version string, annotations map[string]string) operator.Bundle {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("operators.coreos.com/v1alpha1")
+	obj.SetKind("ClusterServiceVersion")
+	obj.SetName("test.v" + version)
+	obj.SetAnnotations(annotations)
+
+	return operator.Bundle{
+		Name:      "test",
+		Version:   version,
+		Channels:  []string{"alpha"},
+		Manifests: []*unstructured.Unstructured{obj},
+	}
+}
@@ -0,0 +1,214 @@
+package am0029
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	ocmv1 "github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+func init() {
+	validator.Register(NewMinKubeVersion)
+}
+
+const (
+	code = 29
+	name = "min_kube_version"
+	desc = "Ensure the CSV minKubeVersion is satisfied by every OCP version the addon declares support for"
+)
+
+// clusterVersionKey is the addOnRequirement data key
+// constraining the OCP version of clusters.
+const clusterVersionKey = "version.raw_id"
+
+// ocpKubeVersions maps OCP minor versions to the
+// Kubernetes minor version they ship with.
+var ocpKubeVersions = []struct {
+	OCP, Kube semver.Version
+}{
+	{OCP: semver.MustParse("4.1.0"), Kube: semver.MustParse("1.13.0")},
+	{OCP: semver.MustParse("4.2.0"), Kube: semver.MustParse("1.14.0")},
+	{OCP: semver.MustParse("4.3.0"), Kube: semver.MustParse("1.16.0")},
+	{OCP: semver.MustParse("4.4.0"), Kube: semver.MustParse("1.17.0")},
+	{OCP: semver.MustParse("4.5.0"), Kube: semver.MustParse("1.18.0")},
+	{OCP: semver.MustParse("4.6.0"), Kube: semver.MustParse("1.19.0")},
+	{OCP: semver.MustParse("4.7.0"), Kube: semver.MustParse("1.20.0")},
+	{OCP: semver.MustParse("4.8.0"), Kube: semver.MustParse("1.21.0")},
+	{OCP: semver.MustParse("4.9.0"), Kube: semver.MustParse("1.22.0")},
+	{OCP: semver.MustParse("4.10.0"), Kube: semver.MustParse("1.23.0")},
+	{OCP: semver.MustParse("4.11.0"), Kube: semver.MustParse("1.24.0")},
+	{OCP: semver.MustParse("4.12.0"), Kube: semver.MustParse("1.25.0")},
+	{OCP: semver.MustParse("4.13.0"), Kube: semver.MustParse("1.26.0")},
+	{OCP: semver.MustParse("4.14.0"), Kube: semver.MustParse("1.27.0")},
+	{OCP: semver.MustParse("4.15.0"), Kube: semver.MustParse("1.28.0")},
+	{OCP: semver.MustParse("4.16.0"), Kube: semver.MustParse("1.29.0")},
+	{OCP: semver.MustParse("4.17.0"), Kube: semver.MustParse("1.30.0")},
+	{OCP: semver.MustParse("4.18.0"), Kube: semver.MustParse("1.31.0")},
+	{OCP: semver.MustParse("4.19.0"), Kube: semver.MustParse("1.32.0")},
+	{OCP: semver.MustParse("4.20.0"), Kube: semver.MustParse("1.33.0")},
+}
+
+var docs = validator.Docs{
+	Details: "Cluster addOnRequirements constraining '" + clusterVersionKey + "' declare the OCP versions an addon " +
+		"supports. The minKubeVersion of the head bundle CSV must not be higher than the Kubernetes version shipped " +
+		"with any of these OCP versions as OLM would refuse to install the bundle on such clusters. Versions are " +
+		"compared by their major and minor version only.",
+	ExampleFailures: []string{
+		"bundle \"reference-addon:0.1.6\": minKubeVersion 1.25.0 is higher than Kubernetes 1.24 of supported OCP version 4.11",
+		"addOnRequirements[0]: requirement \"cluster-version-req\" has invalid version.raw_id \"~> 4.10\"",
+	},
+	Remediation: "Lower the minKubeVersion of the CSV or restrict the cluster version addOnRequirement " +
+		"to the OCP versions which ship a compatible Kubernetes version.",
+	Tags: []string{validator.TagBundle, validator.TagConsistency, validator.TagImageSet},
+}
+
+func NewMinKubeVersion(deps validator.Dependencies) (validator.Validator, error) {
+	base, err := validator.NewBase(
+		code,
+		validator.BaseName(name),
+		validator.BaseDesc(desc),
+		validator.BaseDocs(docs),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MinKubeVersion{
+		Base: base,
+	}, nil
+}
+
+type MinKubeVersion struct {
+	*validator.Base
+}
+
+func (m *MinKubeVersion) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
+	head, ok := mb.HeadBundle()
+	if !ok || head.ClusterServiceVersion.Spec.MinKubeVersion == "" {
+		return m.Success()
+	}
+
+	var findings []validator.Finding
+
+	var ranges []semver.Range
+
+	if meta := mb.AddonMeta; meta != nil && meta.AddOnRequirements != nil {
+		for i, req := range *meta.AddOnRequirements {
+			raw, ok := clusterVersionConstraint(req)
+			if !ok {
+				continue
+			}
+
+			rng, err := parseVersionRange(raw)
+			if err != nil {
+				findings = append(findings, validator.FindingAt(mb, fmt.Sprintf("addOnRequirements[%d]", i),
+					fmt.Sprintf("requirement %q has invalid %s %q: %v", req.ID, clusterVersionKey, raw, err),
+				))
+
+				continue
+			}
+
+			ranges = append(ranges, rng)
+		}
+	}
+
+	if len(ranges) > 0 {
+		nameVer := head.GetNameVersion()
+		rawMin := head.ClusterServiceVersion.Spec.MinKubeVersion
+
+		minKube, err := semver.ParseTolerant(rawMin)
+		if err != nil {
+			findings = append(findings, validator.Finding{
+				Message: fmt.Sprintf("bundle %q has invalid minKubeVersion %q: %v", nameVer, rawMin, err),
+			})
+		} else {
+			for _, v := range ocpKubeVersions {
+				if !supportsMinor(ranges, v.OCP) || !newerMinor(minKube, v.Kube) {
+					continue
+				}
+
+				findings = append(findings, validator.Finding{
+					Message: fmt.Sprintf("bundle %q: minKubeVersion %s is higher than Kubernetes %d.%d of supported OCP version %d.%d",
+						nameVer, minKube, v.Kube.Major, v.Kube.Minor, v.OCP.Major, v.OCP.Minor,
+					),
+				})
+			}
+		}
+	}
+
+	if len(findings) > 0 {
+		return m.FailWith(findings...)
+	}
+
+	return m.Success()
+}
+
+// clusterVersionConstraint returns the OCP version constraint
+// of req if it is an enabled cluster requirement.
+func clusterVersionConstraint(req ocmv1.AddOnRequirement) (string, bool) {
+	if !req.Enabled || req.Resource != ocmv1.AddOnRequirementResourceTypeCluster {
+		return "", false
+	}
+
+	data, ok := req.Data[clusterVersionKey]
+	if !ok {
+		return "", false
+	}
+
+	var raw string
+	if err := json.Unmarshal(data.Raw, &raw); err != nil {
+		return "", false
+	}
+
+	return raw, true
+}
+
+var shortVersion = regexp.MustCompile(`\d+(\.\d+)*`)
+
+// parseVersionRange parses a version constraint as used by OCM
+// (e.g. '> 4.6') completing versions without a patch number.
+func parseVersionRange(raw string) (semver.Range, error) {
+	expanded := shortVersion.ReplaceAllStringFunc(raw, func(v string) string {
+		for strings.Count(v, ".") < 2 {
+			v += ".0"
+		}
+
+		return v
+	})
+
+	return semver.ParseRange(expanded)
+}
+
+// maxPatch is used to check whether any patch release of
+// an OCP minor version satisfies a version constraint.
+const maxPatch = 999
+
+// supportsMinor reports whether the first or a late patch
+// release of the minor version ocp satisfies all ranges.
+func supportsMinor(ranges []semver.Range, ocp semver.Version) bool {
+	first := semver.Version{Major: ocp.Major, Minor: ocp.Minor}
+	last := semver.Version{Major: ocp.Major, Minor: ocp.Minor, Patch: maxPatch}
+
+	for _, rng := range ranges {
+		if !rng(first) && !rng(last) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// newerMinor reports whether a has a higher major or minor version than b.
+func newerMinor(a, b semver.Version) bool {
+	if a.Major != b.Major {
+		return a.Major > b.Major
+	}
+
+	return a.Minor > b.Minor
+}
@@ -0,0 +1,96 @@
+package am0029
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	ocmv1 "github.com/mt-sre/addon-metadata-operator/pkg/ocm/v1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator/testutils"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func TestMinKubeVersionValid(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewMinKubeVersion)
+	tester.TestValidBundles(map[string]types.MetaBundle{
+		"no bundles": *types.NewMetaBundleBuilder().
+			WithMeta(&v1alpha1.AddonMetadataSpec{
+				AddOnRequirements: &[]ocmv1.AddOnRequirement{clusterVersionReq("> 4.6", true)},
+			}).
+			Build(),
+		"no minKubeVersion": newMetaBundle("",
+			clusterVersionReq(">= 4.6", true),
+		),
+		"no cluster version requirement": newMetaBundle("1.27.0"),
+		"compatible minimum OCP version": newMetaBundle("1.24.0",
+			clusterVersionReq(">= 4.11", true),
+		),
+		"patch versions are ignored": newMetaBundle("1.24.5",
+			clusterVersionReq(">= 4.11.0", true),
+		),
+		"excluded OCP minor version": newMetaBundle("1.25.0",
+			clusterVersionReq("> 4.11.999", true),
+		),
+		"disabled requirement": newMetaBundle("1.27.0",
+			clusterVersionReq(">= 4.6", false),
+		),
+	})
+}
+
+func TestMinKubeVersionInvalid(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewMinKubeVersion)
+	tester.TestCases(map[string]testutils.TestCase{
+		"unsupported OCP versions": {
+			MetaBundle: newMetaBundle("1.25.0", clusterVersionReq("> 4.10 < 4.13", true)),
+			FailsWith: []string{
+				`bundle "test:1.0.0": minKubeVersion 1.25.0 is higher than Kubernetes 1.23 of supported OCP version 4.10`,
+				`bundle "test:1.0.0": minKubeVersion 1.25.0 is higher than Kubernetes 1.24 of supported OCP version 4.11`,
+			},
+		},
+		"invalid minKubeVersion": {
+			MetaBundle: newMetaBundle("latest", clusterVersionReq(">= 4.11", true)),
+			FailsWith: []string{
+				`bundle "test:1.0.0" has invalid minKubeVersion "latest"`,
+			},
+		},
+		"invalid version constraint": {
+			MetaBundle: newMetaBundle("1.24.0", clusterVersionReq("~> 4.11", true)),
+			FailsWith: []string{
+				`requirement "cluster-version" has invalid version.raw_id "~> 4.11"`,
+			},
+		},
+	})
+}
+
+func newMetaBundle(minKubeVersion string, reqs ...ocmv1.AddOnRequirement) types.MetaBundle {
+	var csv operator.ClusterServiceVersion
+
+	csv.Name = "test.v1.0.0"
+	csv.Spec.MinKubeVersion = minKubeVersion
+
+	return *types.NewMetaBundleBuilder().
+		WithMeta(&v1alpha1.AddonMetadataSpec{AddOnRequirements: &reqs}).
+		WithBundles(operator.Bundle{
+			Name:                  "test",
+			Version:               "1.0.0",
+			ClusterServiceVersion: csv,
+		}).
+		Build()
+}
+
+func clusterVersionReq(constraint string, enabled bool) ocmv1.AddOnRequirement {
+	return ocmv1.AddOnRequirement{
+		ID:       "cluster-version",
+		Resource: ocmv1.AddOnRequirementResourceTypeCluster,
+		Data: ocmv1.AddOnRequirementData{
+			clusterVersionKey: apiextensionsv1.JSON{Raw: []byte(fmt.Sprintf("%q", constraint))},
+		},
+		Enabled: enabled,
+	}
+}
@@ -0,0 +1,157 @@
+package am0030
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/kube"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func init() {
+	validator.Register(NewSubscriptionConfig)
+}
+
+const (
+	code = 30
+	name = "subscription_config"
+	desc = "Ensure the env and secrets of the addon config are valid and do not override variables of the CSV deployments"
+)
+
+var docs = validator.Docs{
+	Details: "The config of an addon is passed to the OLM subscription. Its env vars must have valid and unique names " +
+		"which are not already set by a container of the head bundle CSV deployments as the config would silently " +
+		"override them. The names and destinationSecretNames of its secrets must be valid kubernetes secret names.",
+	ExampleFailures: []string{
+		"config.env[0]: \"1_LOG_LEVEL\" is not a valid kubernetes environment variable name: a valid environment variable name must consist of alphabetic characters, digits, '_', '-', or '.', and must not start with a digit",
+		"config.env[1]: env var \"LOG_LEVEL\" is defined more than once",
+		"config.env[0]: env var \"LOG_LEVEL\" overrides the value set by container \"manager\" of deployment \"reference-addon\" in bundle \"reference-addon:0.1.6\"",
+		"config.secrets[0].destinationSecretName: \"Pull_Secret\" is not a valid kubernetes secret name: a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character",
+	},
+	Remediation: "Fix the reported names, remove duplicated env vars and rename env vars clashing with the CSV " +
+		"deployments or change their default value in the CSV instead.",
+	Tags: []string{validator.TagBundle, validator.TagConsistency, validator.TagImageSet},
+}
+
+func NewSubscriptionConfig(deps validator.Dependencies) (validator.Validator, error) {
+	base, err := validator.NewBase(
+		code,
+		validator.BaseName(name),
+		validator.BaseDesc(desc),
+		validator.BaseDocs(docs),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SubscriptionConfig{
+		Base: base,
+	}, nil
+}
+
+type SubscriptionConfig struct {
+	*validator.Base
+}
+
+func (s *SubscriptionConfig) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
+	if mb.AddonMeta == nil || mb.AddonMeta.Config == nil {
+		return s.Success()
+	}
+
+	config := mb.AddonMeta.Config
+
+	var findings []validator.Finding
+
+	if config.Env != nil {
+		var csvEnv map[string]envSource
+
+		if head, ok := mb.HeadBundle(); ok {
+			csvEnv = deploymentEnv(head)
+		}
+
+		seen := make(map[string]struct{}, len(*config.Env))
+
+		for i, env := range *config.Env {
+			path := fmt.Sprintf("config.env[%d]", i)
+
+			if msg := kube.IsValidk8sEnvVarName(env.Name); msg != "" {
+				findings = append(findings, validator.FindingAt(mb, path, msg))
+			}
+
+			if _, ok := seen[env.Name]; ok {
+				findings = append(findings, validator.FindingAt(mb, path,
+					fmt.Sprintf("env var %q is defined more than once", env.Name),
+				))
+			}
+
+			seen[env.Name] = struct{}{}
+
+			if src, ok := csvEnv[env.Name]; ok {
+				findings = append(findings, validator.FindingAt(mb, path,
+					fmt.Sprintf("env var %q overrides the value set by %s", env.Name, src),
+				))
+			}
+		}
+	}
+
+	if config.Secrets != nil {
+		for i, secret := range *config.Secrets {
+			path := fmt.Sprintf("config.secrets[%d]", i)
+
+			if msg := kube.IsValidk8sSecretName(secret.Name); msg != "" {
+				findings = append(findings, validator.FindingAt(mb, path, msg))
+			}
+
+			if dest := secret.DestinationSecretName; dest != nil {
+				if msg := kube.IsValidk8sSecretName(*dest); msg != "" {
+					findings = append(findings, validator.FindingAt(mb, path+".destinationSecretName", msg))
+				}
+			}
+		}
+	}
+
+	if len(findings) > 0 {
+		return s.FailWith(findings...)
+	}
+
+	return s.Success()
+}
+
+type envSource struct {
+	Bundle, Deployment, Container string
+}
+
+func (e envSource) String() string {
+	return fmt.Sprintf("container %q of deployment %q in bundle %q", e.Container, e.Deployment, e.Bundle)
+}
+
+// deploymentEnv returns the env vars set by the containers of the
+// CSV deployments of bundle mapped to the first container setting them.
+func deploymentEnv(bundle operator.Bundle) map[string]envSource {
+	res := make(map[string]envSource)
+
+	for _, deploymentSpec := range bundle.ClusterServiceVersion.Spec.InstallStrategy.StrategySpec.DeploymentSpecs {
+		podSpec := deploymentSpec.Spec.Template.Spec
+
+		for _, containers := range [][]corev1.Container{podSpec.InitContainers, podSpec.Containers} {
+			for _, container := range containers {
+				for _, env := range container.Env {
+					if _, ok := res[env.Name]; ok {
+						continue
+					}
+
+					res[env.Name] = envSource{
+						Bundle:     bundle.GetNameVersion(),
+						Deployment: deploymentSpec.Name,
+						Container:  container.Name,
+					}
+				}
+			}
+		}
+	}
+
+	return res
+}
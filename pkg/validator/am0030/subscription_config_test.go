@@ -0,0 +1,112 @@
+package am0030
+
+import (
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	mtsrev1 "github.com/mt-sre/addon-metadata-operator/pkg/mtsre/v1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator/testutils"
+	opsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestSubscriptionConfigValid(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewSubscriptionConfig)
+	tester.TestValidBundles(map[string]types.MetaBundle{
+		"no config": *types.NewMetaBundleBuilder().
+			WithMeta(&v1alpha1.AddonMetadataSpec{}).
+			Build(),
+		"empty config": newMetaBundle(&mtsrev1.Config{}),
+		"env and secrets": newMetaBundle(&mtsrev1.Config{
+			Env: &[]mtsrev1.EnvItem{
+				{Name: "LOG_LEVEL", Value: "debug"},
+				{Name: "my.env-name", Value: "value"},
+			},
+			Secrets: &[]mtsrev1.Secret{
+				{Name: "pull-secret", Type: "kubernetes.io/dockerconfigjson", DestinationSecretName: ptr("addon-pull-secret")},
+			},
+		}),
+		"env not set by CSV": newMetaBundle(&mtsrev1.Config{
+			Env: &[]mtsrev1.EnvItem{{Name: "LOG_LEVEL", Value: "debug"}},
+		}, newBundle(corev1.EnvVar{Name: "WATCH_NAMESPACE"})),
+	})
+}
+
+func TestSubscriptionConfigInvalid(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewSubscriptionConfig)
+	tester.TestCases(map[string]testutils.TestCase{
+		"invalid env var name": {
+			MetaBundle: newMetaBundle(&mtsrev1.Config{
+				Env: &[]mtsrev1.EnvItem{{Name: "1_LOG_LEVEL", Value: "debug"}},
+			}),
+			FailsWith: []string{
+				`"1_LOG_LEVEL" is not a valid kubernetes environment variable name`,
+			},
+		},
+		"duplicate env var": {
+			MetaBundle: newMetaBundle(&mtsrev1.Config{
+				Env: &[]mtsrev1.EnvItem{
+					{Name: "LOG_LEVEL", Value: "debug"},
+					{Name: "LOG_LEVEL", Value: "info"},
+				},
+			}),
+			FailsWith: []string{
+				`env var "LOG_LEVEL" is defined more than once`,
+			},
+		},
+		"env var set by CSV": {
+			MetaBundle: newMetaBundle(&mtsrev1.Config{
+				Env: &[]mtsrev1.EnvItem{{Name: "LOG_LEVEL", Value: "debug"}},
+			}, newBundle(corev1.EnvVar{Name: "LOG_LEVEL", Value: "info"})),
+			FailsWith: []string{
+				`env var "LOG_LEVEL" overrides the value set by container "manager" of deployment "test" in bundle "test:1.0.0"`,
+			},
+		},
+		"invalid secret names": {
+			MetaBundle: newMetaBundle(&mtsrev1.Config{
+				Secrets: &[]mtsrev1.Secret{
+					{Name: "Pull_Secret", Type: "kubernetes.io/dockerconfigjson"},
+					{Name: "pull-secret", Type: "kubernetes.io/dockerconfigjson", DestinationSecretName: ptr("addon_pull_secret")},
+				},
+			}),
+			FailsWith: []string{
+				`"Pull_Secret" is not a valid kubernetes secret name`,
+				`"addon_pull_secret" is not a valid kubernetes secret name`,
+			},
+		},
+	})
+}
+
+func newMetaBundle(config *mtsrev1.Config, bundles ...operator.Bundle) types.MetaBundle {
+	return *types.NewMetaBundleBuilder().
+		WithMeta(&v1alpha1.AddonMetadataSpec{Config: config}).
+		WithBundles(bundles...).
+		Build()
+}
+
+func newBundle(env ...corev1.EnvVar) operator.Bundle {
+	var csv operator.ClusterServiceVersion
+
+	csv.Name = "test.v1.0.0"
+	csv.Spec.InstallStrategy.StrategySpec.DeploymentSpecs = []opsv1alpha1.StrategyDeploymentSpec{
+		{Name: "test"},
+	}
+
+	csv.Spec.InstallStrategy.StrategySpec.DeploymentSpecs[0].Spec.Template.Spec.Containers = []corev1.Container{
+		{Name: "manager", Env: env},
+	}
+
+	return operator.Bundle{
+		Name:                  "test",
+		Version:               "1.0.0",
+		ClusterServiceVersion: csv,
+	}
+}
+
+func ptr(s string) *string { return &s }
@@ -0,0 +1,97 @@
+package am0031
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+)
+
+func init() {
+	validator.Register(NewDescription)
+}
+
+const (
+	code = 31
+	name = "description"
+	desc = "Ensure the addon description is complete"
+)
+
+// minDescriptionLength is the number of characters
+// a description needs to explain what an addon does.
+const minDescriptionLength = 20
+
+// placeholderRegexp matches words marking descriptions which were not written yet.
+var placeholderRegexp = regexp.MustCompile(`(?i)\b(todo|tbd|fixme|lorem ipsum)\b`)
+
+var docs = validator.Docs{
+	Details: "The description is shown to customers in the OCM console. " +
+		fmt.Sprintf("It must be at least %d characters long and must not contain placeholders such as ", minDescriptionLength) +
+		"'TODO' or 'TBD'. Descriptions which do not end with a full sentence are reported as info.",
+	ExampleFailures: []string{
+		"description of reference-addon is shorter than 20 characters",
+		"description of reference-addon contains the placeholder \"TODO\"",
+		"description of reference-addon does not end with '.', '!' or '?'",
+	},
+	Remediation: "Describe what the addon provides in full sentences.",
+	Severity:    validator.SeverityWarning,
+}
+
+func NewDescription(deps validator.Dependencies) (validator.Validator, error) {
+	base, err := validator.NewBase(
+		code,
+		validator.BaseName(name),
+		validator.BaseDesc(desc),
+		validator.BaseDocs(docs),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Description{
+		Base: base,
+	}, nil
+}
+
+type Description struct {
+	*validator.Base
+}
+
+func (d *Description) Run(ctx context.Context, mb types.MetaBundle) validator.Result {
+	var (
+		id          = mb.AddonMeta.ID
+		description = strings.TrimSpace(mb.AddonMeta.Description)
+		findings    []validator.Finding
+	)
+
+	if utf8.RuneCountInString(description) < minDescriptionLength {
+		findings = append(findings, validator.FindingAt(mb, "description",
+			fmt.Sprintf("description of %s is shorter than %d characters", id, minDescriptionLength),
+		))
+	}
+
+	for _, p := range placeholderRegexp.FindAllString(description, -1) {
+		findings = append(findings, validator.FindingAt(mb, "description",
+			fmt.Sprintf("description of %s contains the placeholder %q", id, p),
+		))
+	}
+
+	if description != "" && !strings.ContainsAny(description[len(description)-1:], ".!?") {
+		f := validator.FindingAt(mb, "description",
+			fmt.Sprintf("description of %s does not end with '.', '!' or '?'", id),
+		)
+		f.Severity = validator.SeverityInfo
+
+		findings = append(findings, f)
+	}
+
+	if len(findings) > 0 {
+		return d.FailWith(findings...)
+	}
+
+	return d.Success()
+}
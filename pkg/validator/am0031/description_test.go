@@ -0,0 +1,66 @@
+package am0031
+
+import (
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescription(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewDescription)
+	tester.TestCases(map[string]testutils.TestCase{
+		"complete description": {
+			MetaBundle: newMetaBundle("Reference Addon is a real Addon, created to validate and demonstrate the Addons Flow."),
+		},
+		"words containing placeholders": {
+			MetaBundle: newMetaBundle("Keeps track of todos and other tasks of your cluster."),
+		},
+		"short description": {
+			MetaBundle: newMetaBundle("An addon."),
+			FailsWith:  []string{"description of test-addon is shorter than 20 characters"},
+		},
+		"placeholders": {
+			MetaBundle: newMetaBundle("TODO: describe the addon; tbd."),
+			FailsWith: []string{
+				`description of test-addon contains the placeholder "TODO"`,
+				`description of test-addon contains the placeholder "tbd"`,
+			},
+		},
+		"incomplete sentence": {
+			MetaBundle: newMetaBundle("Install and Test the OCM Add-On workflow"),
+			FailsWith:  []string{"description of test-addon does not end with '.', '!' or '?'"},
+		},
+	})
+}
+
+func TestDescriptionSeverities(t *testing.T) {
+	t.Parallel()
+
+	tester := testutils.NewValidatorTester(t, NewDescription)
+
+	res := tester.TestSingleBundle(newMetaBundle("TODO"))
+	assert.Equal(t, validator.SeverityWarning, res.Severity)
+	assert.False(t, res.IsBlocking())
+
+	res = tester.TestSingleBundle(newMetaBundle("Install and Test the OCM Add-On workflow"))
+	if assert.Len(t, res.Findings, 1) {
+		assert.Equal(t, validator.SeverityInfo, res.SeverityOf(res.Findings[0]))
+	}
+
+	assert.False(t, res.IsBlockingAt(validator.SeverityWarning))
+}
+
+func newMetaBundle(description string) types.MetaBundle {
+	return types.MetaBundle{
+		AddonMeta: &v1alpha1.AddonMetadataSpec{
+			ID:          "test-addon",
+			Description: description,
+		},
+	}
+}
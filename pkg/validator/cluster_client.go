@@ -0,0 +1,112 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ClusterClient submits objects to a live cluster without persisting them.
+type ClusterClient interface {
+	// DryRunApply performs a server-side apply of obj using dry-run
+	// so that the object passes through schema validation and
+	// admission without being persisted. Namespaced objects without
+	// a namespace are applied to the client's default namespace.
+	DryRunApply(ctx context.Context, obj *unstructured.Unstructured) error
+}
+
+// NewClusterClient returns a ClusterClientImpl for the cluster
+// described by cfg or an error if the client cannot be created.
+func NewClusterClient(cfg *rest.Config, opts ...ClusterClientOption) (*ClusterClientImpl, error) {
+	var ccCfg ClusterClientConfig
+
+	ccCfg.Option(opts...)
+	ccCfg.Default()
+
+	c, err := client.New(cfg, client.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("creating client: %w", err)
+	}
+
+	return &ClusterClientImpl{
+		cfg:    ccCfg,
+		client: c,
+	}, nil
+}
+
+type ClusterClientImpl struct {
+	cfg    ClusterClientConfig
+	client client.Client
+}
+
+func (c *ClusterClientImpl) DryRunApply(ctx context.Context, obj *unstructured.Unstructured) error {
+	obj = obj.DeepCopy()
+
+	// fields managed by the API server are rejected by server-side apply
+	obj.SetResourceVersion("")
+	obj.SetManagedFields(nil)
+	obj.SetUID("")
+
+	namespaced, err := c.client.IsObjectNamespaced(obj)
+	if err != nil {
+		return err
+	}
+
+	if namespaced && obj.GetNamespace() == "" {
+		obj.SetNamespace(c.cfg.Namespace)
+	} else if !namespaced {
+		obj.SetNamespace("")
+	}
+
+	return c.client.Patch(ctx, obj, client.Apply,
+		client.DryRunAll,
+		client.ForceOwnership,
+		client.FieldOwner(c.cfg.FieldOwner),
+	)
+}
+
+type ClusterClientConfig struct {
+	// FieldOwner is the field manager used for applies.
+	FieldOwner string
+	// Namespace is applied to namespaced objects without a namespace.
+	Namespace string
+}
+
+func (c *ClusterClientConfig) Option(opts ...ClusterClientOption) {
+	for _, opt := range opts {
+		opt.ConfigureClusterClient(c)
+	}
+}
+
+func (c *ClusterClientConfig) Default() {
+	if c.FieldOwner == "" {
+		c.FieldOwner = "mtcli"
+	}
+
+	if c.Namespace == "" {
+		c.Namespace = "default"
+	}
+}
+
+type ClusterClientOption interface {
+	ConfigureClusterClient(*ClusterClientConfig)
+}
+
+// WithFieldOwner applies the field manager used for applies.
+// Defaults to 'mtcli'.
+type WithFieldOwner string
+
+func (w WithFieldOwner) ConfigureClusterClient(c *ClusterClientConfig) {
+	c.FieldOwner = string(w)
+}
+
+// WithDefaultNamespace applies the namespace used for namespaced
+// objects which do not specify one. Defaults to 'default'.
+type WithDefaultNamespace string
+
+func (w WithDefaultNamespace) ConfigureClusterClient(c *ClusterClientConfig) {
+	c.Namespace = string(w)
+}
@@ -0,0 +1,203 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrDependencyCycle is returned by NewRunner if validators
+// directly or indirectly depend on themselves.
+var ErrDependencyCycle = errors.New("validators depend on each other")
+
+// verifyDependencies returns an error wrapping ErrDependencyCycle if the
+// prerequisites of entries form a cycle. Prerequisites which are not
+// registered are ignored.
+func verifyDependencies(entries map[Code]validatorEntry) error {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make(map[Code]int, len(entries))
+
+	var visit func(code Code, path []Code) error
+
+	visit = func(code Code, path []Code) error {
+		entry, ok := entries[code]
+		if !ok {
+			return nil
+		}
+
+		path = append(path, code)
+
+		switch state[code] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("%w: %s", ErrDependencyCycle, joinCodes(path))
+		}
+
+		state[code] = visiting
+
+		for _, dep := range DocsOf(entry.Validator).DependsOn {
+			if err := visit(dep, path); err != nil {
+				return err
+			}
+		}
+
+		state[code] = visited
+
+		return nil
+	}
+
+	codes := make([]Code, 0, len(entries))
+	for code := range entries {
+		codes = append(codes, code)
+	}
+
+	// reports the same cycle on every run
+	slices.Sort(codes)
+
+	for _, code := range codes {
+		if err := visit(code, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func joinCodes(codes []Code) string {
+	strs := make([]string, 0, len(codes))
+	for _, c := range codes {
+		strs = append(strs, c.String())
+	}
+
+	return strings.Join(strs, " -> ")
+}
+
+// prerequisites tracks the results of the validators of a single run.
+type prerequisites map[Code]*prerequisite
+
+type prerequisite struct {
+	// done is closed once res is set.
+	done chan struct{}
+	res  Result
+}
+
+// finishedPrerequisites returns prerequisites which are already
+// done with the given results.
+func finishedPrerequisites(results ResultList) prerequisites {
+	res := make(prerequisites, len(results))
+
+	for _, r := range results {
+		done := make(chan struct{})
+		close(done)
+
+		res[r.Code] = &prerequisite{done: done, res: r}
+	}
+
+	return res
+}
+
+// add tracks the results of vals which are yet to finish.
+func (p prerequisites) add(vals []Validator) {
+	for _, v := range vals {
+		p[v.Code()] = &prerequisite{done: make(chan struct{})}
+	}
+}
+
+// finish records the result of the validator with the given code and
+// releases its dependents. Results of cancelled validators are empty
+// and thus not successful.
+func (p prerequisites) finish(code Code, res Result) {
+	prereq := p[code]
+	prereq.res = res
+
+	close(prereq.done)
+}
+
+// skipReason waits for the prerequisites of v which are part of the run
+// and returns why v is skipped or an empty string if all of them
+// succeeded. An error is returned if ctx is cancelled while waiting.
+func (p prerequisites) skipReason(ctx context.Context, v Validator) (string, error) {
+	var failed []string
+
+	for _, code := range DocsOf(v).DependsOn {
+		prereq, ok := p[code]
+		if !ok {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-prereq.done:
+		}
+
+		if !prereq.res.IsSuccess() {
+			failed = append(failed, code.String())
+		}
+	}
+
+	if len(failed) == 0 {
+		return "", nil
+	}
+
+	return fmt.Sprintf("prerequisite failed: %s", strings.Join(failed, ", ")), nil
+}
+
+// Stages returns the Validators satisfying all filters grouped in
+// the order Run runs them. Validators of a stage only depend on
+// Validators of earlier stages and may thus run concurrently.
+// Prerequisites which do not satisfy the filters are ignored
+// like they are by Run. Each stage is ordered by code.
+func (r *Runner) Stages(filters ...Filter) [][]Validator {
+	vals := r.GetValidators(filters...)
+
+	selected := make(map[Code]Validator, len(vals))
+	for _, v := range vals {
+		selected[v.Code()] = v
+	}
+
+	stages := make(map[Code]int, len(vals))
+
+	// NewRunner rejects dependency cycles
+	var stageOf func(v Validator) int
+
+	stageOf = func(v Validator) int {
+		if stage, ok := stages[v.Code()]; ok {
+			return stage
+		}
+
+		var stage int
+
+		for _, code := range DocsOf(v).DependsOn {
+			if dep, ok := selected[code]; ok {
+				stage = max(stage, stageOf(dep)+1)
+			}
+		}
+
+		stages[v.Code()] = stage
+
+		return stage
+	}
+
+	var res [][]Validator
+
+	for _, v := range vals {
+		stage := stageOf(v)
+
+		for len(res) <= stage {
+			res = append(res, nil)
+		}
+
+		res[stage] = append(res[stage], v)
+	}
+
+	return res
+}
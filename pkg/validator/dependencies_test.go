@@ -0,0 +1,215 @@
+package validator
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunnerDependencies(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu      sync.Mutex
+		started []Code
+	)
+
+	newDependent := func(code Code, success bool, dependsOn ...Code) Initializer {
+		return func(Dependencies) (Validator, error) {
+			base, err := NewBase(code, BaseDocs(Docs{DependsOn: dependsOn}))
+
+			return &ValidatorMock{
+				Base: base,
+				runner: func(context.Context, types.MetaBundle) Result {
+					mu.Lock()
+					started = append(started, code)
+					mu.Unlock()
+
+					if success {
+						return base.Success()
+					}
+
+					return base.Fail("failed")
+				},
+			}, err
+		}
+	}
+
+	runner, err := NewRunner(context.Background(),
+		// prerequisites must not be starved by waiting dependents
+		WithConcurrency(1),
+		WithInitializers{
+			newDependent(1, false),
+			newDependent(2, true, 1),
+			// skipped prerequisites do not succeed either
+			newDependent(3, true, 2),
+			newDependent(4, true, 5),
+			newDependent(5, true),
+			// prerequisites which are not run are ignored
+			newDependent(6, true, 7),
+			newDependent(7, false),
+		},
+	)
+	require.NoError(t, err)
+
+	var results ResultList
+
+	for res := range runner.Run(context.Background(), types.MetaBundle{}, Not(MatchesCodes(7))) {
+		results = append(results, res)
+	}
+
+	sort.Sort(results)
+
+	require.Len(t, results, 6)
+
+	assert.False(t, results[0].IsSkipped())
+	assert.True(t, results[0].IsBlocking())
+
+	assert.True(t, results[1].IsSkipped())
+	assert.Equal(t, "prerequisite failed: AM0001", results[1].SkipReason)
+	assert.False(t, results[1].IsBlocking())
+
+	assert.True(t, results[2].IsSkipped())
+	assert.Equal(t, "prerequisite failed: AM0002", results[2].SkipReason)
+
+	assert.True(t, results[3].IsSuccess())
+	assert.True(t, results[4].IsSuccess())
+	assert.True(t, results[5].IsSuccess())
+
+	assert.NotContains(t, started, Code(2))
+	assert.NotContains(t, started, Code(3))
+	assert.Less(t, slices.Index(started, 5), slices.Index(started, 4))
+}
+
+func TestRunnerResume(t *testing.T) {
+	t.Parallel()
+
+	newDependent := func(code Code, dependsOn ...Code) Initializer {
+		return func(Dependencies) (Validator, error) {
+			base, err := NewBase(code, BaseDocs(Docs{DependsOn: dependsOn}))
+
+			return &ValidatorMock{
+				Base: base,
+				runner: func(context.Context, types.MetaBundle) Result {
+					return base.Success()
+				},
+			}, err
+		}
+	}
+
+	runner, err := NewRunner(context.Background(), WithInitializers{
+		newDependent(1),
+		newDependent(2, 1),
+		newDependent(3),
+		newDependent(4, 3),
+	})
+	require.NoError(t, err)
+
+	prereq1, err := NewBase(1)
+	require.NoError(t, err)
+
+	prereq3, err := NewBase(3)
+	require.NoError(t, err)
+
+	finished := ResultList{prereq1.Fail("failed"), prereq3.Success()}
+
+	var results ResultList
+
+	for res := range runner.Resume(context.Background(), types.MetaBundle{}, finished) {
+		results = append(results, res)
+	}
+
+	sort.Sort(results)
+
+	// finished validators are not run again
+	require.Len(t, results, 2)
+
+	assert.Equal(t, Code(2), results[0].Code)
+	assert.True(t, results[0].IsSkipped())
+	assert.Equal(t, "prerequisite failed: AM0001", results[0].SkipReason)
+
+	assert.Equal(t, Code(4), results[1].Code)
+	assert.True(t, results[1].IsSuccess())
+}
+
+func TestRunnerDependencyCycles(t *testing.T) {
+	t.Parallel()
+
+	newDependent := func(code Code, dependsOn ...Code) Initializer {
+		return func(Dependencies) (Validator, error) {
+			base, err := NewBase(code, BaseDocs(Docs{DependsOn: dependsOn}))
+
+			return &ValidatorMock{Base: base}, err
+		}
+	}
+
+	_, err := NewRunner(context.Background(), WithInitializers{
+		newDependent(1, 3),
+		newDependent(2, 1),
+		newDependent(3, 2),
+		newDependent(4),
+	})
+	require.ErrorIs(t, err, ErrDependencyCycle)
+	assert.ErrorContains(t, err, "AM0001 -> AM0003 -> AM0002 -> AM0001")
+
+	_, err = NewRunner(context.Background(), WithInitializers{
+		newDependent(1, 1),
+	})
+	require.ErrorIs(t, err, ErrDependencyCycle)
+
+	// unknown prerequisites are ignored
+	_, err = NewRunner(context.Background(), WithInitializers{
+		newDependent(1, 2),
+	})
+	require.NoError(t, err)
+}
+
+func TestRunnerStages(t *testing.T) {
+	t.Parallel()
+
+	newDependent := func(code Code, dependsOn ...Code) Initializer {
+		return func(Dependencies) (Validator, error) {
+			base, err := NewBase(code, BaseDocs(Docs{DependsOn: dependsOn}))
+
+			return &ValidatorMock{Base: base}, err
+		}
+	}
+
+	runner, err := NewRunner(context.Background(), WithInitializers{
+		newDependent(1),
+		newDependent(2, 1),
+		newDependent(3, 2, 5),
+		newDependent(4),
+		newDependent(5, 1),
+		// prerequisites which are not selected are ignored
+		newDependent(6, 7),
+		newDependent(7),
+	})
+	require.NoError(t, err)
+
+	codesOf := func(stages [][]Validator) [][]Code {
+		res := make([][]Code, 0, len(stages))
+
+		for _, stage := range stages {
+			var codes []Code
+
+			for _, v := range stage {
+				codes = append(codes, v.Code())
+			}
+
+			res = append(res, codes)
+		}
+
+		return res
+	}
+
+	assert.Equal(t, [][]Code{{1, 4, 6}, {2, 5}, {3}}, codesOf(runner.Stages(Not(MatchesCodes(7)))))
+	assert.Equal(t, [][]Code{{1, 4, 7}, {2, 5, 6}, {3}}, codesOf(runner.Stages()))
+	assert.Empty(t, runner.Stages(MatchesCodes(8)))
+}
@@ -0,0 +1,318 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Exec validators are executables implementing a JSON protocol so that
+// validators can be written in any language:
+//
+//   - '<executable> describe' prints an ExecDescription to stdout.
+//   - '<executable> run' reads an ExecInput from stdin and prints
+//     an ExecOutput to stdout.
+//
+// Both must exit with status zero; any other status, and output which
+// is no valid JSON, are reported as validator errors including stderr.
+const (
+	ExecArgDescribe = "describe"
+	ExecArgRun      = "run"
+)
+
+// ExecDescription describes an exec validator.
+type ExecDescription struct {
+	// Code like 'AM9001'; it must not be used by another validator.
+	Code            string   `json:"code"`
+	Name            string   `json:"name"`
+	Description     string   `json:"description"`
+	Details         string   `json:"details,omitempty"`
+	Envs            []string `json:"envs,omitempty"`
+	ExampleFailures []string `json:"exampleFailures,omitempty"`
+	Remediation     string   `json:"remediation,omitempty"`
+	DocsURL         string   `json:"docsUrl,omitempty"`
+	Severity        Severity `json:"severity,omitempty"`
+	Tags            []string `json:"tags,omitempty"`
+	// DependsOn lists the codes of prerequisite validators.
+	DependsOn []string `json:"dependsOn,omitempty"`
+	// Replaces lists the codes of deprecated validators
+	// superseded by this one.
+	Replaces []string `json:"replaces,omitempty"`
+}
+
+// ExecInput is the JSON encoding of a types.MetaBundle
+// passed to exec validators.
+type ExecInput struct {
+	Metadata *v1alpha1.AddonMetadataSpec `json:"metadata"`
+	ImageSet *v1alpha1.AddonImageSetSpec `json:"imageSet,omitempty"`
+	Bundles  []ExecBundle                `json:"bundles"`
+}
+
+// ExecBundle is the JSON encoding of an operator bundle.
+type ExecBundle struct {
+	Name        string   `json:"name"`
+	Package     string   `json:"package"`
+	Version     string   `json:"version"`
+	BundleImage string   `json:"bundleImage,omitempty"`
+	Channels    []string `json:"channels,omitempty"`
+	// Manifests holds every object of the bundle's manifests
+	// directory including the ClusterServiceVersion.
+	Manifests []*unstructured.Unstructured `json:"manifests,omitempty"`
+}
+
+// NewExecInput encodes mb for exec validators.
+func NewExecInput(mb types.MetaBundle) ExecInput {
+	in := ExecInput{
+		Metadata: mb.AddonMeta,
+		ImageSet: mb.ImageSet,
+		Bundles:  make([]ExecBundle, 0, len(mb.Bundles)),
+	}
+
+	for _, b := range mb.Bundles {
+		in.Bundles = append(in.Bundles, ExecBundle{
+			Name:        b.Name,
+			Package:     b.Package,
+			Version:     b.Version,
+			BundleImage: b.BundleImage,
+			Channels:    b.Channels,
+			Manifests:   b.Manifests,
+		})
+	}
+
+	return in
+}
+
+// ExecOutput is the result of an exec validator. Error is set if
+// the validator could not complete; otherwise FailureMsgs or Findings
+// must be set unless Success is 'true'.
+type ExecOutput struct {
+	Success     bool     `json:"success"`
+	FailureMsgs []string `json:"failureMsgs,omitempty"`
+	// Findings are reported in addition to FailureMsgs.
+	Findings []Finding `json:"findings,omitempty"`
+	Error    string    `json:"error,omitempty"`
+	// Retryable marks errors which are temporary, e.g.
+	// failed requests to remote services.
+	Retryable bool `json:"retryable,omitempty"`
+}
+
+var ErrInvalidExecValidator = errors.New("invalid exec validator")
+
+// describeTimeout limits the time executables may take to describe themselves.
+const describeTimeout = 10 * time.Second
+
+// LoadExecValidators registers every executable file in dir
+// in lexical order as exec validator.
+func LoadExecValidators(ctx context.Context, dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("reading exec validator directory: %w", err)
+	}
+
+	var paths []string
+
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil {
+			return fmt.Errorf("reading exec validator directory: %w", err)
+		}
+
+		if !info.Mode().IsRegular() || info.Mode().Perm()&0o111 == 0 {
+			continue
+		}
+
+		paths = append(paths, filepath.Join(dir, e.Name()))
+	}
+
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		val, err := NewExecValidator(ctx, path)
+		if err != nil {
+			return fmt.Errorf("loading exec validator '%s': %w", path, err)
+		}
+
+		Register(func(Dependencies) (Validator, error) { return val, nil })
+	}
+
+	return nil
+}
+
+// NewExecValidator returns an ExecValidator for the executable at
+// path described by itself. An error is returned if the description
+// can not be obtained or is invalid.
+func NewExecValidator(ctx context.Context, path string) (*ExecValidator, error) {
+	base, err := describeExec(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExecValidator{Base: base, path: path}, nil
+}
+
+func describeExec(ctx context.Context, path string) (*Base, error) {
+	ctx, cancel := context.WithTimeout(ctx, describeTimeout)
+	defer cancel()
+
+	out, err := runExec(ctx, path, ExecArgDescribe, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return newDescribedBase(out)
+}
+
+// newDescribedBase returns a Base for the ExecDescription
+// encoded by out. An error is returned if it is invalid.
+func newDescribedBase(out []byte) (*Base, error) {
+	var desc ExecDescription
+
+	if err := json.Unmarshal(out, &desc); err != nil {
+		return nil, fmt.Errorf("%w: decoding description: %v", ErrInvalidExecValidator, err)
+	}
+
+	code, err := ParseCode(desc.Code)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidExecValidator, err)
+	}
+
+	if desc.Name == "" {
+		return nil, fmt.Errorf("%w: missing name", ErrInvalidExecValidator)
+	}
+
+	if desc.Severity != "" && !slices.Contains(Severities, desc.Severity) {
+		return nil, fmt.Errorf("%w: unknown severity '%s'", ErrInvalidExecValidator, desc.Severity)
+	}
+
+	for _, tag := range desc.Tags {
+		if !slices.Contains(Tags, tag) {
+			return nil, fmt.Errorf("%w: unknown tag '%s'", ErrInvalidExecValidator, tag)
+		}
+	}
+
+	dependsOn := make([]Code, 0, len(desc.DependsOn))
+
+	for _, dep := range desc.DependsOn {
+		depCode, err := ParseCode(dep)
+		if err != nil {
+			return nil, fmt.Errorf("%w: dependsOn: %v", ErrInvalidExecValidator, err)
+		}
+
+		dependsOn = append(dependsOn, depCode)
+	}
+
+	replaces := make([]Code, 0, len(desc.Replaces))
+
+	for _, old := range desc.Replaces {
+		oldCode, err := ParseCode(old)
+		if err != nil {
+			return nil, fmt.Errorf("%w: replaces: %v", ErrInvalidExecValidator, err)
+		}
+
+		replaces = append(replaces, oldCode)
+	}
+
+	return NewBase(
+		code,
+		BaseName(desc.Name),
+		BaseDesc(desc.Description),
+		BaseDocs(Docs{
+			Details:         desc.Details,
+			Envs:            desc.Envs,
+			ExampleFailures: desc.ExampleFailures,
+			Remediation:     desc.Remediation,
+			DocsURL:         desc.DocsURL,
+			Severity:        desc.Severity,
+			Tags:            desc.Tags,
+			DependsOn:       dependsOn,
+			Replaces:        replaces,
+		}),
+	)
+}
+
+// ExecValidator runs an executable implementing the exec validator protocol.
+type ExecValidator struct {
+	*Base
+	path string
+}
+
+func (e *ExecValidator) Run(ctx context.Context, mb types.MetaBundle) Result {
+	in, err := json.Marshal(NewExecInput(mb))
+	if err != nil {
+		return e.Error(fmt.Errorf("encoding input: %w", err))
+	}
+
+	out, err := runExec(ctx, e.path, ExecArgRun, in)
+	if err != nil {
+		return e.Error(err)
+	}
+
+	return e.resultOf(out)
+}
+
+// resultOf returns the Result for the ExecOutput encoded by out.
+func (b *Base) resultOf(out []byte) Result {
+	var res ExecOutput
+
+	if err := json.Unmarshal(out, &res); err != nil {
+		return b.Error(fmt.Errorf("decoding output: %w", err))
+	}
+
+	switch {
+	case res.Error != "" && res.Retryable:
+		return b.RetryableError(errors.New(res.Error))
+	case res.Error != "":
+		return b.Error(errors.New(res.Error))
+	case res.Success:
+		return b.Success()
+	case len(res.FailureMsgs) == 0 && len(res.Findings) == 0:
+		return b.Error(errors.New("failed without failure messages"))
+	}
+
+	findings := make([]Finding, 0, len(res.FailureMsgs)+len(res.Findings))
+	for _, msg := range res.FailureMsgs {
+		findings = append(findings, Finding{Message: msg})
+	}
+
+	for _, f := range res.Findings {
+		if f.Severity != "" && !slices.Contains(Severities, f.Severity) {
+			return b.Error(fmt.Errorf("unknown severity '%s' of finding '%s'", f.Severity, f.Message))
+		}
+
+		findings = append(findings, f)
+	}
+
+	return b.FailWith(findings...)
+}
+
+func runExec(ctx context.Context, path, arg string, stdin []byte) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, path, arg)
+	cmd.Stdin = bytes.NewReader(stdin)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("running '%s %s': %w: %s", filepath.Base(path), arg, err, msg)
+		}
+
+		return nil, fmt.Errorf("running '%s %s': %w", filepath.Base(path), arg, err)
+	}
+
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,174 @@
+package validator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testExecDescription = `{"code": "AM9002", "name": "test_exec", "description": "Test exec validator", "severity": "warning"}`
+
+// writeExec writes a shell script printing the given description and
+// executing run for 'run' to a temporary directory and returns its path.
+func writeExec(t *testing.T, desc, run string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "validator")
+
+	script := "#!/bin/sh\n" +
+		"if [ \"$1\" = describe ]; then\n" +
+		"  echo '" + desc + "'\n" +
+		"  exit 0\n" +
+		"fi\n" +
+		run + "\n"
+
+	require.NoError(t, os.WriteFile(path, []byte(script), 0o700))
+
+	return path
+}
+
+func TestNewExecValidator(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Description    string
+		ErrorAssertion assert.ErrorAssertionFunc
+	}{
+		"valid": {
+			Description:    testExecDescription,
+			ErrorAssertion: assert.NoError,
+		},
+		"invalid JSON": {
+			Description:    `{"code":`,
+			ErrorAssertion: assert.Error,
+		},
+		"invalid code": {
+			Description:    `{"code": "9002", "name": "test_exec"}`,
+			ErrorAssertion: assert.Error,
+		},
+		"missing name": {
+			Description:    `{"code": "AM9002"}`,
+			ErrorAssertion: assert.Error,
+		},
+		"unknown severity": {
+			Description:    `{"code": "AM9002", "name": "test_exec", "severity": "fatal"}`,
+			ErrorAssertion: assert.Error,
+		},
+		"unknown tag": {
+			Description:    `{"code": "AM9002", "name": "test_exec", "tags": ["python"]}`,
+			ErrorAssertion: assert.Error,
+		},
+		"with prerequisites": {
+			Description:    `{"code": "AM9002", "name": "test_exec", "dependsOn": ["AM0003"]}`,
+			ErrorAssertion: assert.NoError,
+		},
+		"with replaced codes": {
+			Description:    `{"code": "AM9002", "name": "test_exec", "replaces": ["AM9001"]}`,
+			ErrorAssertion: assert.NoError,
+		},
+		"invalid replaced code": {
+			Description:    `{"code": "AM9002", "name": "test_exec", "replaces": ["owner_org"]}`,
+			ErrorAssertion: assert.Error,
+		},
+		"invalid prerequisite": {
+			Description:    `{"code": "AM9002", "name": "test_exec", "dependsOn": ["operator_name"]}`,
+			ErrorAssertion: assert.Error,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewExecValidator(context.Background(), writeExec(t, tc.Description, "exit 1"))
+			tc.ErrorAssertion(t, err)
+		})
+	}
+}
+
+func TestExecValidatorRun(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Run              string
+		ExpectedSuccess  bool
+		ExpectedFailures []string
+		ExpectedError    string
+	}{
+		"success": {
+			Run:             `echo '{"success": true}'`,
+			ExpectedSuccess: true,
+		},
+		"failure": {
+			Run:              `echo '{"failureMsgs": ["addonOwner is missing"]}'`,
+			ExpectedFailures: []string{"addonOwner is missing"},
+		},
+		"findings": {
+			Run:              `echo '{"failureMsgs": ["addonOwner is missing"], "findings": [{"message": "invalid", "field": "addOnParameters[0]"}]}'`,
+			ExpectedFailures: []string{"addonOwner is missing", "addOnParameters[0]: invalid"},
+		},
+		"finding of unknown severity": {
+			Run:           `echo '{"findings": [{"message": "invalid", "severity": "fatal"}]}'`,
+			ExpectedError: "unknown severity 'fatal'",
+		},
+		"reads input": {
+			Run:              `grep -q '"metadata":{.*"id":"test-addon"' && echo '{"failureMsgs": ["found"]}'`,
+			ExpectedFailures: []string{"found"},
+		},
+		"error": {
+			Run:           `echo '{"error": "quay unavailable"}'`,
+			ExpectedError: "quay unavailable",
+		},
+		"failure without messages": {
+			Run:           `echo '{}'`,
+			ExpectedError: "failed without failure messages",
+		},
+		"invalid output": {
+			Run:           `echo 'Traceback'`,
+			ExpectedError: "decoding output",
+		},
+		"non-zero exit": {
+			Run:           `echo 'ModuleNotFoundError' >&2; exit 1`,
+			ExpectedError: "exit status 1: ModuleNotFoundError",
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			val, err := NewExecValidator(context.Background(), writeExec(t, testExecDescription, tc.Run))
+			require.NoError(t, err)
+
+			assert.Equal(t, Code(9002), val.Code())
+			assert.Equal(t, "test_exec", val.Name())
+
+			res := val.Run(context.Background(), types.MetaBundle{
+				AddonMeta: &v1alpha1.AddonMetadataSpec{ID: "test-addon"},
+			})
+
+			assert.Equal(t, tc.ExpectedSuccess, res.IsSuccess())
+			assert.Equal(t, tc.ExpectedFailures, res.Messages())
+			assert.Equal(t, SeverityWarning, res.Severity)
+
+			if tc.ExpectedError == "" {
+				assert.NoError(t, res.Error)
+			} else {
+				assert.ErrorContains(t, res.Error, tc.ExpectedError)
+			}
+		})
+	}
+}
+
+func TestLoadExecValidatorsMissingDir(t *testing.T) {
+	t.Parallel()
+
+	err := LoadExecValidators(context.Background(), filepath.Join(t.TempDir(), "missing"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
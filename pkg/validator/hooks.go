@@ -0,0 +1,48 @@
+package validator
+
+import "context"
+
+// Hooks are notified by the Runner around the execution of validators,
+// e.g. to record timing metrics, tracing spans or send notifications.
+// Hooks of different validators are called concurrently and should
+// return quickly as they delay the validators.
+type Hooks interface {
+	// BeforeValidator is called before v is run. The returned context
+	// is passed to v and to AfterValidator, e.g. to carry a span.
+	// Validators skipped as a prerequisite did not succeed are not run.
+	BeforeValidator(ctx context.Context, v Validator) context.Context
+	// AfterValidator is called with the result of v once it finished.
+	AfterValidator(ctx context.Context, v Validator, res Result)
+	// AfterRun is called with the results of all validators of a run,
+	// including skipped ones, ordered by code before the channel
+	// returned by Runner.Run is closed. ctx may be cancelled.
+	AfterRun(ctx context.Context, results ResultList)
+}
+
+// HookFuncs implements Hooks using the functions which are set
+// so that callers only need to provide the hooks they use.
+type HookFuncs struct {
+	BeforeValidatorFunc func(context.Context, Validator) context.Context
+	AfterValidatorFunc  func(context.Context, Validator, Result)
+	AfterRunFunc        func(context.Context, ResultList)
+}
+
+func (h HookFuncs) BeforeValidator(ctx context.Context, v Validator) context.Context {
+	if h.BeforeValidatorFunc == nil {
+		return ctx
+	}
+
+	return h.BeforeValidatorFunc(ctx, v)
+}
+
+func (h HookFuncs) AfterValidator(ctx context.Context, v Validator, res Result) {
+	if h.AfterValidatorFunc != nil {
+		h.AfterValidatorFunc(ctx, v, res)
+	}
+}
+
+func (h HookFuncs) AfterRun(ctx context.Context, results ResultList) {
+	if h.AfterRunFunc != nil {
+		h.AfterRunFunc(ctx, results)
+	}
+}
@@ -0,0 +1,11 @@
+package validator
+
+import "context"
+
+// ImageVerifier checks images against an image signature policy.
+type ImageVerifier interface {
+	// Verify returns an error wrapping imagepolicy.ErrRejected or
+	// imagepolicy.ErrUnsigned if image violates the policy. Any
+	// other error means the image could not be checked.
+	Verify(ctx context.Context, image string) error
+}
@@ -36,11 +36,15 @@ func (r *RetryMiddleware) Wrap(run RunFunc) RunFunc {
 
 		for attempts := 0; attempts < r.cfg.MaxAttempts; attempts++ {
 			res = run(ctx, mb)
-			if !res.IsRetryableError() {
+			if !res.IsRetryableError() || attempts == r.cfg.MaxAttempts-1 {
 				return res
 			}
 
-			time.Sleep(time.Duration(r.cfg.Delay))
+			select {
+			case <-ctx.Done():
+				return res
+			case <-time.After(r.cfg.Delay):
+			}
 		}
 
 		return res
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/mt-sre/addon-metadata-operator/pkg/httputil"
 	sdk "github.com/openshift-online/ocm-sdk-go"
 )
 
@@ -39,6 +40,22 @@ type QuotaRuleGetter interface {
 	QuotaRuleExists(context.Context, string) (bool, error)
 }
 
+type ocmClientContextKey struct{}
+
+// NewOCMClientContext returns a copy of ctx carrying client so that
+// runners created with it query OCM through client, e.g. using the
+// credentials of the request a validation is run for.
+func NewOCMClientContext(ctx context.Context, client OCMClient) context.Context {
+	return context.WithValue(ctx, ocmClientContextKey{}, client)
+}
+
+// OCMClientFromContext returns the OCMClient carried by ctx or nil.
+func OCMClientFromContext(ctx context.Context) OCMClient {
+	client, _ := ctx.Value(ocmClientContextKey{}).(OCMClient)
+
+	return client
+}
+
 // OCMResponseError is used to wrap HTTP error (400 - 599) response codes
 // which are returned from a request to OCM.
 type OCMResponseError int
@@ -182,7 +199,10 @@ func (c *OCMConnectorImpl) Connect(opts ...OCMConnectOption) (*sdk.Connection, e
 	cfg.Option(opts...)
 	cfg.Default()
 
-	builder := sdk.NewConnectionBuilder().URL(cfg.APIURL)
+	builder := sdk.NewConnectionBuilder().
+		URL(cfg.APIURL).
+		// the SDK retries requests itself
+		TransportWrapper(httputil.Wrap(httputil.WithMaxRetries(-1)))
 
 	if cfg.ClientID != "" && cfg.ClientSecret != "" {
 		builder = builder.Client(cfg.ClientID, cfg.ClientSecret)
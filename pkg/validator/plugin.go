@@ -0,0 +1,73 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"plugin"
+	"sort"
+)
+
+// PluginSymbol is the name of the function which Go plugins providing
+// out-of-tree validators must export. Its signature must be
+// 'func(register func(validator.Initializer))'; it is called once
+// with "Register" when the plugin is loaded.
+const PluginSymbol = "RegisterValidators"
+
+// PluginRegisterFunc is the signature of the function named by PluginSymbol.
+type PluginRegisterFunc = func(register func(Initializer))
+
+var ErrInvalidPlugin = errors.New("invalid validator plugin")
+
+// LoadPlugins opens every '.so' file in dir in lexical order and
+// registers the validators they provide. Plugins must be built with
+// '-buildmode=plugin' by the same Go version and against the same
+// version of this module as the binary loading them.
+func LoadPlugins(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("reading plugin directory: %w", err)
+	}
+
+	var paths []string
+
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".so" {
+			continue
+		}
+
+		paths = append(paths, filepath.Join(dir, e.Name()))
+	}
+
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		if err := loadPlugin(path); err != nil {
+			return fmt.Errorf("loading plugin '%s': %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+func loadPlugin(path string) error {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return err
+	}
+
+	sym, err := p.Lookup(PluginSymbol)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPlugin, err)
+	}
+
+	register, ok := sym.(PluginRegisterFunc)
+	if !ok {
+		return fmt.Errorf("%w: '%s' has type %T instead of %T", ErrInvalidPlugin, PluginSymbol, sym, PluginRegisterFunc(nil))
+	}
+
+	register(Register)
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadPlugins(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Files          map[string]string
+		ErrorAssertion assert.ErrorAssertionFunc
+	}{
+		"empty directory": {
+			ErrorAssertion: assert.NoError,
+		},
+		"other files only": {
+			Files: map[string]string{
+				"README.md": "# plugins",
+				"plugin.go": "package main",
+			},
+			ErrorAssertion: assert.NoError,
+		},
+		"invalid shared object": {
+			Files: map[string]string{
+				"invalid.so": "not an ELF file",
+			},
+			ErrorAssertion: assert.Error,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+
+			for name, content := range tc.Files {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+			}
+
+			tc.ErrorAssertion(t, LoadPlugins(dir))
+		})
+	}
+}
+
+func TestLoadPluginsMissingDir(t *testing.T) {
+	t.Parallel()
+
+	err := LoadPlugins(filepath.Join(t.TempDir(), "missing"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
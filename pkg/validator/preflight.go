@@ -0,0 +1,154 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// PreflightRunner runs Red Hat preflight certification checks.
+type PreflightRunner interface {
+	// CheckContainer runs the container checks against the given image.
+	CheckContainer(ctx context.Context, image string) (PreflightResult, error)
+}
+
+// PreflightResult holds the outcome of the checks run against a single image.
+type PreflightResult struct {
+	Image  string
+	Passed bool
+	// PassedChecks are the checks the image passed.
+	PassedChecks []PreflightCheck
+	// FailedChecks are the checks the image failed.
+	FailedChecks []PreflightCheck
+	// ErroredChecks are the checks which could not be completed.
+	ErroredChecks []PreflightCheck
+}
+
+type PreflightCheck struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Help        string `json:"help"`
+	Suggestion  string `json:"suggestion"`
+}
+
+// NewPreflightRunner returns a PreflightRunnerImpl which executes
+// the preflight binary configured through a variadic slice of options.
+func NewPreflightRunner(opts ...PreflightRunnerOption) *PreflightRunnerImpl {
+	var cfg PreflightRunnerConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+
+	return &PreflightRunnerImpl{cfg: cfg}
+}
+
+type PreflightRunnerImpl struct {
+	cfg PreflightRunnerConfig
+}
+
+func (r *PreflightRunnerImpl) CheckContainer(ctx context.Context, image string) (PreflightResult, error) {
+	artifacts, err := os.MkdirTemp("", "preflight-")
+	if err != nil {
+		return PreflightResult{}, fmt.Errorf("creating artifacts dir: %w", err)
+	}
+
+	defer os.RemoveAll(artifacts)
+
+	args := []string{"check", "container", image, "--artifacts", artifacts}
+	if r.cfg.DockerConfig != "" {
+		args = append(args, "--docker-config", r.cfg.DockerConfig)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, r.cfg.Binary, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	// preflight exits non-zero for failed checks while still
+	// reporting them, so the output is parsed regardless
+	runErr := cmd.Run()
+
+	res, err := parsePreflightOutput(stdout.Bytes())
+	if err != nil {
+		if runErr != nil {
+			return PreflightResult{}, fmt.Errorf("running preflight: %w: %s", runErr, strings.TrimSpace(stderr.String()))
+		}
+
+		return PreflightResult{}, fmt.Errorf("parsing preflight output: %w", err)
+	}
+
+	if res.Image == "" {
+		res.Image = image
+	}
+
+	return res, nil
+}
+
+func parsePreflightOutput(data []byte) (PreflightResult, error) {
+	var out struct {
+		Image   string `json:"image"`
+		Passed  bool   `json:"passed"`
+		Results struct {
+			Passed []PreflightCheck `json:"passed"`
+			Failed []PreflightCheck `json:"failed"`
+			Errors []PreflightCheck `json:"errors"`
+		} `json:"results"`
+	}
+
+	if err := json.Unmarshal(data, &out); err != nil {
+		return PreflightResult{}, err
+	}
+
+	return PreflightResult{
+		Image:         out.Image,
+		Passed:        out.Passed,
+		PassedChecks:  out.Results.Passed,
+		FailedChecks:  out.Results.Failed,
+		ErroredChecks: out.Results.Errors,
+	}, nil
+}
+
+type PreflightRunnerConfig struct {
+	// Binary is the name or path of the preflight binary.
+	Binary string
+	// DockerConfig is the path of a docker config holding
+	// credentials for pulling the checked images.
+	DockerConfig string
+}
+
+func (c *PreflightRunnerConfig) Option(opts ...PreflightRunnerOption) {
+	for _, opt := range opts {
+		opt.ConfigurePreflightRunner(c)
+	}
+}
+
+func (c *PreflightRunnerConfig) Default() {
+	if c.Binary == "" {
+		c.Binary = "preflight"
+	}
+}
+
+type PreflightRunnerOption interface {
+	ConfigurePreflightRunner(*PreflightRunnerConfig)
+}
+
+// WithPreflightBinary applies the name or path of the
+// preflight binary. Defaults to 'preflight'.
+type WithPreflightBinary string
+
+func (w WithPreflightBinary) ConfigurePreflightRunner(c *PreflightRunnerConfig) {
+	c.Binary = string(w)
+}
+
+// WithDockerConfig applies the docker config
+// used to pull the checked images.
+type WithDockerConfig string
+
+func (w WithDockerConfig) ConfigurePreflightRunner(c *PreflightRunnerConfig) {
+	c.DockerConfig = string(w)
+}
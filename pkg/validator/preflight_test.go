@@ -0,0 +1,69 @@
+package validator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreflightRunnerImplCheckContainer(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a POSIX shell")
+	}
+
+	for name, tc := range map[string]struct {
+		Script      string
+		ExpectedErr bool
+		Expected    PreflightResult
+	}{
+		"passed": {
+			Script: `echo '{"image":"'$3'","passed":true,"results":{"passed":[{"name":"HasLicense"}],"failed":[],"errors":[]}}'`,
+			Expected: PreflightResult{
+				Image:         "quay.io/test/image:v1",
+				Passed:        true,
+				PassedChecks:  []PreflightCheck{{Name: "HasLicense"}},
+				FailedChecks:  []PreflightCheck{},
+				ErroredChecks: []PreflightCheck{},
+			},
+		},
+		"failed with non-zero exit": {
+			Script: `echo '{"passed":false,"results":{"failed":[{"name":"RunAsNonRoot","suggestion":"Use a non-root user"}]}}'; exit 1`,
+			Expected: PreflightResult{
+				Image:        "quay.io/test/image:v1",
+				FailedChecks: []PreflightCheck{{Name: "RunAsNonRoot", Suggestion: "Use a non-root user"}},
+			},
+		},
+		"unusable output": {
+			Script:      `echo 'pulling image failed' >&2; exit 2`,
+			ExpectedErr: true,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			bin := filepath.Join(t.TempDir(), "preflight")
+			require.NoError(t, os.WriteFile(bin, []byte("#!/bin/sh\n"+tc.Script+"\n"), 0o755))
+
+			runner := NewPreflightRunner(WithPreflightBinary(bin))
+
+			res, err := runner.CheckContainer(context.Background(), "quay.io/test/image:v1")
+			if tc.ExpectedErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expected, res)
+		})
+	}
+}
@@ -5,7 +5,7 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/mt-sre/client"
+	"github.com/mt-sre/addon-metadata-operator/pkg/httputil"
 )
 
 type QuayClient interface {
@@ -17,28 +17,40 @@ func NewQuayClient() *DefaultV2RegistryClient {
 }
 
 func NewDefaultV2RegistryClient(url string) *DefaultV2RegistryClient {
-	c := client.NewClient(client.WithWrapper{TransportWrapper: client.NewRetryWrapper()})
-
 	return &DefaultV2RegistryClient{
 		baseURL: url,
-		client:  c,
+		client:  httputil.NewClient(),
 	}
 }
 
 type DefaultV2RegistryClient struct {
 	baseURL string
-	client  *client.Client
+	client  *http.Client
 }
 
+// HasReference returns 'true' if ref exists in the registry. Errors
+// caused by unavailable registries or the network wrap ErrRetryable.
 func (c *DefaultV2RegistryClient) HasReference(ctx context.Context, ref ImageReference) (bool, error) {
-	res, err := c.client.Head(ctx, fmt.Sprintf("%s/v2/%s/manifests/%s", c.baseURL, ref.ShortName(), ref.Tag()))
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, fmt.Sprintf("%s/v2/%s/manifests/%s", c.baseURL, ref.ShortName(), ref.Tag()), nil)
 	if err != nil {
-		return false, fmt.Errorf("sending HTTP request: %w", err)
+		return false, fmt.Errorf("creating HTTP request: %w", err)
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return false, RetryableError(fmt.Errorf("sending HTTP request: %w", err))
 	}
 
 	defer res.Body.Close()
 
-	return res.StatusCode == http.StatusOK, nil
+	switch code := res.StatusCode; {
+	case code == http.StatusOK:
+		return true, nil
+	case code == http.StatusTooManyRequests || code >= http.StatusInternalServerError:
+		return false, RetryableError(fmt.Errorf("registry responded with status %d", code))
+	default:
+		return false, nil
+	}
 }
 
 type ImageReference interface {
@@ -0,0 +1,61 @@
+package validator
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/httputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testReference struct{}
+
+func (testReference) ShortName() string { return "osd-addons/reference-addon" }
+func (testReference) Tag() string       { return "latest" }
+
+func TestDefaultV2RegistryClientHasReference(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Status    int
+		Expected  bool
+		Retryable bool
+	}{
+		"exists":              {Status: http.StatusOK, Expected: true},
+		"does not exist":      {Status: http.StatusNotFound},
+		"rate limited":        {Status: http.StatusTooManyRequests, Retryable: true},
+		"service unavailable": {Status: http.StatusServiceUnavailable, Retryable: true},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/v2/osd-addons/reference-addon/manifests/latest", r.URL.Path)
+
+				w.WriteHeader(tc.Status)
+			}))
+			defer srv.Close()
+
+			client := &DefaultV2RegistryClient{
+				baseURL: srv.URL,
+				client:  httputil.NewClient(httputil.WithMaxRetries(-1)),
+			}
+
+			ok, err := client.HasReference(context.Background(), testReference{})
+			assert.Equal(t, tc.Expected, ok)
+
+			if !tc.Retryable {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.ErrorIs(t, err, ErrRetryable)
+		})
+	}
+}
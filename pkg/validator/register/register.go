@@ -17,4 +17,18 @@ import (
 	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/am0015"
 	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/am0016"
 	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/am0017"
+	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/am0018"
+	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/am0019"
+	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/am0020"
+	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/am0021"
+	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/am0022"
+	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/am0023"
+	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/am0024"
+	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/am0025"
+	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/am0026"
+	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/am0027"
+	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/am0028"
+	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/am0029"
+	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/am0030"
+	_ "github.com/mt-sre/addon-metadata-operator/pkg/validator/am0031"
 )
@@ -1,28 +1,216 @@
 package validator
 
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+)
+
 // Result encapsulates the status and reason for the result of
 // a Validator task running against a types.MetaBundle.
 type Result struct {
 	Code        Code
 	Name        string
 	Description string
-	FailureMsgs []string
-	Error       error
-	retryable   bool
-	success     bool
+	// Findings lists the problems reported by failed validators.
+	Findings []Finding
+	Error    error
+	// Severity is the severity of the Validator; an unset
+	// severity is treated as SeverityError.
+	Severity Severity
+	// Remediation explains how failures are resolved.
+	Remediation string
+	// DocsURL links to the documentation of the Validator.
+	DocsURL string
+	// Duration is the time the validator took to complete
+	// including retries. It is set by the Runner.
+	Duration time.Duration
+	// Cached is true if the result was recorded by an earlier
+	// run instead of running the validator.
+	Cached bool
+	// SkipReason explains why the validator was not run, e.g. as
+	// a prerequisite did not succeed (see Docs.DependsOn).
+	SkipReason string
+	success    bool
+}
+
+// Finding is a single problem reported by a Validator.
+type Finding struct {
+	Message string `json:"message"`
+	// Severity overrides the severity of the Validator
+	// for this finding if set.
+	Severity Severity `json:"severity,omitempty"`
+	// File is the path of the offending file relative to the
+	// addon, e.g. 'metadata/stage/addon.yaml', if known.
+	File string `json:"file,omitempty"`
+	// Field is the path of the offending field within File,
+	// e.g. 'addOnParameters[0].defaultValue', if known.
+	Field string `json:"field,omitempty"`
+}
+
+// FindingAt returns a finding with the given message pointing to the
+// metadata field at path (e.g. 'addOnParameters[0].defaultValue') and
+// the file it was defined in if recorded by the provenance of mb.
+func FindingAt(mb types.MetaBundle, path, msg string) Finding {
+	f := Finding{Message: msg, Field: path}
+
+	if src, ok := mb.Provenance.Lookup(path); ok {
+		f.File = src.File
+	}
+
+	return f
+}
+
+// Location returns the file and field f points to
+// or an empty string if f lacks a locator.
+func (f Finding) Location() string {
+	switch {
+	case f.File != "" && f.Field != "":
+		return fmt.Sprintf("%s (%s)", f.File, f.Field)
+	case f.File != "":
+		return f.File
+	default:
+		return f.Field
+	}
+}
+
+// String returns the message of f prefixed by its location.
+func (f Finding) String() string {
+	if loc := f.Location(); loc != "" {
+		return loc + ": " + f.Message
+	}
+
+	return f.Message
+}
+
+// RestoreResult returns a result of v which succeeded or failed with
+// the given findings, e.g. to report results recorded by an earlier
+// run. The result is marked as cached.
+func RestoreResult(v Validator, success bool, findings ...Finding) Result {
+	res := newResult(v)
+	res.Findings = findings
+	res.success = success
+	res.Cached = true
+
+	return res
 }
 
 // IsSuccess returns 'true' if the Validator task which
 // returned it was successful.
 func (r Result) IsSuccess() bool { return r.success }
 
+// Messages returns the findings of r including their locations.
+func (r Result) Messages() []string {
+	var msgs []string
+
+	for _, f := range r.Findings {
+		msgs = append(msgs, f.String())
+	}
+
+	return msgs
+}
+
+// SeverityOf returns the severity of f which defaults to the
+// severity of r and SeverityError if neither is set.
+func (r Result) SeverityOf(f Finding) Severity {
+	switch {
+	case f.Severity != "":
+		return f.Severity
+	case r.Severity != "":
+		return r.Severity
+	default:
+		return SeverityError
+	}
+}
+
+// SeverityFunc returns the severity to report failures of the
+// Validator with the given code with instead of the given severity.
+type SeverityFunc func(Code, Severity) Severity
+
+// WithSeverities returns r with its severity and the severities
+// set for its findings mapped by f. Findings without a severity
+// keep inheriting the mapped severity of r.
+func (r Result) WithSeverities(f SeverityFunc) Result {
+	if f == nil {
+		return r
+	}
+
+	r.Severity = f(r.Code, r.Severity)
+
+	findings := make([]Finding, 0, len(r.Findings))
+
+	for _, finding := range r.Findings {
+		if finding.Severity != "" {
+			finding.Severity = f(r.Code, finding.Severity)
+		}
+
+		findings = append(findings, finding)
+	}
+
+	if r.Findings != nil {
+		r.Findings = findings
+	}
+
+	return r
+}
+
+// IsSkipped returns 'true' if the Validator was not run.
+// Skipped results are neither successes nor failures.
+func (r Result) IsSkipped() bool { return r.SkipReason != "" }
+
+// IsBlocking returns 'true' if the Validator task which returned
+// it encountered an error or failed with severity SeverityError.
+// Failures of lower severities do not fail a validation unless any
+// of their findings are of severity SeverityError (see SeverityOf).
+func (r Result) IsBlocking() bool { return r.IsBlockingAt(SeverityError) }
+
+// IsBlockingAt returns 'true' if the Validator task which returned
+// it encountered an error or failed with a severity of at least
+// threshold, e.g. SeverityWarning to also fail on warnings.
+func (r Result) IsBlockingAt(threshold Severity) bool {
+	if r.IsSuccess() || r.IsSkipped() {
+		return false
+	}
+
+	if r.IsError() {
+		return true
+	}
+
+	if len(r.Findings) == 0 {
+		return r.Severity.AtLeast(threshold)
+	}
+
+	for _, f := range r.Findings {
+		if r.SeverityOf(f).AtLeast(threshold) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // IsError returns 'true' if the Validator task which
 // returned it encountered an error.
 func (r Result) IsError() bool { return r.Error != nil }
 
+// IsTimeout returns 'true' if the Validator task which
+// returned it did not complete before its deadline.
+func (r Result) IsTimeout() bool { return errors.Is(r.Error, ErrTimeout) }
+
+// ErrTimeout is wrapped by the errors of results returned
+// for validators which exceeded their deadline.
+var ErrTimeout = errors.New("validator timed out")
+
 // IsRetryableError returns 'true' if the Validator task which
 // returned it encountered an error, but the error can be retried.
-func (r Result) IsRetryableError() bool { return r.retryable }
+func (r Result) IsRetryableError() bool { return errors.Is(r.Error, ErrRetryable) }
+
+// IsInternalError returns 'true' if the Validator task which
+// returned it encountered an error which retrying does not
+// resolve, e.g. a bug in the validator or a panic.
+func (r Result) IsInternalError() bool { return errors.Is(r.Error, ErrInternal) }
 
 // ResultList is a sortable slice of Result instances.
 type ResultList []Result
@@ -35,7 +223,7 @@ func (l ResultList) Swap(i, j int)      { l[i], l[j] = l[j], l[i] }
 // are failures or errors.
 func (l ResultList) HasFailure() bool {
 	for _, r := range l {
-		if r.IsSuccess() {
+		if r.IsSuccess() || r.IsSkipped() {
 			continue
 		}
 
@@ -45,6 +233,22 @@ func (l ResultList) HasFailure() bool {
 	return false
 }
 
+// HasBlocking returns 'true' if any of the ResultList
+// members are blocking (see Result.IsBlocking).
+func (l ResultList) HasBlocking() bool { return l.HasBlockingAt(SeverityError) }
+
+// HasBlockingAt returns 'true' if any of the ResultList members
+// are blocking at the given threshold (see Result.IsBlockingAt).
+func (l ResultList) HasBlockingAt(threshold Severity) bool {
+	for _, r := range l {
+		if r.IsBlockingAt(threshold) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Errors returns a slice of errors from the ResultList
 // members. If no errors were encountered then an empty slice
 // is returned.
@@ -0,0 +1,65 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultIsBlockingAt(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Result          Result
+		ExpectedError   bool
+		ExpectedWarning bool
+	}{
+		"success": {
+			Result: Result{Code: 1, success: true},
+		},
+		"skipped": {
+			Result: Result{Code: 1, SkipReason: "prerequisite failed: AM0002"},
+		},
+		"error": {
+			Result:          Result{Code: 1, Severity: SeverityInfo, Error: errors.New("boom")},
+			ExpectedError:   true,
+			ExpectedWarning: true,
+		},
+		"failure without severity": {
+			Result:          Result{Code: 1, Findings: []Finding{{Message: "failed"}}},
+			ExpectedError:   true,
+			ExpectedWarning: true,
+		},
+		"error failure": {
+			Result:          Result{Code: 1, Severity: SeverityError, Findings: []Finding{{Message: "failed"}}},
+			ExpectedError:   true,
+			ExpectedWarning: true,
+		},
+		"warning failure": {
+			Result:          Result{Code: 1, Severity: SeverityWarning, Findings: []Finding{{Message: "failed"}}},
+			ExpectedWarning: true,
+		},
+		"info failure": {
+			Result: Result{Code: 1, Severity: SeverityInfo, Findings: []Finding{{Message: "failed"}}},
+		},
+		"info failure with warning finding": {
+			Result: Result{Code: 1, Severity: SeverityInfo, Findings: []Finding{
+				{Message: "failed"},
+				{Message: "failed", Severity: SeverityWarning},
+			}},
+			ExpectedWarning: true,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.ExpectedError, tc.Result.IsBlockingAt(SeverityError))
+			assert.Equal(t, tc.ExpectedError, tc.Result.IsBlocking())
+			assert.Equal(t, tc.ExpectedWarning, tc.Result.IsBlockingAt(SeverityWarning))
+			assert.Equal(t, tc.ExpectedWarning, ResultList{tc.Result}.HasBlockingAt(SeverityWarning))
+		})
+	}
+}
@@ -2,11 +2,18 @@ package validator
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"runtime/debug"
+	"slices"
 	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/mt-sre/addon-metadata-operator/api/v1alpha1"
+	"github.com/mt-sre/addon-metadata-operator/pkg/imagepolicy"
 	"github.com/mt-sre/addon-metadata-operator/pkg/types"
 )
 
@@ -18,6 +25,25 @@ func Register(init Initializer) {
 	initializers = append(initializers, init)
 }
 
+type initializersContextKey struct{}
+
+// NewInitializersContext returns a copy of ctx carrying inits in addition
+// to those already carried by ctx so that runners created with it
+// initialize them along with the registered validators, e.g. WASM
+// validators loaded for a single command (see LoadWasmValidators).
+func NewInitializersContext(ctx context.Context, inits ...Initializer) context.Context {
+	return context.WithValue(ctx, initializersContextKey{},
+		append(slices.Clone(InitializersFromContext(ctx)), inits...),
+	)
+}
+
+// InitializersFromContext returns the initializers carried by ctx.
+func InitializersFromContext(ctx context.Context) []Initializer {
+	inits, _ := ctx.Value(initializersContextKey{}).([]Initializer)
+
+	return inits
+}
+
 // Initializer is a function which will initialize a Validator
 // with dependencies. An error is returned if the Validator
 // cannot be initalized properly.
@@ -25,14 +51,20 @@ type Initializer func(Dependencies) (Validator, error)
 
 // Dependencies abstracts common dependencies for Validators.
 type Dependencies struct {
+	ClusterClient   ClusterClient
+	ImageVerifier   ImageVerifier
 	Logger          logr.Logger
 	OCMClient       OCMClient
+	PreflightRunner PreflightRunner
 	QuayClient      QuayClient
 	ValidatorConfig ValidatorConfig
 }
 
 type ValidatorConfig struct {
 	ExcludedNamespaces []string
+	// RBACAllowlist lists resources given as 'resource[.group]'
+	// which operators may be granted privileged access to.
+	RBACAllowlist []string
 }
 
 func (c *ValidatorConfig) Option(opts ...ValidatorOption) {
@@ -51,12 +83,25 @@ func (w WithExcludedNamespaces) ConfigureValidator(c *ValidatorConfig) {
 	c.ExcludedNamespaces = append(c.ExcludedNamespaces, w...)
 }
 
+// WithRBACAllowlist allows privileged access to the given
+// resources (see ValidatorConfig.RBACAllowlist).
+type WithRBACAllowlist []string
+
+func (w WithRBACAllowlist) ConfigureValidator(c *ValidatorConfig) {
+	c.RBACAllowlist = append(c.RBACAllowlist, w...)
+}
+
 // NewRunner returns a Runner configured with a variadic
-// slice of options or an error if an issue occurs.
-func NewRunner(opts ...RunnerOption) (*Runner, error) {
+// slice of options or an error if an issue occurs. Dependencies
+// which are not given as options default to the values carried
+// by ctx: its logger, its image policy (see imagepolicy.NewContext),
+// its OCMClient (see NewOCMClientContext) and the initializers run
+// along with the registered ones (see NewInitializersContext).
+func NewRunner(ctx context.Context, opts ...RunnerOption) (*Runner, error) {
 	var cfg RunnerConfig
 
 	cfg.Option(opts...)
+	cfg.DefaultFromContext(ctx)
 	cfg.Default()
 
 	var valCfg ValidatorConfig
@@ -64,8 +109,11 @@ func NewRunner(opts ...RunnerOption) (*Runner, error) {
 	valCfg.Option(cfg.ValidatorOptions...)
 
 	deps := Dependencies{
+		ClusterClient:   cfg.ClusterClient,
+		ImageVerifier:   cfg.ImageVerifier,
 		Logger:          cfg.Logger,
 		OCMClient:       cfg.OCMClient,
+		PreflightRunner: cfg.PreflightRunner,
 		QuayClient:      cfg.QuayClient,
 		ValidatorConfig: valCfg,
 	}
@@ -80,7 +128,7 @@ func NewRunner(opts ...RunnerOption) (*Runner, error) {
 
 		if existing, ok := entries[val.Code()]; ok {
 			return nil, fmt.Errorf(
-				"code '%d' is already registered for validator '%s'",
+				"code '%s' is already registered for validator '%s'",
 				val.Code(),
 				existing.Name(),
 			)
@@ -91,6 +139,10 @@ func NewRunner(opts ...RunnerOption) (*Runner, error) {
 		}
 	}
 
+	if err := verifyDependencies(entries); err != nil {
+		return nil, err
+	}
+
 	return &Runner{
 		cfg:     cfg,
 		entries: entries,
@@ -102,36 +154,132 @@ type Runner struct {
 	entries map[Code]validatorEntry
 }
 
+// Run runs the validators satisfying all filters against mb and sends
+// their results through the returned channel which is closed once all
+// validators finished or ctx is cancelled. Validators only run once the
+// prerequisites they depend on (see Docs.DependsOn) finished; they are
+// skipped if any of them did not succeed.
 func (r *Runner) Run(ctx context.Context, mb types.MetaBundle, filters ...Filter) <-chan Result {
+	return r.Resume(ctx, mb, nil, filters...)
+}
+
+// Resume is like Run, but treats finished as the results of validators
+// which already ran against mb. These validators are not run again and
+// only their dependents are released or skipped based on the results.
+func (r *Runner) Resume(ctx context.Context, mb types.MetaBundle, finished ResultList, filters ...Filter) <-chan Result {
 	resultCh := make(chan Result)
 
 	var wg sync.WaitGroup
 
-	vals := r.GetValidators(filters...)
+	prereqs := finishedPrerequisites(finished)
+
+	var vals []Validator
+
+	for _, v := range r.GetValidators(filters...) {
+		if _, ok := prereqs[v.Code()]; !ok {
+			vals = append(vals, v)
+		}
+	}
+
+	prereqs.add(vals)
+
+	// collected for Hooks.AfterRun
+	var (
+		mu      sync.Mutex
+		results ResultList
+	)
 
 	wg.Add(len(vals))
 
+	// a nil channel never blocks which leaves concurrency unbounded
+	var sem chan struct{}
+	if r.cfg.Concurrency > 0 {
+		sem = make(chan struct{}, r.cfg.Concurrency)
+	}
+
 	for _, val := range vals {
 		go func(v Validator) {
 			defer wg.Done()
 
-			run := r.applyMiddleware(v.Run)
+			res, ok := r.runAfterPrerequisites(ctx, v, mb, prereqs, sem)
+
+			// dependents are released before the result is consumed
+			prereqs.finish(v.Code(), res)
+
+			if !ok {
+				return
+			}
+
+			if len(r.cfg.Hooks) > 0 {
+				mu.Lock()
+				results = append(results, res)
+				mu.Unlock()
+			}
 
 			select {
 			case <-ctx.Done():
-			case resultCh <- run(ctx, mb):
+			case resultCh <- res:
 			}
 		}(val)
 	}
 
 	go func() {
 		wg.Wait()
+
+		if len(r.cfg.Hooks) > 0 {
+			sort.Sort(results)
+
+			for _, h := range r.cfg.Hooks {
+				h.AfterRun(ctx, results)
+			}
+		}
+
 		close(resultCh)
 	}()
 
 	return resultCh
 }
 
+// runAfterPrerequisites runs v once its prerequisites finished and a slot
+// of sem is available or skips it if a prerequisite did not succeed.
+// 'false' is returned if ctx is cancelled before v could be run.
+func (r *Runner) runAfterPrerequisites(ctx context.Context, v Validator, mb types.MetaBundle, prereqs prerequisites, sem chan struct{}) (Result, bool) {
+	// awaited before acquiring a slot so that
+	// prerequisites are not starved by their dependents
+	reason, err := prereqs.skipReason(ctx, v)
+	if err != nil {
+		return Result{}, false
+	}
+
+	if reason != "" {
+		return newSkippedResult(v, reason), true
+	}
+
+	if sem != nil {
+		select {
+		case <-ctx.Done():
+			return Result{}, false
+		case sem <- struct{}{}:
+		}
+
+		defer func() { <-sem }()
+	}
+
+	for _, h := range r.cfg.Hooks {
+		ctx = h.BeforeValidator(ctx, v)
+	}
+
+	start := time.Now()
+	res := r.runValidator(ctx, v, mb)
+	res.Duration = time.Since(start)
+
+	for _, h := range r.cfg.Hooks {
+		h.AfterValidator(ctx, v, res)
+	}
+
+	return res, true
+}
+
 func (r *Runner) GetValidators(filters ...Filter) []Validator {
 	var result ValidatorList
 
@@ -148,6 +296,117 @@ func (r *Runner) GetValidators(filters ...Filter) []Validator {
 	return result
 }
 
+// ReplacementOf returns the Validator replacing the deprecated Validator
+// with the given code (see Docs.Replaces) and 'true'. If no Validator
+// replaces it or a Validator with that code is still registered 'false'
+// is returned.
+func (r *Runner) ReplacementOf(code Code) (Validator, bool) {
+	if _, ok := r.entries[code]; ok {
+		return nil, false
+	}
+
+	for _, v := range r.GetValidators() {
+		if slices.Contains(DocsOf(v).Replaces, code) {
+			return v, true
+		}
+	}
+
+	return nil, false
+}
+
+// runValidator runs v with all middleware applied. If a timeout is
+// configured a result wrapping ErrTimeout is returned once it expires,
+// even if v does not return as it ignores ctx. Panics of v or the
+// middleware are returned as result wrapping ErrPanic.
+func (r *Runner) runValidator(ctx context.Context, v Validator, mb types.MetaBundle) Result {
+	run := r.recoverPanic(v, r.applyMiddleware(v.Run))
+
+	if r.cfg.Timeout <= 0 {
+		return run(ctx, mb)
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, r.cfg.Timeout)
+	defer cancel()
+
+	// buffered so that validators finishing late do not leak
+	done := make(chan Result, 1)
+
+	go func() { done <- run(runCtx, mb) }()
+
+	select {
+	case res := <-done:
+		// validators honouring ctx report the deadline as error
+		if !res.IsError() || !errors.Is(runCtx.Err(), context.DeadlineExceeded) || ctx.Err() != nil {
+			return res
+		}
+	case <-runCtx.Done():
+		if err := ctx.Err(); err != nil {
+			return newErrorResult(v, err)
+		}
+	}
+
+	return newErrorResult(v, fmt.Errorf("%w after %s", ErrTimeout, r.cfg.Timeout))
+}
+
+// ErrPanic is wrapped by the errors of results returned
+// for validators which panicked. They also wrap ErrInternal.
+var ErrPanic = errors.New("validator panicked")
+
+// recoverPanic returns run which recovers from panics so that
+// a single faulty validator does not abort the whole run.
+func (r *Runner) recoverPanic(v Validator, run RunFunc) RunFunc {
+	return func(ctx context.Context, mb types.MetaBundle) (res Result) {
+		defer func() {
+			if p := recover(); p != nil {
+				r.cfg.Logger.Error(nil, "validator panicked",
+					"code", v.Code().String(),
+					"panic", p,
+					"stack", string(debug.Stack()),
+				)
+
+				res = newErrorResult(v, classifiedError{
+					err:   fmt.Errorf("%w: %v", ErrPanic, p),
+					class: ErrInternal,
+				})
+			}
+		}()
+
+		return run(ctx, mb)
+	}
+}
+
+// newErrorResult returns a result of v with the given error
+// for validators which did not return a result themselves.
+func newErrorResult(v Validator, err error) Result {
+	res := newResult(v)
+	res.Error = err
+
+	return res
+}
+
+// newSkippedResult returns a result of v which was
+// not run for the given reason.
+func newSkippedResult(v Validator, reason string) Result {
+	res := newResult(v)
+	res.SkipReason = reason
+
+	return res
+}
+
+// newResult returns a result populated with the documentation of v.
+func newResult(v Validator) Result {
+	docs := DocsOf(v)
+
+	return Result{
+		Code:        v.Code(),
+		Name:        v.Name(),
+		Description: v.Description(),
+		Severity:    docs.EffectiveSeverity(),
+		Remediation: docs.Remediation,
+		DocsURL:     docs.EffectiveDocsURL(v.Code()),
+	}
+}
+
 func (r *Runner) applyMiddleware(run RunFunc) RunFunc {
 	res := run
 
@@ -159,11 +418,22 @@ func (r *Runner) applyMiddleware(run RunFunc) RunFunc {
 }
 
 type RunnerConfig struct {
-	Initializers     []Initializer
-	Logger           logr.Logger
-	Middleware       []Middleware
-	OCMClient        OCMClient
-	QuayClient       QuayClient
+	// Concurrency limits the number of validators running
+	// at the same time. Values less than one mean no limit.
+	Concurrency   int
+	ClusterClient ClusterClient
+	// Hooks are notified around the execution of validators.
+	Hooks           []Hooks
+	ImageVerifier   ImageVerifier
+	Initializers    []Initializer
+	Logger          logr.Logger
+	Middleware      []Middleware
+	OCMClient       OCMClient
+	PreflightRunner PreflightRunner
+	QuayClient      QuayClient
+	// Timeout limits the time each validator may take including
+	// retries. Values less than one mean no limit.
+	Timeout          time.Duration
 	ValidatorOptions []ValidatorOption
 }
 
@@ -173,6 +443,31 @@ func (c *RunnerConfig) Option(opts ...RunnerOption) {
 	}
 }
 
+// DefaultFromContext sets the dependencies which are not
+// configured to the values carried by ctx, if any.
+func (c *RunnerConfig) DefaultFromContext(ctx context.Context) {
+	if c.Logger.GetSink() == nil {
+		if log, err := logr.FromContext(ctx); err == nil {
+			c.Logger = log
+		}
+	}
+
+	if c.ImageVerifier == nil {
+		// a nil *Verifier must not become a non-nil ImageVerifier
+		if policy := imagepolicy.FromContext(ctx); policy != nil {
+			c.ImageVerifier = policy
+		}
+	}
+
+	if c.OCMClient == nil {
+		c.OCMClient = OCMClientFromContext(ctx)
+	}
+
+	if inits := InitializersFromContext(ctx); len(c.Initializers) == 0 && len(inits) > 0 {
+		c.Initializers = append(slices.Clone(initializers), inits...)
+	}
+}
+
 func (c *RunnerConfig) Default() {
 	if len(c.Initializers) == 0 {
 		c.Initializers = initializers
@@ -195,6 +490,28 @@ type RunnerOption interface {
 	ApplyToRunnerConfig(*RunnerConfig)
 }
 
+// WithClusterClient applies the given ClusterClient. Validators which
+// require a live cluster are skipped unless a ClusterClient is applied.
+type WithClusterClient struct{ ClusterClient }
+
+func (w WithClusterClient) ApplyToRunnerConfig(c *RunnerConfig) { c.ClusterClient = w.ClusterClient }
+
+// WithImageVerifier applies the given ImageVerifier. Images referenced
+// by the addon are not checked against a signature policy unless an
+// ImageVerifier is applied.
+type WithImageVerifier struct{ ImageVerifier }
+
+func (w WithImageVerifier) ApplyToRunnerConfig(c *RunnerConfig) { c.ImageVerifier = w.ImageVerifier }
+
+type WithConcurrency int
+
+func (w WithConcurrency) ApplyToRunnerConfig(c *RunnerConfig) { c.Concurrency = int(w) }
+
+// WithTimeout limits the time each validator may take.
+type WithTimeout time.Duration
+
+func (w WithTimeout) ApplyToRunnerConfig(c *RunnerConfig) { c.Timeout = time.Duration(w) }
+
 type WithLogger struct{ logr.Logger }
 
 func (l WithLogger) ApplyToRunnerConfig(c *RunnerConfig) { c.Logger = l.Logger }
@@ -203,13 +520,27 @@ type WithInitializers []Initializer
 
 func (i WithInitializers) ApplyToRunnerConfig(c *RunnerConfig) { c.Initializers = i }
 
+// WithHooks appends hooks which are notified
+// around the execution of validators.
+type WithHooks []Hooks
+
+func (w WithHooks) ApplyToRunnerConfig(c *RunnerConfig) { c.Hooks = append(c.Hooks, w...) }
+
 type WithMiddleware []Middleware
 
 func (m WithMiddleware) ApplyToRunnerConfig(c *RunnerConfig) { c.Middleware = m }
 
 type WithOCMClient struct{ OCMClient }
 
-func (o WithOCMClient) ApplyToRunnerConfig(c *RunnerConfig) { c.OCMClient = o }
+func (o WithOCMClient) ApplyToRunnerConfig(c *RunnerConfig) { c.OCMClient = o.OCMClient }
+
+// WithPreflightRunner applies the given PreflightRunner. Preflight
+// certification checks are skipped unless a PreflightRunner is applied.
+type WithPreflightRunner struct{ PreflightRunner }
+
+func (w WithPreflightRunner) ApplyToRunnerConfig(c *RunnerConfig) {
+	c.PreflightRunner = w.PreflightRunner
+}
 
 type WithQuayClient struct{ QuayClient }
 
@@ -231,7 +562,7 @@ func (e *validatorEntry) Satisfies(filters ...Filter) bool {
 	}
 
 	for _, f := range filters {
-		if f == nil || f(e) {
+		if f == nil || f(e.Validator) {
 			continue
 		}
 
@@ -243,10 +574,38 @@ func (e *validatorEntry) Satisfies(filters ...Filter) bool {
 
 type Filter func(Validator) bool
 
+// MatchesCodes matches Validators with any of the given codes
+// or replacing deprecated Validators with any of them (see
+// Docs.Replaces).
 func MatchesCodes(codes ...Code) Filter {
 	return func(v Validator) bool {
+		replaces := DocsOf(v).Replaces
+
 		for _, c := range codes {
-			if v.Code() == c {
+			if v.Code() == c || slices.Contains(replaces, c) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// MatchesPrefixes matches Validators whose codes
+// belong to the family of any of the given prefixes.
+func MatchesPrefixes(prefixes ...Prefix) Filter {
+	return func(v Validator) bool {
+		return slices.Contains(prefixes, v.Code().Prefix())
+	}
+}
+
+// MatchesTags matches Validators documenting any of the given tags.
+func MatchesTags(tags ...string) Filter {
+	return func(v Validator) bool {
+		docs := DocsOf(v)
+
+		for _, t := range tags {
+			if docs.HasTag(t) {
 				return true
 			}
 		}
@@ -255,8 +614,68 @@ func MatchesCodes(codes ...Code) Filter {
 	}
 }
 
+// ExcludedCodes returns the codes of the Validators excluded by the
+// 'excludedValidators' of the given addon metadata. Exclusions with an
+// invalid code or without justification are not honored.
+func ExcludedCodes(meta *v1alpha1.AddonMetadataSpec) []Code {
+	if meta == nil || meta.ExcludedValidators == nil {
+		return nil
+	}
+
+	var res []Code
+
+	for _, excl := range *meta.ExcludedValidators {
+		if strings.TrimSpace(excl.Justification) == "" {
+			continue
+		}
+
+		code, err := ParseCode(excl.Code)
+		if err != nil {
+			continue
+		}
+
+		res = append(res, code)
+	}
+
+	return res
+}
+
+// EnabledForEnv matches Validators applying to the given environment.
+func EnabledForEnv(env string) Filter {
+	return func(v Validator) bool {
+		return DocsOf(v).AppliesToEnv(env)
+	}
+}
+
 func Not(f Filter) Filter {
 	return func(v Validator) bool {
 		return !f(v)
 	}
 }
+
+// All matches Validators matched by all of the given filters.
+// Nil filters match every Validator.
+func All(filters ...Filter) Filter {
+	return func(v Validator) bool {
+		for _, f := range filters {
+			if f != nil && !f(v) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// Any matches Validators matched by at least one of the given filters.
+func Any(filters ...Filter) Filter {
+	return func(v Validator) bool {
+		for _, f := range filters {
+			if f(v) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
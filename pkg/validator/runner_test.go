@@ -2,6 +2,10 @@ package validator
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
 	"testing"
 	"time"
 
@@ -53,6 +57,31 @@ func TestParseCode(t *testing.T) {
 			Expected:       Code(0),
 			ErrorAssertion: assert.Error,
 		},
+		"other family": {
+			Input:          "OSD0001",
+			Expected:       PrefixOSD.Code(1),
+			ErrorAssertion: assert.NoError,
+		},
+		"lower case prefix of other family": {
+			Input:          "sec0042",
+			Expected:       PrefixSEC.Code(42),
+			ErrorAssertion: assert.NoError,
+		},
+		"unregistered prefix": {
+			Input:          "XYZ0001",
+			Expected:       Code(0),
+			ErrorAssertion: assert.Error,
+		},
+		"non-numeric suffix": {
+			Input:          "AM000x",
+			Expected:       Code(0),
+			ErrorAssertion: assert.Error,
+		},
+		"signed number": {
+			Input:          "AM+001",
+			Expected:       Code(0),
+			ErrorAssertion: assert.Error,
+		},
 	} {
 		tc := tc
 
@@ -65,6 +94,112 @@ func TestParseCode(t *testing.T) {
 	}
 }
 
+func TestCodePrefixes(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, Code(1), PrefixAM.Code(1))
+	assert.NotEqual(t, PrefixAM.Code(1), PrefixOSD.Code(1))
+
+	for _, code := range []Code{PrefixAM.Code(1), PrefixOSD.Code(9999), PrefixSEC.Code(0)} {
+		parsed, err := ParseCode(code.String())
+		require.NoError(t, err)
+
+		assert.Equal(t, code, parsed)
+	}
+
+	assert.Equal(t, "OSD0012", PrefixOSD.Code(12).String())
+	assert.Equal(t, PrefixSEC, PrefixSEC.Code(12).Prefix())
+	assert.Equal(t, 12, PrefixSEC.Code(12).Number())
+
+	assert.Equal(t, Code(10000), PrefixOSD.Code(0))
+	assert.Equal(t, Code(20042), PrefixSEC.Code(42))
+
+	assert.Equal(t, InvalidCode, Prefix("XYZ").Code(1))
+	assert.Equal(t, InvalidCode, PrefixAM.Code(10000))
+	assert.Equal(t, InvalidCode, PrefixAM.Code(-1))
+
+	_, err := NewBase(Prefix("XYZ").Code(1))
+	assert.Error(t, err)
+}
+
+func TestRegisterPrefix(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Prefix Prefix
+		Base   Code
+	}{
+		"invalid prefix":        {Prefix: "x1", Base: 90000},
+		"unaligned base":        {Prefix: "ACME", Base: 90001},
+		"zero base":             {Prefix: "ACME", Base: 0},
+		"base of another":       {Prefix: "ACME", Base: PrefixOSD.Code(0)},
+		"registered prefix":     {Prefix: PrefixOSD, Base: 90000},
+		"lower case prefix":     {Prefix: "acme", Base: 90000},
+		"prefix exceeds length": {Prefix: "ACMES", Base: 90000},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Error(t, RegisterPrefix(tc.Prefix, tc.Base))
+		})
+	}
+
+	// registering a built-in prefix again with its own base has no effect
+	assert.NoError(t, RegisterPrefix(PrefixSEC, PrefixSEC.Code(0)))
+	assert.Equal(t, []Prefix{PrefixAM, PrefixOSD, PrefixSEC}, Prefixes()[:3])
+}
+
+func TestRegisterPrefixConcurrently(t *testing.T) {
+	t.Parallel()
+
+	var wg sync.WaitGroup
+
+	// prefixes may be registered while runners format codes
+	for i, p := range []Prefix{"CONA", "CONB", "CONC"} {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			assert.NoError(t, RegisterPrefix(p, Code(50+i)*10000))
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			assert.Equal(t, "OSD0001", PrefixOSD.Code(1).String())
+			assert.Contains(t, Prefixes(), PrefixAM)
+		}()
+	}
+
+	wg.Wait()
+
+	code, err := ParseCode("CONB0001")
+	require.NoError(t, err)
+	assert.Equal(t, Code(510001), code)
+}
+
+func TestRunnerRegistersFamiliesIndependently(t *testing.T) {
+	t.Parallel()
+
+	success := func(context.Context, types.MetaBundle) Result { return Result{success: true} }
+
+	runner, err := NewRunner(
+		context.Background(),
+		WithInitializers{
+			NewValidatorMock(PrefixAM.Code(1), "upstream", "desc", success),
+			NewValidatorMock(PrefixOSD.Code(1), "downstream", "desc", success),
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Len(t, runner.GetValidators(), 2)
+	assert.Len(t, runner.GetValidators(MatchesPrefixes(PrefixOSD)), 1)
+	assert.Len(t, runner.GetValidators(Not(MatchesPrefixes(PrefixOSD, PrefixSEC))), 1)
+}
+
 func TestRunnerRegistration(t *testing.T) {
 	t.Parallel()
 
@@ -85,7 +220,7 @@ func TestRunnerRegistration(t *testing.T) {
 		),
 	)
 
-	runner, err := NewRunner()
+	runner, err := NewRunner(context.Background())
 	require.NoError(t, err)
 
 	vals := runner.GetValidators(MatchesCodes(code))
@@ -98,6 +233,159 @@ func TestRunnerRegistration(t *testing.T) {
 	assert.Len(t, vals, 0)
 }
 
+func TestRunnerInitializersFromContext(t *testing.T) {
+	t.Parallel()
+
+	success := func(context.Context, types.MetaBundle) Result { return Result{success: true} }
+
+	var (
+		first  = PrefixSEC.Code(9001)
+		second = PrefixSEC.Code(9002)
+	)
+
+	ctx := NewInitializersContext(context.Background(), NewValidatorMock(first, "first", "desc", success))
+	ctx = NewInitializersContext(ctx, NewValidatorMock(second, "second", "desc", success))
+
+	runner, err := NewRunner(ctx)
+	require.NoError(t, err)
+
+	assert.Len(t, runner.GetValidators(MatchesCodes(first, second)), 2)
+
+	// a fresh context does not carry them
+	runner, err = NewRunner(context.Background())
+	require.NoError(t, err)
+
+	assert.Empty(t, runner.GetValidators(MatchesCodes(first, second)))
+
+	// explicit initializers take precedence
+	runner, err = NewRunner(ctx, WithInitializers{NewValidatorMock(PrefixSEC.Code(1), "explicit", "desc", success)})
+	require.NoError(t, err)
+
+	assert.Len(t, runner.GetValidators(), 1)
+}
+
+func TestRunnerDependenciesFromContext(t *testing.T) {
+	t.Parallel()
+
+	fromCtx := &namedOCMClient{Name: "from context"}
+	explicit := &namedOCMClient{Name: "explicit"}
+
+	ctx := NewOCMClientContext(context.Background(), fromCtx)
+
+	for name, tc := range map[string]struct {
+		Options  []RunnerOption
+		Expected OCMClient
+	}{
+		"from context": {
+			Expected: fromCtx,
+		},
+		"explicit option": {
+			Options:  []RunnerOption{WithOCMClient{OCMClient: explicit}},
+			Expected: explicit,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var deps Dependencies
+
+			init := func(d Dependencies) (Validator, error) {
+				deps = d
+
+				return NewValidatorMock(Code(0), "dummy_validator", "this is a dummy validator", nil)(d)
+			}
+
+			_, err := NewRunner(ctx, append(tc.Options, WithInitializers{init})...)
+			require.NoError(t, err)
+
+			assert.Same(t, tc.Expected, deps.OCMClient)
+		})
+	}
+}
+
+type namedOCMClient struct {
+	DisconnectedOCMClient
+	Name string
+}
+
+func TestRunnerDocsFilters(t *testing.T) {
+	t.Parallel()
+
+	newDocumented := func(code Code, docs Docs) Initializer {
+		return func(Dependencies) (Validator, error) {
+			base, err := NewBase(code, BaseDocs(docs))
+
+			return &ValidatorMock{Base: base}, err
+		}
+	}
+
+	runner, err := NewRunner(context.Background(), WithInitializers{
+		newDocumented(1, Docs{Tags: []string{TagBundle}}),
+		newDocumented(2, Docs{Envs: []string{"stage"}, Tags: []string{TagService}}),
+		newDocumented(3, Docs{}),
+	})
+	require.NoError(t, err)
+
+	codes := func(vals []Validator) []Code {
+		var res []Code
+
+		for _, v := range vals {
+			res = append(res, v.Code())
+		}
+
+		return res
+	}
+
+	assert.Equal(t, []Code{1}, codes(runner.GetValidators(MatchesTags(TagBundle))))
+	assert.Equal(t, []Code{1, 2}, codes(runner.GetValidators(MatchesTags(TagBundle, TagService))))
+	assert.Equal(t, []Code{1, 2, 3}, codes(runner.GetValidators(EnabledForEnv("stage"))))
+	assert.Equal(t, []Code{1, 3}, codes(runner.GetValidators(EnabledForEnv("production"))))
+	assert.Equal(t, []Code{1, 3}, codes(runner.GetValidators(Any(MatchesTags(TagBundle), Not(MatchesTags(TagService))))))
+	assert.Empty(t, codes(runner.GetValidators(Any())))
+	assert.Equal(t, []Code{2}, codes(runner.GetValidators(All(MatchesTags(TagService), EnabledForEnv("stage")))))
+	assert.Equal(t, []Code{1, 2, 3}, codes(runner.GetValidators(All(nil))))
+}
+
+func TestRunnerReplacements(t *testing.T) {
+	t.Parallel()
+
+	newReplacing := func(code Code, replaces ...Code) Initializer {
+		return func(Dependencies) (Validator, error) {
+			base, err := NewBase(code, BaseDocs(Docs{Replaces: replaces}))
+
+			return &ValidatorMock{Base: base}, err
+		}
+	}
+
+	runner, err := NewRunner(context.Background(), WithInitializers{
+		newReplacing(1),
+		newReplacing(2, 3, 4),
+		newReplacing(5, 1),
+	})
+	require.NoError(t, err)
+
+	replacement, ok := runner.ReplacementOf(3)
+	require.True(t, ok)
+	assert.Equal(t, Code(2), replacement.Code())
+
+	// replaced validators which are still registered are not deprecated
+	_, ok = runner.ReplacementOf(1)
+	assert.False(t, ok)
+
+	_, ok = runner.ReplacementOf(6)
+	assert.False(t, ok)
+
+	var codes []Code
+
+	for _, v := range runner.GetValidators(MatchesCodes(4)) {
+		codes = append(codes, v.Code())
+	}
+
+	assert.Equal(t, []Code{2}, codes)
+}
+
 func TestRunnerMiddleware(t *testing.T) {
 	t.Parallel()
 
@@ -112,6 +400,7 @@ func TestRunnerMiddleware(t *testing.T) {
 	var actualCount int
 
 	runner, err := NewRunner(
+		context.Background(),
 		WithInitializers{
 			NewValidatorMock(
 				code,
@@ -120,7 +409,7 @@ func TestRunnerMiddleware(t *testing.T) {
 				func(context.Context, types.MetaBundle) Result {
 					actualCount++
 
-					return Result{retryable: true}
+					return Result{Error: RetryableError(errors.New("temporary"))}
 				},
 			),
 		},
@@ -137,6 +426,369 @@ func TestRunnerMiddleware(t *testing.T) {
 	assert.Equal(t, expectedCount, actualCount)
 }
 
+func TestRunnerHooks(t *testing.T) {
+	t.Parallel()
+
+	type spanKey struct{}
+
+	newDependent := func(code Code, success bool, dependsOn ...Code) Initializer {
+		return func(Dependencies) (Validator, error) {
+			base, err := NewBase(code, BaseDocs(Docs{DependsOn: dependsOn}))
+
+			return &ValidatorMock{
+				Base: base,
+				runner: func(ctx context.Context, _ types.MetaBundle) Result {
+					// contexts returned by BeforeValidator are passed on
+					if ctx.Value(spanKey{}) != code.String() {
+						return base.Error(errors.New("missing span"))
+					}
+
+					if success {
+						return base.Success()
+					}
+
+					return base.Fail("failed")
+				},
+			}, err
+		}
+	}
+
+	var (
+		mu     sync.Mutex
+		before []Code
+		after  = make(map[Code]Result)
+		run    ResultList
+	)
+
+	hooks := HookFuncs{
+		BeforeValidatorFunc: func(ctx context.Context, v Validator) context.Context {
+			mu.Lock()
+			defer mu.Unlock()
+
+			before = append(before, v.Code())
+
+			return context.WithValue(ctx, spanKey{}, v.Code().String())
+		},
+		AfterValidatorFunc: func(ctx context.Context, v Validator, res Result) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			assert.Equal(t, v.Code().String(), ctx.Value(spanKey{}))
+
+			after[v.Code()] = res
+		},
+		AfterRunFunc: func(_ context.Context, results ResultList) {
+			run = results
+		},
+	}
+
+	runner, err := NewRunner(context.Background(),
+		WithInitializers{
+			newDependent(1, false),
+			newDependent(2, true, 1),
+			newDependent(3, true),
+		},
+		// hooks only providing some of the functions are supported
+		WithHooks{hooks, HookFuncs{}},
+	)
+	require.NoError(t, err)
+
+	var results ResultList
+
+	for res := range runner.Run(context.Background(), types.MetaBundle{}) {
+		results = append(results, res)
+	}
+
+	sort.Sort(results)
+
+	// AfterRun is called before the results channel is closed
+	require.Len(t, run, 3)
+	assert.Equal(t, results, run)
+
+	// skipped validators are not run
+	assert.ElementsMatch(t, []Code{1, 3}, before)
+	require.Len(t, after, 2)
+	assert.True(t, after[1].IsBlocking())
+	assert.True(t, after[3].IsSuccess())
+	assert.True(t, run[1].IsSkipped())
+}
+
+func TestRunnerMeasuresDuration(t *testing.T) {
+	t.Parallel()
+
+	const delay = 10 * time.Millisecond
+
+	runner, err := NewRunner(
+		context.Background(),
+		WithInitializers{
+			NewValidatorMock(
+				Code(0),
+				"dummy_validator",
+				"this is a dummy validator",
+				func(context.Context, types.MetaBundle) Result {
+					time.Sleep(delay)
+
+					return Result{success: true}
+				},
+			),
+		},
+	)
+	require.NoError(t, err)
+
+	res := <-runner.Run(context.Background(), types.MetaBundle{})
+	assert.GreaterOrEqual(t, res.Duration, delay)
+}
+
+func TestRunnerTimeout(t *testing.T) {
+	t.Parallel()
+
+	block := make(chan struct{})
+	defer close(block)
+
+	runner, err := NewRunner(
+		context.Background(),
+		WithTimeout(10*time.Millisecond),
+		WithInitializers{
+			NewValidatorMock(
+				Code(0),
+				"ignores_context",
+				"this validator ignores its context",
+				func(context.Context, types.MetaBundle) Result {
+					<-block
+
+					return Result{success: true}
+				},
+			),
+			NewValidatorMock(
+				Code(1),
+				"honours_context",
+				"this validator returns once its context is done",
+				func(ctx context.Context, _ types.MetaBundle) Result {
+					<-ctx.Done()
+
+					return Result{Error: ctx.Err()}
+				},
+			),
+			NewValidatorMock(
+				Code(2),
+				"fast",
+				"this validator completes in time",
+				func(context.Context, types.MetaBundle) Result {
+					return Result{success: true}
+				},
+			),
+		},
+	)
+	require.NoError(t, err)
+
+	var results ResultList
+
+	for res := range runner.Run(context.Background(), types.MetaBundle{}) {
+		results = append(results, res)
+	}
+
+	require.Len(t, results, 3)
+
+	var timedOut []Code
+
+	for _, res := range results {
+		if res.IsSuccess() {
+			continue
+		}
+
+		assert.True(t, res.IsError())
+		assert.True(t, res.IsTimeout())
+		assert.EqualError(t, res.Error, "validator timed out after 10ms")
+
+		timedOut = append(timedOut, res.Code)
+	}
+
+	assert.ElementsMatch(t, []Code{0, 1}, timedOut)
+}
+
+func TestRunnerConcurrency(t *testing.T) {
+	t.Parallel()
+
+	const limit = 2
+
+	var (
+		mu            sync.Mutex
+		running, peak int
+		initializers  WithInitializers
+	)
+
+	for i := 0; i < 6; i++ {
+		initializers = append(initializers, NewValidatorMock(
+			Code(i),
+			fmt.Sprintf("dummy_validator_%d", i),
+			"this is a dummy validator",
+			func(context.Context, types.MetaBundle) Result {
+				mu.Lock()
+				running++
+				if running > peak {
+					peak = running
+				}
+				mu.Unlock()
+
+				time.Sleep(10 * time.Millisecond)
+
+				mu.Lock()
+				running--
+				mu.Unlock()
+
+				return Result{success: true}
+			},
+		))
+	}
+
+	runner, err := NewRunner(context.Background(), initializers, WithConcurrency(limit))
+	require.NoError(t, err)
+
+	var count int
+
+	for range runner.Run(context.Background(), types.MetaBundle{}) {
+		count++
+	}
+
+	assert.Equal(t, len(initializers), count)
+	assert.LessOrEqual(t, peak, limit)
+}
+
+func TestRunnerRecoversPanics(t *testing.T) {
+	t.Parallel()
+
+	for name, timeout := range map[string]time.Duration{
+		"without timeout": 0,
+		"with timeout":    time.Minute,
+	} {
+		timeout := timeout
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			runner, err := NewRunner(
+				context.Background(),
+				WithTimeout(timeout),
+				WithInitializers{
+					NewValidatorMock(
+						Code(0),
+						"panics",
+						"this validator panics",
+						func(context.Context, types.MetaBundle) Result {
+							var meta *types.MetaBundle
+
+							return Result{Name: meta.AddonMeta.ID}
+						},
+					),
+					NewValidatorMock(
+						Code(1),
+						"succeeds",
+						"this validator succeeds",
+						func(context.Context, types.MetaBundle) Result {
+							return Result{Code: 1, success: true}
+						},
+					),
+				},
+			)
+			require.NoError(t, err)
+
+			var results ResultList
+
+			for res := range runner.Run(context.Background(), types.MetaBundle{}) {
+				results = append(results, res)
+			}
+
+			sort.Sort(results)
+
+			require.Len(t, results, 2)
+
+			assert.Equal(t, Code(0), results[0].Code)
+			assert.Equal(t, "panics", results[0].Name)
+			assert.ErrorIs(t, results[0].Error, ErrPanic)
+			assert.True(t, results[0].IsInternalError())
+			assert.ErrorContains(t, results[0].Error, "nil pointer dereference")
+
+			assert.True(t, results[1].IsSuccess())
+		})
+	}
+}
+
+func TestBaseErrorIsInternal(t *testing.T) {
+	t.Parallel()
+
+	base, err := NewBase(Code(1))
+	require.NoError(t, err)
+
+	cause := errors.New("cause")
+
+	for _, res := range []Result{base.Error(cause), base.RetryableError(cause)} {
+		assert.ErrorIs(t, res.Error, ErrValidatorInternal)
+		assert.ErrorIs(t, res.Error, cause)
+	}
+
+	assert.Equal(t, "validator internal error: cause", base.Error(base.Error(cause).Error).Error.Error())
+}
+
+func TestBaseErrorClassification(t *testing.T) {
+	t.Parallel()
+
+	base, err := NewBase(Code(1))
+	require.NoError(t, err)
+
+	cause := errors.New("cause")
+
+	for name, tc := range map[string]struct {
+		Result    Result
+		Retryable bool
+	}{
+		"error": {
+			Result: base.Error(cause),
+		},
+		"retryable error": {
+			Result:    base.RetryableError(cause),
+			Retryable: true,
+		},
+		"error wrapping ErrRetryable": {
+			Result:    base.Error(fmt.Errorf("fetching: %w", RetryableError(cause))),
+			Retryable: true,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.Retryable, tc.Result.IsRetryableError())
+			assert.Equal(t, !tc.Retryable, tc.Result.IsInternalError())
+			assert.ErrorIs(t, tc.Result.Error, cause)
+		})
+	}
+
+	assert.Equal(t, "validator internal error: cause", base.RetryableError(cause).Error.Error())
+}
+
+func TestRetryMiddlewareHonoursContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var attempts int
+
+	run := NewRetryMiddleware(WithDelay(time.Hour)).Wrap(
+		func(context.Context, types.MetaBundle) Result {
+			attempts++
+			cancel()
+
+			return Result{Error: RetryableError(errors.New("temporary"))}
+		},
+	)
+
+	res := run(ctx, types.MetaBundle{})
+
+	assert.True(t, res.IsRetryableError())
+	assert.Equal(t, 1, attempts)
+}
+
 func NewValidatorMock(
 	code Code,
 	name, desc string,
@@ -0,0 +1,22 @@
+package testutils
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func NewMockClusterClient() *MockClusterClient {
+	return &MockClusterClient{}
+}
+
+type MockClusterClient struct {
+	mock.Mock
+}
+
+func (c *MockClusterClient) DryRunApply(ctx context.Context, obj *unstructured.Unstructured) error {
+	args := c.Called(ctx, obj)
+
+	return args.Error(0)
+}
@@ -0,0 +1,51 @@
+package testutils
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/internal/testdata"
+	"github.com/mt-sre/addon-metadata-operator/pkg/metadata"
+	"github.com/mt-sre/addon-metadata-operator/pkg/operator"
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+// ReferenceAddon returns the 'stage' environment of the reference addon
+// fixture together with its single bundle. Unlike DefaultValidBundleMap
+// no index image is pulled. Every call returns a new MetaBundle which
+// may be modified freely.
+func ReferenceAddon(t *testing.T) types.MetaBundle {
+	t.Helper()
+
+	mb, err := metadata.NewLoader(testdata.FixtureAddonName,
+		metadata.WithFS{FS: testdata.FixtureAddon()},
+		metadata.WithEnv("stage"),
+	).LoadMetaBundle(context.Background())
+	require.NoError(t, err)
+
+	bundleDir := t.TempDir()
+	require.NoError(t, os.CopyFS(bundleDir, testdata.FixtureBundle()))
+
+	bundle, err := operator.NewBundleFromDirectory(bundleDir)
+	require.NoError(t, err)
+
+	mb.Bundles = []operator.Bundle{bundle}
+
+	return *mb
+}
+
+// ReferenceAddonWith returns ReferenceAddon modified by mods,
+// e.g. to derive invalid addons from the valid fixture.
+func ReferenceAddonWith(t *testing.T, mods ...func(*types.MetaBundle)) types.MetaBundle {
+	t.Helper()
+
+	mb := ReferenceAddon(t)
+
+	for _, mod := range mods {
+		mod(&mb)
+	}
+
+	return mb
+}
@@ -0,0 +1,26 @@
+package testutils
+
+import (
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReferenceAddon(t *testing.T) {
+	t.Parallel()
+
+	mb := ReferenceAddonWith(t, func(mb *types.MetaBundle) {
+		mb.AddonMeta.Label = "invalid"
+	})
+	assert.Equal(t, "invalid", mb.AddonMeta.Label)
+
+	// modifications do not affect other fixtures
+	mb = ReferenceAddon(t)
+	assert.Equal(t, "reference-addon", mb.AddonMeta.ID)
+	assert.Equal(t, "api.openshift.com/addon-reference-addon", mb.AddonMeta.Label)
+
+	require.Len(t, mb.Bundles, 1)
+	assert.Equal(t, "reference-addon", mb.Bundles[0].Annotations.PackageName)
+}
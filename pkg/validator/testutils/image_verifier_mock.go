@@ -0,0 +1,21 @@
+package testutils
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func NewMockImageVerifier() *MockImageVerifier {
+	return &MockImageVerifier{}
+}
+
+type MockImageVerifier struct {
+	mock.Mock
+}
+
+func (v *MockImageVerifier) Verify(ctx context.Context, image string) error {
+	args := v.Called(ctx, image)
+
+	return args.Error(0)
+}
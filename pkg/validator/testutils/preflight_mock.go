@@ -0,0 +1,22 @@
+package testutils
+
+import (
+	"context"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/stretchr/testify/mock"
+)
+
+func NewMockPreflightRunner() *MockPreflightRunner {
+	return &MockPreflightRunner{}
+}
+
+type MockPreflightRunner struct {
+	mock.Mock
+}
+
+func (r *MockPreflightRunner) CheckContainer(ctx context.Context, image string) (validator.PreflightResult, error) {
+	args := r.Called(ctx, image)
+
+	return args.Get(0).(validator.PreflightResult), args.Error(1)
+}
@@ -0,0 +1,12 @@
+package testutils
+
+import (
+	"testing"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockPreflightRunnerInterfaces(t *testing.T) {
+	require.Implements(t, new(validator.PreflightRunner), new(MockPreflightRunner))
+}
@@ -3,6 +3,8 @@ package testutils
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/go-logr/logr"
@@ -30,9 +32,13 @@ func NewValidatorTester(t *testing.T, init validator.Initializer, opts ...Valida
 
 	// This also ensures that a validator implements the validator.Validator interface
 	vt.Val, err = init(validator.Dependencies{
-		Logger:     vt.log,
-		OCMClient:  vt.ocm,
-		QuayClient: vt.quay,
+		ClusterClient:   vt.cluster,
+		ImageVerifier:   vt.images,
+		Logger:          vt.log,
+		OCMClient:       vt.ocm,
+		PreflightRunner: vt.preflight,
+		QuayClient:      vt.quay,
+		ValidatorConfig: vt.config,
 	})
 	require.NoError(t, err)
 
@@ -41,10 +47,14 @@ func NewValidatorTester(t *testing.T, init validator.Initializer, opts ...Valida
 
 type ValidatorTester struct {
 	*testing.T
-	Val  validator.Validator
-	log  logr.Logger
-	ocm  validator.OCMClient
-	quay validator.QuayClient
+	Val       validator.Validator
+	cluster   validator.ClusterClient
+	config    validator.ValidatorConfig
+	images    validator.ImageVerifier
+	log       logr.Logger
+	ocm       validator.OCMClient
+	preflight validator.PreflightRunner
+	quay      validator.QuayClient
 }
 
 func (v *ValidatorTester) TestSingleBundle(mb types.MetaBundle) validator.Result {
@@ -79,10 +89,88 @@ func (v *ValidatorTester) testBundles(bundles map[string]types.MetaBundle, asser
 	}
 }
 
+// TestCase is an addon and the expected result of the tested validator.
+type TestCase struct {
+	MetaBundle types.MetaBundle
+	// FailsWith lists messages the findings of the validator must
+	// contain (see AssertFailsWith). The validator must succeed if
+	// FailsWith is empty.
+	FailsWith []string
+}
+
+// TestCases runs the validator against each case in a parallel subtest.
+func (v *ValidatorTester) TestCases(cases map[string]TestCase) {
+	v.Helper()
+
+	for name, tc := range cases {
+		tc := tc
+
+		v.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			res := v.Val.Run(context.Background(), tc.MetaBundle)
+
+			if len(tc.FailsWith) == 0 {
+				AssertPasses(t, res)
+			} else {
+				AssertFailsWith(t, res, tc.FailsWith...)
+			}
+		})
+	}
+}
+
+// AssertPasses asserts that res is a success.
+func AssertPasses(t *testing.T, res validator.Result) bool {
+	t.Helper()
+
+	return assert.NoError(t, res.Error) &&
+		assert.True(t, res.IsSuccess(), "Actual Result: %+v", res)
+}
+
+// AssertFailsWith asserts that res is a failure rather than an error and
+// that each of msgs is contained in the message of one of its findings.
+func AssertFailsWith(t *testing.T, res validator.Result, msgs ...string) bool {
+	t.Helper()
+
+	if !assert.NoError(t, res.Error) || !assert.False(t, res.IsSuccess(), "Actual Result: %+v", res) {
+		return false
+	}
+
+	ok := true
+
+	for _, msg := range msgs {
+		found := slices.ContainsFunc(res.Findings, func(f validator.Finding) bool {
+			return strings.Contains(f.Message, msg)
+		})
+
+		ok = assert.True(t, found, "no finding contains %q: %v", msg, res.Messages()) && ok
+	}
+
+	return ok
+}
+
 func (v *ValidatorTester) Option(opt ValidatorTesterOption) { opt(v) }
 
 type ValidatorTesterOption func(*ValidatorTester)
 
+func ValidatorTesterClusterClient(cluster validator.ClusterClient) ValidatorTesterOption {
+	return func(v *ValidatorTester) {
+		v.cluster = cluster
+	}
+}
+
+func ValidatorTesterValidatorConfig(opts ...validator.ValidatorOption) ValidatorTesterOption {
+	return func(v *ValidatorTester) {
+		v.config.Option(opts...)
+	}
+}
+
+func ValidatorTesterImageVerifier(images validator.ImageVerifier) ValidatorTesterOption {
+	return func(v *ValidatorTester) {
+		v.images = images
+	}
+}
+
 func ValidatorTesterLogger(l logr.Logger) ValidatorTesterOption {
 	return func(v *ValidatorTester) {
 		v.log = l
@@ -95,6 +183,12 @@ func ValidatorTesterOCMClient(ocm validator.OCMClient) ValidatorTesterOption {
 	}
 }
 
+func ValidatorTesterPreflightRunner(preflight validator.PreflightRunner) ValidatorTesterOption {
+	return func(v *ValidatorTester) {
+		v.preflight = preflight
+	}
+}
+
 func ValidatorTesterQuayClient(quay validator.QuayClient) ValidatorTesterOption {
 	return func(v *ValidatorTester) {
 		v.quay = quay
@@ -2,8 +2,12 @@ package validator
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/mt-sre/addon-metadata-operator/pkg/types"
 )
@@ -24,11 +28,157 @@ type Validator interface {
 	Run(context.Context, types.MetaBundle) Result
 }
 
+// Fixer is optionally implemented by Validators whose failures can be
+// remediated mechanically. Fix returns the changes of the addon metadata
+// which resolve the failures Run reports for the given types.MetaBundle;
+// no changes are returned if there is nothing to fix. Fixers only get the
+// addon metadata; no bundles are extracted.
+type Fixer interface {
+	Fix(context.Context, types.MetaBundle) ([]types.Fix, error)
+}
+
+// Documenter is optionally implemented by Validators which document
+// themselves beyond their description.
+type Documenter interface {
+	Docs() Docs
+}
+
+// Docs extend the description of a Validator with the details
+// needed to understand and resolve its failures.
+type Docs struct {
+	// Details explain what is checked in full.
+	Details string
+	// Envs lists the environments the Validator applies to;
+	// it applies to all environments if none are listed.
+	Envs []string
+	// ExampleFailures are examples of reported failure messages.
+	ExampleFailures []string
+	// Remediation explains how failures are resolved.
+	Remediation string
+	// DocsURL links to further documentation; it defaults
+	// to the wiki page of the Validator.
+	DocsURL string
+	// Severity of failures; only failures of severity
+	// SeverityError, the default, fail a validation.
+	Severity Severity
+	// Tags group Validators by what they depend on.
+	Tags []string
+	// DependsOn lists the codes of Validators which must succeed
+	// for the results of this one to be meaningful. The Validator is
+	// run after them and skipped if any of them did not succeed.
+	DependsOn []Code
+	// Replaces lists the codes of deprecated Validators superseded
+	// by this one. Filters matching a deprecated code match this
+	// Validator instead (see MatchesCodes).
+	Replaces []Code
+}
+
+// WikiURL is the base URL of the wiki pages documenting
+// each Validator; pages are named after their code.
+const WikiURL = "https://github.com/mt-sre/addon-metadata-operator/wiki/"
+
+// EffectiveDocsURL returns the DocsURL of d or the wiki page
+// of the Validator with the given code if none is set.
+func (d Docs) EffectiveDocsURL(code Code) string {
+	if d.DocsURL == "" {
+		return WikiURL + code.String()
+	}
+
+	return d.DocsURL
+}
+
+// Severity grades the failures of a Validator.
+type Severity string
+
+const (
+	// SeverityError marks failures which must be resolved.
+	SeverityError Severity = "error"
+	// SeverityWarning marks failures which should be resolved,
+	// but do not fail a validation.
+	SeverityWarning Severity = "warning"
+	// SeverityInfo marks failures which are only informational.
+	SeverityInfo Severity = "info"
+)
+
+// Severities lists all severities from highest to lowest.
+var Severities = []Severity{SeverityError, SeverityWarning, SeverityInfo}
+
+// AtLeast reports whether s is as severe as threshold or more
+// severe. Unset and unknown severities are treated as SeverityError.
+func (s Severity) AtLeast(threshold Severity) bool {
+	return severityRank(s) <= severityRank(threshold)
+}
+
+// severityRank returns the index of s in Severities
+// or that of SeverityError if s is not listed.
+func severityRank(s Severity) int {
+	if i := slices.Index(Severities, s); i >= 0 {
+		return i
+	}
+
+	return 0
+}
+
+// EffectiveSeverity returns the severity documented by d or
+// SeverityError if none is documented.
+func (d Docs) EffectiveSeverity() Severity {
+	if d.Severity == "" {
+		return SeverityError
+	}
+
+	return d.Severity
+}
+
+const (
+	// TagBundle marks Validators checking the bundles extracted
+	// from the index image rather than the addon metadata alone.
+	TagBundle = "bundle"
+	// TagConsistency marks bundle Validators checking that the
+	// bundles are consistent with the addon metadata, e.g. with
+	// its default channel or install mode.
+	TagConsistency = "consistency"
+	// TagImageSet marks Validators checking fields which imagesets
+	// may set such as 'addOnParameters' or 'relatedImages'.
+	TagImageSet = "imageset"
+	// TagSecurity marks Validators checking permissions or other
+	// settings which affect the security of the clusters the addon
+	// is installed on.
+	TagSecurity = "security"
+	// TagService marks Validators depending on remote services
+	// such as quay, OCM, a cluster or preflight.
+	TagService = "service"
+)
+
+// Tags lists all tags Validators are grouped by.
+var Tags = []string{TagBundle, TagConsistency, TagImageSet, TagSecurity, TagService}
+
+// DocsOf returns the Docs of v or empty Docs if v
+// is not a Documenter.
+func DocsOf(v Validator) Docs {
+	if d, ok := v.(Documenter); ok {
+		return d.Docs()
+	}
+
+	return Docs{}
+}
+
+// AppliesToEnv reports whether a Validator documented by d
+// applies to the given environment.
+func (d Docs) AppliesToEnv(env string) bool {
+	return len(d.Envs) == 0 || slices.Contains(d.Envs, env)
+}
+
+// HasTag reports whether d lists the given tag.
+func (d Docs) HasTag(tag string) bool {
+	return slices.Contains(d.Tags, tag)
+}
+
 // NewBase returns a base Validator implementation with a given code and optional
 // parameters. An error is returned if an invalid code is given.
 func NewBase(code Code, opts ...BaseOption) (*Base, error) {
 	if code < Code(0) {
-		return nil, fmt.Errorf("validator codes must be non-negative integers not %d", code)
+		return nil, fmt.Errorf("validator codes must be non-negative integers not %d; "+
+			"codes of unregistered prefixes and out of range numbers are invalid", code)
 	}
 
 	cfg := Base{code: code}
@@ -44,11 +194,13 @@ type Base struct {
 	code Code
 	name string
 	desc string
+	docs Docs
 }
 
 func (b *Base) Code() Code          { return b.code }
 func (b *Base) Name() string        { return b.name }
 func (b *Base) Description() string { return b.desc }
+func (b *Base) Docs() Docs          { return b.docs }
 
 // Option applies a variadic slice of options to a Base instance.
 func (b *Base) Option(opts ...BaseOption) {
@@ -80,18 +232,30 @@ func (b *Base) Success() Result {
 // A variadic slice of messages are passed to describe the reason(s)
 // that a validation task failed.
 func (b *Base) Fail(msgs ...string) Result {
+	findings := make([]Finding, 0, len(msgs))
+	for _, msg := range msgs {
+		findings = append(findings, Finding{Message: msg})
+	}
+
+	return b.FailWith(findings...)
+}
+
+// FailWith is like Fail, but reports findings which may point to the
+// offending file and field or override the severity of the Validator.
+func (b *Base) FailWith(findings ...Finding) Result {
 	res := b.populateResult()
-	res.FailureMsgs = msgs
+	res.Findings = findings
 
 	return res
 }
 
 // Error is a helper which returns a populated Error result.
 // An error instnace is passed to give context for what error
-// caused a validation task to exit.
+// caused a validation task to exit. Errors wrapping ErrRetryable
+// are retried by middleware while all others wrap ErrInternal.
 func (b *Base) Error(err error) Result {
 	res := b.populateResult()
-	res.Error = err
+	res.Error = internalError(err)
 
 	return res
 }
@@ -101,17 +265,64 @@ func (b *Base) Error(err error) Result {
 // may be retried.
 func (b *Base) RetryableError(err error) Result {
 	res := b.populateResult()
-	res.Error = err
-	res.retryable = true
+	res.Error = internalError(RetryableError(err))
 
 	return res
 }
 
+// ErrValidatorInternal is wrapped by the errors of all results
+// returned by the Error and RetryableError helpers.
+var ErrValidatorInternal = errors.New("validator internal error")
+
+// ErrRetryable is wrapped by errors caused by transient problems,
+// e.g. an unreachable registry, which may not recur when retried.
+var ErrRetryable = errors.New("retryable error")
+
+// ErrInternal is wrapped by the errors of results for validators
+// which failed for reasons retrying does not resolve.
+var ErrInternal = errors.New("internal error")
+
+// RetryableError annotates err with ErrRetryable
+// without altering its message.
+func RetryableError(err error) error {
+	if err == nil || errors.Is(err, ErrRetryable) {
+		return err
+	}
+
+	return classifiedError{err: err, class: ErrRetryable}
+}
+
+func internalError(err error) error {
+	if err == nil || errors.Is(err, ErrValidatorInternal) {
+		return err
+	}
+
+	if !errors.Is(err, ErrRetryable) {
+		err = classifiedError{err: err, class: ErrInternal}
+	}
+
+	return fmt.Errorf("%w: %w", ErrValidatorInternal, err)
+}
+
+// classifiedError is err matching class without
+// adding the message of class to its own.
+type classifiedError struct {
+	err   error
+	class error
+}
+
+func (e classifiedError) Error() string { return e.err.Error() }
+
+func (e classifiedError) Unwrap() []error { return []error{e.err, e.class} }
+
 func (b *Base) populateResult() Result {
 	return Result{
 		Code:        b.code,
 		Name:        b.name,
 		Description: b.desc,
+		Severity:    b.docs.EffectiveSeverity(),
+		Remediation: b.docs.Remediation,
+		DocsURL:     b.docs.EffectiveDocsURL(b.code),
 	}
 }
 
@@ -129,6 +340,11 @@ func BaseDesc(desc string) BaseOption {
 	return func(b *Base) { b.desc = desc }
 }
 
+// BaseDocs applies the given docs to a base instance.
+func BaseDocs(docs Docs) BaseOption {
+	return func(b *Base) { b.docs = docs }
+}
+
 // ValidatorList is a sortable slice of Validators.
 type ValidatorList []Validator
 
@@ -136,28 +352,184 @@ func (l ValidatorList) Len() int           { return len(l) }
 func (l ValidatorList) Less(i, j int) bool { return l[i].Code() < l[j].Code() }
 func (l ValidatorList) Swap(i, j int)      { l[i], l[j] = l[j], l[i] }
 
-const codePrefix = "AM"
+// Prefix is the prefix of the codes of a family of Validators. Each
+// family has its own range of codes so that downstream consumers can
+// register their own Validators without colliding with AM codes.
+type Prefix string
+
+const (
+	// PrefixAM is the prefix of the upstream addon metadata Validators.
+	PrefixAM Prefix = "AM"
+	// PrefixOSD is the prefix of Validators specific to OpenShift Dedicated.
+	PrefixOSD Prefix = "OSD"
+	// PrefixSEC is the prefix of security focused Validators.
+	PrefixSEC Prefix = "SEC"
+)
+
+var (
+	// prefixMu guards prefixBases which is read by runners
+	// while prefixes may still be registered, e.g. by plugins.
+	prefixMu sync.RWMutex
+	// prefixBases maps the registered prefixes to the first code of their
+	// range. Bases are fixed so that codes do not depend on the order
+	// prefixes are registered in.
+	prefixBases = map[Prefix]Code{
+		PrefixAM:  0,
+		PrefixOSD: 1 * codesPerPrefix,
+		PrefixSEC: 2 * codesPerPrefix,
+	}
+)
+
+// prefixBase returns the first code of the range of p and 'true'
+// or 'false' if p is not registered.
+func prefixBase(p Prefix) (Code, bool) {
+	prefixMu.RLock()
+	defer prefixMu.RUnlock()
+
+	base, ok := prefixBases[p]
+
+	return base, ok
+}
+
+// RegisterPrefix registers the prefix of a downstream family of Validators
+// so that its codes can be created and parsed. Its codes start at base
+// which must be a multiple of 10000 not used by another prefix, e.g.
+// 90000 for codes 90000 to 99999. Registering a prefix again with the
+// same base has no effect. An error is returned if p does not consist
+// of two to four uppercase letters or clashes with a registered prefix.
+func RegisterPrefix(p Prefix, base Code) error {
+	if !p.isValid() {
+		return fmt.Errorf("invalid validator code prefix '%s'", p)
+	}
+
+	if base <= 0 || base%codesPerPrefix != 0 {
+		return fmt.Errorf("base %d of validator code prefix '%s' must be a positive multiple of %d", base, p, codesPerPrefix)
+	}
+
+	prefixMu.Lock()
+	defer prefixMu.Unlock()
+
+	for other, otherBase := range prefixBases {
+		if other == p && otherBase == base {
+			return nil
+		}
+
+		if other == p || otherBase == base {
+			return fmt.Errorf("validator code prefix '%s' with base %d clashes with registered prefix '%s' with base %d", p, base, other, otherBase)
+		}
+	}
+
+	prefixBases[p] = base
+
+	return nil
+}
+
+// Prefixes returns all registered prefixes in the order of their code ranges.
+func Prefixes() []Prefix {
+	prefixMu.RLock()
+	defer prefixMu.RUnlock()
+
+	res := make([]Prefix, 0, len(prefixBases))
+	for p := range prefixBases {
+		res = append(res, p)
+	}
 
-// Code is a prefixed integer ID used to distinguish Validator implementations.
+	slices.SortFunc(res, func(a, b Prefix) int { return int(prefixBases[a] - prefixBases[b]) })
+
+	return res
+}
+
+// ParsePrefix converts a given string to a registered Prefix.
+func ParsePrefix(maybePrefix string) (Prefix, error) {
+	p := Prefix(strings.ToUpper(maybePrefix))
+	if _, ok := prefixBase(p); !ok {
+		return "", fmt.Errorf("unknown code prefix '%s'", maybePrefix)
+	}
+
+	return p, nil
+}
+
+// InvalidCode is returned by Prefix.Code for unregistered
+// prefixes and numbers out of range; NewBase rejects it.
+const InvalidCode Code = -1
+
+// Code returns the Code of the family with the given number or
+// InvalidCode if p is not registered or n is not within [0, 9999].
+func (p Prefix) Code(n int) Code {
+	base, ok := prefixBase(p)
+	if !ok || n < 0 || n >= codesPerPrefix {
+		return InvalidCode
+	}
+
+	return base + Code(n)
+}
+
+func (p Prefix) isValid() bool {
+	if len(p) < 2 || len(p) > 4 {
+		return false
+	}
+
+	for _, r := range p {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+const codesPerPrefix = 10000
+
+// Code is a prefixed integer ID used to distinguish Validator
+// implementations. Codes of the AM family equal their number
+// while the codes of other families are offset by their base.
 type Code int
 
+// Prefix returns the prefix of the family c belongs to.
+func (c Code) Prefix() Prefix {
+	if c < 0 {
+		return ""
+	}
+
+	prefixMu.RLock()
+	defer prefixMu.RUnlock()
+
+	for p, base := range prefixBases {
+		if c-c%codesPerPrefix == base {
+			return p
+		}
+	}
+
+	return ""
+}
+
+// Number returns the number of c within its family.
+func (c Code) Number() int { return int(c) % codesPerPrefix }
+
 func (c Code) String() string {
-	return fmt.Sprintf("%s%04d", codePrefix, c)
+	return fmt.Sprintf("%s%04d", c.Prefix(), c.Number())
 }
 
 // ParseCode converts a given string to a Code value.
-// An error is returned if the string is incorrectly formatted.
+// An error is returned if the string is incorrectly formatted
+// or its prefix is not registered (see RegisterPrefix).
 func ParseCode(maybeCode string) (Code, error) {
-	var result Code
+	upper := strings.ToUpper(maybeCode)
+	digits := strings.TrimLeftFunc(upper, func(r rune) bool { return r >= 'A' && r <= 'Z' })
+
+	if len(digits) != 4 || digits == upper {
+		return 0, fmt.Errorf("code must be of the format '<prefix>XXXX', e.g. '%sXXXX'", PrefixAM)
+	}
 
-	if len(maybeCode) != 6 {
-		return result, fmt.Errorf("code must be of the format '%sXXXX'", codePrefix)
+	prefix, err := ParsePrefix(strings.TrimSuffix(upper, digits))
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse code from '%s': %w", maybeCode, err)
 	}
 
-	n, err := fmt.Sscanf(strings.ToUpper(maybeCode), codePrefix+"%04d", &result)
-	if err != nil || n < 1 {
-		return result, fmt.Errorf("unable to parse code from '%s'", maybeCode)
+	n, err := strconv.Atoi(digits)
+	if err != nil || strings.ContainsAny(digits, "+-") {
+		return 0, fmt.Errorf("unable to parse code from '%s'", maybeCode)
 	}
 
-	return result, nil
+	return prefix.Code(n), nil
 }
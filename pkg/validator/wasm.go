@@ -0,0 +1,209 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mt-sre/addon-metadata-operator/pkg/types"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	"github.com/tetratelabs/wazero/sys"
+)
+
+// wasmMemoryLimitPages limits the memory of WASM validators
+// to 256 MiB; a page has 64 KiB.
+const wasmMemoryLimitPages = 4096
+
+// LoadWasmValidators loads every '.wasm' file in dir in lexical order
+// as WASM validator. They are not registered globally; runners only
+// run them if given their initializers, e.g. through a context returned
+// by NewInitializersContext. The returned validators must be closed once
+// no runner uses them. See NewWasmValidator for the requirements modules
+// must meet.
+func LoadWasmValidators(ctx context.Context, dir string) (WasmValidators, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading wasm validator directory: %w", err)
+	}
+
+	var paths []string
+
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".wasm" {
+			continue
+		}
+
+		paths = append(paths, filepath.Join(dir, e.Name()))
+	}
+
+	sort.Strings(paths)
+
+	vals := make(WasmValidators, 0, len(paths))
+
+	for _, path := range paths {
+		wasm, err := os.ReadFile(path)
+		if err != nil {
+			_ = vals.Close()
+
+			return nil, fmt.Errorf("reading wasm validator: %w", err)
+		}
+
+		val, err := NewWasmValidator(ctx, filepath.Base(path), wasm)
+		if err != nil {
+			_ = vals.Close()
+
+			return nil, fmt.Errorf("loading wasm validator '%s': %w", path, err)
+		}
+
+		vals = append(vals, val)
+	}
+
+	return vals, nil
+}
+
+// WasmValidators are loaded WASM validators.
+type WasmValidators []*WasmValidator
+
+// Initializers returns an Initializer for each of the validators.
+func (l WasmValidators) Initializers() []Initializer {
+	res := make([]Initializer, 0, len(l))
+
+	for _, val := range l {
+		val := val
+
+		res = append(res, func(Dependencies) (Validator, error) { return val, nil })
+	}
+
+	return res
+}
+
+// Close releases the runtimes and compiled modules of all validators.
+func (l WasmValidators) Close() error {
+	errs := make([]error, 0, len(l))
+
+	for _, val := range l {
+		errs = append(errs, val.Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+// NewWasmValidator compiles the given WASI command module and returns
+// a WasmValidator described by it. Modules implement the exec validator
+// protocol (see ExecArgRun) with name as program name. They run
+// sandboxed: only stdin, stdout and stderr are available to them, but
+// neither the file system, the network nor the environment. An error is
+// returned if the module can not be compiled or its description is invalid.
+func NewWasmValidator(ctx context.Context, name string, wasm []byte) (*WasmValidator, error) {
+	runtime := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithCloseOnContextDone(true).
+		WithMemoryLimitPages(wasmMemoryLimitPages),
+	)
+
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, runtime); err != nil {
+		_ = runtime.Close(ctx)
+
+		return nil, fmt.Errorf("instantiating WASI: %w", err)
+	}
+
+	module, err := runtime.CompileModule(ctx, wasm)
+	if err != nil {
+		_ = runtime.Close(ctx)
+
+		return nil, fmt.Errorf("%w: compiling module: %v", ErrInvalidExecValidator, err)
+	}
+
+	val := &WasmValidator{
+		name:    name,
+		runtime: runtime,
+		module:  module,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, describeTimeout)
+	defer cancel()
+
+	out, err := val.run(ctx, ExecArgDescribe, nil)
+	if err != nil {
+		_ = runtime.Close(ctx)
+
+		return nil, err
+	}
+
+	if val.Base, err = newDescribedBase(out); err != nil {
+		_ = runtime.Close(ctx)
+
+		return nil, err
+	}
+
+	return val, nil
+}
+
+// WasmValidator runs a sandboxed WASM module implementing
+// the exec validator protocol.
+type WasmValidator struct {
+	*Base
+	name    string
+	runtime wazero.Runtime
+	module  wazero.CompiledModule
+}
+
+// Close releases the runtime and the compiled module of w;
+// it must not be run afterwards.
+func (w *WasmValidator) Close() error {
+	return w.runtime.Close(context.Background())
+}
+
+func (w *WasmValidator) Run(ctx context.Context, mb types.MetaBundle) Result {
+	in, err := json.Marshal(NewExecInput(mb))
+	if err != nil {
+		return w.Error(fmt.Errorf("encoding input: %w", err))
+	}
+
+	out, err := w.run(ctx, ExecArgRun, in)
+	if err != nil {
+		return w.Error(err)
+	}
+
+	return w.resultOf(out)
+}
+
+// run instantiates a new instance of the module so that no
+// state is shared between runs.
+func (w *WasmValidator) run(ctx context.Context, arg string, stdin []byte) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+
+	cfg := wazero.NewModuleConfig().
+		// anonymous so that modules may run concurrently
+		WithName("").
+		WithArgs(w.name, arg).
+		WithStdin(bytes.NewReader(stdin)).
+		WithStdout(&stdout).
+		WithStderr(&stderr)
+
+	mod, err := w.runtime.InstantiateModule(ctx, w.module, cfg)
+	if mod != nil {
+		defer mod.Close(ctx)
+	}
+
+	var exitErr *sys.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 0 {
+		err = nil
+	}
+
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("running '%s %s': %w: %s", w.name, arg, err, msg)
+		}
+
+		return nil, fmt.Errorf("running '%s %s': %w", w.name, arg, err)
+	}
+
+	return stdout.Bytes(), nil
+}
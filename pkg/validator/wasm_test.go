@@ -0,0 +1,52 @@
+package validator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWasmValidatorInvalidModule(t *testing.T) {
+	t.Parallel()
+
+	for name, wasm := range map[string][]byte{
+		"empty":         nil,
+		"not wasm":      []byte("#!/bin/sh\necho '{}'\n"),
+		"missing start": []byte("\x00asm\x01\x00\x00\x00"),
+	} {
+		wasm := wasm
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewWasmValidator(context.Background(), "validator.wasm", wasm)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLoadWasmValidators(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	// files without '.wasm' extension are ignored
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# validators"), 0o600))
+
+	vals, err := LoadWasmValidators(context.Background(), dir)
+	require.NoError(t, err)
+	assert.Empty(t, vals)
+	assert.NoError(t, vals.Close())
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "invalid.wasm"), []byte("invalid"), 0o600))
+
+	_, err = LoadWasmValidators(context.Background(), dir)
+	assert.ErrorIs(t, err, ErrInvalidExecValidator)
+
+	_, err = LoadWasmValidators(context.Background(), filepath.Join(dir, "missing"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}